@@ -1,17 +1,24 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/blockchain"
 	"github.com/tunecent/backend/internal/config"
 	"github.com/tunecent/backend/internal/database"
 	"github.com/tunecent/backend/internal/handlers"
+	"github.com/tunecent/backend/internal/logger"
+	"github.com/tunecent/backend/internal/middleware"
 	"github.com/tunecent/backend/internal/models"
 	"github.com/tunecent/backend/internal/services"
 	"github.com/tunecent/backend/pkg/fingerprint"
@@ -86,19 +93,29 @@ import (
 func main() {
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using environment variables")
+		slog.Info("No .env file found, using environment variables")
 	}
 
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatal("Failed to load configuration:", err)
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
 	}
 
+	// Initialize structured logging (LOG_LEVEL, LOG_FORMAT)
+	appLogger, err := logger.New(os.Stdout, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		slog.Error("Invalid logging configuration", "error", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(appLogger)
+
 	// Initialize database
-	gormDB, err := initDB()
+	gormDB, err := initDB(appLogger)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		slog.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
 	}
 
 	// Wrap GORM DB in our database wrapper
@@ -106,43 +123,108 @@ func main() {
 
 	// Run migrations - DISABLED for PoC (using schema.sql instead)
 	// if err := runMigrations(gormDB); err != nil {
-	// 	log.Fatal("Failed to run migrations:", err)
+	// 	slog.Error("Failed to run migrations", "error", err)
+	// 	os.Exit(1)
 	// }
 
+	// Initialize blockchain client (optional, enables ENS lookups and on-chain provenance checks)
+	var blockchainClient *blockchain.Client
+	var blockchainService *blockchain.Service
+	var ensResolver services.ENSResolver
+	var gasOracle services.GasOracle
+	if cfg.Blockchain.MusicRegistryAddress != "" {
+		blockchainClient, err = blockchain.NewClient(cfg)
+		if err != nil {
+			slog.Warn("Failed to connect to blockchain, continuing in database-only mode", "error", err)
+		} else {
+			blockchainService = blockchain.NewService(blockchainClient)
+			ensResolver = blockchainClient
+			gasOracle = blockchainClient.GetClient()
+			defer blockchainClient.Close()
+			slog.Info("Blockchain client connected successfully")
+		}
+	}
+
 	// Initialize services
 	ipfsService := ipfs.NewService(cfg)
-	fingerprintService := fingerprint.NewService()
-	musicService := services.NewMusicService(db, ipfsService, fingerprintService, nil)
+	fingerprintAlgorithm, err := fingerprint.NewAlgorithm(cfg.Fingerprint.Algorithm, cfg.Fingerprint.Command, cfg.Fingerprint.Timeout)
+	if err != nil {
+		slog.Error("Invalid fingerprint configuration", "error", err)
+		os.Exit(1)
+	}
+	fingerprintService := fingerprint.NewService(fingerprintAlgorithm)
+	slog.Info("Audio fingerprinting configured", "algorithm", fingerprintService.Algorithm())
+	musicService := services.NewMusicService(db, ipfsService, fingerprintService, blockchainService)
+	musicService.SetStorageQuota(cfg.Upload.CreatorQuotaBytes)
 	distributionService := services.NewDistributionService(db)
 	notificationService := services.NewNotificationService(db)
 	ledgerService := services.NewLedgerService(db)
+	royaltyService := services.NewRoyaltyService(db)
+	usageService := services.NewUsageService(db)
 	reinvestmentService := services.NewReinvestmentService(db)
+	recommendationService := services.NewRecommendationService(db)
+	addressResolver := services.NewAddressResolver(ensResolver, cfg.Blockchain.ENSCacheTTL)
+	trendingService := services.NewTrendingService(db)
+	leaderboardService := services.NewLeaderboardService(db)
+	creatorSnapshotService := services.NewCreatorSnapshotService(db)
+	feeEstimator := services.NewFeeEstimator(gasOracle, map[string]common.Address{
+		services.ContractMusicRegistry:      common.HexToAddress(cfg.Blockchain.MusicRegistryAddress),
+		services.ContractCrowdfundingPool:   common.HexToAddress(cfg.Blockchain.CrowdfundingPoolAddress),
+		services.ContractRoyaltyDistributor: common.HexToAddress(cfg.Blockchain.RoyaltyDistributorAddress),
+	}, services.GasPriceCacheTTL)
+	musicService.OnRegistered(leaderboardService.InvalidateStats)
+	royaltyService.OnDistributed(leaderboardService.InvalidateStats)
+	usageService.OnIngested(leaderboardService.InvalidateStats)
+	outboxWorker := services.NewOutboxWorker(db, cfg.Webhook)
+	savingsCalculator := services.NewSavingsCalculator(cfg.Fees)
+
+	// Background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go trendingService.Run(jobsCtx, services.TrendingScoreInterval)
+	go leaderboardService.Run(jobsCtx, services.RankSnapshotInterval)
+	go creatorSnapshotService.Run(jobsCtx, services.CreatorSnapshotInterval)
+	go outboxWorker.Run(jobsCtx, services.OutboxPollInterval)
 
 	// Initialize handlers
-	musicHandler := handlers.NewMusicHandler(musicService)
+	handlers.SetPageSizes(cfg.Pagination.DefaultPageSize, cfg.Pagination.MaxPageSize)
+	musicHandler := handlers.NewMusicHandler(musicService, cfg.Upload, cfg.JWT.Secret)
 	campaignHandler := handlers.NewCampaignHandler(db)
-	royaltyHandler := handlers.NewRoyaltyHandler(db)
+	campaignHandler.OnFundingChanged(leaderboardService.InvalidateStats)
+	royaltyHandler := handlers.NewRoyaltyHandler(db, royaltyService, feeEstimator, cfg.Fees)
 	userHandler := handlers.NewUserHandler(db)
 
 	// PoC handlers
 	dashboardHandler := handlers.NewDashboardHandler(db)
 	analyticsHandler := handlers.NewAnalyticsHandler(db)
-	walletHandler := handlers.NewWalletHandler(db)
-	leaderboardHandler := handlers.NewLeaderboardHandler(db)
+	walletHandler := handlers.NewWalletHandler(db, savingsCalculator)
+	leaderboardHandler := handlers.NewLeaderboardHandler(db, leaderboardService)
 	portfolioHandler := handlers.NewPortfolioHandler(db)
 
 	// New service handlers
 	distributionHandler := handlers.NewDistributionHandler(distributionService)
 	notificationHandler := handlers.NewNotificationHandler(notificationService)
 	ledgerHandler := handlers.NewLedgerHandler(ledgerService)
+	usageHandler := handlers.NewUsageHandler(usageService)
+	blockchainHandler := handlers.NewBlockchainHandler(feeEstimator, cfg.Blockchain.ChainID, blockchainClient != nil)
 	reinvestmentHandler := handlers.NewReinvestmentHandler(reinvestmentService)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService)
+	adminHandler := handlers.NewAdminHandler(db, outboxWorker, musicService, savingsCalculator)
+	reportHandler := handlers.NewReportHandler(db)
 
 	// Initialize Gin router
-	r := gin.Default()
+	r := gin.New()
 
 	// Middleware
-	r.Use(gin.Logger())
+	r.Use(logger.RequestLogger(appLogger))
 	r.Use(gin.Recovery())
+
+	gzipMiddleware, err := middleware.Gzip(cfg.Gzip.Level, cfg.Gzip.MinSize)
+	if err != nil {
+		slog.Error("Invalid compression configuration", "error", err)
+		os.Exit(1)
+	}
+	r.Use(gzipMiddleware)
 	r.Use(CORSMiddleware())
 
 	// Swagger documentation
@@ -157,33 +239,76 @@ func main() {
 		// Music routes
 		music := v1.Group("/music")
 		{
-			music.POST("/register", musicHandler.RegisterMusic)
-			music.GET("/:tokenId", musicHandler.GetMusic)
+			music.POST("/register", handlers.RequireAuth(cfg.JWT.Secret), musicHandler.RegisterMusic)
+			music.POST("/upload-url", handlers.RequireAuth(cfg.JWT.Secret), musicHandler.CreateUploadURL)
+			music.POST("/check-fingerprint", musicHandler.CheckFingerprint)
+			music.GET("/:tokenId", middleware.CacheControl(cfg.Cache.MetadataMaxAge), musicHandler.GetMusic)
 			music.GET("/", musicHandler.ListMusic)
 			music.GET("/:tokenId/analytics", musicHandler.GetMusicAnalytics)
+			music.GET("/:tokenId/features", musicHandler.GetMusicFeatures)
+			music.GET("/:tokenId/links", musicHandler.GetMusicLinks)
+			music.GET("/:tokenId/provenance", musicHandler.GetMusicProvenance)
+			music.POST("/:tokenId/cover", handlers.RequireAuth(cfg.JWT.Secret), musicHandler.UpdateCoverArt)
+			music.GET("/:tokenId/usage", usageHandler.GetTrackUsage)
 		}
 
+		// IPFS proxy for content referenced by tracks and profiles
+		v1.GET("/ipfs/:cid", musicHandler.GetIPFSContent)
+
 		// Campaign routes
 		campaigns := v1.Group("/campaigns")
 		{
 			campaigns.POST("/", campaignHandler.CreateCampaign)
+			campaigns.GET("/recommended", recommendationHandler.GetRecommendedCampaigns)
+			campaigns.GET("/trending", campaignHandler.GetTrendingCampaigns)
+			campaigns.GET("/ending-soon", campaignHandler.GetEndingSoon)
 			campaigns.GET("/:campaignId", campaignHandler.GetCampaign)
+			campaigns.GET("/:campaignId/risk-breakdown", campaignHandler.GetRiskBreakdown)
+			campaigns.GET("/:campaignId/funding-forecast", campaignHandler.GetFundingForecast)
+			campaigns.GET("/:campaignId/analytics", campaignHandler.GetCampaignAnalytics)
+			campaigns.GET("/:campaignId/contribution-stats", campaignHandler.GetContributionStats)
+			campaigns.GET("/:campaignId/break-even", campaignHandler.GetBreakEven)
+			campaigns.GET("/:campaignId/share-preview", campaignHandler.GetSharePreview)
+			campaigns.PATCH("/:campaignId", handlers.RequireAuth(cfg.JWT.Secret), campaignHandler.UpdateCampaign)
+			campaigns.POST("/:campaignId/cancel", handlers.RequireAuth(cfg.JWT.Secret), campaignHandler.CancelCampaign)
 			campaigns.GET("/", campaignHandler.ListCampaigns)
 			campaigns.POST("/:campaignId/contribute", campaignHandler.Contribute)
+			campaigns.POST("/:campaignId/recompute-shares", handlers.RequireAuth(cfg.JWT.Secret), campaignHandler.RecomputeShares)
+			campaigns.POST("/:campaignId/adjust", handlers.RequireRole(cfg.JWT.Secret, auth.RoleAdmin), campaignHandler.AdjustContribution)
 		}
 
 		// Royalty routes
 		royalties := v1.Group("/royalties")
 		{
 			royalties.GET("/token/:tokenId", royaltyHandler.GetRoyalties)
+			royalties.GET("/token/:tokenId/pending", royaltyHandler.GetPendingRoyalties)
+			royalties.GET("/token/:tokenId/my-cut", royaltyHandler.GetMyCut)
+			royalties.GET("/payment/:paymentId/distributions", royaltyHandler.GetPaymentDistributions)
 			royalties.POST("/simulate", royaltyHandler.SimulateRoyaltyPayment)
+			royalties.POST("/:paymentId/distribute", handlers.RequireRole(cfg.JWT.Secret, auth.RoleUser, auth.RoleAdmin, auth.RoleService), royaltyHandler.DistributePayment)
+			royalties.GET("/:paymentId/fee-estimate", royaltyHandler.GetFeeEstimate)
 		}
 
 		// User/Reputation routes
-		users := v1.Group("/users")
+		users := v1.Group("/users", handlers.ResolveAddressParam(addressResolver))
 		{
 			users.GET("/:address", userHandler.GetUserProfile)
 			users.GET("/:address/reputation", userHandler.GetReputation)
+			users.GET("/:address/pending-royalties", userHandler.GetPendingRoyalties)
+			users.GET("/:address/pending-actions", userHandler.GetPendingActions)
+			users.GET("/:address/storage", musicHandler.GetStorageQuota)
+			users.GET("/:address/avg-royalty", userHandler.GetAverageRoyalty)
+			users.GET("/:address/campaign-track-record", userHandler.GetCampaignTrackRecord)
+			users.GET("/:address/avg-time-to-fund", userHandler.GetAvgTimeToFund)
+			users.GET("/:address/audience-growth", userHandler.GetAudienceGrowth)
+			users.GET("/:address/genre-breakdown", userHandler.GetGenreBreakdown)
+			users.GET("/:address/earning-tokens", userHandler.GetEarningTokens)
+			users.GET("/:address/earnings-concentration", userHandler.GetEarningsConcentration)
+			users.GET("/:address/tier-progress", userHandler.GetTierProgress)
+			users.GET("/:address/weekly-snapshots", userHandler.GetWeeklySnapshots)
+			users.GET("/:address/streak", userHandler.GetReleaseStreak)
+			users.GET("/:address/timeline", userHandler.GetTimeline)
+			users.GET("/:address/export", handlers.RequireOwner(cfg.JWT.Secret), userHandler.ExportUserData)
 		}
 
 		// Dashboard routes (PoC)
@@ -197,23 +322,34 @@ func main() {
 			dashboard.GET("/viral-performance", dashboardHandler.GetViralPerformance)
 			dashboard.GET("/weekly-progress", dashboardHandler.GetWeeklyProgress)
 			dashboard.GET("/royalty-pulse", dashboardHandler.GetRoyaltyPulse)
+			dashboard.GET("/engagement-ratios", dashboardHandler.GetEngagementRatios)
+			dashboard.GET("/all", dashboardHandler.GetAll)
 		}
 
 		// Analytics routes (PoC)
-		analytics := v1.Group("/analytics")
+		analytics := v1.Group("/analytics", middleware.CacheControl(cfg.Cache.AnalyticsMaxAge))
 		{
 			analytics.GET("/:tokenId/platform-stats", analyticsHandler.GetPlatformStats)
+			analytics.GET("/:tokenId/platform-stats/history", analyticsHandler.GetPlatformStatsHistory)
 			analytics.GET("/:tokenId/viral-score", analyticsHandler.GetViralScore)
 			analytics.GET("/:tokenId/growth", analyticsHandler.GetGrowthMetrics)
 			analytics.GET("/:tokenId/listeners", analyticsHandler.GetListenerMetrics)
 			analytics.GET("/:tokenId/views", analyticsHandler.GetViewMetrics)
 			analytics.GET("/:tokenId/trending", analyticsHandler.GetTrendingIndicators)
 			analytics.GET("/:tokenId/reach", analyticsHandler.GetEstimatedReach)
+			analytics.GET("/:tokenId/reach-trend", analyticsHandler.GetReachTrend)
+			analytics.GET("/:tokenId/projected-royalties", analyticsHandler.GetProjectedRoyalties)
+			analytics.GET("/:tokenId/royalty-efficiency", analyticsHandler.GetRoyaltyEfficiency)
+			analytics.GET("/:tokenId/momentum", analyticsHandler.GetMomentum)
 			analytics.GET("/global/top-songs", analyticsHandler.GetTopSongs)
+			analytics.GET("/trending-feed", analyticsHandler.GetTrendingFeed)
+			analytics.POST("/batch", analyticsHandler.GetBatchAnalytics)
+			analytics.GET("/compare", analyticsHandler.CompareTracks)
+			analytics.GET("/overlap", analyticsHandler.GetListenerOverlap)
 		}
 
 		// Wallet routes (PoC)
-		wallet := v1.Group("/wallet")
+		wallet := v1.Group("/wallet", handlers.ResolveAddressParam(addressResolver))
 		{
 			wallet.GET("/:address/transactions", walletHandler.GetTransactions)
 			wallet.GET("/:address/balance", walletHandler.GetBalance)
@@ -225,17 +361,25 @@ func main() {
 		leaderboard := v1.Group("/leaderboard")
 		{
 			leaderboard.GET("/top-artists", leaderboardHandler.GetTopArtists)
+			leaderboard.GET("/top-investors", leaderboardHandler.GetTopInvestors)
 			leaderboard.GET("/:address/rank", leaderboardHandler.GetUserRank)
+			leaderboard.GET("/:address/movement", leaderboardHandler.GetRankMovement)
 			leaderboard.GET("/stats", leaderboardHandler.GetLeaderboardStats)
 		}
 
 		// Portfolio routes (PoC)
-		portfolio := v1.Group("/portfolio")
+		portfolio := v1.Group("/portfolio", handlers.ResolveAddressParam(addressResolver))
 		{
 			portfolio.GET("/:address", portfolioHandler.GetPortfolio)
 			portfolio.GET("/:address/growth", portfolioHandler.GetGrowthStats)
 			portfolio.GET("/:address/performance", portfolioHandler.GetPerformanceMetrics)
 			portfolio.GET("/:address/pools", portfolioHandler.GetPoolsInvested)
+			portfolio.GET("/:address/diversification", portfolioHandler.GetDiversification)
+			portfolio.GET("/:address/lockups", portfolioHandler.GetLockups)
+			portfolio.GET("/:address/reinvested-pools", portfolioHandler.GetReinvestedPools)
+			portfolio.GET("/:address/watchlist", portfolioHandler.GetWatchlist)
+			portfolio.POST("/:address/watchlist", handlers.RequireOwner(cfg.JWT.Secret), portfolioHandler.AddToWatchlist)
+			portfolio.DELETE("/:address/watchlist", handlers.RequireOwner(cfg.JWT.Secret), portfolioHandler.RemoveFromWatchlist)
 		}
 
 		// Distribution routes
@@ -243,9 +387,14 @@ func main() {
 		{
 			distribution.POST("/submit", distributionHandler.SubmitDistribution)
 			distribution.GET("/:tokenId/status", distributionHandler.GetDistributionStatus)
+			distribution.GET("/:tokenId/timeline", distributionHandler.GetTimeline)
+			distribution.POST("/:tokenId/platform", handlers.RequireRole(cfg.JWT.Secret, auth.RoleUser, auth.RoleAdmin, auth.RoleService), distributionHandler.AddPlatform)
 			distribution.GET("/:tokenId/platform/:platform", distributionHandler.GetPlatformStatus)
 			distribution.PUT("/:tokenId/platform/:platform", distributionHandler.UpdatePlatformStatus)
+			distribution.POST("/:tokenId/platform/:platform/retry", handlers.RequireRole(cfg.JWT.Secret, auth.RoleUser, auth.RoleAdmin, auth.RoleService), distributionHandler.RetryPlatformDistribution)
 			distribution.GET("/list", distributionHandler.ListDistributions)
+			distribution.GET("/platforms", distributionHandler.GetPlatforms)
+			distribution.GET("/pending-tracks", distributionHandler.ListPendingTracks)
 		}
 
 		// Notification routes
@@ -253,22 +402,40 @@ func main() {
 		{
 			notifications.GET("", notificationHandler.GetNotifications)
 			notifications.GET("/unread/count", notificationHandler.GetUnreadCount)
+			notifications.GET("/stats", notificationHandler.GetStats)
 			notifications.PUT("/:id/read", notificationHandler.MarkAsRead)
 			notifications.PUT("/read-all", notificationHandler.MarkAllAsRead)
+			notifications.PUT("/read-by-type", handlers.RequireAuth(cfg.JWT.Secret), notificationHandler.MarkTypeAsRead)
 			notifications.DELETE("/:id", notificationHandler.DeleteNotification)
 			notifications.GET("/preferences", notificationHandler.GetPreferences)
 			notifications.PUT("/preferences", notificationHandler.UpdatePreferences)
+			notifications.GET("/preferences/history", notificationHandler.GetPreferenceHistory)
 		}
 
 		// Ledger routes
 		ledger := v1.Group("/ledger")
 		{
 			ledger.GET("/:tokenId/splits", ledgerHandler.GetSplitHistory)
+			ledger.GET("/:tokenId/splits/export", ledgerHandler.ExportSplits)
 			ledger.GET("/:tokenId/contributors", ledgerHandler.GetContributorBreakdown)
+			ledger.GET("/:tokenId/fairness", ledgerHandler.GetFairness)
 			ledger.GET("/audit/:txHash", ledgerHandler.GetSplitByTxHash)
+			ledger.GET("/distribution/:id/split", ledgerHandler.GetDistributionSplit)
 			ledger.GET("/user/:address", ledgerHandler.GetUserLedger)
 		}
 
+		// Platform-wide activity feed
+		v1.GET("/activities/global", dashboardHandler.GetGlobalActivities)
+
+		// UI context: prices, gas and chain status
+		v1.GET("/context", blockchainHandler.GetContext)
+
+		// Blockchain routes
+		chain := v1.Group("/blockchain")
+		{
+			chain.GET("/estimate-fee", blockchainHandler.EstimateFee)
+		}
+
 		// Audit routes
 		audit := v1.Group("/audit")
 		{
@@ -281,10 +448,35 @@ func main() {
 		reinvest := v1.Group("/reinvest")
 		{
 			reinvest.GET("/suggestions", reinvestmentHandler.GetSuggestions)
+			reinvest.POST("/suggestions/:id/dismiss", handlers.RequireAuth(cfg.JWT.Secret), reinvestmentHandler.DismissSuggestion)
 			reinvest.POST("/quick", reinvestmentHandler.QuickReinvest)
+			reinvest.POST("/batch", handlers.RequireAuth(cfg.JWT.Secret), reinvestmentHandler.BatchReinvest)
 			reinvest.GET("/history", reinvestmentHandler.GetHistory)
 			reinvest.GET("/stats", reinvestmentHandler.GetStats)
 		}
+
+		// Usage detection routes (bearer token with service or admin role)
+		usage := v1.Group("/usage", handlers.RequireRole(cfg.JWT.Secret, auth.RoleService, auth.RoleAdmin))
+		{
+			usage.POST("/detect", usageHandler.IngestDetections)
+		}
+
+		// Content reports (bearer token; the reporter is the token subject)
+		v1.POST("/reports", handlers.RequireAuth(cfg.JWT.Secret), reportHandler.CreateReport)
+
+		// Admin routes (bearer token with admin role)
+		admin := v1.Group("/admin", handlers.RequireRole(cfg.JWT.Secret, auth.RoleAdmin))
+		{
+			admin.GET("/stats/savings", adminHandler.GetSavingsStats)
+			admin.PATCH("/campaigns/:id/trending", adminHandler.SetCampaignTrending)
+			admin.POST("/backfill-analytics", adminHandler.BackfillAnalytics)
+			admin.GET("/distributions", adminHandler.ListDistributions)
+			admin.GET("/reports", adminHandler.ListReports)
+			admin.POST("/reports/:id/resolve", adminHandler.ResolveReport)
+			admin.GET("/webhooks/deliveries", adminHandler.ListWebhookDeliveries)
+			admin.POST("/webhooks/:id/replay", adminHandler.ReplayWebhook)
+			admin.GET("/near-duplicates", adminHandler.GetNearDuplicates)
+		}
 	}
 
 	// Start server
@@ -293,31 +485,42 @@ func main() {
 		port = "8080"
 	}
 
-	log.Printf("🚀 TuneCent Backend API starting on port %s", port)
-	log.Printf("📊 Total endpoints: 68")
-	log.Printf("✅ Music endpoints: 4")
-	log.Printf("✅ Campaign endpoints: 4")
-	log.Printf("✅ Royalty endpoints: 2")
-	log.Printf("✅ User endpoints: 2")
-	log.Printf("✅ Dashboard endpoints: 8")
-	log.Printf("✅ Analytics endpoints: 8")
-	log.Printf("✅ Wallet endpoints: 4")
-	log.Printf("✅ Leaderboard endpoints: 3")
-	log.Printf("✅ Portfolio endpoints: 4")
-	log.Printf("✅ Distribution endpoints: 5")
-	log.Printf("✅ Notification endpoints: 7")
-	log.Printf("✅ Ledger endpoints: 4")
-	log.Printf("✅ Audit endpoints: 3")
-	log.Printf("✅ Reinvestment endpoints: 4")
-	log.Printf("🎯 PoC Mode: Using mock data for platform stats")
-	log.Printf("🆕 New Features: Distribution Hub, Notifications, Split Ledger, Audit Tools, Reinvestment")
+	slog.Info("TuneCent Backend API starting",
+		"port", port,
+		"mode", "poc",
+		slog.Group("endpoints",
+			"total", 155,
+			"music", 11,
+			"campaigns", 17,
+			"royalties", 7,
+			"users", 17,
+			"dashboard", 10,
+			"analytics", 17,
+			"wallet", 4,
+			"leaderboard", 5,
+			"portfolio", 10,
+			"distribution", 10,
+			"notifications", 10,
+			"ledger", 7,
+			"blockchain", 1,
+			"context", 1,
+			"activities", 1,
+			"audit", 3,
+			"reinvestment", 6,
+			"usage", 1,
+			"reports", 1,
+			"admin", 9,
+			"ipfs", 1,
+		),
+	)
 
 	if err := r.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+		slog.Error("Failed to start server", "error", err)
+		os.Exit(1)
 	}
 }
 
-func initDB() (*gorm.DB, error) {
+func initDB(appLogger *slog.Logger) (*gorm.DB, error) {
 	// Get database credentials from environment
 	dbUser := os.Getenv("DB_USER")
 	if dbUser == "" {
@@ -344,21 +547,26 @@ func initDB() (*gorm.DB, error) {
 		dbName = "tunecent_db"
 	}
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
 		dbUser, dbPassword, dbHost, dbPort, dbName,
 	)
 
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
+		Logger: logger.NewGormLogger(appLogger),
+		NowFunc: func() time.Time {
+			return time.Now().UTC()
+		},
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	log.Println("✅ Database connected successfully")
+	slog.Info("Database connected successfully")
 	return db, nil
 }
 
 func runMigrations(db *gorm.DB) error {
-	log.Println("🔄 Running database migrations...")
+	slog.Info("Running database migrations")
 
 	err := db.AutoMigrate(
 		&models.User{},
@@ -370,21 +578,30 @@ func runMigrations(db *gorm.DB) error {
 		&models.UsageDetection{},
 		&models.Analytics{},
 		&models.Transaction{},
+		&models.ContributionAdjustment{},
+		&models.Watchlist{},
 		&models.Activity{},
 		&models.DistributionSubmission{},
 		&models.PlatformDistribution{},
+		&models.DistributionEvent{},
 		&models.Notification{},
 		&models.NotificationPreference{},
+		&models.PreferenceChangeLog{},
 		&models.SplitRecord{},
 		&models.ReinvestmentSuggestion{},
 		&models.ReinvestmentHistory{},
+		&models.RankSnapshot{},
+		&models.CreatorWeeklySnapshot{},
+		&models.OutboxEvent{},
+		&models.StorageUsage{},
+		&models.Report{},
 	)
 
 	if err != nil {
 		return err
 	}
 
-	log.Println("✅ Migrations completed successfully")
+	slog.Info("Migrations completed successfully")
 	return nil
 }
 
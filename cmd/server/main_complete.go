@@ -1,13 +1,19 @@
 package main
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"os"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
 	"github.com/tunecent/backend/internal/blockchain"
 	"github.com/tunecent/backend/internal/config"
 	"github.com/tunecent/backend/internal/database"
 	"github.com/tunecent/backend/internal/handlers"
+	"github.com/tunecent/backend/internal/logger"
+	"github.com/tunecent/backend/internal/middleware"
 	"github.com/tunecent/backend/internal/services"
 	"github.com/tunecent/backend/pkg/fingerprint"
 	"github.com/tunecent/backend/pkg/ipfs"
@@ -17,61 +23,106 @@ func main() {
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatal("Failed to load configuration:", err)
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
 	}
 
-	log.Printf("Starting TuneCent Backend API v1.0.0-poc in %s mode", cfg.Server.Env)
+	appLogger, err := logger.New(os.Stdout, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		slog.Error("Invalid logging configuration", "error", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(appLogger)
+
+	slog.Info("Starting TuneCent Backend API", "version", "1.0.0-poc", "env", cfg.Server.Env)
 
 	// Initialize database
 	db, err := database.New(cfg)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		slog.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
 	}
 	defer db.Close()
 
 	// Run migrations
 	if err := db.Migrate(); err != nil {
-		log.Fatal("Failed to run migrations:", err)
+		slog.Error("Failed to run migrations", "error", err)
+		os.Exit(1)
 	}
 
 	// Initialize blockchain client (optional for PoC without contract addresses)
 	var blockchainClient *blockchain.Client
 	var blockchainService *blockchain.Service
+	var ensResolver services.ENSResolver
+	var gasOracle services.GasOracle
 	if cfg.Blockchain.MusicRegistryAddress != "" {
 		blockchainClient, err = blockchain.NewClient(cfg)
 		if err != nil {
-			log.Printf("Warning: Failed to connect to blockchain: %v", err)
-			log.Println("Continuing in database-only mode")
+			slog.Warn("Failed to connect to blockchain, continuing in database-only mode", "error", err)
 		} else {
 			blockchainService = blockchain.NewService(blockchainClient)
+			ensResolver = blockchainClient
+			gasOracle = blockchainClient.GetClient()
 			defer blockchainClient.Close()
-			log.Println("Blockchain client connected successfully")
+			slog.Info("Blockchain client connected successfully")
 		}
 	} else {
-		log.Println("No blockchain addresses configured, running in database-only mode")
+		slog.Info("No blockchain addresses configured, running in database-only mode")
 	}
 
 	// Initialize services
 	ipfsService := ipfs.NewService(cfg)
-	fingerprintService := fingerprint.NewService()
+	fingerprintAlgorithm, err := fingerprint.NewAlgorithm(cfg.Fingerprint.Algorithm, cfg.Fingerprint.Command, cfg.Fingerprint.Timeout)
+	if err != nil {
+		slog.Error("Invalid fingerprint configuration", "error", err)
+		os.Exit(1)
+	}
+	fingerprintService := fingerprint.NewService(fingerprintAlgorithm)
+	slog.Info("Audio fingerprinting configured", "algorithm", fingerprintService.Algorithm())
 
 	// Initialize business logic services
 	musicService := services.NewMusicService(db, ipfsService, fingerprintService, blockchainService)
+	musicService.SetStorageQuota(cfg.Upload.CreatorQuotaBytes)
+	royaltyService := services.NewRoyaltyService(db)
+	recommendationService := services.NewRecommendationService(db)
+	usageService := services.NewUsageService(db)
+	outboxWorker := services.NewOutboxWorker(db, cfg.Webhook)
+	addressResolver := services.NewAddressResolver(ensResolver, cfg.Blockchain.ENSCacheTTL)
+	feeEstimator := services.NewFeeEstimator(gasOracle, map[string]common.Address{
+		services.ContractRoyaltyDistributor: common.HexToAddress(cfg.Blockchain.RoyaltyDistributorAddress),
+	}, services.GasPriceCacheTTL)
+
+	// Background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go outboxWorker.Run(jobsCtx, services.OutboxPollInterval)
 
 	// Initialize handlers
-	musicHandler := handlers.NewMusicHandler(musicService)
+	handlers.SetPageSizes(cfg.Pagination.DefaultPageSize, cfg.Pagination.MaxPageSize)
+	musicHandler := handlers.NewMusicHandler(musicService, cfg.Upload, cfg.JWT.Secret)
 	campaignHandler := handlers.NewCampaignHandler(db)
-	royaltyHandler := handlers.NewRoyaltyHandler(db)
+	royaltyHandler := handlers.NewRoyaltyHandler(db, royaltyService, feeEstimator, cfg.Fees)
 	userHandler := handlers.NewUserHandler(db)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService)
+	usageHandler := handlers.NewUsageHandler(usageService)
 
 	// Setup Gin
 	if cfg.Server.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	r := gin.Default()
+	r := gin.New()
 
 	// Middleware
+	r.Use(logger.RequestLogger(appLogger))
+	r.Use(gin.Recovery())
+
+	gzipMiddleware, err := middleware.Gzip(cfg.Gzip.Level, cfg.Gzip.MinSize)
+	if err != nil {
+		slog.Error("Invalid compression configuration", "error", err)
+		os.Exit(1)
+	}
+	r.Use(gzipMiddleware)
 	r.Use(CORSMiddleware())
 
 	// Health check
@@ -101,44 +152,90 @@ func main() {
 		// Music routes
 		music := v1.Group("/music")
 		{
-			music.POST("/register", musicHandler.RegisterMusic)
-			music.GET("/:tokenId", musicHandler.GetMusic)
+			music.POST("/register", handlers.RequireAuth(cfg.JWT.Secret), musicHandler.RegisterMusic)
+			music.POST("/upload-url", handlers.RequireAuth(cfg.JWT.Secret), musicHandler.CreateUploadURL)
+			music.POST("/check-fingerprint", musicHandler.CheckFingerprint)
+			music.GET("/:tokenId", middleware.CacheControl(cfg.Cache.MetadataMaxAge), musicHandler.GetMusic)
 			music.GET("/", musicHandler.ListMusic)
 			music.GET("/:tokenId/analytics", musicHandler.GetMusicAnalytics)
+			music.GET("/:tokenId/features", musicHandler.GetMusicFeatures)
+			music.GET("/:tokenId/links", musicHandler.GetMusicLinks)
+			music.GET("/:tokenId/provenance", musicHandler.GetMusicProvenance)
+			music.POST("/:tokenId/cover", handlers.RequireAuth(cfg.JWT.Secret), musicHandler.UpdateCoverArt)
+			music.GET("/:tokenId/usage", usageHandler.GetTrackUsage)
 		}
 
+		// IPFS proxy for content referenced by tracks and profiles
+		v1.GET("/ipfs/:cid", musicHandler.GetIPFSContent)
+
 		// Campaign routes
 		campaigns := v1.Group("/campaigns")
 		{
 			campaigns.POST("/", campaignHandler.CreateCampaign)
+			campaigns.GET("/recommended", recommendationHandler.GetRecommendedCampaigns)
+			campaigns.GET("/trending", campaignHandler.GetTrendingCampaigns)
+			campaigns.GET("/ending-soon", campaignHandler.GetEndingSoon)
 			campaigns.GET("/:campaignId", campaignHandler.GetCampaign)
+			campaigns.GET("/:campaignId/risk-breakdown", campaignHandler.GetRiskBreakdown)
+			campaigns.GET("/:campaignId/funding-forecast", campaignHandler.GetFundingForecast)
+			campaigns.GET("/:campaignId/analytics", campaignHandler.GetCampaignAnalytics)
+			campaigns.GET("/:campaignId/contribution-stats", campaignHandler.GetContributionStats)
+			campaigns.GET("/:campaignId/break-even", campaignHandler.GetBreakEven)
+			campaigns.GET("/:campaignId/share-preview", campaignHandler.GetSharePreview)
+			campaigns.PATCH("/:campaignId", handlers.RequireAuth(cfg.JWT.Secret), campaignHandler.UpdateCampaign)
+			campaigns.POST("/:campaignId/cancel", handlers.RequireAuth(cfg.JWT.Secret), campaignHandler.CancelCampaign)
 			campaigns.GET("/", campaignHandler.ListCampaigns)
 			campaigns.POST("/:campaignId/contribute", campaignHandler.Contribute)
+			campaigns.POST("/:campaignId/recompute-shares", handlers.RequireAuth(cfg.JWT.Secret), campaignHandler.RecomputeShares)
+			campaigns.POST("/:campaignId/adjust", handlers.RequireRole(cfg.JWT.Secret, auth.RoleAdmin), campaignHandler.AdjustContribution)
 		}
 
 		// Royalty routes
 		royalties := v1.Group("/royalties")
 		{
 			royalties.GET("/token/:tokenId", royaltyHandler.GetRoyalties)
+			royalties.GET("/token/:tokenId/pending", royaltyHandler.GetPendingRoyalties)
+			royalties.GET("/token/:tokenId/my-cut", royaltyHandler.GetMyCut)
+			royalties.GET("/payment/:paymentId/distributions", royaltyHandler.GetPaymentDistributions)
 			royalties.POST("/simulate", royaltyHandler.SimulateRoyaltyPayment)
+			royalties.POST("/:paymentId/distribute", handlers.RequireRole(cfg.JWT.Secret, auth.RoleUser, auth.RoleAdmin, auth.RoleService), royaltyHandler.DistributePayment)
+			royalties.GET("/:paymentId/fee-estimate", royaltyHandler.GetFeeEstimate)
 		}
 
 		// User/Reputation routes
-		users := v1.Group("/users")
+		users := v1.Group("/users", handlers.ResolveAddressParam(addressResolver))
 		{
 			users.GET("/:address", userHandler.GetUserProfile)
 			users.GET("/:address/reputation", userHandler.GetReputation)
+			users.GET("/:address/pending-royalties", userHandler.GetPendingRoyalties)
+			users.GET("/:address/pending-actions", userHandler.GetPendingActions)
+			users.GET("/:address/storage", musicHandler.GetStorageQuota)
+			users.GET("/:address/avg-royalty", userHandler.GetAverageRoyalty)
+			users.GET("/:address/campaign-track-record", userHandler.GetCampaignTrackRecord)
+			users.GET("/:address/avg-time-to-fund", userHandler.GetAvgTimeToFund)
+			users.GET("/:address/audience-growth", userHandler.GetAudienceGrowth)
+			users.GET("/:address/genre-breakdown", userHandler.GetGenreBreakdown)
+			users.GET("/:address/earning-tokens", userHandler.GetEarningTokens)
+			users.GET("/:address/earnings-concentration", userHandler.GetEarningsConcentration)
+			users.GET("/:address/tier-progress", userHandler.GetTierProgress)
+			users.GET("/:address/weekly-snapshots", userHandler.GetWeeklySnapshots)
+			users.GET("/:address/streak", userHandler.GetReleaseStreak)
+			users.GET("/:address/timeline", userHandler.GetTimeline)
+			users.GET("/:address/export", handlers.RequireOwner(cfg.JWT.Secret), userHandler.ExportUserData)
 		}
 	}
 
 	// Start server
 	addr := ":" + cfg.Server.Port
-	log.Printf("🚀 Server listening on %s", addr)
-	log.Printf("📊 Health check: http://localhost%s/health", addr)
-	log.Printf("📝 API docs: http://localhost%s/api/v1", addr)
+	slog.Info("Server listening",
+		"addr", addr,
+		"health_check", "http://localhost"+addr+"/health",
+		"api_docs", "http://localhost"+addr+"/api/v1",
+	)
 
 	if err := r.Run(addr); err != nil {
-		log.Fatal("Failed to start server:", err)
+		slog.Error("Failed to start server", "error", err)
+		os.Exit(1)
 	}
 }
 
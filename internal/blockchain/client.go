@@ -17,6 +17,7 @@ type Client struct {
 	royaltyDistributorAddress common.Address
 	crowdfundingPoolAddress   common.Address
 	reputationScoreAddress    common.Address
+	ensRegistryAddress        common.Address
 }
 
 func NewClient(cfg *config.Config) (*Client, error) {
@@ -42,6 +43,7 @@ func NewClient(cfg *config.Config) (*Client, error) {
 		royaltyDistributorAddress: common.HexToAddress(cfg.Blockchain.RoyaltyDistributorAddress),
 		crowdfundingPoolAddress:   common.HexToAddress(cfg.Blockchain.CrowdfundingPoolAddress),
 		reputationScoreAddress:    common.HexToAddress(cfg.Blockchain.ReputationScoreAddress),
+		ensRegistryAddress:        common.HexToAddress(cfg.Blockchain.ENSRegistryAddress),
 	}, nil
 }
 
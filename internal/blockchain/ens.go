@@ -0,0 +1,65 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Function selectors for the ENS registry and public resolver
+var (
+	ensResolverSelector = common.FromHex("0x0178b8bf") // resolver(bytes32)
+	ensAddrSelector     = common.FromHex("0x3b3b57de") // addr(bytes32)
+)
+
+// ResolveENS resolves an ENS name (e.g. "artist.eth") to its address by
+// looking up the name's resolver in the ENS registry and calling addr() on it
+func (c *Client) ResolveENS(ctx context.Context, name string) (common.Address, error) {
+	node := NameHash(name)
+
+	resolverData, err := c.client.CallContract(ctx, ethereum.CallMsg{
+		To:   &c.ensRegistryAddress,
+		Data: append(append([]byte{}, ensResolverSelector...), node[:]...),
+	}, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to query ENS registry: %w", err)
+	}
+	resolver := common.BytesToAddress(resolverData)
+	if len(resolverData) < 32 || resolver == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("no resolver set for %s", name)
+	}
+
+	addrData, err := c.client.CallContract(ctx, ethereum.CallMsg{
+		To:   &resolver,
+		Data: append(append([]byte{}, ensAddrSelector...), node[:]...),
+	}, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to query ENS resolver: %w", err)
+	}
+	addr := common.BytesToAddress(addrData)
+	if len(addrData) < 32 || addr == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("%s does not resolve to an address", name)
+	}
+
+	return addr, nil
+}
+
+// NameHash computes the EIP-137 namehash of an ENS name
+func NameHash(name string) common.Hash {
+	var node common.Hash
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := crypto.Keccak256([]byte(labels[i]))
+		node = common.BytesToHash(crypto.Keccak256(node[:], labelHash))
+	}
+	return node
+}
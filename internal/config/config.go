@@ -1,20 +1,31 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server     ServerConfig
-	Database   DatabaseConfig
-	Blockchain BlockchainConfig
-	IPFS       IPFSConfig
-	JWT        JWTConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	Blockchain  BlockchainConfig
+	IPFS        IPFSConfig
+	JWT         JWTConfig
+	Log         LogConfig
+	Gzip        GzipConfig
+	Upload      UploadConfig
+	Webhook     WebhookConfig
+	Cache       CacheConfig
+	Pagination  PaginationConfig
+	Fingerprint FingerprintConfig
+	Fees        FeeConfig
 }
 
 type ServerConfig struct {
@@ -37,22 +48,79 @@ type BlockchainConfig struct {
 	RoyaltyDistributorAddress string
 	CrowdfundingPoolAddress   string
 	ReputationScoreAddress    string
+	ENSRegistryAddress        string
+	ENSCacheTTL               time.Duration
 }
 
 type IPFSConfig struct {
-	Gateway       string
-	PinataAPIKey  string
-	PinataSecret  string
+	Gateways       []string      // tried in order when fetching content
+	GatewayTimeout time.Duration // per-gateway request timeout
+	PinataAPIKey   string
+	PinataSecret   string
+	PinataJWT      string // required for signed direct upload URLs
+}
+
+// LogConfig selects the slog level (debug, info, warn, error) and format (text, json)
+type LogConfig struct {
+	Level  string
+	Format string
+}
+
+// GzipConfig controls response compression; Level follows compress/gzip (-2..9)
+type GzipConfig struct {
+	Level   int
+	MinSize int // bytes; smaller responses are sent uncompressed
 }
 
 type JWTConfig struct {
 	Secret string
 }
 
+// WebhookConfig is where outbox webhook events are POSTed; an empty URL disables them
+type WebhookConfig struct {
+	URL     string
+	Secret  string // HMAC-SHA256 key for the X-TuneCent-Signature header
+	Timeout time.Duration
+}
+
+// CacheConfig sets the Cache-Control max-age of cacheable GET endpoints; 0 sends no-cache
+type CacheConfig struct {
+	AnalyticsMaxAge time.Duration // short: analytics are recomputed frequently
+	MetadataMaxAge  time.Duration // long: registered track metadata rarely changes
+}
+
+// PaginationConfig sets the page size of list endpoints that are not given a
+// limit, and the largest limit a request may ask for
+type PaginationConfig struct {
+	DefaultPageSize int
+	MaxPageSize     int
+}
+
+// FingerprintConfig selects the audio fingerprint algorithm: sha256 (mock)
+// or external, which runs Command with the audio on stdin
+type FingerprintConfig struct {
+	Algorithm string
+	Command   string
+	Timeout   time.Duration
+}
+
+// FeeConfig is the share of each royalty payment the platform keeps and the
+// discount on that fee stakers receive, both in basis points
+type FeeConfig struct {
+	PlatformFeeBasisPoints     uint16
+	StakingDiscountBasisPoints uint16
+}
+
+type UploadConfig struct {
+	MaxAudioBytes     int64
+	URLTTL            time.Duration // lifetime of signed direct upload URLs and upload tokens
+	CreatorQuotaBytes int64         // total audio a creator may upload; 0 = unlimited
+}
+
 func Load() (*Config, error) {
 	// Load .env file if it exists
 	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using environment variables")
+		slog.Info("No .env file found, using environment variables")
 	}
 
 	chainID, err := strconv.ParseInt(getEnv("CHAIN_ID", "84532"), 10, 64)
@@ -60,6 +128,87 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid CHAIN_ID: %w", err)
 	}
 
+	maxAudioMB, err := strconv.ParseInt(getEnv("MAX_AUDIO_SIZE_MB", "30"), 10, 64)
+	if err != nil || maxAudioMB <= 0 {
+		return nil, fmt.Errorf("invalid MAX_AUDIO_SIZE_MB: %q", os.Getenv("MAX_AUDIO_SIZE_MB"))
+	}
+
+	// Tokens signed with a guessable key could be forged, so there is no default
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		return nil, errors.New("JWT_SECRET must be set")
+	}
+
+	ensCacheTTL, err := time.ParseDuration(getEnv("ENS_CACHE_TTL", "10m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ENS_CACHE_TTL: %w", err)
+	}
+
+	gatewayTimeout, err := time.ParseDuration(getEnv("IPFS_GATEWAY_TIMEOUT", "10s"))
+	if err != nil || gatewayTimeout <= 0 {
+		return nil, fmt.Errorf("invalid IPFS_GATEWAY_TIMEOUT: %q", os.Getenv("IPFS_GATEWAY_TIMEOUT"))
+	}
+
+	gzipLevel, err := strconv.Atoi(getEnv("GZIP_LEVEL", "-1"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid GZIP_LEVEL: %w", err)
+	}
+
+	gzipMinSize, err := strconv.Atoi(getEnv("GZIP_MIN_SIZE", "1024"))
+	if err != nil || gzipMinSize < 0 {
+		return nil, fmt.Errorf("invalid GZIP_MIN_SIZE: %q", os.Getenv("GZIP_MIN_SIZE"))
+	}
+
+	uploadURLTTL, err := time.ParseDuration(getEnv("UPLOAD_URL_TTL", "15m"))
+	if err != nil || uploadURLTTL <= 0 {
+		return nil, fmt.Errorf("invalid UPLOAD_URL_TTL: %q", os.Getenv("UPLOAD_URL_TTL"))
+	}
+
+	storageQuotaMB, err := strconv.ParseInt(getEnv("STORAGE_QUOTA_MB", "1024"), 10, 64)
+	if err != nil || storageQuotaMB < 0 {
+		return nil, fmt.Errorf("invalid STORAGE_QUOTA_MB: %q", os.Getenv("STORAGE_QUOTA_MB"))
+	}
+
+	webhookTimeout, err := time.ParseDuration(getEnv("WEBHOOK_TIMEOUT", "10s"))
+	if err != nil || webhookTimeout <= 0 {
+		return nil, fmt.Errorf("invalid WEBHOOK_TIMEOUT: %q", os.Getenv("WEBHOOK_TIMEOUT"))
+	}
+
+	cacheAnalyticsMaxAge, err := time.ParseDuration(getEnv("CACHE_ANALYTICS_MAX_AGE", "60s"))
+	if err != nil || cacheAnalyticsMaxAge < 0 {
+		return nil, fmt.Errorf("invalid CACHE_ANALYTICS_MAX_AGE: %q", os.Getenv("CACHE_ANALYTICS_MAX_AGE"))
+	}
+
+	cacheMetadataMaxAge, err := time.ParseDuration(getEnv("CACHE_METADATA_MAX_AGE", "1h"))
+	if err != nil || cacheMetadataMaxAge < 0 {
+		return nil, fmt.Errorf("invalid CACHE_METADATA_MAX_AGE: %q", os.Getenv("CACHE_METADATA_MAX_AGE"))
+	}
+
+	defaultPageSize, err := strconv.Atoi(getEnv("PAGE_SIZE_DEFAULT", "20"))
+	if err != nil || defaultPageSize <= 0 {
+		return nil, fmt.Errorf("invalid PAGE_SIZE_DEFAULT: %q", os.Getenv("PAGE_SIZE_DEFAULT"))
+	}
+
+	maxPageSize, err := strconv.Atoi(getEnv("PAGE_SIZE_MAX", "100"))
+	if err != nil || maxPageSize < defaultPageSize {
+		return nil, fmt.Errorf("invalid PAGE_SIZE_MAX: %q (must be at least PAGE_SIZE_DEFAULT)", os.Getenv("PAGE_SIZE_MAX"))
+	}
+
+	fingerprintTimeout, err := time.ParseDuration(getEnv("FINGERPRINT_TIMEOUT", "30s"))
+	if err != nil || fingerprintTimeout <= 0 {
+		return nil, fmt.Errorf("invalid FINGERPRINT_TIMEOUT: %q", os.Getenv("FINGERPRINT_TIMEOUT"))
+	}
+
+	platformFeeBps, err := strconv.ParseUint(getEnv("PLATFORM_FEE_BPS", "1000"), 10, 16)
+	if err != nil || platformFeeBps > 10000 {
+		return nil, fmt.Errorf("invalid PLATFORM_FEE_BPS: %q (must be 0-10000)", os.Getenv("PLATFORM_FEE_BPS"))
+	}
+
+	stakingDiscountBps, err := strconv.ParseUint(getEnv("STAKING_DISCOUNT_BPS", "1000"), 10, 16)
+	if err != nil || stakingDiscountBps > 10000 {
+		return nil, fmt.Errorf("invalid STAKING_DISCOUNT_BPS: %q (must be 0-10000)", os.Getenv("STAKING_DISCOUNT_BPS"))
+	}
+
 	config := &Config{
 		Server: ServerConfig{
 			Port: getEnv("PORT", "8080"),
@@ -79,14 +228,53 @@ func Load() (*Config, error) {
 			RoyaltyDistributorAddress: getEnv("ROYALTY_DISTRIBUTOR_ADDRESS", ""),
 			CrowdfundingPoolAddress:   getEnv("CROWDFUNDING_POOL_ADDRESS", ""),
 			ReputationScoreAddress:    getEnv("REPUTATION_SCORE_ADDRESS", ""),
+			ENSRegistryAddress:        getEnv("ENS_REGISTRY_ADDRESS", "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"),
+			ENSCacheTTL:               ensCacheTTL,
 		},
 		IPFS: IPFSConfig{
-			Gateway:      getEnv("IPFS_GATEWAY", "https://gateway.pinata.cloud/ipfs/"),
-			PinataAPIKey: getEnv("PINATA_API_KEY", ""),
-			PinataSecret: getEnv("PINATA_SECRET_KEY", ""),
+			Gateways:       parseGateways(getEnv("IPFS_GATEWAY", "https://gateway.pinata.cloud/ipfs/")),
+			GatewayTimeout: gatewayTimeout,
+			PinataAPIKey:   getEnv("PINATA_API_KEY", ""),
+			PinataSecret:   getEnv("PINATA_SECRET_KEY", ""),
+			PinataJWT:      getEnv("PINATA_JWT", ""),
 		},
 		JWT: JWTConfig{
-			Secret: getEnv("JWT_SECRET", "default-secret-change-in-production"),
+			Secret: jwtSecret,
+		},
+		Log: LogConfig{
+			Level:  getEnv("LOG_LEVEL", "info"),
+			Format: getEnv("LOG_FORMAT", "text"),
+		},
+		Gzip: GzipConfig{
+			Level:   gzipLevel,
+			MinSize: gzipMinSize,
+		},
+		Upload: UploadConfig{
+			MaxAudioBytes:     maxAudioMB << 20,
+			URLTTL:            uploadURLTTL,
+			CreatorQuotaBytes: storageQuotaMB << 20,
+		},
+		Webhook: WebhookConfig{
+			URL:     getEnv("WEBHOOK_URL", ""),
+			Secret:  getEnv("WEBHOOK_SECRET", ""),
+			Timeout: webhookTimeout,
+		},
+		Cache: CacheConfig{
+			AnalyticsMaxAge: cacheAnalyticsMaxAge,
+			MetadataMaxAge:  cacheMetadataMaxAge,
+		},
+		Pagination: PaginationConfig{
+			DefaultPageSize: defaultPageSize,
+			MaxPageSize:     maxPageSize,
+		},
+		Fingerprint: FingerprintConfig{
+			Algorithm: getEnv("FINGERPRINT_ALGORITHM", "sha256"),
+			Command:   getEnv("FINGERPRINT_COMMAND", ""),
+			Timeout:   fingerprintTimeout,
+		},
+		Fees: FeeConfig{
+			PlatformFeeBasisPoints:     uint16(platformFeeBps),
+			StakingDiscountBasisPoints: uint16(stakingDiscountBps),
 		},
 	}
 
@@ -94,7 +282,7 @@ func Load() (*Config, error) {
 }
 
 func (c *Config) GetDSN() string {
-	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
 		c.Database.User,
 		c.Database.Password,
 		c.Database.Host,
@@ -103,6 +291,23 @@ func (c *Config) GetDSN() string {
 	)
 }
 
+// parseGateways splits a comma-separated gateway list, making sure every
+// entry ends with a slash so a CID can be appended directly
+func parseGateways(value string) []string {
+	var gateways []string
+	for _, gateway := range strings.Split(value, ",") {
+		gateway = strings.TrimSpace(gateway)
+		if gateway == "" {
+			continue
+		}
+		if !strings.HasSuffix(gateway, "/") {
+			gateway += "/"
+		}
+		gateways = append(gateways, gateway)
+	}
+	return gateways
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGateways(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{name: "single gateway", value: "https://gateway.pinata.cloud/ipfs/", want: []string{"https://gateway.pinata.cloud/ipfs/"}},
+		{name: "fallbacks keep their order", value: "https://gateway.pinata.cloud/ipfs/,https://ipfs.io/ipfs/", want: []string{"https://gateway.pinata.cloud/ipfs/", "https://ipfs.io/ipfs/"}},
+		{name: "missing trailing slash is added", value: "https://ipfs.io/ipfs", want: []string{"https://ipfs.io/ipfs/"}},
+		{name: "spaces and empty entries are dropped", value: " https://ipfs.io/ipfs/ ,, ", want: []string{"https://ipfs.io/ipfs/"}},
+		{name: "empty", value: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseGateways(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseGateways(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadPageSizes(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultSize string
+		maxSize     string
+		wantDefault int
+		wantMax     int
+		wantErr     bool
+	}{
+		{name: "built-in defaults", wantDefault: 20, wantMax: 100},
+		{name: "configured sizes", defaultSize: "50", maxSize: "500", wantDefault: 50, wantMax: 500},
+		{name: "max equal to the default", defaultSize: "30", maxSize: "30", wantDefault: 30, wantMax: 30},
+		{name: "max below the default", defaultSize: "50", maxSize: "40", wantErr: true},
+		{name: "max below the built-in default", maxSize: "10", wantErr: true},
+		{name: "zero default", defaultSize: "0", wantErr: true},
+		{name: "non-numeric max", maxSize: "lots", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_SECRET", "test-secret")
+			t.Setenv("PAGE_SIZE_DEFAULT", tt.defaultSize)
+			t.Setenv("PAGE_SIZE_MAX", tt.maxSize)
+
+			cfg, err := Load()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if cfg.Pagination.DefaultPageSize != tt.wantDefault || cfg.Pagination.MaxPageSize != tt.wantMax {
+				t.Errorf("page sizes = %d, %d, want %d, %d", cfg.Pagination.DefaultPageSize, cfg.Pagination.MaxPageSize, tt.wantDefault, tt.wantMax)
+			}
+		})
+	}
+}
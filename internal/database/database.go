@@ -2,14 +2,14 @@ package database
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/tunecent/backend/internal/config"
+	"github.com/tunecent/backend/internal/logger"
 	"github.com/tunecent/backend/internal/models"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 type DB struct {
@@ -19,16 +19,8 @@ type DB struct {
 func New(cfg *config.Config) (*DB, error) {
 	dsn := cfg.GetDSN()
 
-	// Configure GORM logger
-	var gormLogger logger.Interface
-	if cfg.Server.Env == "development" {
-		gormLogger = logger.Default.LogMode(logger.Info)
-	} else {
-		gormLogger = logger.Default.LogMode(logger.Silent)
-	}
-
 	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: gormLogger,
+		Logger: logger.NewGormLogger(slog.Default()),
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
@@ -48,13 +40,13 @@ func New(cfg *config.Config) (*DB, error) {
 	sqlDB.SetMaxOpenConns(100)
 	sqlDB.SetConnMaxLifetime(time.Hour)
 
-	log.Println("Database connection established")
+	slog.Info("Database connection established")
 
 	return &DB{db}, nil
 }
 
 func (db *DB) Migrate() error {
-	log.Println("Running database migrations...")
+	slog.Info("Running database migrations")
 
 	err := db.AutoMigrate(
 		&models.User{},
@@ -71,7 +63,7 @@ func (db *DB) Migrate() error {
 		return fmt.Errorf("migration failed: %w", err)
 	}
 
-	log.Println("Database migrations completed successfully")
+	slog.Info("Database migrations completed successfully")
 	return nil
 }
 
@@ -0,0 +1,45 @@
+// Package dbtest provides a database.DB backed by sqlmock for handler and
+// service tests that need to assert on the SQL they issue.
+package dbtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/tunecent/backend/internal/database"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// New returns a DB whose queries are answered by the returned mock. Queries
+// are matched as regular expressions, in order, and the test fails if any
+// expectation is left unmet when it ends.
+func New(t testing.TB) (*database.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Discard,
+		NowFunc: func() time.Time {
+			return time.Now().UTC()
+		},
+	})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet SQL expectations: %v", err)
+		}
+		sqlDB.Close()
+	})
+
+	return &database.DB{DB: db}, mock
+}
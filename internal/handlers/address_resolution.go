@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// ResolveAddressParam rewrites an ENS `:address` path parameter to its hex
+// address before the route handler runs, so handlers only ever see hex addresses
+func ResolveAddressParam(resolver *services.AddressResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for i, param := range c.Params {
+			if param.Key != "address" || !services.IsENSName(param.Value) {
+				continue
+			}
+
+			resolved, err := resolver.Resolve(c.Request.Context(), param.Value)
+			if err != nil {
+				status := http.StatusBadRequest
+				if errors.Is(err, services.ErrENSNotConfigured) {
+					status = http.StatusServiceUnavailable
+				}
+				c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+				return
+			}
+
+			c.Params[i].Value = resolved
+		}
+
+		c.Next()
+	}
+}
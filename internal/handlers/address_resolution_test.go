@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// stubENS resolves "artist.eth" and fails every other name
+type stubENS struct{}
+
+func (stubENS) ResolveENS(_ context.Context, name string) (common.Address, error) {
+	if name == "artist.eth" {
+		return common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"), nil
+	}
+	return common.Address{}, errors.New("no resolver set")
+}
+
+func TestResolveAddressParam(t *testing.T) {
+	tests := []struct {
+		name        string
+		ens         services.ENSResolver
+		path        string
+		wantStatus  int
+		wantAddress string
+	}{
+		{name: "hex address passes through", ens: stubENS{}, path: "/users/0xabc", wantStatus: http.StatusOK, wantAddress: "0xabc"},
+		{name: "ens name is rewritten", ens: stubENS{}, path: "/users/artist.eth", wantStatus: http.StatusOK, wantAddress: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{name: "unresolvable ens name", ens: stubENS{}, path: "/users/nobody.eth", wantStatus: http.StatusBadRequest},
+		{name: "ens name without a blockchain client", path: "/users/artist.eth", wantStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			router := gin.New()
+			router.GET("/users/:address", ResolveAddressParam(services.NewAddressResolver(tt.ens, time.Minute)), func(c *gin.Context) {
+				got = c.Param("address")
+				c.Status(http.StatusOK)
+			})
+
+			if status := serve(router, http.MethodGet, tt.path, "", ""); status != tt.wantStatus {
+				t.Fatalf("GET %s = %d, want %d", tt.path, status, tt.wantStatus)
+			}
+			if got != tt.wantAddress {
+				t.Errorf("handler saw address %q, want %q", got, tt.wantAddress)
+			}
+		})
+	}
+}
@@ -0,0 +1,466 @@
+package handlers
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AdminHandler handles operator-only endpoints (admin role required)
+type AdminHandler struct {
+	db           *database.DB
+	outboxWorker *services.OutboxWorker
+	musicService *services.MusicService
+	savings      *services.SavingsCalculator
+}
+
+func NewAdminHandler(db *database.DB, outboxWorker *services.OutboxWorker, musicService *services.MusicService, savings *services.SavingsCalculator) *AdminHandler {
+	return &AdminHandler{db: db, outboxWorker: outboxWorker, musicService: musicService, savings: savings}
+}
+
+// errReportResolved is returned when resolving a report that is no longer open
+var errReportResolved = errors.New("report already resolved")
+
+// topSaversLimit is how many users GetSavingsStats lists
+const topSaversLimit = 10
+
+// GetSavingsStats returns staking fee savings summed across all creators
+// GET /api/v1/admin/stats/savings
+func (h *AdminHandler) GetSavingsStats(c *gin.Context) {
+	var totals struct {
+		Total string
+		Users int64
+	}
+	if err := h.db.Table("royalty_distributions rd").
+		Select(`COALESCE(SUM(CAST(rd.amount AS DECIMAL(30,0))), 0) as total,
+			COUNT(DISTINCT m.creator_address) as users`).
+		Joins("JOIN music_metadata m ON rd.token_id = m.token_id").
+		Scan(&totals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	type saver struct {
+		Address        string `json:"address"`
+		TotalRoyalties string `json:"total_royalties"`
+		TotalSaved     string `json:"total_saved"`
+	}
+
+	var top []saver
+	if err := h.db.Table("royalty_distributions rd").
+		Select(`m.creator_address as address,
+			COALESCE(SUM(CAST(rd.amount AS DECIMAL(30,0))), 0) as total_royalties`).
+		Joins("JOIN music_metadata m ON rd.token_id = m.token_id").
+		Group("m.creator_address").
+		Order("total_royalties DESC").
+		Limit(topSaversLimit).
+		Scan(&top).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	for i := range top {
+		top[i].TotalSaved = h.savings.OnWei(top[i].TotalRoyalties)
+	}
+
+	// Savings are linear in royalties, so the platform total can be derived
+	// from the summed royalties instead of adding up per-user savings
+	totalSaved, ok := new(big.Int).SetString(h.savings.OnWei(totals.Total), 10)
+	if !ok {
+		totalSaved = new(big.Int)
+	}
+	average := new(big.Int)
+	if totals.Users > 0 {
+		average.Quo(totalSaved, big.NewInt(totals.Users))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"total_royalties":  totals.Total,
+		"total_saved":      totalSaved.String(),
+		"users":            totals.Users,
+		"average_per_user": average.String(),
+		"top_savers":       top,
+	})
+}
+
+// SetCampaignTrending pins a campaign's trending flag, overriding the scoring
+// job. Sending {"is_trending": null} clears the override.
+// PATCH /api/v1/admin/campaigns/:id/trending
+func (h *AdminHandler) SetCampaignTrending(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID"})
+		return
+	}
+
+	var req map[string]*bool
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	override, ok := req["is_trending"]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "is_trending is required (true, false or null)"})
+		return
+	}
+
+	var campaign models.Campaign
+	if err := h.db.Where("campaign_id = ?", campaignID).First(&campaign).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
+		return
+	}
+
+	campaign.TrendingOverride = override
+	campaign.IsTrending = services.EffectiveTrending(override, campaign.IsTrending)
+
+	if err := h.db.Model(&campaign).Select("trending_override", "is_trending").Updates(&campaign).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update campaign"})
+		return
+	}
+
+	c.JSON(http.StatusOK, campaign)
+}
+
+// BackfillAnalytics creates the missing analytics row for every track that
+// has none. Running it again is a no-op.
+// POST /api/v1/admin/backfill-analytics
+func (h *AdminHandler) BackfillAnalytics(c *gin.Context) {
+	var tokenIDs []uint64
+	if err := h.db.Table("music_metadata m").
+		Select("m.token_id").
+		Joins("LEFT JOIN analytics a ON m.token_id = a.token_id").
+		Where("a.id IS NULL AND m.deleted_at IS NULL").
+		Pluck("m.token_id", &tokenIDs).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	backfilled := int64(0)
+	if len(tokenIDs) > 0 {
+		now := time.Now()
+		rows := make([]models.Analytics, len(tokenIDs))
+		for i, tokenID := range tokenIDs {
+			rows[i] = models.Analytics{
+				TokenID:        tokenID,
+				TotalRoyalties: "0",
+				LastUpdated:    now,
+			}
+		}
+
+		// token_id is unique, so rows created concurrently are skipped instead of failing
+		result := h.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(rows, 500)
+		if result.Error != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+			return
+		}
+		backfilled = result.RowsAffected
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"missing":    len(tokenIDs),
+		"backfilled": backfilled,
+		"token_ids":  tokenIDs,
+	})
+}
+
+// adminDistribution is a submission together with its track's platform rows
+type adminDistribution struct {
+	models.DistributionSubmission
+	PlatformRows []models.PlatformDistribution `json:"platform_rows"`
+}
+
+// ListDistributions lists distribution submissions across all users, newest
+// first. status filters on the submission status; platform keeps submissions
+// whose track has a row for that platform and narrows platform_rows to it.
+// GET /api/v1/admin/distributions?status=processing&platform=spotify&limit=20&offset=0
+func (h *AdminHandler) ListDistributions(c *gin.Context) {
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := c.Query("status")
+	platform := c.Query("platform")
+
+	query := h.db.WithContext(c.Request.Context()).Model(&models.DistributionSubmission{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if platform != "" {
+		query = query.Where(`EXISTS (
+			SELECT 1 FROM platform_distributions pd
+			WHERE pd.token_id = distribution_submissions.token_id
+				AND pd.platform = ?
+				AND pd.deleted_at IS NULL
+		)`, platform)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var submissions []models.DistributionSubmission
+	if err := query.Order("submitted_at DESC, id DESC").Limit(limit).Offset(offset).Find(&submissions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Platform rows are keyed by track, so load them for the whole page at once
+	rowsByToken := make(map[uint64][]models.PlatformDistribution)
+	if len(submissions) > 0 {
+		tokenIDs := make([]uint64, len(submissions))
+		for i, submission := range submissions {
+			tokenIDs[i] = submission.TokenID
+		}
+
+		rowQuery := h.db.WithContext(c.Request.Context()).Where("token_id IN ?", tokenIDs)
+		if platform != "" {
+			rowQuery = rowQuery.Where("platform = ?", platform)
+		}
+		var rows []models.PlatformDistribution
+		if err := rowQuery.Order("platform ASC").Find(&rows).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		for _, row := range rows {
+			rowsByToken[row.TokenID] = append(rowsByToken[row.TokenID], row)
+		}
+	}
+
+	data := make([]adminDistribution, len(submissions))
+	for i, submission := range submissions {
+		rows := rowsByToken[submission.TokenID]
+		if rows == nil {
+			rows = []models.PlatformDistribution{}
+		}
+		data[i] = adminDistribution{DistributionSubmission: submission, PlatformRows: rows}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":     data,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+		"status":   status,
+		"platform": platform,
+	})
+}
+
+// ListReports lists content reports, oldest first so the queue is worked in
+// order; status filters on open, dismissed or actioned
+// GET /api/v1/admin/reports?status=open&limit=20&offset=0
+func (h *AdminHandler) ListReports(c *gin.Context) {
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := h.db.WithContext(c.Request.Context()).Model(&models.Report{})
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	reports := []models.Report{}
+	if err := query.Order("created_at ASC, id ASC").Limit(limit).Offset(offset).Find(&reports).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":   reports,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// ResolveReport closes an open report. action "dismiss" takes no further
+// step; "deactivate" also deactivates the reported track or cancels the
+// reported campaign.
+// POST /api/v1/admin/reports/:id/resolve
+func (h *AdminHandler) ResolveReport(c *gin.Context) {
+	reportID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid report ID"})
+		return
+	}
+
+	var req struct {
+		Action string `json:"action" binding:"required,oneof=dismiss deactivate"`
+		Note   string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var report models.Report
+	targetDeactivated := false
+	err = h.db.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&report, reportID).Error; err != nil {
+			return err
+		}
+		if report.Status != "open" {
+			return errReportResolved
+		}
+
+		status := "dismissed"
+		if req.Action == "deactivate" {
+			status = "actioned"
+
+			var update *gorm.DB
+			if report.TokenID != nil {
+				update = tx.Model(&models.MusicMetadata{}).
+					Where("token_id = ? AND is_active = ?", *report.TokenID, true).
+					Update("is_active", false)
+			} else {
+				update = tx.Model(&models.Campaign{}).
+					Where("campaign_id = ? AND status = ?", *report.CampaignID, "active").
+					Update("status", "cancelled")
+			}
+			if update.Error != nil {
+				return update.Error
+			}
+			targetDeactivated = update.RowsAffected > 0
+		}
+
+		now := time.Now()
+		report.Status = status
+		report.ResolvedBy = authClaims(c).Subject
+		report.ResolutionNote = req.Note
+		report.ResolvedAt = &now
+		return tx.Model(&report).Select("status", "resolved_by", "resolution_note", "resolved_at").Updates(&report).Error
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
+		case errors.Is(err, errReportResolved):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"report":             report,
+		"target_deactivated": targetDeactivated,
+	})
+}
+
+// ListWebhookDeliveries lists webhook outbox events, most recently updated
+// first; status filters on pending, done, failed or skipped
+// GET /api/v1/admin/webhooks/deliveries?status=failed&limit=20&offset=0
+func (h *AdminHandler) ListWebhookDeliveries(c *gin.Context) {
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	query := h.db.WithContext(c.Request.Context()).Model(&models.OutboxEvent{}).
+		Where("channel = ?", services.OutboxChannelWebhook)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	deliveries := []models.OutboxEvent{}
+	if err := query.Order("updated_at DESC, id DESC").Limit(limit).Offset(offset).Find(&deliveries).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":        deliveries,
+		"total":       total,
+		"limit":       limit,
+		"offset":      offset,
+		"max_replays": services.MaxWebhookReplays,
+	})
+}
+
+// ReplayWebhook resends a failed or skipped webhook event now, re-signing
+// its payload, and returns the event with the outcome recorded
+// POST /api/v1/admin/webhooks/:id/replay
+func (h *AdminHandler) ReplayWebhook(c *gin.Context) {
+	eventID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID"})
+		return
+	}
+
+	event, err := h.outboxWorker.ReplayWebhook(c.Request.Context(), uint(eventID))
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Webhook event not found"})
+		case errors.Is(err, services.ErrNotWebhookEvent):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrWebhookNotReplayable), errors.Is(err, services.ErrReplayLimitReached):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrWebhookNotConfigured):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"event":     event,
+		"delivered": event.Status == services.OutboxStatusDone,
+	})
+}
+
+// GetNearDuplicates clusters tracks whose fingerprints are at least
+// threshold similar (default SimilarityThreshold). Only the most recently
+// registered MaxNearDuplicateScan tracks are compared.
+// GET /api/v1/admin/near-duplicates?threshold=0.85
+func (h *AdminHandler) GetNearDuplicates(c *gin.Context) {
+	threshold := services.SimilarityThreshold
+	if value := c.Query("threshold"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid threshold"})
+			return
+		}
+		threshold = parsed
+	}
+
+	report, err := h.musicService.FindNearDuplicates(c.Request.Context(), threshold)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidThreshold) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,372 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestSetCampaignTrending(t *testing.T) {
+	tests := []struct {
+		name         string
+		isTrending   bool // stored before the request
+		body         string
+		wantStatus   int
+		wantTrending bool
+		wantOverride *bool
+	}{
+		{name: "pin on", isTrending: false, body: `{"is_trending":true}`, wantStatus: http.StatusOK, wantTrending: true, wantOverride: boolPtr(true)},
+		{name: "pin off", isTrending: true, body: `{"is_trending":false}`, wantStatus: http.StatusOK, wantTrending: false, wantOverride: boolPtr(false)},
+		{name: "clearing keeps the scored flag", isTrending: true, body: `{"is_trending":null}`, wantStatus: http.StatusOK, wantTrending: true},
+		{name: "missing is_trending", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "not a boolean", body: `{"is_trending":"yes"}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			if tt.wantStatus == http.StatusOK {
+				mock.ExpectQuery("SELECT \\* FROM `campaigns` WHERE campaign_id = \\?").
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "is_trending"}).AddRow(1, 7, tt.isTrending))
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `campaigns` SET `is_trending`=\\?,`trending_override`=\\?").
+					WithArgs(tt.wantTrending, tt.wantOverride, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			router := gin.New()
+			router.PATCH("/admin/campaigns/:id/trending", RequireRole(testSecret, auth.RoleAdmin), NewAdminHandler(db, nil, nil, nil).SetCampaignTrending)
+
+			rec := record(router, http.MethodPatch, "/admin/campaigns/7/trending", bearer(t, testSecret, "admin", auth.RoleAdmin, time.Minute), tt.body)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				IsTrending       bool  `json:"is_trending"`
+				TrendingOverride *bool `json:"trending_override"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.IsTrending != tt.wantTrending {
+				t.Errorf("is_trending = %v, want %v", body.IsTrending, tt.wantTrending)
+			}
+			if (body.TrendingOverride == nil) != (tt.wantOverride == nil) || (body.TrendingOverride != nil && *body.TrendingOverride != *tt.wantOverride) {
+				t.Errorf("trending_override = %v, want %v", body.TrendingOverride, tt.wantOverride)
+			}
+		})
+	}
+}
+
+func TestSetCampaignTrendingRequiresAdmin(t *testing.T) {
+	db, _ := dbtest.New(t)
+	router := gin.New()
+	router.PATCH("/admin/campaigns/:id/trending", RequireRole(testSecret, auth.RoleAdmin), NewAdminHandler(db, nil, nil, nil).SetCampaignTrending)
+
+	status := serve(router, http.MethodPatch, "/admin/campaigns/7/trending", bearer(t, testSecret, "0xabc", auth.RoleUser, time.Minute), `{"is_trending":true}`)
+	if status != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", status, http.StatusForbidden)
+	}
+}
+
+func boolPtr(v bool) *bool { return &v }
+
+func TestBackfillAnalytics(t *testing.T) {
+	tests := []struct {
+		name           string
+		missing        []uint64
+		inserted       int64 // rows the insert reports; fewer when another writer got there first
+		wantBackfilled int64
+	}{
+		{name: "tracks without analytics", missing: []uint64{3, 9}, inserted: 2, wantBackfilled: 2},
+		{name: "row created concurrently is skipped", missing: []uint64{3, 9}, inserted: 1, wantBackfilled: 1},
+		{name: "nothing missing on a second run", wantBackfilled: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows([]string{"token_id"})
+			for _, tokenID := range tt.missing {
+				rows.AddRow(tokenID)
+			}
+			mock.ExpectQuery("SELECT m.token_id FROM music_metadata m LEFT JOIN analytics a ON m.token_id = a.token_id WHERE a.id IS NULL AND m.deleted_at IS NULL").
+				WillReturnRows(rows)
+			if len(tt.missing) > 0 {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `analytics` .* VALUES \\(.*\\),\\(.*\\) ON DUPLICATE KEY UPDATE").
+					WillReturnResult(sqlmock.NewResult(1, tt.inserted))
+				mock.ExpectCommit()
+			}
+
+			router := gin.New()
+			router.POST("/admin/backfill-analytics", RequireRole(testSecret, auth.RoleAdmin), NewAdminHandler(db, nil, nil, nil).BackfillAnalytics)
+
+			rec := record(router, http.MethodPost, "/admin/backfill-analytics", bearer(t, testSecret, "admin", auth.RoleAdmin, time.Minute), "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Missing    int      `json:"missing"`
+				Backfilled int64    `json:"backfilled"`
+				TokenIDs   []uint64 `json:"token_ids"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Missing != len(tt.missing) || body.Backfilled != tt.wantBackfilled {
+				t.Errorf("missing = %d, backfilled = %d, want %d and %d", body.Missing, body.Backfilled, len(tt.missing), tt.wantBackfilled)
+			}
+		})
+	}
+}
+
+func TestBackfillAnalyticsRequiresAdmin(t *testing.T) {
+	db, _ := dbtest.New(t)
+	router := gin.New()
+	router.POST("/admin/backfill-analytics", RequireRole(testSecret, auth.RoleAdmin), NewAdminHandler(db, nil, nil, nil).BackfillAnalytics)
+
+	if status := serve(router, http.MethodPost, "/admin/backfill-analytics", bearer(t, testSecret, "0xabc", auth.RoleUser, time.Minute), ""); status != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", status, http.StatusForbidden)
+	}
+}
+
+func TestListAdminDistributions(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		filter     string
+		filterArgs []driver.Value
+		rowFilter  string
+		rowArgs    []driver.Value
+		platforms  []string // token 3's platform rows; token 9 has none
+	}{
+		{
+			name:       "by status",
+			query:      "?status=failed",
+			filter:     "WHERE status = \\? AND `distribution_submissions`.`deleted_at` IS NULL",
+			filterArgs: []driver.Value{"failed"},
+			rowFilter:  "WHERE token_id IN \\(\\?,\\?\\) AND `platform_distributions`.`deleted_at` IS NULL",
+			rowArgs:    []driver.Value{3, 9},
+			platforms:  []string{"spotify", "tiktok"},
+		},
+		{
+			name:       "by status and platform",
+			query:      "?status=failed&platform=spotify",
+			filter:     "WHERE status = \\? AND EXISTS \\(.*pd.platform = \\?.*\\) AND `distribution_submissions`.`deleted_at` IS NULL",
+			filterArgs: []driver.Value{"failed", "spotify"},
+			rowFilter:  "WHERE token_id IN \\(\\?,\\?\\) AND platform = \\? AND `platform_distributions`.`deleted_at` IS NULL",
+			rowArgs:    []driver.Value{3, 9, "spotify"},
+			platforms:  []string{"spotify"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("(?s)SELECT count\\(\\*\\) FROM `distribution_submissions` " + tt.filter).
+				WithArgs(tt.filterArgs...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			mock.ExpectQuery("(?s)SELECT \\* FROM `distribution_submissions` " + tt.filter + " ORDER BY submitted_at DESC, id DESC LIMIT 20").
+				WithArgs(tt.filterArgs...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "status"}).
+					AddRow(5, 3, "failed").
+					AddRow(4, 9, "failed"))
+			rows := sqlmock.NewRows([]string{"id", "token_id", "platform", "status"})
+			for i, platform := range tt.platforms {
+				rows.AddRow(i+1, 3, platform, "failed")
+			}
+			mock.ExpectQuery("SELECT \\* FROM `platform_distributions` " + tt.rowFilter + " ORDER BY platform ASC").
+				WithArgs(tt.rowArgs...).
+				WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/admin/distributions", RequireRole(testSecret, auth.RoleAdmin), NewAdminHandler(db, nil, nil, nil).ListDistributions)
+
+			rec := record(router, http.MethodGet, "/admin/distributions"+tt.query, bearer(t, testSecret, "admin", auth.RoleAdmin, time.Minute), "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Data []struct {
+					TokenID      uint64            `json:"token_id"`
+					Status       string            `json:"status"`
+					PlatformRows []json.RawMessage `json:"platform_rows"`
+				} `json:"data"`
+				Total int64 `json:"total"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Total != 2 || len(body.Data) != 2 {
+				t.Fatalf("got %d submissions of %d, want 2 of 2", len(body.Data), body.Total)
+			}
+			wantRows := map[uint64]int{3: len(tt.platforms), 9: 0}
+			for _, submission := range body.Data {
+				if submission.Status != "failed" {
+					t.Errorf("submission for token %d is %s, want failed", submission.TokenID, submission.Status)
+				}
+				if submission.PlatformRows == nil || len(submission.PlatformRows) != wantRows[submission.TokenID] {
+					t.Errorf("token %d has platform_rows %v, want %d rows", submission.TokenID, submission.PlatformRows, wantRows[submission.TokenID])
+				}
+			}
+		})
+	}
+}
+
+func TestListAdminDistributionsEmptyPage(t *testing.T) {
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `distribution_submissions`").
+		WithArgs("distributed").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT \\* FROM `distribution_submissions`").
+		WithArgs("distributed").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	router := gin.New()
+	router.GET("/admin/distributions", RequireRole(testSecret, auth.RoleAdmin), NewAdminHandler(db, nil, nil, nil).ListDistributions)
+
+	rec := record(router, http.MethodGet, "/admin/distributions?status=distributed", bearer(t, testSecret, "admin", auth.RoleAdmin, time.Minute), "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	if status := serve(router, http.MethodGet, "/admin/distributions", bearer(t, testSecret, "0xabc", auth.RoleUser, time.Minute), ""); status != http.StatusForbidden {
+		t.Errorf("non-admin status = %d, want %d", status, http.StatusForbidden)
+	}
+}
+
+func TestResolveReport(t *testing.T) {
+	reportColumns := []string{"id", "reporter_address", "token_id", "campaign_id", "reason", "status"}
+
+	tests := []struct {
+		name            string
+		report          []driver.Value // stored report row, none when missing
+		body            string
+		deactivate      string // UPDATE expected for the target, none when empty
+		deactivateArgs  []driver.Value
+		targetAffected  int64
+		want            int
+		wantStatus      string
+		wantDeactivated bool
+	}{
+		{
+			name:            "deactivate a reported track",
+			report:          []driver.Value{4, "0xreporter", 7, nil, "copyright", "open"},
+			body:            `{"action":"deactivate","note":"confirmed"}`,
+			deactivate:      "UPDATE `music_metadata` SET `is_active`=\\?,`updated_at`=\\? WHERE \\(token_id = \\? AND is_active = \\?\\)",
+			deactivateArgs:  []driver.Value{false, sqlmock.AnyArg(), 7, true},
+			targetAffected:  1,
+			want:            http.StatusOK,
+			wantStatus:      "actioned",
+			wantDeactivated: true,
+		},
+		{
+			name:            "cancel a reported campaign",
+			report:          []driver.Value{4, "0xreporter", nil, 3, "fraud", "open"},
+			body:            `{"action":"deactivate"}`,
+			deactivate:      "UPDATE `campaigns` SET `status`=\\?,`updated_at`=\\? WHERE \\(campaign_id = \\? AND status = \\?\\)",
+			deactivateArgs:  []driver.Value{"cancelled", sqlmock.AnyArg(), 3, "active"},
+			targetAffected:  1,
+			want:            http.StatusOK,
+			wantStatus:      "actioned",
+			wantDeactivated: true,
+		},
+		{
+			name:           "target already inactive",
+			report:         []driver.Value{4, "0xreporter", 7, nil, "spam", "open"},
+			body:           `{"action":"deactivate"}`,
+			deactivate:     "UPDATE `music_metadata` SET `is_active`=\\?,`updated_at`=\\? WHERE \\(token_id = \\? AND is_active = \\?\\)",
+			deactivateArgs: []driver.Value{false, sqlmock.AnyArg(), 7, true},
+			want:           http.StatusOK,
+			wantStatus:     "actioned",
+		},
+		{
+			name:       "dismiss leaves the target alone",
+			report:     []driver.Value{4, "0xreporter", 7, nil, "spam", "open"},
+			body:       `{"action":"dismiss"}`,
+			want:       http.StatusOK,
+			wantStatus: "dismissed",
+		},
+		{
+			name:   "already resolved",
+			report: []driver.Value{4, "0xreporter", 7, nil, "spam", "dismissed"},
+			body:   `{"action":"deactivate"}`,
+			want:   http.StatusConflict,
+		},
+		{name: "unknown report", body: `{"action":"dismiss"}`, want: http.StatusNotFound},
+		{name: "unknown action", body: `{"action":"delete"}`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			if tt.want != http.StatusBadRequest {
+				rows := sqlmock.NewRows(reportColumns)
+				if tt.report != nil {
+					rows.AddRow(tt.report...)
+				}
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `reports` WHERE `reports`.`id` = \\? ORDER BY `reports`.`id` LIMIT 1 FOR UPDATE").
+					WithArgs(4).
+					WillReturnRows(rows)
+				if tt.deactivate != "" {
+					mock.ExpectExec(tt.deactivate).
+						WithArgs(tt.deactivateArgs...).
+						WillReturnResult(sqlmock.NewResult(0, tt.targetAffected))
+				}
+				if tt.want == http.StatusOK {
+					mock.ExpectExec("UPDATE `reports` SET `status`=\\?,`resolved_by`=\\?,`resolution_note`=\\?,`resolved_at`=\\?,`updated_at`=\\? WHERE `id` = \\?").
+						WithArgs(tt.wantStatus, "admin", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 4).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectCommit()
+				} else {
+					mock.ExpectRollback()
+				}
+			}
+
+			router := gin.New()
+			router.POST("/admin/reports/:id/resolve", RequireRole(testSecret, auth.RoleAdmin), NewAdminHandler(db, nil, nil, nil).ResolveReport)
+
+			rec := record(router, http.MethodPost, "/admin/reports/4/resolve", bearer(t, testSecret, "admin", auth.RoleAdmin, time.Minute), tt.body)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Report struct {
+					Status     string     `json:"status"`
+					ResolvedBy string     `json:"resolved_by"`
+					ResolvedAt *time.Time `json:"resolved_at"`
+				} `json:"report"`
+				TargetDeactivated bool `json:"target_deactivated"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Report.Status != tt.wantStatus || body.Report.ResolvedBy != "admin" || body.Report.ResolvedAt == nil {
+				t.Errorf("report = %+v, want %s by admin with a resolution time", body.Report, tt.wantStatus)
+			}
+			if body.TargetDeactivated != tt.wantDeactivated {
+				t.Errorf("target_deactivated = %v, want %v", body.TargetDeactivated, tt.wantDeactivated)
+			}
+		})
+	}
+}
@@ -1,13 +1,22 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tunecent/backend/internal/database"
 	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+	"github.com/tunecent/backend/pkg/metrics"
 	"github.com/tunecent/backend/pkg/mockdata"
+	"gorm.io/gorm"
 )
 
 // AnalyticsHandler handles analytics-related endpoints
@@ -185,8 +194,11 @@ func (h *AnalyticsHandler) GetViewMetrics(c *gin.Context) {
 // GET /api/v1/analytics/global/top-songs?address=0x...&limit=10
 func (h *AnalyticsHandler) GetTopSongs(c *gin.Context) {
 	address := c.Query("address") // Optional: filter by creator
-	limitStr := c.DefaultQuery("limit", "10")
-	limit, _ := strconv.Atoi(limitStr)
+	limit, _, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	type TopSong struct {
 		TokenID       uint64  `json:"token_id"`
@@ -217,6 +229,91 @@ func (h *AnalyticsHandler) GetTopSongs(c *gin.Context) {
 	})
 }
 
+// GetTrendingFeed returns the platform-wide "what's hot" feed: every active
+// track with a stored trending rank, best rank first, with its creator and
+// weekly growth
+// GET /api/v1/analytics/trending-feed?limit=20
+func (h *AnalyticsHandler) GetTrendingFeed(c *gin.Context) {
+	limit, _, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	type FeedCreator struct {
+		Address     string `json:"address"`
+		DisplayName string `json:"display_name,omitempty"`
+		AvatarURL   string `json:"avatar_url,omitempty"`
+		IsVerified  bool   `json:"is_verified"`
+	}
+
+	type FeedItem struct {
+		TokenID       uint64      `json:"token_id"`
+		Title         string      `json:"title"`
+		Artist        string      `json:"artist"`
+		Genre         string      `json:"genre,omitempty"`
+		CoverImageURL string      `json:"cover_image_url,omitempty"`
+		TrendingRank  int         `json:"trending_rank"`
+		ViralScore    float64     `json:"viral_score"`
+		WeeklyGrowth  float64     `json:"weekly_growth"`
+		Creator       FeedCreator `json:"creator"`
+	}
+
+	var rows []struct {
+		TokenID        uint64
+		Title          string
+		Artist         string
+		Genre          string
+		CoverImageURL  string
+		TrendingRank   int
+		ViralScore     float64
+		WeeklyGrowth   float64
+		CreatorAddress string
+		DisplayName    string
+		AvatarURL      string
+		IsVerified     bool
+	}
+	if err := h.db.Table("music_metadata m").
+		Select(`m.token_id, m.title, m.artist, m.genre, m.cover_image_url, m.trending_rank, m.viral_score,
+			COALESCE(a.weekly_growth, 0) as weekly_growth,
+			m.creator_address, COALESCE(NULLIF(u.display_name, ''), u.username, '') as display_name,
+			COALESCE(u.avatar_url, '') as avatar_url, COALESCE(u.is_verified, false) as is_verified`).
+		Joins("LEFT JOIN analytics a ON a.token_id = m.token_id").
+		Joins("LEFT JOIN users u ON u.wallet_address = m.creator_address AND u.deleted_at IS NULL").
+		Where("m.trending_rank > ? AND m.is_active = ? AND m.deleted_at IS NULL", 0, true).
+		Order("m.trending_rank ASC, m.viral_score DESC, m.token_id ASC").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	feed := make([]FeedItem, len(rows))
+	for i, row := range rows {
+		feed[i] = FeedItem{
+			TokenID:       row.TokenID,
+			Title:         row.Title,
+			Artist:        row.Artist,
+			Genre:         row.Genre,
+			CoverImageURL: row.CoverImageURL,
+			TrendingRank:  row.TrendingRank,
+			ViralScore:    row.ViralScore,
+			WeeklyGrowth:  row.WeeklyGrowth,
+			Creator: FeedCreator{
+				Address:     row.CreatorAddress,
+				DisplayName: row.DisplayName,
+				AvatarURL:   row.AvatarURL,
+				IsVerified:  row.IsVerified,
+			},
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"feed":  feed,
+		"total": len(feed),
+	})
+}
+
 // GetTrendingIndicators returns trending indicators for a song
 // GET /api/v1/analytics/:tokenId/trending
 func (h *AnalyticsHandler) GetTrendingIndicators(c *gin.Context) {
@@ -291,3 +388,521 @@ func (h *AnalyticsHandler) GetEstimatedReach(c *gin.Context) {
 		"methodology": "Estimated unique reach accounting for 30% cross-platform overlap",
 	})
 }
+
+// Reach trend and platform stats history windows, in days
+const (
+	defaultReachTrendDays = 30
+	maxReachTrendDays     = 365
+)
+
+// ReachPoint is a track's estimated reach at the end of one day
+type ReachPoint struct {
+	Date           string `json:"date"`
+	EstimatedReach uint64 `json:"estimated_reach"`
+}
+
+// GetReachTrend returns the estimated reach at the end of each day of the
+// window, oldest first, computed with the same overlap methodology as
+// GetEstimatedReach. Days before the track was registered have zero reach.
+// GET /api/v1/analytics/:tokenId/reach-trend?days=30
+func (h *AnalyticsHandler) GetReachTrend(c *gin.Context) {
+	tokenIDStr := c.Param("tokenId")
+	tokenID, err := strconv.ParseUint(tokenIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	days, err := parseNonNegativeQuery(c, "days", defaultReachTrendDays)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if days < 1 || days > maxReachTrendDays {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("days must be between 1 and %d", maxReachTrendDays)})
+		return
+	}
+
+	var music models.MusicMetadata
+	if err := h.db.Where("token_id = ?", tokenID).First(&music).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Music not found"})
+		return
+	}
+
+	// Each point is taken at the end of its UTC day; today's is taken now so
+	// the last point matches GetEstimatedReach
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	trend := make([]ReachPoint, days)
+	for i := range trend {
+		day := today.AddDate(0, 0, i-(days-1))
+		asOf := day.Add(24 * time.Hour)
+		if asOf.After(now) {
+			asOf = now
+		}
+
+		var reach uint64
+		if !asOf.Before(music.RegisteredAt) {
+			reach = mockdata.GenerateEstimatedReach(mockdata.GeneratePlatformStatsAt(tokenID, music.RegisteredAt, asOf))
+		}
+		trend[i] = ReachPoint{Date: day.Format("2006-01-02"), EstimatedReach: reach}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token_id":    tokenID,
+		"days":        days,
+		"trend":       trend,
+		"methodology": "Estimated unique reach accounting for 30% cross-platform overlap",
+	})
+}
+
+// GetPlatformStatsHistory returns the estimated platform stats at the close
+// of each UTC day of the window, oldest first, for charting. Points are
+// deterministic per track and day and end with today's projected close.
+// GET /api/v1/analytics/:tokenId/platform-stats/history?days=30
+func (h *AnalyticsHandler) GetPlatformStatsHistory(c *gin.Context) {
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	days, err := parseNonNegativeQuery(c, "days", defaultReachTrendDays)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if days < 1 || days > maxReachTrendDays {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("days must be between 1 and %d", maxReachTrendDays)})
+		return
+	}
+
+	var music models.MusicMetadata
+	if err := h.db.Where("token_id = ?", tokenID).First(&music).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Music not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token_id":  tokenID,
+		"days":      days,
+		"history":   mockdata.GeneratePlatformStatsHistory(tokenID, music.RegisteredAt, time.Now(), days),
+		"estimated": true,
+	})
+}
+
+// maxBatchTokens caps the number of token IDs accepted by batch endpoints
+const maxBatchTokens = 100
+
+// GetBatchAnalytics returns analytics for several tokens in one query.
+// Unknown token IDs are reported in "missing" instead of failing the batch.
+// POST /api/v1/analytics/batch
+func (h *AnalyticsHandler) GetBatchAnalytics(c *gin.Context) {
+	var req struct {
+		TokenIDs []uint64 `json:"token_ids" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(req.TokenIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "token_ids must not be empty"})
+		return
+	}
+	if len(req.TokenIDs) > maxBatchTokens {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("at most %d token_ids per batch", maxBatchTokens)})
+		return
+	}
+
+	type TokenAnalytics struct {
+		TokenID        uint64  `json:"token_id"`
+		Title          string  `json:"title"`
+		ViralScore     float64 `json:"viral_score"`
+		PlayCount      uint64  `json:"play_count"`
+		ViewCount      uint64  `json:"view_count"`
+		ListenerCount  uint64  `json:"listener_count"`
+		TrendingRank   int     `json:"trending_rank"`
+		TotalUsages    uint64  `json:"total_usages"`
+		TotalRoyalties string  `json:"total_royalties"`
+	}
+
+	var rows []TokenAnalytics
+	h.db.Table("music_metadata m").
+		Select(`m.token_id, m.title, m.viral_score, m.play_count, m.view_count, m.listener_count, m.trending_rank,
+			COALESCE(a.total_usages, 0) as total_usages,
+			COALESCE(a.total_royalties, '0') as total_royalties`).
+		Joins("LEFT JOIN analytics a ON m.token_id = a.token_id").
+		Where("m.token_id IN ? AND m.deleted_at IS NULL", req.TokenIDs).
+		Scan(&rows)
+
+	results := make(map[string]TokenAnalytics, len(rows))
+	for _, row := range rows {
+		results[strconv.FormatUint(row.TokenID, 10)] = row
+	}
+
+	missing := []uint64{}
+	seen := make(map[uint64]bool, len(req.TokenIDs))
+	for _, tokenID := range req.TokenIDs {
+		if seen[tokenID] {
+			continue
+		}
+		seen[tokenID] = true
+		if _, ok := results[strconv.FormatUint(tokenID, 10)]; !ok {
+			missing = append(missing, tokenID)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":    results,
+		"found":   len(results),
+		"missing": missing,
+	})
+}
+
+// CompareTracks returns two tracks' performance side by side with a delta and
+// winner per metric. When address is given, both tracks must belong to it.
+// GET /api/v1/analytics/compare?token_a=1&token_b=2&address=0x...
+func (h *AnalyticsHandler) CompareTracks(c *gin.Context) {
+	tokenA, errA := strconv.ParseUint(c.Query("token_a"), 10, 64)
+	tokenB, errB := strconv.ParseUint(c.Query("token_b"), 10, 64)
+	if errA != nil || errB != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "token_a and token_b must be valid token IDs"})
+		return
+	}
+	if tokenA == tokenB {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "token_a and token_b must be different"})
+		return
+	}
+
+	type trackPerformance struct {
+		TokenID        uint64  `json:"token_id"`
+		Title          string  `json:"title"`
+		Artist         string  `json:"artist"`
+		CreatorAddress string  `json:"creator_address"`
+		PlayCount      uint64  `json:"play_count"`
+		ViewCount      uint64  `json:"view_count"`
+		ListenerCount  uint64  `json:"listener_count"`
+		ViralScore     float64 `json:"viral_score"`
+		TotalRoyalties string  `json:"total_royalties"`
+	}
+
+	var rows []trackPerformance
+	if err := h.db.Table("music_metadata m").
+		Select(`m.token_id, m.title, m.artist, m.creator_address,
+			m.play_count, m.view_count, m.listener_count, m.viral_score,
+			COALESCE(a.total_royalties, '0') as total_royalties`).
+		Joins("LEFT JOIN analytics a ON m.token_id = a.token_id").
+		Where("m.token_id IN ? AND m.deleted_at IS NULL", []uint64{tokenA, tokenB}).
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	tracks := make(map[uint64]trackPerformance, len(rows))
+	for _, row := range rows {
+		tracks[row.TokenID] = row
+	}
+	a, okA := tracks[tokenA]
+	b, okB := tracks[tokenB]
+	if !okA || !okB {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Music not found"})
+		return
+	}
+
+	if address := c.Query("address"); address != "" {
+		if !strings.EqualFold(a.CreatorAddress, address) || !strings.EqualFold(b.CreatorAddress, address) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "both tracks must belong to the given address"})
+			return
+		}
+	}
+
+	earningsA, okA := new(big.Int).SetString(a.TotalRoyalties, 10)
+	earningsB, okB := new(big.Int).SetString(b.TotalRoyalties, 10)
+	if !okA {
+		earningsA = new(big.Int)
+	}
+	if !okB {
+		earningsB = new(big.Int)
+	}
+
+	comparisons := []metrics.Comparison{
+		metrics.CompareInts("plays", new(big.Int).SetUint64(a.PlayCount), new(big.Int).SetUint64(b.PlayCount)),
+		metrics.CompareInts("views", new(big.Int).SetUint64(a.ViewCount), new(big.Int).SetUint64(b.ViewCount)),
+		metrics.CompareInts("listeners", new(big.Int).SetUint64(a.ListenerCount), new(big.Int).SetUint64(b.ListenerCount)),
+		metrics.CompareFloats("viral_score", a.ViralScore, b.ViralScore),
+		metrics.CompareInts("earnings", earningsA, earningsB),
+	}
+
+	wins := map[string]int{metrics.WinnerA: 0, metrics.WinnerB: 0, metrics.WinnerTie: 0}
+	for _, cmp := range comparisons {
+		wins[cmp.Winner]++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token_a": a,
+		"token_b": b,
+		"metrics": comparisons,
+		"wins":    wins,
+	})
+}
+
+// GetListenerOverlap estimates how many listeners two tracks share, from
+// their audiences per platform, and their combined unique reach
+// GET /api/v1/analytics/overlap?token_a=1&token_b=2
+func (h *AnalyticsHandler) GetListenerOverlap(c *gin.Context) {
+	tokenA, errA := strconv.ParseUint(c.Query("token_a"), 10, 64)
+	tokenB, errB := strconv.ParseUint(c.Query("token_b"), 10, 64)
+	if errA != nil || errB != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "token_a and token_b must be valid token IDs"})
+		return
+	}
+	if tokenA == tokenB {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "token_a and token_b must be different"})
+		return
+	}
+
+	var tracks []models.MusicMetadata
+	if err := h.db.WithContext(c.Request.Context()).
+		Select("token_id", "title", "artist", "registered_at").
+		Where("token_id IN ?", []uint64{tokenA, tokenB}).
+		Find(&tracks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	byToken := make(map[uint64]models.MusicMetadata, len(tracks))
+	for _, track := range tracks {
+		byToken[track.TokenID] = track
+	}
+	a, okA := byToken[tokenA]
+	b, okB := byToken[tokenB]
+	if !okA || !okB {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Music not found"})
+		return
+	}
+
+	audienceA := mockdata.PlatformAudiences(mockdata.GeneratePlatformStats(a.TokenID, a.RegisteredAt))
+	audienceB := mockdata.PlatformAudiences(mockdata.GeneratePlatformStats(b.TokenID, b.RegisteredAt))
+
+	c.JSON(http.StatusOK, gin.H{
+		"token_a":     gin.H{"token_id": a.TokenID, "title": a.Title, "artist": a.Artist, "audience": audienceA},
+		"token_b":     gin.H{"token_id": b.TokenID, "title": b.Title, "artist": b.Artist, "audience": audienceB},
+		"overlap":     metrics.EstimateOverlap(audienceA, audienceB),
+		"methodology": "Per platform, up to half of the smaller audience is shared, scaled by how similar the tracks' platform mixes are",
+	})
+}
+
+// GetProjectedRoyalties annualises the track's recent royalty run-rate and
+// estimates the investor pool's cut under its campaign split. With address,
+// the contributor's own share of that pool is included.
+// GET /api/v1/analytics/:tokenId/projected-royalties?address=0x...
+func (h *AnalyticsHandler) GetProjectedRoyalties(c *gin.Context) {
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	var music models.MusicMetadata
+	if err := h.db.Where("token_id = ?", tokenID).First(&music).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Music not found"})
+		return
+	}
+
+	now := time.Now()
+	since := now.Add(-services.ProjectionWindow)
+	if music.RegisteredAt.After(since) {
+		since = music.RegisteredAt
+	}
+
+	var recent struct {
+		Total string
+		Count int64
+	}
+	if err := h.db.Model(&models.RoyaltyPayment{}).
+		Select("COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) as total, COUNT(*) as count").
+		Where("token_id = ? AND paid_at >= ?", tokenID, since).
+		Scan(&recent).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	recentTotal, ok := new(big.Int).SetString(recent.Total, 10)
+	if !ok {
+		recentTotal = new(big.Int)
+	}
+	observed := now.Sub(since)
+	annual := services.AnnualizeRoyalties(recentTotal, observed)
+
+	response := gin.H{
+		"token_id":           tokenID,
+		"window_start":       since,
+		"observed_days":      math.Round(observed.Hours()/24*100) / 100,
+		"payment_count":      recent.Count,
+		"recent_royalties":   recentTotal.String(),
+		"projected_annual":   annual.String(),
+		"confidence":         services.ProjectionConfidence(recent.Count, observed),
+		"campaign_id":        nil,
+		"royalty_percentage": 0,
+		"investor_pool":      "0",
+	}
+
+	// Prefer the funded campaign; fall back to one still raising
+	var campaign models.Campaign
+	err = h.db.Where("token_id = ? AND status IN ?", tokenID, []string{"successful", "active"}).
+		Order("FIELD(status, 'successful', 'active'), created_at DESC").
+		First(&campaign).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	pool := services.BasisPointsOf(annual, campaign.RoyaltyPercentage)
+	response["campaign_id"] = campaign.CampaignID
+	response["royalty_percentage"] = campaign.RoyaltyPercentage
+	response["investor_pool"] = pool.String()
+
+	// The cut is what DistributePayment would give the address from the
+	// projected royalties, using current contribution amounts
+	if address := c.Query("address"); address != "" {
+		contributions, err := services.CampaignContributionTotals(h.db.WithContext(c.Request.Context()), campaign.CampaignID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		contributed, stake := new(big.Int), new(big.Int)
+		for contributor, amount := range contributions {
+			contributed.Add(contributed, amount)
+			if strings.EqualFold(contributor, address) {
+				stake.Add(stake, amount)
+			}
+		}
+
+		// A creator's own contribution is folded into the creator split, not the investor pool
+		cut := new(big.Int)
+		if stake.Sign() > 0 && !strings.EqualFold(address, music.CreatorAddress) {
+			cut = services.SplitAmountFor(services.ComputeSplits(annual, music.CreatorAddress, campaign.RoyaltyPercentage, contributions), address)
+		}
+		response["address"] = address
+		response["share_percentage"] = services.ContributionShare(stake, contributed)
+		response["projected_investor_cut"] = cut.String()
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetRoyaltyEfficiency ranks the platforms paying a track by royalties per
+// play. Plays come from the platform stats (views for TikTok); platforms
+// paying royalties without play data are listed last, unrated.
+// GET /api/v1/analytics/:tokenId/royalty-efficiency
+func (h *AnalyticsHandler) GetRoyaltyEfficiency(c *gin.Context) {
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	var music models.MusicMetadata
+	if err := h.db.Where("token_id = ?", tokenID).First(&music).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Music not found"})
+		return
+	}
+
+	var rows []struct {
+		Platform string
+		Total    string
+	}
+	if err := h.db.Model(&models.RoyaltyPayment{}).
+		Select("platform, COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) as total").
+		Where("token_id = ?", tokenID).
+		Group("platform").
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	royalties := make(map[string]*big.Int, len(rows))
+	for _, row := range rows {
+		total, ok := new(big.Int).SetString(row.Total, 10)
+		if !ok {
+			continue
+		}
+		key := services.PlatformKey(row.Platform)
+		if royalties[key] == nil {
+			royalties[key] = new(big.Int)
+		}
+		royalties[key].Add(royalties[key], total)
+	}
+
+	stats := mockdata.GeneratePlatformStats(tokenID, music.RegisteredAt)
+	plays := map[string]uint64{
+		"spotify":     stats.Spotify.Plays,
+		"tiktok":      stats.TikTok.Views,
+		"apple_music": stats.AppleMusic.Plays,
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token_id": tokenID,
+		"ranking":  services.RankRoyaltyEfficiency(royalties, plays),
+		"unit":     "wei per play",
+	})
+}
+
+// Momentum periods, in days
+const (
+	defaultMomentumPeriodDays = 7
+	maxMomentumPeriodDays     = 90
+)
+
+// GetMomentum compares the growth in plays and views over the latest period
+// with the period before it, from platform stats snapshots taken at the start
+// of each period and now. Tracks without two full periods of history get
+// zero, flat momentum.
+// GET /api/v1/analytics/:tokenId/momentum?period_days=7
+func (h *AnalyticsHandler) GetMomentum(c *gin.Context) {
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	periodDays, err := parseNonNegativeQuery(c, "period_days", defaultMomentumPeriodDays)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if periodDays < 1 || periodDays > maxMomentumPeriodDays {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("period_days must be between 1 and %d", maxMomentumPeriodDays)})
+		return
+	}
+
+	var music models.MusicMetadata
+	if err := h.db.Where("token_id = ?", tokenID).First(&music).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Music not found"})
+		return
+	}
+
+	now := time.Now()
+	snapshots := []uint64{}
+	for i := 2; i >= 0; i-- {
+		asOf := now.AddDate(0, 0, -i*periodDays)
+		if asOf.Before(music.RegisteredAt) {
+			continue // no snapshot before the track existed
+		}
+		stats := mockdata.GeneratePlatformStatsAt(tokenID, music.RegisteredAt, asOf)
+		snapshots = append(snapshots, stats.Spotify.Plays+stats.AppleMusic.Plays+stats.TikTok.Views)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token_id":    tokenID,
+		"period_days": periodDays,
+		"snapshots":   snapshots,
+		"momentum":    services.ComputeMomentum(snapshots, periodDays),
+	})
+}
@@ -0,0 +1,433 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestGetBatchAnalytics(t *testing.T) {
+	columns := []string{"token_id", "title", "viral_score", "play_count", "view_count", "listener_count", "trending_rank", "total_usages", "total_royalties"}
+
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery(`FROM music_metadata m LEFT JOIN analytics a .* WHERE m.token_id IN \(\?,\?,\?,\?\) AND m.deleted_at IS NULL`).
+		WithArgs(1, 2, 3, 2).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, "First", 81.5, 1200, 300, 90, 4, 2, "5000").
+			AddRow(3, "Third", 12.0, 10, 0, 3, 0, 0, "0"))
+
+	router := gin.New()
+	router.POST("/analytics/batch", NewAnalyticsHandler(db).GetBatchAnalytics)
+
+	rec := record(router, http.MethodPost, "/analytics/batch", "", `{"token_ids":[1,2,3,2]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+
+	var body struct {
+		Data map[string]struct {
+			TokenID        uint64  `json:"token_id"`
+			ViralScore     float64 `json:"viral_score"`
+			PlayCount      uint64  `json:"play_count"`
+			TrendingRank   int     `json:"trending_rank"`
+			TotalRoyalties string  `json:"total_royalties"`
+		} `json:"data"`
+		Found   int      `json:"found"`
+		Missing []uint64 `json:"missing"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	if body.Found != 2 {
+		t.Errorf("found = %d, want 2", body.Found)
+	}
+	if !reflect.DeepEqual(body.Missing, []uint64{2}) {
+		t.Errorf("missing = %v, want [2]", body.Missing)
+	}
+	first, ok := body.Data["1"]
+	if !ok {
+		t.Fatalf("data has no entry for token 1: %v", body.Data)
+	}
+	if first.ViralScore != 81.5 || first.PlayCount != 1200 || first.TrendingRank != 4 || first.TotalRoyalties != "5000" {
+		t.Errorf("data[1] = %+v, want the stored analytics", first)
+	}
+	if _, ok := body.Data["3"]; !ok {
+		t.Errorf("data has no entry for token 3: %v", body.Data)
+	}
+}
+
+func TestGetBatchAnalyticsValidation(t *testing.T) {
+	ids := make([]string, maxBatchTokens+1)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i + 1)
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "no body", body: ""},
+		{name: "missing token_ids", body: `{}`},
+		{name: "empty token_ids", body: `{"token_ids":[]}`},
+		{name: "non-numeric token id", body: `{"token_ids":["one"]}`},
+		{name: "over the batch cap", body: `{"token_ids":[` + strings.Join(ids, ",") + `]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := dbtest.New(t)
+			router := gin.New()
+			router.POST("/analytics/batch", NewAnalyticsHandler(db).GetBatchAnalytics)
+
+			if status := serve(router, http.MethodPost, "/analytics/batch", "", tt.body); status != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestCompareTracks(t *testing.T) {
+	columns := []string{"token_id", "title", "artist", "creator_address", "play_count", "view_count", "listener_count", "viral_score", "total_royalties"}
+	bothTracks := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`FROM music_metadata m LEFT JOIN analytics a .* WHERE m.token_id IN \(\?,\?\)`).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, "A", "Artist", "0xCreator", 1500, 300, 90, 80.0, "2000").
+				AddRow(2, "B", "Artist", "0xcreator", 1000, 300, 120, 64.0, "oops"))
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		expect func(mock sqlmock.Sqlmock)
+		want   int
+	}{
+		{name: "compares both tracks", query: "token_a=1&token_b=2", expect: bothTracks, want: http.StatusOK},
+		{name: "owner address matches case-insensitively", query: "token_a=1&token_b=2&address=0xCREATOR", expect: bothTracks, want: http.StatusOK},
+		{name: "tracks of another address", query: "token_a=1&token_b=2&address=0xother", expect: bothTracks, want: http.StatusForbidden},
+		{
+			name:  "missing track",
+			query: "token_a=1&token_b=2",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM music_metadata m`).WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(1, "A", "Artist", "0xcreator", 1, 1, 1, 1.0, "0"))
+			},
+			want: http.StatusNotFound,
+		},
+		{name: "same token twice", query: "token_a=1&token_b=1", expect: func(sqlmock.Sqlmock) {}, want: http.StatusBadRequest},
+		{name: "invalid token", query: "token_a=x&token_b=2", expect: func(sqlmock.Sqlmock) {}, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			tt.expect(mock)
+			router := gin.New()
+			router.GET("/analytics/compare", NewAnalyticsHandler(db).CompareTracks)
+
+			rec := record(router, http.MethodGet, "/analytics/compare?"+tt.query, "", "")
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Metrics []struct {
+					Metric string `json:"metric"`
+					Delta  string `json:"delta"`
+					Winner string `json:"winner"`
+				} `json:"metrics"`
+				Wins map[string]int `json:"wins"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+
+			want := map[string][2]string{
+				"plays":       {"500", "a"},
+				"views":       {"0", "tie"},
+				"listeners":   {"-30", "b"},
+				"viral_score": {"16.00", "a"},
+				"earnings":    {"2000", "a"}, // B's unparsable royalties count as 0
+			}
+			if len(body.Metrics) != len(want) {
+				t.Fatalf("got %d metrics, want %d", len(body.Metrics), len(want))
+			}
+			for _, m := range body.Metrics {
+				if w := want[m.Metric]; m.Delta != w[0] || m.Winner != w[1] {
+					t.Errorf("%s = delta %s winner %s, want delta %s winner %s", m.Metric, m.Delta, m.Winner, w[0], w[1])
+				}
+			}
+			if !reflect.DeepEqual(body.Wins, map[string]int{"a": 3, "b": 1, "tie": 1}) {
+				t.Errorf("wins = %v, want a:3 b:1 tie:1", body.Wins)
+			}
+		})
+	}
+}
+
+func TestGetProjectedRoyalties(t *testing.T) {
+	registeredLongAgo := time.Now().AddDate(-1, 0, 0)
+	musicColumns := []string{"id", "token_id", "creator_address", "registered_at"}
+	campaignColumns := []string{"id", "campaign_id", "token_id", "royalty_percentage", "status"}
+
+	tests := []struct {
+		name     string
+		query    string
+		payments *sqlmock.Rows
+		campaign *sqlmock.Rows
+		expect   func(mock sqlmock.Sqlmock)
+		want     map[string]interface{}
+	}{
+		{
+			name:     "steady run-rate with a funded campaign",
+			payments: sqlmock.NewRows([]string{"total", "count"}).AddRow("900", 12),
+			campaign: sqlmock.NewRows(campaignColumns).AddRow(1, 5, 7, 3000, "successful"),
+			want: map[string]interface{}{
+				"recent_royalties": "900",
+				"projected_annual": "3650",
+				"confidence":       "high",
+				"investor_pool":    "1095",
+			},
+		},
+		{
+			name:     "investor's projected cut",
+			query:    "?address=0xA",
+			payments: sqlmock.NewRows([]string{"total", "count"}).AddRow("900", 12),
+			campaign: sqlmock.NewRows(campaignColumns).AddRow(1, 5, 7, 3000, "successful"),
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT `contributor_address`,`amount` FROM `contributions` WHERE campaign_id = \\?").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows([]string{"contributor_address", "amount"}).
+						AddRow("0xa", "100").
+						AddRow("0xb", "300"))
+			},
+			want: map[string]interface{}{
+				"projected_annual":       "3650",
+				"investor_pool":          "1095",
+				"share_percentage":       25.0,
+				"projected_investor_cut": "273",
+			},
+		},
+		{
+			name:     "no payments and no campaign",
+			payments: sqlmock.NewRows([]string{"total", "count"}).AddRow("0", 0),
+			campaign: sqlmock.NewRows(campaignColumns),
+			want: map[string]interface{}{
+				"projected_annual": "0",
+				"confidence":       "none",
+				"investor_pool":    "0",
+				"campaign_id":      nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+				WithArgs(7).
+				WillReturnRows(sqlmock.NewRows(musicColumns).AddRow(1, 7, "0xcreator", registeredLongAgo))
+			mock.ExpectQuery("SELECT COALESCE\\(SUM\\(CAST\\(amount AS DECIMAL\\(30,0\\)\\)\\), 0\\) as total, COUNT\\(\\*\\) as count FROM `royalty_payments` WHERE token_id = \\? AND paid_at >= \\?").
+				WithArgs(7, sqlmock.AnyArg()).
+				WillReturnRows(tt.payments)
+			mock.ExpectQuery("SELECT \\* FROM `campaigns` WHERE \\(token_id = \\? AND status IN \\(\\?,\\?\\)\\) AND `campaigns`.`deleted_at` IS NULL ORDER BY FIELD\\(status, 'successful', 'active'\\), created_at DESC").
+				WithArgs(7, "successful", "active").
+				WillReturnRows(tt.campaign)
+			if tt.expect != nil {
+				tt.expect(mock)
+			}
+
+			router := gin.New()
+			router.GET("/analytics/:tokenId/projected-royalties", NewAnalyticsHandler(db).GetProjectedRoyalties)
+
+			rec := record(router, http.MethodGet, "/analytics/7/projected-royalties"+tt.query, "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			var body map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			for key, want := range tt.want {
+				if got := body[key]; got != want {
+					t.Errorf("%s = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetProjectedRoyaltiesSinceRegistration(t *testing.T) {
+	registered := time.Now().Add(-10 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "registered_at"}).AddRow(1, 7, registered))
+	// A track younger than the window is only observed since it was registered
+	mock.ExpectQuery("FROM `royalty_payments` WHERE token_id = \\? AND paid_at >= \\?").
+		WithArgs(7, registered).
+		WillReturnRows(sqlmock.NewRows([]string{"total", "count"}).AddRow("100", 4))
+	mock.ExpectQuery("SELECT \\* FROM `campaigns`").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	router := gin.New()
+	router.GET("/analytics/:tokenId/projected-royalties", NewAnalyticsHandler(db).GetProjectedRoyalties)
+
+	rec := record(router, http.MethodGet, "/analytics/7/projected-royalties", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var body struct {
+		ObservedDays float64 `json:"observed_days"`
+		Confidence   string  `json:"confidence"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.ObservedDays != 10 || body.Confidence != "low" {
+		t.Errorf("observed %v days with %s confidence, want 10 days with low confidence", body.ObservedDays, body.Confidence)
+	}
+}
+
+func TestGetTrendingFeed(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		limit string
+	}{
+		{name: "default limit", limit: "20"},
+		{name: "custom limit", query: "?limit=5", limit: "5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			// Untrended tracks are filtered out by the rank condition and the
+			// database returns the rest best rank first
+			mock.ExpectQuery("FROM music_metadata m LEFT JOIN analytics a .* LEFT JOIN users u .* "+
+				"WHERE m.trending_rank > \\? AND m.is_active = \\? AND m.deleted_at IS NULL "+
+				"ORDER BY m.trending_rank ASC, m.viral_score DESC, m.token_id ASC LIMIT "+tt.limit).
+				WithArgs(0, true).
+				WillReturnRows(sqlmock.NewRows([]string{"token_id", "title", "trending_rank", "weekly_growth", "creator_address", "display_name", "is_verified"}).
+					AddRow(12, "Hit", 1, 0.4, "0xstar", "Star", true).
+					AddRow(7, "Riser", 2, 1.5, "0xnew", "", false))
+
+			router := gin.New()
+			router.GET("/analytics/trending-feed", NewAnalyticsHandler(db).GetTrendingFeed)
+
+			rec := record(router, http.MethodGet, "/analytics/trending-feed"+tt.query, "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Feed []struct {
+					TokenID      uint64  `json:"token_id"`
+					TrendingRank int     `json:"trending_rank"`
+					WeeklyGrowth float64 `json:"weekly_growth"`
+					Creator      struct {
+						Address     string `json:"address"`
+						DisplayName string `json:"display_name"`
+						IsVerified  bool   `json:"is_verified"`
+					} `json:"creator"`
+				} `json:"feed"`
+				Total int `json:"total"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Total != 2 || len(body.Feed) != 2 {
+				t.Fatalf("got %d items (total %d), want 2", len(body.Feed), body.Total)
+			}
+			if body.Feed[0].TokenID != 12 || body.Feed[0].TrendingRank != 1 || body.Feed[1].TokenID != 7 || body.Feed[1].TrendingRank != 2 {
+				t.Errorf("feed = %+v, want token 12 at rank 1 then token 7 at rank 2", body.Feed)
+			}
+			if c := body.Feed[0].Creator; c.Address != "0xstar" || c.DisplayName != "Star" || !c.IsVerified {
+				t.Errorf("creator = %+v, want verified 0xstar named Star", c)
+			}
+			if body.Feed[1].WeeklyGrowth != 1.5 {
+				t.Errorf("weekly_growth = %v, want 1.5", body.Feed[1].WeeklyGrowth)
+			}
+		})
+	}
+}
+
+func TestGetReachTrend(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		query      string
+		registered time.Time
+		wantDays   int
+		wantZero   int // leading days before the track was registered
+	}{
+		{name: "default window", registered: today.AddDate(-1, 0, 0), wantDays: defaultReachTrendDays},
+		{name: "one week", query: "?days=7", registered: today.AddDate(-1, 0, 0), wantDays: 7},
+		{name: "single day", query: "?days=1", registered: today.AddDate(-1, 0, 0), wantDays: 1},
+		{name: "maximum window", query: "?days=365", registered: today.AddDate(-2, 0, 0), wantDays: maxReachTrendDays},
+		{name: "track younger than the window", query: "?days=10", registered: today.AddDate(0, 0, -3).Add(12 * time.Hour), wantDays: 10, wantZero: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+				WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "registered_at"}).AddRow(1, 7, tt.registered))
+
+			router := gin.New()
+			router.GET("/analytics/:tokenId/reach-trend", NewAnalyticsHandler(db).GetReachTrend)
+
+			rec := record(router, http.MethodGet, "/analytics/7/reach-trend"+tt.query, "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Days  int          `json:"days"`
+				Trend []ReachPoint `json:"trend"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Days != tt.wantDays || len(body.Trend) != tt.wantDays {
+				t.Fatalf("got %d points for %d days, want %d", len(body.Trend), body.Days, tt.wantDays)
+			}
+
+			// One point per day, oldest first, ending today
+			for i, point := range body.Trend {
+				if want := today.AddDate(0, 0, i-(tt.wantDays-1)).Format("2006-01-02"); point.Date != want {
+					t.Errorf("point %d date = %s, want %s", i, point.Date, want)
+				}
+				if (i < tt.wantZero) != (point.EstimatedReach == 0) {
+					t.Errorf("point %d (%s) reach = %d, want zero only before registration", i, point.Date, point.EstimatedReach)
+				}
+			}
+		})
+	}
+}
+
+func TestGetReachTrendRejectsWindow(t *testing.T) {
+	db, _ := dbtest.New(t)
+	router := gin.New()
+	router.GET("/analytics/:tokenId/reach-trend", NewAnalyticsHandler(db).GetReachTrend)
+
+	for _, query := range []string{"?days=0", "?days=366", "?days=-1", "?days=week"} {
+		if status := serve(router, http.MethodGet, "/analytics/7/reach-trend"+query, "", ""); status != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", query, status, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// Audience growth windows, in days
+const (
+	defaultAudienceGrowthDays = 30
+	maxAudienceGrowthDays     = 365
+)
+
+// GetAudienceGrowth returns how much the creator's total listeners across
+// their catalog grew over the last days, absolute, as a percentage and per
+// day, measured from the weekly snapshots. With fewer than two snapshots the
+// growth figures are null; when the snapshots start inside the window the
+// growth covers only the days they span.
+// GET /api/v1/users/:address/audience-growth?days=30
+func (h *UserHandler) GetAudienceGrowth(c *gin.Context) {
+	address := c.Param("address")
+
+	days, err := parseNonNegativeQuery(c, "days", defaultAudienceGrowthDays)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if days < 7 || days > maxAudienceGrowthDays {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("days must be between 7 and %d", maxAudienceGrowthDays)})
+		return
+	}
+
+	// A week's totals are taken at its end, so the snapshot covering the
+	// window's start can begin up to two weeks before it
+	now := time.Now()
+	var snapshots []models.CreatorWeeklySnapshot
+	if err := h.db.WithContext(c.Request.Context()).
+		Where("wallet_address = ? AND week_start >= ?", address, services.WeekStart(now.AddDate(0, 0, -days-7))).
+		Order("week_start ASC").
+		Find(&snapshots).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address": address,
+		"growth":  services.ComputeAudienceGrowth(snapshots, days, now),
+	})
+}
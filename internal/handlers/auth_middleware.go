@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+)
+
+// claimsKey is the gin context key holding the authenticated caller's claims
+const claimsKey = "auth_claims"
+
+// verifyToken checks the bearer token and stores its claims on the context,
+// aborting with 401 when it is missing or invalid
+func verifyToken(c *gin.Context, secret string) (*auth.Claims, bool) {
+	header := c.GetHeader("Authorization")
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
+		return nil, false
+	}
+
+	claims, err := auth.ParseToken(secret, token)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	c.Set(claimsKey, claims)
+	return claims, true
+}
+
+// verifyUserToken is verifyToken for user and admin tokens only: upload and
+// service tokens are scoped to their own endpoints and do not identify a
+// user, so they are rejected with 403
+func verifyUserToken(c *gin.Context, secret string) (*auth.Claims, bool) {
+	claims, ok := verifyToken(c, secret)
+	if !ok {
+		return nil, false
+	}
+	if claims.Role != auth.RoleUser && claims.Role != auth.RoleAdmin {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "token is not valid for this endpoint"})
+		return nil, false
+	}
+	return claims, true
+}
+
+// RequireAuth verifies a user or admin bearer token and stores its claims on
+// the context
+func RequireAuth(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := verifyUserToken(c, secret); !ok {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireOwner allows the request only when a user token's subject matches
+// the :address path parameter (admins may act on any address)
+func RequireOwner(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := verifyUserToken(c, secret)
+		if !ok {
+			return
+		}
+		if !ownsAddress(claims, c.Param("address")) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "token does not belong to this address"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ownsAddress reports whether claims may act on address: its own subject, or
+// any address for admins
+func ownsAddress(claims *auth.Claims, address string) bool {
+	return claims.Role == auth.RoleAdmin || strings.EqualFold(claims.Subject, address)
+}
+
+// RequireRole allows the request only when the token carries one of the given roles
+func RequireRole(secret string, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := verifyToken(c, secret)
+		if !ok {
+			return
+		}
+
+		for _, role := range roles {
+			if claims.Role == role {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+	}
+}
+
+func authClaims(c *gin.Context) *auth.Claims {
+	if value, ok := c.Get(claimsKey); ok {
+		if claims, ok := value.(*auth.Claims); ok {
+			return claims
+		}
+	}
+	return nil
+}
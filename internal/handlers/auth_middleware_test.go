@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+)
+
+const testSecret = "test-secret"
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// bearer issues a token for subject and role, signed with secret
+func bearer(t *testing.T, secret, subject, role string, ttl time.Duration) string {
+	t.Helper()
+	token, err := auth.IssueToken(secret, subject, role, ttl)
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+	return "Bearer " + token
+}
+
+// serve runs one request through router and returns the response status
+func serve(router *gin.Engine, method, path, authorization, body string) int {
+	return record(router, method, path, authorization, body).Code
+}
+
+// record runs one request through router and returns the full response
+func record(router *gin.Engine, method, path, authorization, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	router := gin.New()
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	router.GET("/auth", RequireAuth(testSecret), ok)
+	router.GET("/owner/:address", RequireOwner(testSecret), ok)
+	router.GET("/role", RequireRole(testSecret, auth.RoleAdmin, auth.RoleService), ok)
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		want          int
+	}{
+		{name: "auth: missing token", path: "/auth", want: http.StatusUnauthorized},
+		{name: "auth: not a bearer token", path: "/auth", authorization: "Basic abc", want: http.StatusUnauthorized},
+		{name: "auth: wrong secret", path: "/auth", authorization: bearer(t, "other-secret", "0xabc", auth.RoleUser, time.Hour), want: http.StatusUnauthorized},
+		{name: "auth: expired token", path: "/auth", authorization: bearer(t, testSecret, "0xabc", auth.RoleUser, -time.Minute), want: http.StatusUnauthorized},
+		{name: "auth: user token", path: "/auth", authorization: bearer(t, testSecret, "0xabc", auth.RoleUser, time.Hour), want: http.StatusNoContent},
+		{name: "auth: admin token", path: "/auth", authorization: bearer(t, testSecret, "0xadmin", auth.RoleAdmin, time.Hour), want: http.StatusNoContent},
+		{name: "auth: upload token", path: "/auth", authorization: bearer(t, testSecret, "0xabc", auth.RoleUpload, time.Hour), want: http.StatusForbidden},
+		{name: "auth: service token", path: "/auth", authorization: bearer(t, testSecret, "indexer", auth.RoleService, time.Hour), want: http.StatusForbidden},
+		{name: "owner: own address", path: "/owner/0xabc", authorization: bearer(t, testSecret, "0xabc", auth.RoleUser, time.Hour), want: http.StatusNoContent},
+		{name: "owner: own address in another case", path: "/owner/0xABC", authorization: bearer(t, testSecret, "0xabc", auth.RoleUser, time.Hour), want: http.StatusNoContent},
+		{name: "owner: someone else's address", path: "/owner/0xdef", authorization: bearer(t, testSecret, "0xabc", auth.RoleUser, time.Hour), want: http.StatusForbidden},
+		{name: "owner: admin on any address", path: "/owner/0xdef", authorization: bearer(t, testSecret, "0xadmin", auth.RoleAdmin, time.Hour), want: http.StatusNoContent},
+		{name: "owner: upload token for the address", path: "/owner/0xabc", authorization: bearer(t, testSecret, "0xabc", auth.RoleUpload, time.Hour), want: http.StatusForbidden},
+		{name: "role: missing token", path: "/role", want: http.StatusUnauthorized},
+		{name: "role: listed role", path: "/role", authorization: bearer(t, testSecret, "indexer", auth.RoleService, time.Hour), want: http.StatusNoContent},
+		{name: "role: unlisted role", path: "/role", authorization: bearer(t, testSecret, "0xabc", auth.RoleUser, time.Hour), want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serve(router, http.MethodGet, tt.path, tt.authorization, ""); got != tt.want {
+				t.Errorf("GET %s = %d, want %d", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireOwnerStopsBeforeHandler(t *testing.T) {
+	router := gin.New()
+	ran := false
+	router.GET("/owner/:address", RequireOwner(testSecret), func(c *gin.Context) {
+		ran = true
+		c.Status(http.StatusNoContent)
+	})
+
+	if got := serve(router, http.MethodGet, "/owner/0xdef", bearer(t, testSecret, "0xabc", auth.RoleUser, time.Hour), ""); got != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", got, http.StatusForbidden)
+	}
+	if ran {
+		t.Error("handler ran for a caller who does not own the address")
+	}
+}
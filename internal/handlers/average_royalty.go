@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"math"
+	"math/big"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+)
+
+// GetAverageRoyalty returns the raised-amount-weighted average royalty share
+// the creator has committed across their active campaigns, with min and max.
+// When nothing has been raised yet every campaign counts equally.
+// GET /api/v1/users/:address/avg-royalty
+func (h *UserHandler) GetAverageRoyalty(c *gin.Context) {
+	address := c.Param("address")
+
+	var campaigns []models.Campaign
+	if err := h.db.Select("campaign_id", "royalty_percentage", "raised_amount").
+		Where("creator_address = ? AND status = ?", address, "active").
+		Find(&campaigns).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := gin.H{
+		"address":        address,
+		"campaign_count": len(campaigns),
+		"weighted_by":    "raised_amount",
+		"average_bps":    0.0,
+		"average_pct":    0.0,
+		"min_bps":        0,
+		"max_bps":        0,
+		"total_raised":   "0",
+	}
+	if len(campaigns) == 0 {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	weighted := new(big.Int)
+	totalRaised := new(big.Int)
+	unweightedSum := 0
+	minBps, maxBps := campaigns[0].RoyaltyPercentage, campaigns[0].RoyaltyPercentage
+	for _, campaign := range campaigns {
+		bps := campaign.RoyaltyPercentage
+		if bps < minBps {
+			minBps = bps
+		}
+		if bps > maxBps {
+			maxBps = bps
+		}
+		unweightedSum += int(bps)
+
+		raised, ok := new(big.Int).SetString(campaign.RaisedAmount, 10)
+		if !ok || raised.Sign() <= 0 {
+			continue
+		}
+		totalRaised.Add(totalRaised, raised)
+		weighted.Add(weighted, new(big.Int).Mul(raised, big.NewInt(int64(bps))))
+	}
+
+	var average float64
+	if totalRaised.Sign() > 0 {
+		average, _ = new(big.Rat).SetFrac(weighted, totalRaised).Float64()
+	} else {
+		average = float64(unweightedSum) / float64(len(campaigns))
+		response["weighted_by"] = "none"
+	}
+
+	response["average_bps"] = math.Round(average*100) / 100
+	response["average_pct"] = math.Round(average) / 100
+	response["min_bps"] = minBps
+	response["max_bps"] = maxBps
+	response["total_raised"] = totalRaised.String()
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestGetAverageRoyalty(t *testing.T) {
+	type campaign struct {
+		bps    int
+		raised string
+	}
+
+	tests := []struct {
+		name         string
+		campaigns    []campaign
+		wantAverage  float64
+		wantPct      float64
+		wantMin      int
+		wantMax      int
+		wantRaised   string
+		wantWeighted string
+	}{
+		{
+			name:         "weighted by raised amount",
+			campaigns:    []campaign{{3000, "1000"}, {1000, "3000"}},
+			wantAverage:  1500,
+			wantPct:      15,
+			wantMin:      1000,
+			wantMax:      3000,
+			wantRaised:   "4000",
+			wantWeighted: "raised_amount",
+		},
+		{
+			name:         "average is rounded to two decimals",
+			campaigns:    []campaign{{3000, "1"}, {1000, "2"}},
+			wantAverage:  1666.67,
+			wantPct:      16.67,
+			wantMin:      1000,
+			wantMax:      3000,
+			wantRaised:   "3",
+			wantWeighted: "raised_amount",
+		},
+		{
+			name:         "campaigns with nothing raised carry no weight but count toward min and max",
+			campaigns:    []campaign{{2000, "0"}, {4000, "500"}, {500, "bad"}},
+			wantAverage:  4000,
+			wantPct:      40,
+			wantMin:      500,
+			wantMax:      4000,
+			wantRaised:   "500",
+			wantWeighted: "raised_amount",
+		},
+		{
+			name:         "amounts beyond int64 are weighted exactly",
+			campaigns:    []campaign{{2500, "3000000000000000000000"}, {2000, "1000000000000000000000"}},
+			wantAverage:  2375,
+			wantPct:      23.75,
+			wantMin:      2000,
+			wantMax:      2500,
+			wantRaised:   "4000000000000000000000",
+			wantWeighted: "raised_amount",
+		},
+		{
+			name:         "nothing raised falls back to an unweighted mean",
+			campaigns:    []campaign{{2000, "0"}, {2500, "0"}},
+			wantAverage:  2250,
+			wantPct:      22.5,
+			wantMin:      2000,
+			wantMax:      2500,
+			wantRaised:   "0",
+			wantWeighted: "none",
+		},
+		{
+			name:         "no active campaigns",
+			wantRaised:   "0",
+			wantWeighted: "raised_amount",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows([]string{"campaign_id", "royalty_percentage", "raised_amount"})
+			for i, c := range tt.campaigns {
+				rows.AddRow(i+1, c.bps, c.raised)
+			}
+			mock.ExpectQuery("SELECT `campaign_id`,`royalty_percentage`,`raised_amount` FROM `campaigns` WHERE \\(creator_address = \\? AND status = \\?\\)").
+				WithArgs("0xcreator", "active").
+				WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/users/:address/avg-royalty", NewUserHandler(db).GetAverageRoyalty)
+
+			rec := record(router, http.MethodGet, "/users/0xcreator/avg-royalty", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Count      int     `json:"campaign_count"`
+				WeightedBy string  `json:"weighted_by"`
+				Average    float64 `json:"average_bps"`
+				Pct        float64 `json:"average_pct"`
+				Min        int     `json:"min_bps"`
+				Max        int     `json:"max_bps"`
+				Raised     string  `json:"total_raised"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Count != len(tt.campaigns) || body.WeightedBy != tt.wantWeighted {
+				t.Errorf("campaign_count = %d, weighted_by = %s, want %d, %s", body.Count, body.WeightedBy, len(tt.campaigns), tt.wantWeighted)
+			}
+			if body.Average != tt.wantAverage || body.Pct != tt.wantPct {
+				t.Errorf("average = %v bps (%v%%), want %v bps (%v%%)", body.Average, body.Pct, tt.wantAverage, tt.wantPct)
+			}
+			if body.Min != tt.wantMin || body.Max != tt.wantMax || body.Raised != tt.wantRaised {
+				t.Errorf("min %d, max %d, raised %s, want %d, %d, %s", body.Min, body.Max, body.Raised, tt.wantMin, tt.wantMax, tt.wantRaised)
+			}
+		})
+	}
+}
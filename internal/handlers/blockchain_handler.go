@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/blockchain"
+	"github.com/tunecent/backend/internal/services"
+)
+
+type BlockchainHandler struct {
+	feeEstimator   *services.FeeEstimator
+	chainID        int64
+	chainConnected bool
+}
+
+// NewBlockchainHandler creates the handler; chainConnected reports whether
+// the node for chainID was reachable at startup
+func NewBlockchainHandler(feeEstimator *services.FeeEstimator, chainID int64, chainConnected bool) *BlockchainHandler {
+	return &BlockchainHandler{
+		feeEstimator:   feeEstimator,
+		chainID:        chainID,
+		chainConnected: chainConnected,
+	}
+}
+
+// GetContext handles GET /api/v1/context
+// Returns the ETH/USD price, gas price, chain ID and server time the UI shows
+// in one call. A dependency that is unavailable is reported in its own field
+// and leaves the rest of the response intact.
+func (h *BlockchainHandler) GetContext(c *gin.Context) {
+	gas := gin.H{"available": false, "gas_price_wei": nil}
+	gasPrice, err := h.feeEstimator.GasPrice(c.Request.Context())
+	switch {
+	case err == nil:
+		gas["available"] = true
+		gas["gas_price_wei"] = gasPrice.String()
+	case errors.Is(err, services.ErrBlockchainNotConfigured):
+		gas["error"] = err.Error()
+	default:
+		slog.WarnContext(c.Request.Context(), "Gas price unavailable for context", "error", err)
+		gas["error"] = "gas price temporarily unavailable"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"eth_price_usd": gin.H{
+			"price":  services.ETHPriceUSD,
+			"source": "mock",
+		},
+		"gas": gas,
+		"chain": gin.H{
+			"chain_id":  h.chainID,
+			"connected": h.chainConnected,
+		},
+		"server_time": time.Now().UTC(),
+	})
+}
+
+// EstimateFee handles GET /api/v1/blockchain/estimate-fee?action=register_music&from=0x...
+func (h *BlockchainHandler) EstimateFee(c *gin.Context) {
+	action := c.Query("action")
+	if action == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "action is required",
+			"actions": services.FeeActions(),
+		})
+		return
+	}
+
+	var from common.Address
+	if sender := c.Query("from"); sender != "" {
+		if !blockchain.IsValidAddress(sender) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be a valid address"})
+			return
+		}
+		from = common.HexToAddress(sender)
+	}
+
+	estimate, err := h.feeEstimator.Estimate(c.Request.Context(), action, from)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUnknownAction):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   err.Error(),
+				"actions": services.FeeActions(),
+			})
+		case errors.Is(err, services.ErrBlockchainNotConfigured):
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error":      err.Error(),
+				"configured": false,
+			})
+		default:
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, estimate)
+}
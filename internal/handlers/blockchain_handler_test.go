@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// gasOracle is a services.GasOracle with a fixed gas price and estimate
+type gasOracle struct {
+	price    *big.Int
+	priceErr error
+	gas      uint64
+}
+
+func (o gasOracle) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return o.price, o.priceErr
+}
+
+func (o gasOracle) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return o.gas, nil
+}
+
+func TestEstimateFee(t *testing.T) {
+	tests := []struct {
+		name    string
+		oracle  services.GasOracle
+		query   string
+		want    int
+		wantFee string
+	}{
+		{name: "known gas price", oracle: gasOracle{price: big.NewInt(20e9), gas: 100000}, query: "?action=contribute", want: http.StatusOK, wantFee: "2000000000000000"},
+		{name: "valid sender", oracle: gasOracle{price: big.NewInt(20e9), gas: 100000}, query: "?action=contribute&from=0x00000000000000000000000000000000000000aa", want: http.StatusOK, wantFee: "2000000000000000"},
+		{name: "missing action", oracle: gasOracle{price: big.NewInt(1)}, want: http.StatusBadRequest},
+		{name: "unknown action", oracle: gasOracle{price: big.NewInt(1)}, query: "?action=mint", want: http.StatusBadRequest},
+		{name: "invalid sender", oracle: gasOracle{price: big.NewInt(1)}, query: "?action=contribute&from=nope", want: http.StatusBadRequest},
+		{name: "blockchain not configured", query: "?action=contribute", want: http.StatusServiceUnavailable},
+		{name: "node unavailable", oracle: gasOracle{priceErr: errors.New("rpc down")}, query: "?action=contribute", want: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/blockchain/estimate-fee", NewBlockchainHandler(services.NewFeeEstimator(tt.oracle, nil, services.GasPriceCacheTTL), 1, tt.oracle != nil).EstimateFee)
+
+			rec := record(router, http.MethodGet, "/blockchain/estimate-fee"+tt.query, "", "")
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.wantFee == "" {
+				return
+			}
+
+			var estimate services.FeeEstimate
+			if err := json.Unmarshal(rec.Body.Bytes(), &estimate); err != nil {
+				t.Fatal(err)
+			}
+			if estimate.FeeWei != tt.wantFee || estimate.GasPriceWei != "20000000000" || estimate.FeeETH != 0.002 {
+				t.Errorf("estimate = %+v, want %s wei at 20 gwei", estimate, tt.wantFee)
+			}
+		})
+	}
+}
+
+func TestGetContext(t *testing.T) {
+	tests := []struct {
+		name          string
+		oracle        services.GasOracle
+		chainID       int64
+		wantAvailable bool
+		wantGasPrice  string // empty when no price is reported
+		wantGasError  string
+	}{
+		{name: "every dependency up", oracle: gasOracle{price: big.NewInt(20e9)}, chainID: 11155111, wantAvailable: true, wantGasPrice: "20000000000"},
+		{name: "blockchain not configured", wantGasError: services.ErrBlockchainNotConfigured.Error()},
+		{name: "node unavailable", oracle: gasOracle{priceErr: errors.New("rpc down")}, chainID: 11155111, wantGasError: "gas price temporarily unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/context", NewBlockchainHandler(services.NewFeeEstimator(tt.oracle, nil, services.GasPriceCacheTTL), tt.chainID, tt.oracle != nil).GetContext)
+
+			before := time.Now().UTC().Truncate(time.Second)
+			rec := record(router, http.MethodGet, "/context", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				ETHPrice struct {
+					Price float64 `json:"price"`
+				} `json:"eth_price_usd"`
+				Gas struct {
+					Available bool    `json:"available"`
+					PriceWei  *string `json:"gas_price_wei"`
+					Error     string  `json:"error"`
+				} `json:"gas"`
+				Chain struct {
+					ChainID   int64 `json:"chain_id"`
+					Connected bool  `json:"connected"`
+				} `json:"chain"`
+				ServerTime time.Time `json:"server_time"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+
+			// The price and server time are reported whatever the chain's state
+			if body.ETHPrice.Price != services.ETHPriceUSD {
+				t.Errorf("eth price = %v, want %v", body.ETHPrice.Price, services.ETHPriceUSD)
+			}
+			if body.ServerTime.Before(before) || body.ServerTime.After(time.Now().UTC()) {
+				t.Errorf("server_time = %v, want the time of the request", body.ServerTime)
+			}
+			if body.Chain.ChainID != tt.chainID || body.Chain.Connected != (tt.oracle != nil) {
+				t.Errorf("chain = %+v, want chain %d, connected %v", body.Chain, tt.chainID, tt.oracle != nil)
+			}
+			gasPrice := ""
+			if body.Gas.PriceWei != nil {
+				gasPrice = *body.Gas.PriceWei
+			}
+			if body.Gas.Available != tt.wantAvailable || gasPrice != tt.wantGasPrice || body.Gas.Error != tt.wantGasError {
+				t.Errorf("gas = available %v, price %q, error %q, want %v, %q, %q", body.Gas.Available, gasPrice, body.Gas.Error, tt.wantAvailable, tt.wantGasPrice, tt.wantGasError)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// GetCampaignAnalytics summarizes the campaign's track analytics and the
+// royalties the investor pool would earn at the track's recent run-rate
+// GET /api/v1/campaigns/:campaignId/analytics
+func (h *CampaignHandler) GetCampaignAnalytics(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID"})
+		return
+	}
+
+	var row struct {
+		CampaignID        uint64
+		TokenID           uint64
+		Status            string
+		RoyaltyPercentage uint16
+		Title             string
+		RegisteredAt      time.Time
+		PlayCount         uint64
+		ViewCount         uint64
+		ListenerCount     uint64
+		ViralScore        float64
+		TrendingRank      int
+		TotalUsages       uint64
+		TotalRoyalties    string
+		WeeklyGrowth      float64
+		SpotifyGrowth     float64
+		TikTokGrowth      float64
+		AppleMusicGrowth  float64
+	}
+	result := h.db.Table("campaigns").
+		Select(`campaigns.campaign_id, campaigns.token_id, campaigns.status, campaigns.royalty_percentage,
+			m.title, m.registered_at, m.play_count, m.view_count, m.listener_count, m.viral_score, m.trending_rank,
+			COALESCE(a.total_usages, 0) as total_usages,
+			COALESCE(a.total_royalties, '0') as total_royalties,
+			COALESCE(a.weekly_growth, 0) as weekly_growth,
+			COALESCE(a.spotify_growth, 0) as spotify_growth,
+			COALESCE(a.tik_tok_growth, 0) as tik_tok_growth,
+			COALESCE(a.apple_music_growth, 0) as apple_music_growth`).
+		Joins("JOIN music_metadata m ON m.token_id = campaigns.token_id AND m.deleted_at IS NULL").
+		Joins("LEFT JOIN analytics a ON a.token_id = campaigns.token_id").
+		Where("campaigns.campaign_id = ? AND campaigns.deleted_at IS NULL", campaignID).
+		Limit(1).
+		Scan(&row)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
+		return
+	}
+
+	// Same run-rate methodology as GET /analytics/:tokenId/projected-royalties
+	now := time.Now()
+	since := now.Add(-services.ProjectionWindow)
+	if row.RegisteredAt.After(since) {
+		since = row.RegisteredAt
+	}
+
+	var recent struct {
+		Total string
+		Count int64
+	}
+	if err := h.db.Model(&models.RoyaltyPayment{}).
+		Select("COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) as total, COUNT(*) as count").
+		Where("token_id = ? AND paid_at >= ?", row.TokenID, since).
+		Scan(&recent).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	recentTotal, ok := new(big.Int).SetString(recent.Total, 10)
+	if !ok {
+		recentTotal = new(big.Int)
+	}
+	observed := now.Sub(since)
+	annual := services.AnnualizeRoyalties(recentTotal, observed)
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaign_id": row.CampaignID,
+		"status":      row.Status,
+		"track": gin.H{
+			"token_id":        row.TokenID,
+			"title":           row.Title,
+			"play_count":      row.PlayCount,
+			"view_count":      row.ViewCount,
+			"listener_count":  row.ListenerCount,
+			"viral_score":     row.ViralScore,
+			"trending_rank":   row.TrendingRank,
+			"total_usages":    row.TotalUsages,
+			"total_royalties": row.TotalRoyalties,
+			"growth": gin.H{
+				"weekly":      row.WeeklyGrowth,
+				"spotify":     row.SpotifyGrowth,
+				"tiktok":      row.TikTokGrowth,
+				"apple_music": row.AppleMusicGrowth,
+			},
+		},
+		"estimated_royalties": gin.H{
+			"observed_days":      math.Round(observed.Hours()/24*100) / 100,
+			"payment_count":      recent.Count,
+			"recent_royalties":   recentTotal.String(),
+			"projected_annual":   annual.String(),
+			"royalty_percentage": row.RoyaltyPercentage,
+			"investor_pool":      services.BasisPointsOf(annual, row.RoyaltyPercentage).String(),
+			"confidence":         services.ProjectionConfidence(recent.Count, observed),
+		},
+	})
+}
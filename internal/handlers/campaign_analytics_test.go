@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestGetCampaignAnalytics(t *testing.T) {
+	db, mock := dbtest.New(t)
+	// Campaign 9 funds token 7, so every figure must come from token 7
+	mock.ExpectQuery("FROM `campaigns` JOIN music_metadata m ON m.token_id = campaigns.token_id .* LEFT JOIN analytics a ON a.token_id = campaigns.token_id " +
+		"WHERE campaigns.campaign_id = \\? AND campaigns.deleted_at IS NULL LIMIT 1").
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"campaign_id", "token_id", "status", "royalty_percentage", "title", "registered_at",
+			"play_count", "view_count", "listener_count", "viral_score", "trending_rank", "total_usages", "total_royalties", "weekly_growth", "tik_tok_growth"}).
+			AddRow(9, 7, "active", 3000, "Night Drive", time.Now().AddDate(-1, 0, 0),
+				5000, 800, 1200, 72.5, 3, 40, "2500", 12.5, 30))
+	mock.ExpectQuery("SELECT COALESCE\\(SUM\\(CAST\\(amount AS DECIMAL\\(30,0\\)\\)\\), 0\\) as total, COUNT\\(\\*\\) as count FROM `royalty_payments` WHERE token_id = \\? AND paid_at >= \\?").
+		WithArgs(7, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"total", "count"}).AddRow("900", 12))
+
+	router := gin.New()
+	router.GET("/campaigns/:campaignId/analytics", NewCampaignHandler(db).GetCampaignAnalytics)
+
+	rec := record(router, http.MethodGet, "/campaigns/9/analytics", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+
+	var body struct {
+		CampaignID uint64 `json:"campaign_id"`
+		Track      struct {
+			TokenID        uint64  `json:"token_id"`
+			Title          string  `json:"title"`
+			PlayCount      uint64  `json:"play_count"`
+			ViewCount      uint64  `json:"view_count"`
+			ListenerCount  uint64  `json:"listener_count"`
+			ViralScore     float64 `json:"viral_score"`
+			TotalRoyalties string  `json:"total_royalties"`
+			Growth         struct {
+				Weekly float64 `json:"weekly"`
+				TikTok float64 `json:"tiktok"`
+			} `json:"growth"`
+		} `json:"track"`
+		Estimated struct {
+			ObservedDays    float64 `json:"observed_days"`
+			ProjectedAnnual string  `json:"projected_annual"`
+			InvestorPool    string  `json:"investor_pool"`
+			Confidence      string  `json:"confidence"`
+		} `json:"estimated_royalties"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	track := body.Track
+	if body.CampaignID != 9 || track.TokenID != 7 || track.Title != "Night Drive" {
+		t.Errorf("campaign %d track %d %q, want campaign 9 with track 7 Night Drive", body.CampaignID, track.TokenID, track.Title)
+	}
+	if track.PlayCount != 5000 || track.ViewCount != 800 || track.ListenerCount != 1200 || track.ViralScore != 72.5 || track.TotalRoyalties != "2500" {
+		t.Errorf("track = %+v, want token 7's plays, views, listeners, viral score and royalties", track)
+	}
+	if track.Growth.Weekly != 12.5 || track.Growth.TikTok != 30 {
+		t.Errorf("growth = %+v, want weekly 12.5 and tiktok 30", track.Growth)
+	}
+	// 900 over the 90-day window is 3650 a year, 30% of which goes to the pool
+	estimated := body.Estimated
+	if estimated.ObservedDays != 90 || estimated.ProjectedAnnual != "3650" || estimated.InvestorPool != "1095" || estimated.Confidence != "high" {
+		t.Errorf("estimated royalties = %+v, want 90 days projecting 3650 a year, 1095 to investors, high confidence", estimated)
+	}
+}
+
+func TestGetCampaignAnalyticsErrors(t *testing.T) {
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("FROM `campaigns` JOIN music_metadata m").
+		WithArgs(404).
+		WillReturnRows(sqlmock.NewRows([]string{"campaign_id"}))
+
+	router := gin.New()
+	router.GET("/campaigns/:campaignId/analytics", NewCampaignHandler(db).GetCampaignAnalytics)
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "unknown campaign", path: "/campaigns/404/analytics", want: http.StatusNotFound},
+		{name: "invalid campaign ID", path: "/campaigns/abc/analytics", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := serve(router, http.MethodGet, tt.path, "", ""); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// GetBreakEven estimates the track royalties, and plays at payout_per_play
+// wei (default services.DefaultPayoutPerPlayWei), after which contributors
+// have recovered their principal: the amount raised, or the goal while
+// nothing has been raised. Royalties paid since the campaign started count
+// towards it.
+// GET /api/v1/campaigns/:campaignId/break-even?payout_per_play=1600000000000
+func (h *CampaignHandler) GetBreakEven(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID"})
+		return
+	}
+
+	payoutPerPlay := services.DefaultPayoutPerPlayWei
+	if value := c.Query("payout_per_play"); value != "" {
+		parsed, ok := new(big.Int).SetString(value, 10)
+		if !ok || parsed.Sign() <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "payout_per_play must be a positive wei amount"})
+			return
+		}
+		payoutPerPlay = parsed
+	}
+
+	var campaign models.Campaign
+	if err := h.db.Where("campaign_id = ?", campaignID).First(&campaign).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
+		return
+	}
+
+	principal, ok := new(big.Int).SetString(campaign.RaisedAmount, 10)
+	principalSource := "raised_amount"
+	if !ok || principal.Sign() <= 0 {
+		principal, ok = new(big.Int).SetString(campaign.GoalAmount, 10)
+		principalSource = "goal_amount"
+		if !ok {
+			principal = new(big.Int)
+		}
+	}
+
+	var received struct {
+		Total string
+	}
+	if err := h.db.Model(&models.RoyaltyPayment{}).
+		Select("COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) as total").
+		Where("token_id = ? AND paid_at >= ?", campaign.TokenID, campaign.CreatedAt).
+		Scan(&received).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	receivedTotal, ok := new(big.Int).SetString(received.Total, 10)
+	if !ok {
+		receivedTotal = new(big.Int)
+	}
+
+	breakEven, err := services.ComputeBreakEven(principal, campaign.RoyaltyPercentage, payoutPerPlay, receivedTotal)
+	if err != nil {
+		if errors.Is(err, services.ErrNoInvestorShare) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	payoutETH, _ := new(big.Rat).SetFrac(payoutPerPlay, big.NewInt(1e18)).Float64()
+	c.JSON(http.StatusOK, gin.H{
+		"campaign_id": campaignID,
+		"token_id":    campaign.TokenID,
+		"break_even":  breakEven,
+		"assumptions": gin.H{
+			"principal_source":       principalSource,
+			"payout_per_play_wei":    payoutPerPlay.String(),
+			"payout_per_play_usd":    payoutETH * services.ETHPriceUSD,
+			"eth_price_usd":          services.ETHPriceUSD,
+			"royalties_counted_from": campaign.CreatedAt,
+			"note":                   "Every royalty payment is assumed to pay the investor pool its basis points; plays are rounded up",
+		},
+	})
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	errNotCampaignOwner       = errors.New("only the campaign's creator or an admin can cancel it")
+	errCampaignNotCancellable = errors.New("campaign cannot be cancelled")
+)
+
+// CancelCampaign moves an active campaign that has not reached its goal to
+// cancelled, after which it accepts no contributions. The creator may cancel
+// only before anyone has contributed; admins may also cancel a campaign with
+// contributions, and every contributor is notified.
+// POST /api/v1/campaigns/:campaignId/cancel
+func (h *CampaignHandler) CancelCampaign(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID"})
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	claims := authClaims(c)
+	var campaign models.Campaign
+	var notified []string
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("campaign_id = ?", campaignID).
+			First(&campaign).Error; err != nil {
+			return err
+		}
+
+		isAdmin := claims.Role == auth.RoleAdmin
+		if !isCampaignManager(claims, &campaign) {
+			return errNotCampaignOwner
+		}
+		if campaign.Status != "active" {
+			return fmt.Errorf("%w: campaign is %s", errCampaignNotCancellable, campaign.Status)
+		}
+		raised, _ := new(big.Int).SetString(campaign.RaisedAmount, 10)
+		goal, _ := new(big.Int).SetString(campaign.GoalAmount, 10)
+		if raised != nil && goal != nil && goal.Sign() > 0 && raised.Cmp(goal) >= 0 {
+			return fmt.Errorf("%w: campaign has reached its goal", errCampaignNotCancellable)
+		}
+
+		if err := tx.Model(&models.Contribution{}).
+			Where("campaign_id = ?", campaignID).
+			Distinct().
+			Pluck("contributor_address", &notified).Error; err != nil {
+			return err
+		}
+		if len(notified) > 0 && !isAdmin {
+			return fmt.Errorf("%w: campaign has contributions; ask an admin to cancel it", errCampaignNotCancellable)
+		}
+
+		if err := tx.Model(&campaign).Update("status", "cancelled").Error; err != nil {
+			return err
+		}
+
+		message := fmt.Sprintf("Campaign #%d was cancelled and no longer accepts contributions", campaignID)
+		if req.Reason != "" {
+			message += ": " + req.Reason
+		}
+		for _, contributor := range notified {
+			if err := services.EnqueueNotification(tx, fmt.Sprintf("campaign-cancelled:%d:%s", campaignID, contributor), &services.CreateNotificationRequest{
+				UserAddress: contributor,
+				Type:        "alert",
+				Title:       "Campaign Cancelled",
+				Message:     message,
+				RelatedID:   campaignID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
+		case errors.Is(err, errNotCampaignOwner):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, errCampaignNotCancellable):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel campaign"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaign":              campaign,
+		"contributors_notified": len(notified),
+	})
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestCancelCampaign(t *testing.T) {
+	tests := []struct {
+		name         string
+		subject      string
+		role         string
+		status       string
+		raised       string
+		contributors []string
+		lookedUp     bool // whether the checks get as far as the contributors
+		want         int
+	}{
+		{name: "creator cancels before any contribution", subject: "0xcreator", role: auth.RoleUser, status: "active", raised: "0", lookedUp: true, want: http.StatusOK},
+		{name: "admin cancels with contributions", subject: "admin", role: auth.RoleAdmin, status: "active", raised: "300", contributors: []string{"0xalice", "0xbob"}, lookedUp: true, want: http.StatusOK},
+		{name: "creator with contributions", subject: "0xcreator", role: auth.RoleUser, status: "active", raised: "300", contributors: []string{"0xalice"}, lookedUp: true, want: http.StatusConflict},
+		{name: "goal reached", subject: "admin", role: auth.RoleAdmin, status: "active", raised: "1000", want: http.StatusConflict},
+		{name: "already successful", subject: "admin", role: auth.RoleAdmin, status: "successful", raised: "1000", want: http.StatusConflict},
+		{name: "another user's campaign", subject: "0xsomeoneelse", role: auth.RoleUser, status: "active", raised: "0", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectBegin()
+			created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			mock.ExpectQuery("SELECT \\* FROM `campaigns` WHERE campaign_id = \\? .* FOR UPDATE").
+				WithArgs(5).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "creator_address", "goal_amount", "raised_amount", "status", "created_at"}).
+					AddRow(1, 5, "0xCreator", "1000", tt.raised, tt.status, created))
+			if tt.lookedUp {
+				rows := sqlmock.NewRows([]string{"contributor_address"})
+				for _, contributor := range tt.contributors {
+					rows.AddRow(contributor)
+				}
+				mock.ExpectQuery("SELECT DISTINCT `contributor_address` FROM `contributions` WHERE campaign_id = \\?").
+					WithArgs(5).
+					WillReturnRows(rows)
+			}
+			if tt.want == http.StatusOK {
+				mock.ExpectExec("UPDATE `campaigns` SET `status`=\\?,`updated_at`=\\? WHERE .*`id` = \\?").
+					WithArgs("cancelled", sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				for _, contributor := range tt.contributors {
+					mock.ExpectExec("INSERT INTO `outbox_events`").
+						WithArgs("campaign-cancelled:5:"+contributor, "notification", "notification.alert", sqlmock.AnyArg(), "pending", 0, 0, "", sqlmock.AnyArg(), nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+				}
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			router := gin.New()
+			router.POST("/campaigns/:campaignId/cancel", RequireAuth(testSecret), NewCampaignHandler(db).CancelCampaign)
+
+			rec := record(router, http.MethodPost, "/campaigns/5/cancel", bearer(t, testSecret, tt.subject, tt.role, time.Minute), `{"reason":"artist withdrew"}`)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Campaign struct {
+					Status string `json:"status"`
+				} `json:"campaign"`
+				Notified int `json:"contributors_notified"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Campaign.Status != "cancelled" || body.Notified != len(tt.contributors) {
+				t.Errorf("status %s, %d notified, want cancelled, %d", body.Campaign.Status, body.Notified, len(tt.contributors))
+			}
+		})
+	}
+}
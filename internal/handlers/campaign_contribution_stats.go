@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// GetContributionStats summarizes the size of the campaign's contributions
+// GET /api/v1/campaigns/:campaignId/contribution-stats
+func (h *CampaignHandler) GetContributionStats(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID"})
+		return
+	}
+
+	var campaign models.Campaign
+	if err := h.db.Select("campaign_id").Where("campaign_id = ?", campaignID).First(&campaign).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
+		return
+	}
+
+	var raw []string
+	if err := h.db.Model(&models.Contribution{}).
+		Where("campaign_id = ?", campaignID).
+		Pluck("amount", &raw).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	amounts := make([]*big.Int, 0, len(raw))
+	for _, value := range raw {
+		amount, ok := new(big.Int).SetString(value, 10)
+		if !ok || amount.Sign() <= 0 {
+			continue
+		}
+		amounts = append(amounts, amount)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaign_id": campaignID,
+		"stats":       services.ComputeContributionStats(amounts),
+	})
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// Ending-soon window bounds, in hours
+const (
+	defaultEndingSoonHours = 48
+	maxEndingSoonHours     = 30 * 24
+)
+
+// GetEndingSoon returns active campaigns whose deadline falls within the next
+// hours, soonest first, with how much is still needed to reach the goal
+// GET /api/v1/campaigns/ending-soon?hours=48
+func (h *CampaignHandler) GetEndingSoon(c *gin.Context) {
+	hours, err := parseNonNegativeQuery(c, "hours", defaultEndingSoonHours)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if hours < 1 || hours > maxEndingSoonHours {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("hours must be between 1 and %d", maxEndingSoonHours)})
+		return
+	}
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	type EndingSoonCampaign struct {
+		models.Campaign
+		MusicTitle        string  `json:"music_title"`
+		FundingPercentage float64 `json:"funding_percentage"`
+		RemainingAmount   string  `json:"remaining_amount"` // Wei as string
+		HoursLeft         float64 `json:"hours_left"`
+	}
+
+	now := time.Now()
+	query := h.db.Table("campaigns").
+		Joins("JOIN music_metadata ON campaigns.token_id = music_metadata.token_id").
+		Where("campaigns.status = ? AND campaigns.deleted_at IS NULL", "active").
+		Where("campaigns.deadline > ? AND campaigns.deadline <= ?", now, now.Add(time.Duration(hours)*time.Hour))
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	campaigns := []EndingSoonCampaign{}
+	if err := query.
+		Select(`campaigns.*,
+			music_metadata.title as music_title,
+			` + services.FundingPercentageSQL("campaigns") + ` as funding_percentage`).
+		Order("campaigns.deadline ASC, campaigns.campaign_id ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&campaigns).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for i := range campaigns {
+		raised, ok := new(big.Int).SetString(campaigns[i].RaisedAmount, 10)
+		if !ok {
+			raised = new(big.Int)
+		}
+		goal, ok := new(big.Int).SetString(campaigns[i].GoalAmount, 10)
+		if !ok {
+			goal = new(big.Int)
+		}
+		remaining := new(big.Int).Sub(goal, raised)
+		if remaining.Sign() < 0 {
+			remaining.SetInt64(0)
+		}
+		campaigns[i].RemainingAmount = remaining.String()
+		campaigns[i].HoursLeft = math.Round(campaigns[i].Deadline.Sub(now).Hours()*100) / 100
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaigns": campaigns,
+		"hours":     hours,
+		"total":     total,
+		"limit":     limit,
+		"offset":    offset,
+	})
+}
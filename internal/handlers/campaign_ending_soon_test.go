@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+// nearTime matches a time argument within a second of want
+type nearTime struct {
+	want time.Time
+}
+
+func (n nearTime) Match(v driver.Value) bool {
+	got, ok := v.(time.Time)
+	if !ok {
+		return false
+	}
+	diff := got.Sub(n.want)
+	return diff > -time.Second && diff < time.Second
+}
+
+func TestGetEndingSoon(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		window time.Duration
+	}{
+		{name: "default window", window: 48 * time.Hour},
+		{name: "custom window", query: "?hours=6", window: 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			// Only deadlines after now and inside the window are selected, so
+			// campaigns already closed or ending later never come back
+			window := []driver.Value{"active", nearTime{now}, nearTime{now.Add(tt.window)}}
+			filter := "FROM `campaigns` JOIN music_metadata ON campaigns.token_id = music_metadata.token_id " +
+				"WHERE \\(campaigns.status = \\? AND campaigns.deleted_at IS NULL\\) AND \\(campaigns.deadline > \\? AND campaigns.deadline <= \\?\\)"
+
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT count\\(\\*\\) " + filter).
+				WithArgs(window...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			mock.ExpectQuery("SELECT campaigns.\\*,.* " + filter + " ORDER BY campaigns.deadline ASC, campaigns.campaign_id ASC LIMIT 20").
+				WithArgs(window...).
+				WillReturnRows(sqlmock.NewRows([]string{"campaign_id", "raised_amount", "goal_amount", "deadline", "music_title", "funding_percentage"}).
+					AddRow(4, "250", "1000000000000000000000", now.Add(90*time.Minute), "Soonest", 0).
+					AddRow(2, "1500", "1000", now.Add(5*time.Hour), "Overfunded", 150))
+
+			router := gin.New()
+			router.GET("/campaigns/ending-soon", NewCampaignHandler(db).GetEndingSoon)
+
+			rec := record(router, http.MethodGet, "/campaigns/ending-soon"+tt.query, "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Campaigns []struct {
+					CampaignID      uint64  `json:"campaign_id"`
+					MusicTitle      string  `json:"music_title"`
+					RemainingAmount string  `json:"remaining_amount"`
+					HoursLeft       float64 `json:"hours_left"`
+				} `json:"campaigns"`
+				Hours int   `json:"hours"`
+				Total int64 `json:"total"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Hours != int(tt.window.Hours()) || body.Total != 2 || len(body.Campaigns) != 2 {
+				t.Fatalf("got %d of %d campaigns for %d hours, want 2 of 2 for %v", len(body.Campaigns), body.Total, body.Hours, tt.window.Hours())
+			}
+
+			want := []struct {
+				id        uint64
+				remaining string
+				hoursLeft float64
+			}{
+				{id: 4, remaining: "999999999999999999750", hoursLeft: 1.5},
+				{id: 2, remaining: "0", hoursLeft: 5},
+			}
+			for i, w := range want {
+				got := body.Campaigns[i]
+				if got.CampaignID != w.id || got.RemainingAmount != w.remaining || got.HoursLeft != w.hoursLeft {
+					t.Errorf("campaign %d = %+v, want campaign %d with %s remaining and %v hours left", i, got, w.id, w.remaining, w.hoursLeft)
+				}
+			}
+		})
+	}
+}
+
+func TestGetEndingSoonRejectsWindow(t *testing.T) {
+	db, _ := dbtest.New(t)
+	router := gin.New()
+	router.GET("/campaigns/ending-soon", NewCampaignHandler(db).GetEndingSoon)
+
+	for _, query := range []string{"?hours=0", "?hours=721", "?hours=-5", "?hours=soon"} {
+		if status := serve(router, http.MethodGet, "/campaigns/ending-soon"+query, "", ""); status != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", query, status, http.StatusBadRequest)
+		}
+	}
+}
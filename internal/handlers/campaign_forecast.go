@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// GetFundingForecast projects whether a campaign reaches its goal before the
+// deadline at its recent funding rate
+// GET /api/v1/campaigns/:campaignId/funding-forecast
+func (h *CampaignHandler) GetFundingForecast(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID"})
+		return
+	}
+
+	var campaign models.Campaign
+	if err := h.db.Where("campaign_id = ?", campaignID).First(&campaign).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
+		return
+	}
+
+	now := time.Now()
+	var contributions []models.Contribution
+	if err := h.db.Select("amount", "contributed_at").
+		Where("campaign_id = ? AND contributed_at >= ?", campaignID, now.Add(-services.FundingRateWindow)).
+		Find(&contributions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	points := make([]services.ContributionPoint, 0, len(contributions))
+	for _, contribution := range contributions {
+		amount, ok := new(big.Int).SetString(contribution.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			continue
+		}
+		points = append(points, services.ContributionPoint{Amount: amount, At: contribution.ContributedAt})
+	}
+
+	raised, ok := new(big.Int).SetString(campaign.RaisedAmount, 10)
+	if !ok {
+		raised = new(big.Int)
+	}
+	goal, ok := new(big.Int).SetString(campaign.GoalAmount, 10)
+	if !ok {
+		goal = new(big.Int)
+	}
+
+	forecast := services.ForecastFunding(raised, goal, points, campaign.CreatedAt, campaign.Deadline, now, campaign.Status == "active")
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaign_id": campaign.CampaignID,
+		"forecast":    forecast,
+	})
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+	"github.com/tunecent/backend/pkg/mockdata"
+)
+
+// GetRiskBreakdown recomputes a campaign's risk score from current funding,
+// contributor count and creator reputation, returning each factor's share
+// GET /api/v1/campaigns/:campaignId/risk-breakdown
+func (h *CampaignHandler) GetRiskBreakdown(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID"})
+		return
+	}
+
+	var campaign models.Campaign
+	if err := h.db.Where("campaign_id = ?", campaignID).First(&campaign).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
+		return
+	}
+
+	// Fully refunded contributors are left with zero-amount rows and do not count
+	var contributorCount int64
+	if err := h.db.Model(&models.Contribution{}).
+		Where("campaign_id = ? AND amount <> ?", campaignID, "0").
+		Distinct("contributor_address").
+		Count(&contributorCount).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Creators without a profile have no reputation
+	var creator models.User
+	h.db.Where("wallet_address = ?", campaign.CreatorAddress).Limit(1).Find(&creator)
+
+	fundingPercentage := services.FundingPercentage(campaign.RaisedAmount, campaign.GoalAmount)
+	breakdown := mockdata.CalculateRiskBreakdown(fundingPercentage, uint(contributorCount), creator.ReputationScore)
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaign_id":        campaign.CampaignID,
+		"funding_percentage": fundingPercentage,
+		"contributor_count":  contributorCount,
+		"creator_reputation": creator.ReputationScore,
+		"breakdown":          breakdown,
+		"stored_risk_score":  campaign.RiskScore,
+	})
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/pkg/mockdata"
+)
+
+func TestGetRiskBreakdown(t *testing.T) {
+	tests := []struct {
+		name         string
+		raised       string
+		contributors int
+		profile      *sqlmock.Rows
+		want         mockdata.RiskBreakdown
+	}{
+		{
+			name:         "live factors",
+			raised:       "500",
+			contributors: 10,
+			profile:      sqlmock.NewRows([]string{"id", "wallet_address", "reputation_score"}).AddRow(1, "0xcreator", 5),
+			want:         mockdata.RiskBreakdown{BaseRisk: 100, FundingFactor: 20, ContributorFactor: 6, ReputationFactor: 15, RawScore: 59, Score: 59},
+		},
+		{
+			name:    "creator without a profile",
+			raised:  "0",
+			profile: sqlmock.NewRows([]string{"id"}),
+			want:    mockdata.RiskBreakdown{BaseRisk: 100, RawScore: 100, Score: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			expectCampaign(mock, "0xcreator", "active", tt.raised)
+			mock.ExpectQuery("SELECT COUNT\\(DISTINCT\\(`contributor_address`\\)\\) FROM `contributions` WHERE campaign_id = \\? AND amount <> \\?").
+				WithArgs(5, "0").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.contributors))
+			mock.ExpectQuery("SELECT \\* FROM `users` WHERE wallet_address = \\?").
+				WithArgs("0xcreator").
+				WillReturnRows(tt.profile)
+
+			router := gin.New()
+			router.GET("/campaigns/:campaignId/risk-breakdown", NewCampaignHandler(db).GetRiskBreakdown)
+
+			rec := record(router, http.MethodGet, "/campaigns/5/risk-breakdown", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			var body struct {
+				Breakdown mockdata.RiskBreakdown `json:"breakdown"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Breakdown != tt.want {
+				t.Errorf("breakdown = %+v, want %+v", body.Breakdown, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// GetSharePreview estimates the share of a campaign that contributing amount
+// wei would give, right away and if the campaign closes at its goal, and how
+// it dilutes existing contributors. Pass contributor to count their earlier
+// contributions towards the stake.
+// GET /api/v1/campaigns/:campaignId/share-preview?amount=1000000000000000000&contributor=0x...
+func (h *CampaignHandler) GetSharePreview(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID"})
+		return
+	}
+
+	amount, ok := new(big.Int).SetString(c.Query("amount"), 10)
+	if !ok || amount.Sign() <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": services.ErrInvalidAmount.Error()})
+		return
+	}
+
+	var campaign models.Campaign
+	if err := h.db.Where("campaign_id = ?", campaignID).First(&campaign).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
+		return
+	}
+	goal, ok := new(big.Int).SetString(campaign.GoalAmount, 10)
+	if !ok {
+		goal = new(big.Int)
+	}
+
+	var rows []models.Contribution
+	if err := h.db.Select("contributor_address", "amount").
+		Where("campaign_id = ?", campaignID).
+		Find(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	contributions := make(map[string]*big.Int)
+	for _, row := range rows {
+		value, ok := new(big.Int).SetString(row.Amount, 10)
+		if !ok || value.Sign() <= 0 {
+			continue
+		}
+		if existing, seen := contributions[row.ContributorAddress]; seen {
+			existing.Add(existing, value)
+		} else {
+			contributions[row.ContributorAddress] = value
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaign_id":           campaignID,
+		"status":                campaign.Status,
+		"accepts_contributions": campaign.Status == "active",
+		"preview":               services.PreviewContributionShare(amount, goal, contributions, c.Query("contributor")),
+	})
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+	"gorm.io/gorm"
+)
+
+// shareSumTolerance is how far the recomputed shares may drift from 100% due to rounding
+const shareSumTolerance = 0.01
+
+// RecomputeShares recalculates every contributor's share of a finished
+// campaign from their cumulative contributions. Each contribution row stores
+// its own part of the total, so a contributor's rows add up to their share.
+// Only the campaign's creator or an admin may recompute.
+// POST /api/v1/campaigns/:campaignId/recompute-shares
+func (h *CampaignHandler) RecomputeShares(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID"})
+		return
+	}
+
+	var campaign models.Campaign
+	if err := h.db.Where("campaign_id = ?", campaignID).First(&campaign).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
+		return
+	}
+
+	if !isCampaignManager(authClaims(c), &campaign) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the campaign's creator or an admin can recompute shares"})
+		return
+	}
+
+	if campaign.Status != "successful" {
+		c.JSON(http.StatusConflict, gin.H{"error": "shares can only be recomputed for successful campaigns"})
+		return
+	}
+
+	var shares []services.ContributorShare
+	var total *big.Int
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		shares, total, err = storeContributorShares(tx, campaignID)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to recompute shares"})
+		return
+	}
+
+	sum := 0.0
+	for _, share := range shares {
+		sum += share.SharePercentage
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaign_id":           campaignID,
+		"total_contributed":     total.String(),
+		"raised_amount":         campaign.RaisedAmount,
+		"matches_raised_amount": total.String() == campaign.RaisedAmount,
+		"shares":                shares,
+		"share_sum":             math.Round(sum*1e6) / 1e6,
+		"shares_consistent":     len(shares) == 0 || math.Abs(sum-100) <= shareSumTolerance,
+	})
+}
+
+// storeContributorShares recalculates every contributor's cumulative share
+// of a campaign and stores on each contribution row that row's own part of
+// the total, so summing share_percentage over rows never counts a
+// contributor twice
+func storeContributorShares(tx *gorm.DB, campaignID uint64) ([]services.ContributorShare, *big.Int, error) {
+	var contributions []models.Contribution
+	if err := tx.Where("campaign_id = ?", campaignID).Find(&contributions).Error; err != nil {
+		return nil, nil, err
+	}
+
+	amounts := make(map[string]*big.Int)
+	rowAmounts := make([]*big.Int, len(contributions))
+	for i, contribution := range contributions {
+		amount, ok := new(big.Int).SetString(contribution.Amount, 10)
+		if !ok {
+			amount = new(big.Int)
+		}
+		rowAmounts[i] = amount
+		address := strings.ToLower(contribution.ContributorAddress)
+		if amounts[address] == nil {
+			amounts[address] = new(big.Int)
+		}
+		amounts[address].Add(amounts[address], amount)
+	}
+
+	shares, total := services.ContributorShares(amounts)
+	for i, contribution := range contributions {
+		if err := tx.Model(&contribution).
+			Update("share_percentage", services.ContributionShare(rowAmounts[i], total)).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+	return shares, total, nil
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestRecomputeShares(t *testing.T) {
+	db, mock := dbtest.New(t)
+	expectCampaign(mock, "0xcreator", "successful", "351")
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `contributions` WHERE campaign_id = \\?").
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "contributor_address", "amount", "share_percentage"}).
+			AddRow(1, 5, "0xa", "100", 50).
+			AddRow(2, 5, "0xb", "200", 50).
+			AddRow(3, 5, "0xA", "50", 0). // a later contribution by 0xa under another case
+			AddRow(4, 5, "0xc", "1", 0))
+	// Each row stores its own part of the 351 wei raised
+	for i, share := range []float64{28.490028, 56.980057, 14.245014, 0.2849} {
+		mock.ExpectExec("UPDATE `contributions` SET `share_percentage`=\\?,`updated_at`=\\? WHERE `id` = \\?").
+			WithArgs(share, sqlmock.AnyArg(), i+1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	router := gin.New()
+	router.POST("/campaigns/:campaignId/recompute-shares", RequireAuth(testSecret), NewCampaignHandler(db).RecomputeShares)
+
+	rec := record(router, http.MethodPost, "/campaigns/5/recompute-shares", bearer(t, testSecret, "0xcreator", auth.RoleUser, time.Hour), "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+
+	var body struct {
+		Total      string  `json:"total_contributed"`
+		Matches    bool    `json:"matches_raised_amount"`
+		ShareSum   float64 `json:"share_sum"`
+		Consistent bool    `json:"shares_consistent"`
+		Shares     []struct {
+			ContributorAddress string  `json:"contributor_address"`
+			Amount             string  `json:"amount"`
+			SharePercentage    float64 `json:"share_percentage"`
+		} `json:"shares"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	if body.Total != "351" || !body.Matches {
+		t.Errorf("total = %s, matches = %v, want 351 matching the raised amount", body.Total, body.Matches)
+	}
+	if !body.Consistent || math.Abs(body.ShareSum-100) > shareSumTolerance {
+		t.Errorf("share_sum = %v, consistent = %v, want 100 within %v", body.ShareSum, body.Consistent, shareSumTolerance)
+	}
+
+	want := []struct {
+		address string
+		amount  string
+		share   float64
+	}{
+		{address: "0xb", amount: "200", share: 56.980057},
+		{address: "0xa", amount: "150", share: 42.735043},
+		{address: "0xc", amount: "1", share: 0.2849},
+	}
+	if len(body.Shares) != len(want) {
+		t.Fatalf("got %d shares, want %d", len(body.Shares), len(want))
+	}
+	for i, share := range body.Shares {
+		if share.ContributorAddress != want[i].address || share.Amount != want[i].amount || share.SharePercentage != want[i].share {
+			t.Errorf("share %d = %+v, want %+v", i, share, want[i])
+		}
+	}
+}
+
+func TestRecomputeSharesGuards(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  func(t *testing.T) string
+		expect func(mock sqlmock.Sqlmock)
+		want   int
+	}{
+		{
+			name:   "active campaign",
+			token:  func(t *testing.T) string { return bearer(t, testSecret, "0xcreator", auth.RoleUser, time.Hour) },
+			expect: func(mock sqlmock.Sqlmock) { expectCampaign(mock, "0xcreator", "active", "100") },
+			want:   http.StatusConflict,
+		},
+		{
+			name:   "failed campaign",
+			token:  func(t *testing.T) string { return bearer(t, testSecret, "0xcreator", auth.RoleUser, time.Hour) },
+			expect: func(mock sqlmock.Sqlmock) { expectCampaign(mock, "0xcreator", "failed", "100") },
+			want:   http.StatusConflict,
+		},
+		{
+			name:   "another user",
+			token:  func(t *testing.T) string { return bearer(t, testSecret, "0xother", auth.RoleUser, time.Hour) },
+			expect: func(mock sqlmock.Sqlmock) { expectCampaign(mock, "0xcreator", "successful", "100") },
+			want:   http.StatusForbidden,
+		},
+		{
+			name:  "unknown campaign",
+			token: func(t *testing.T) string { return bearer(t, testSecret, "0xcreator", auth.RoleUser, time.Hour) },
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `campaigns` WHERE campaign_id = \\?").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name:  "no token",
+			token: func(t *testing.T) string { return "" },
+			want:  http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			if tt.expect != nil {
+				tt.expect(mock)
+			}
+			router := gin.New()
+			router.POST("/campaigns/:campaignId/recompute-shares", RequireAuth(testSecret), NewCampaignHandler(db).RecomputeShares)
+
+			if status := serve(router, http.MethodPost, "/campaigns/5/recompute-shares", tt.token(t), ""); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
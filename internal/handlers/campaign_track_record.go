@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+)
+
+// GetCampaignTrackRecord returns how many of the creator's campaigns are
+// in each status, the total raised by successful ones, and the success
+// rate: successful campaigns as a percentage of those that have finished,
+// successfully or not. Active and cancelled campaigns do not count toward
+// the rate.
+// GET /api/v1/users/:address/campaign-track-record
+func (h *UserHandler) GetCampaignTrackRecord(c *gin.Context) {
+	address := c.Param("address")
+
+	var rows []struct {
+		Status string
+		Count  int64
+		Raised string
+	}
+	if err := h.db.WithContext(c.Request.Context()).Model(&models.Campaign{}).
+		Select("status, COUNT(*) as count, CAST(COALESCE(SUM(CAST(raised_amount AS DECIMAL(30,0))), 0) AS CHAR) as raised").
+		Where("creator_address = ?", address).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	counts := map[string]int64{"active": 0, "successful": 0, "failed": 0, "cancelled": 0}
+	var total int64
+	totalRaised := "0"
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+		total += row.Count
+		if row.Status == "successful" {
+			totalRaised = row.Raised
+		}
+	}
+
+	var successRate *float64
+	if finished := counts["successful"] + counts["failed"]; finished > 0 {
+		rate := math.Round(float64(counts["successful"])/float64(finished)*10000) / 100
+		successRate = &rate
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":                 address,
+		"total_campaigns":         total,
+		"successful":              counts["successful"],
+		"failed":                  counts["failed"],
+		"active":                  counts["active"],
+		"cancelled":               counts["cancelled"],
+		"total_raised_successful": totalRaised,
+		"success_rate":            successRate,
+	})
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestGetCampaignTrackRecord(t *testing.T) {
+	type group struct {
+		status string
+		count  int64
+		raised string
+	}
+
+	rate := func(pct float64) *float64 { return &pct }
+
+	tests := []struct {
+		name       string
+		groups     []group
+		wantTotal  int64
+		wantCounts map[string]int64
+		wantRaised string
+		wantRate   *float64
+	}{
+		{
+			name:       "mix of statuses",
+			groups:     []group{{"successful", 3, "9000"}, {"failed", 1, "200"}, {"active", 2, "500"}, {"cancelled", 1, "0"}},
+			wantTotal:  7,
+			wantCounts: map[string]int64{"successful": 3, "failed": 1, "active": 2, "cancelled": 1},
+			wantRaised: "9000",
+			wantRate:   rate(75),
+		},
+		{
+			name:       "rate is rounded to two decimals",
+			groups:     []group{{"successful", 1, "1000"}, {"failed", 2, "0"}},
+			wantTotal:  3,
+			wantCounts: map[string]int64{"successful": 1, "failed": 2, "active": 0, "cancelled": 0},
+			wantRaised: "1000",
+			wantRate:   rate(33.33),
+		},
+		{
+			name:       "every finished campaign failed",
+			groups:     []group{{"failed", 2, "300"}, {"cancelled", 1, "0"}},
+			wantTotal:  3,
+			wantCounts: map[string]int64{"successful": 0, "failed": 2, "active": 0, "cancelled": 1},
+			wantRaised: "0",
+			wantRate:   rate(0),
+		},
+		{
+			name:       "nothing finished has no rate",
+			groups:     []group{{"active", 2, "400"}, {"cancelled", 1, "0"}},
+			wantTotal:  3,
+			wantCounts: map[string]int64{"successful": 0, "failed": 0, "active": 2, "cancelled": 1},
+			wantRaised: "0",
+		},
+		{
+			name:       "no campaigns",
+			wantCounts: map[string]int64{"successful": 0, "failed": 0, "active": 0, "cancelled": 0},
+			wantRaised: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows([]string{"status", "count", "raised"})
+			for _, g := range tt.groups {
+				rows.AddRow(g.status, g.count, g.raised)
+			}
+			mock.ExpectQuery("SELECT status, COUNT\\(\\*\\) as count, .* FROM `campaigns` WHERE creator_address = \\? .*GROUP BY `status`").
+				WithArgs("0xcreator").
+				WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/users/:address/campaign-track-record", NewUserHandler(db).GetCampaignTrackRecord)
+
+			rec := record(router, http.MethodGet, "/users/0xcreator/campaign-track-record", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Total      int64    `json:"total_campaigns"`
+				Successful int64    `json:"successful"`
+				Failed     int64    `json:"failed"`
+				Active     int64    `json:"active"`
+				Cancelled  int64    `json:"cancelled"`
+				Raised     string   `json:"total_raised_successful"`
+				Rate       *float64 `json:"success_rate"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			got := map[string]int64{"successful": body.Successful, "failed": body.Failed, "active": body.Active, "cancelled": body.Cancelled}
+			for status, want := range tt.wantCounts {
+				if got[status] != want {
+					t.Errorf("%s = %d, want %d", status, got[status], want)
+				}
+			}
+			if body.Total != tt.wantTotal || body.Raised != tt.wantRaised {
+				t.Errorf("total %d, raised %s, want %d, %s", body.Total, body.Raised, tt.wantTotal, tt.wantRaised)
+			}
+			switch {
+			case tt.wantRate == nil && body.Rate != nil:
+				t.Errorf("success_rate = %v, want null", *body.Rate)
+			case tt.wantRate != nil && (body.Rate == nil || *body.Rate != *tt.wantRate):
+				t.Errorf("success_rate = %v, want %v", body.Rate, *tt.wantRate)
+			}
+		})
+	}
+}
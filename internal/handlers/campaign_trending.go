@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// GetTrendingCampaigns returns trending active campaigns for public discovery,
+// ranked by funding percentage and recency
+// GET /api/v1/campaigns/trending?limit=10
+func (h *CampaignHandler) GetTrendingCampaigns(c *gin.Context) {
+	limit, _, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	type TrendingCampaign struct {
+		models.Campaign
+		MusicTitle        string  `json:"music_title"`
+		MusicArtist       string  `json:"music_artist"`
+		Genre             string  `json:"genre"`
+		CreatorName       string  `json:"creator_name"`
+		CreatorVerified   bool    `json:"creator_verified"`
+		FundingPercentage float64 `json:"funding_percentage"`
+	}
+
+	var campaigns []TrendingCampaign
+	h.db.Table("campaigns").
+		Select(`campaigns.*,
+			music_metadata.title as music_title,
+			music_metadata.artist as music_artist,
+			COALESCE(music_metadata.genre, '') as genre,
+			COALESCE(users.display_name, '') as creator_name,
+			COALESCE(users.is_verified, false) as creator_verified,
+			`+services.FundingPercentageSQL("campaigns")+` as funding_percentage`).
+		Joins("JOIN music_metadata ON campaigns.token_id = music_metadata.token_id").
+		Joins("LEFT JOIN users ON campaigns.creator_address = users.wallet_address").
+		Where("campaigns.status = ? AND campaigns.is_trending = ? AND campaigns.deleted_at IS NULL", "active", true).
+		Order("funding_percentage DESC, campaigns.created_at DESC").
+		Limit(limit).
+		Scan(&campaigns)
+
+	if campaigns == nil {
+		campaigns = []TrendingCampaign{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaigns": campaigns,
+		"total":     len(campaigns),
+	})
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestGetTrendingCampaigns(t *testing.T) {
+	const trendingSQL = "(?s)NULLIF\\(CAST\\(campaigns.goal_amount AS DECIMAL\\(30,0\\)\\), 0\\) .* as funding_percentage FROM `campaigns` " +
+		"JOIN music_metadata .* WHERE campaigns.status = \\? AND campaigns.is_trending = \\? AND campaigns.deleted_at IS NULL " +
+		"ORDER BY funding_percentage DESC, campaigns.created_at DESC LIMIT %s"
+	columns := []string{"campaign_id", "status", "is_trending", "music_title", "funding_percentage"}
+
+	tests := []struct {
+		name    string
+		query   string
+		limit   string
+		rows    [][3]interface{}
+		wantIDs []uint64
+	}{
+		{
+			name:    "ranked by the database order",
+			limit:   "20",
+			rows:    [][3]interface{}{{uint64(3), "Hit", 92.5}, {uint64(1), "Climber", 40.0}, {uint64(2), "Zero goal", 0.0}},
+			wantIDs: []uint64{3, 1, 2},
+		},
+		{
+			name:    "limit is passed through",
+			query:   "?limit=2",
+			limit:   "2",
+			rows:    [][3]interface{}{{uint64(3), "Hit", 92.5}, {uint64(1), "Climber", 40.0}},
+			wantIDs: []uint64{3, 1},
+		},
+		{
+			name:    "limit is capped",
+			query:   "?limit=500",
+			limit:   "100",
+			wantIDs: []uint64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows(columns)
+			for _, row := range tt.rows {
+				rows.AddRow(row[0], "active", true, row[1], row[2])
+			}
+			mock.ExpectQuery(fmt.Sprintf(trendingSQL, tt.limit)).WithArgs("active", true).WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/campaigns/trending", NewCampaignHandler(db).GetTrendingCampaigns)
+
+			rec := record(router, http.MethodGet, "/campaigns/trending"+tt.query, "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Campaigns []struct {
+					CampaignID        uint64  `json:"campaign_id"`
+					FundingPercentage float64 `json:"funding_percentage"`
+				} `json:"campaigns"`
+				Total int `json:"total"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Total != len(tt.wantIDs) || len(body.Campaigns) != len(tt.wantIDs) {
+				t.Fatalf("got %d campaigns (total %d), want %d", len(body.Campaigns), body.Total, len(tt.wantIDs))
+			}
+			for i, campaign := range body.Campaigns {
+				if campaign.CampaignID != tt.wantIDs[i] {
+					t.Errorf("campaigns[%d] = %d, want %d", i, campaign.CampaignID, tt.wantIDs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetTrendingCampaignsInvalidLimit(t *testing.T) {
+	db, _ := dbtest.New(t)
+	router := gin.New()
+	router.GET("/campaigns/trending", NewCampaignHandler(db).GetTrendingCampaigns)
+
+	if status := serve(router, http.MethodGet, "/campaigns/trending?limit=abc", "", ""); status != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+	}
+}
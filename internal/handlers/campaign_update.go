@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// isCampaignManager reports whether the authenticated caller is the
+// campaign's creator or an admin
+func isCampaignManager(claims *auth.Claims, campaign *models.Campaign) bool {
+	return claims.Role == auth.RoleAdmin || strings.EqualFold(claims.Subject, campaign.CreatorAddress)
+}
+
+// UpdateCampaign changes the terms of an active campaign that has not received
+// contributions yet. Only its creator or an admin may do so. Omitted fields
+// keep their current value.
+// PATCH /api/v1/campaigns/:campaignId
+func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID"})
+		return
+	}
+
+	var req struct {
+		RoyaltyPercentage *uint16 `json:"royalty_percentage"`
+		DurationDays      *int    `json:"duration_days"`
+		LockupDays        *int    `json:"lockup_days"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var campaign models.Campaign
+	if err := h.db.Where("campaign_id = ?", campaignID).First(&campaign).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
+		return
+	}
+
+	if !isCampaignManager(authClaims(c), &campaign) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the campaign's creator or an admin can update it"})
+		return
+	}
+
+	if campaign.Status != "active" || campaign.ContributorCount > 0 || (campaign.RaisedAmount != "" && campaign.RaisedAmount != "0") {
+		c.JSON(http.StatusConflict, gin.H{"error": "only active campaigns without contributions can be updated"})
+		return
+	}
+
+	royalty := int(campaign.RoyaltyPercentage)
+	if req.RoyaltyPercentage != nil {
+		royalty = int(*req.RoyaltyPercentage)
+	}
+	durationDays := int(math.Ceil(campaign.Deadline.Sub(campaign.CreatedAt).Hours() / 24))
+	if req.DurationDays != nil {
+		durationDays = *req.DurationDays
+	}
+	lockupDays := campaign.LockupPeriod
+	if req.LockupDays != nil {
+		lockupDays = *req.LockupDays
+	}
+
+	if err := services.ValidateCampaignTerms(royalty, durationDays, lockupDays); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	campaign.RoyaltyPercentage = uint16(royalty)
+	campaign.LockupPeriod = lockupDays
+	if req.DurationDays != nil {
+		campaign.Deadline = campaign.CreatedAt.UTC().AddDate(0, 0, durationDays)
+	}
+
+	if err := h.db.Model(&campaign).Select("royalty_percentage", "lockup_period", "deadline").Updates(&campaign).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update campaign"})
+		return
+	}
+
+	c.JSON(http.StatusOK, campaign)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+// expectCampaign answers a campaign lookup by campaign_id
+func expectCampaign(mock sqlmock.Sqlmock, creator, status, raised string) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT \\* FROM `campaigns` WHERE campaign_id = \\?").
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "creator_address", "goal_amount", "raised_amount", "royalty_percentage", "deadline", "lockup_period", "status", "created_at"}).
+			AddRow(1, 5, creator, "1000", raised, 3000, created.AddDate(0, 0, 30), 90, status, created))
+}
+
+func TestUpdateCampaign(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		role    string
+		body    string
+		expect  func(mock sqlmock.Sqlmock)
+		want    int
+	}{
+		{
+			name:    "creator updates the terms",
+			subject: "0xcreator",
+			role:    auth.RoleUser,
+			body:    `{"royalty_percentage":2500,"lockup_days":120}`,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectCampaign(mock, "0xCreator", "active", "0")
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `campaigns` SET `royalty_percentage`=\\?,`deadline`=\\?,`lockup_period`=\\?").
+					WithArgs(2500, sqlmock.AnyArg(), 120, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: http.StatusOK,
+		},
+		{
+			name:    "royalty above 100%",
+			subject: "0xcreator",
+			role:    auth.RoleUser,
+			body:    `{"royalty_percentage":10001}`,
+			expect:  func(mock sqlmock.Sqlmock) { expectCampaign(mock, "0xcreator", "active", "0") },
+			want:    http.StatusBadRequest,
+		},
+		{
+			name:    "negative duration",
+			subject: "admin",
+			role:    auth.RoleAdmin,
+			body:    `{"duration_days":-1}`,
+			expect:  func(mock sqlmock.Sqlmock) { expectCampaign(mock, "0xcreator", "active", "0") },
+			want:    http.StatusBadRequest,
+		},
+		{
+			name:    "campaign with contributions",
+			subject: "0xcreator",
+			role:    auth.RoleUser,
+			body:    `{"lockup_days":30}`,
+			expect:  func(mock sqlmock.Sqlmock) { expectCampaign(mock, "0xcreator", "active", "10") },
+			want:    http.StatusConflict,
+		},
+		{
+			name:    "another user's campaign",
+			subject: "0xsomeoneelse",
+			role:    auth.RoleUser,
+			body:    `{"lockup_days":30}`,
+			expect:  func(mock sqlmock.Sqlmock) { expectCampaign(mock, "0xcreator", "active", "0") },
+			want:    http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			tt.expect(mock)
+			router := gin.New()
+			router.PATCH("/campaigns/:campaignId", RequireAuth(testSecret), NewCampaignHandler(db).UpdateCampaign)
+
+			rec := record(router, http.MethodPatch, "/campaigns/5", bearer(t, testSecret, tt.subject, tt.role, time.Minute), tt.body)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
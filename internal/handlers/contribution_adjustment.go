@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	errNoContributions         = errors.New("contributor has no contributions to this campaign")
+	errAdjustmentExceedsAmount = errors.New("adjustment exceeds the contributor's total contribution")
+)
+
+// AdjustContribution records a manual partial refund against a contributor,
+// e.g. to settle a dispute. The amount comes off their newest contributions
+// first and off the campaign's raised amount, a refund transaction and an
+// audit record are written, and shares are recomputed if the campaign has
+// succeeded. The adjustment may not exceed what the contributor put in.
+// POST /api/v1/campaigns/:campaignId/adjust
+func (h *CampaignHandler) AdjustContribution(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID"})
+		return
+	}
+
+	var req struct {
+		ContributorAddress string `json:"contributor_address" binding:"required"`
+		Amount             string `json:"amount" binding:"required"`
+		Reason             string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ContributorAddress = strings.ToLower(strings.TrimSpace(req.ContributorAddress))
+	amount, ok := new(big.Int).SetString(req.Amount, 10)
+	if !ok || amount.Sign() <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": services.ErrInvalidAmount.Error()})
+		return
+	}
+
+	var campaign models.Campaign
+	var adjustment models.ContributionAdjustment
+	var transaction models.Transaction
+	var shares []services.ContributorShare
+	err = h.db.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("campaign_id = ?", campaignID).
+			First(&campaign).Error; err != nil {
+			return err
+		}
+
+		var contributions []models.Contribution
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("campaign_id = ? AND contributor_address = ?", campaignID, req.ContributorAddress).
+			Order("contributed_at DESC, id DESC").
+			Find(&contributions).Error; err != nil {
+			return err
+		}
+		if len(contributions) == 0 {
+			return errNoContributions
+		}
+
+		previous := new(big.Int)
+		amounts := make([]*big.Int, len(contributions))
+		for i, contribution := range contributions {
+			if amounts[i], ok = new(big.Int).SetString(contribution.Amount, 10); !ok {
+				amounts[i] = new(big.Int)
+			}
+			previous.Add(previous, amounts[i])
+		}
+		if amount.Cmp(previous) > 0 {
+			return fmt.Errorf("%w: adjusting %s wei of %s wei", errAdjustmentExceedsAmount, amount, previous)
+		}
+
+		remaining := new(big.Int).Set(amount)
+		for i, contribution := range contributions {
+			if remaining.Sign() == 0 {
+				break
+			}
+			taken := new(big.Int).Set(remaining)
+			if taken.Cmp(amounts[i]) > 0 {
+				taken.Set(amounts[i])
+			}
+			remaining.Sub(remaining, taken)
+			if err := tx.Model(&contribution).
+				Update("amount", new(big.Int).Sub(amounts[i], taken).String()).Error; err != nil {
+				return err
+			}
+		}
+
+		raised, ok := new(big.Int).SetString(campaign.RaisedAmount, 10)
+		if !ok {
+			raised = new(big.Int)
+		}
+		raised.Sub(raised, amount)
+		if raised.Sign() < 0 {
+			raised.SetInt64(0)
+		}
+		if err := tx.Model(&campaign).Update("raised_amount", raised.String()).Error; err != nil {
+			return err
+		}
+
+		transaction = models.Transaction{
+			UserAddress: req.ContributorAddress,
+			Type:        "refund",
+			Amount:      amount.String(),
+			Status:      "confirmed",
+			Description: fmt.Sprintf("Adjustment to campaign #%d: %s", campaignID, req.Reason),
+			RelatedID:   campaignID,
+		}
+		if err := tx.Create(&transaction).Error; err != nil {
+			return err
+		}
+
+		adjustment = models.ContributionAdjustment{
+			CampaignID:         campaignID,
+			ContributorAddress: req.ContributorAddress,
+			Amount:             amount.String(),
+			Reason:             req.Reason,
+			PreviousAmount:     previous.String(),
+			NewAmount:          new(big.Int).Sub(previous, amount).String(),
+			AdjustedBy:         authClaims(c).Subject,
+			TransactionID:      transaction.ID,
+		}
+		if err := tx.Create(&adjustment).Error; err != nil {
+			return err
+		}
+
+		if campaign.Status == "successful" {
+			var err error
+			shares, _, err = storeContributorShares(tx, campaignID)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
+		case errors.Is(err, errNoContributions):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, errAdjustmentExceedsAmount):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record adjustment"})
+		}
+		return
+	}
+	h.fundingChanged()
+
+	var sharePercentage *float64
+	for _, share := range shares {
+		if strings.EqualFold(share.ContributorAddress, req.ContributorAddress) {
+			sharePercentage = &share.SharePercentage
+			break
+		}
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"adjustment":       adjustment,
+		"transaction":      transaction,
+		"raised_amount":    campaign.RaisedAmount,
+		"share_percentage": sharePercentage,
+		"shares_updated":   shares != nil,
+	})
+}
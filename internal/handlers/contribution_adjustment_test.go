@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestAdjustContribution(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    string
+		wantShare float64 // none unless the campaign succeeded
+	}{
+		{name: "active campaign", status: "active"},
+		{name: "successful campaign recomputes shares", status: "successful", wantShare: 66.666667},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectBegin()
+			expectCampaign(mock, "0xcreator", tt.status, "1000")
+			mock.ExpectQuery("SELECT \\* FROM `contributions` WHERE campaign_id = \\? AND contributor_address = \\? ORDER BY contributed_at DESC, id DESC FOR UPDATE").
+				WithArgs(5, "0xa").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "contributor_address", "amount"}).
+					AddRow(3, 5, "0xa", "300").
+					AddRow(1, 5, "0xa", "500"))
+			// 400 wei comes off the newest contribution first, then the next
+			for _, update := range []struct {
+				amount string
+				id     int
+			}{{"0", 3}, {"400", 1}} {
+				mock.ExpectExec("UPDATE `contributions` SET `amount`=\\?,`updated_at`=\\? WHERE `id` = \\?").
+					WithArgs(update.amount, sqlmock.AnyArg(), update.id).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			mock.ExpectExec("UPDATE `campaigns` SET `raised_amount`=\\?,`updated_at`=\\? WHERE .*`id` = \\?").
+				WithArgs("600", sqlmock.AnyArg(), 1).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("INSERT INTO `transactions`").
+				WillReturnResult(sqlmock.NewResult(11, 1))
+			mock.ExpectExec("INSERT INTO `contribution_adjustments`").
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			if tt.status == "successful" {
+				mock.ExpectQuery("SELECT \\* FROM `contributions` WHERE campaign_id = \\?").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "contributor_address", "amount"}).
+						AddRow(1, 5, "0xa", "400").
+						AddRow(2, 5, "0xb", "200").
+						AddRow(3, 5, "0xa", "0"))
+				for i, share := range []float64{66.666667, 33.333333, 0} {
+					mock.ExpectExec("UPDATE `contributions` SET `share_percentage`=\\?,`updated_at`=\\? WHERE `id` = \\?").
+						WithArgs(share, sqlmock.AnyArg(), i+1).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+			}
+			mock.ExpectCommit()
+
+			router := gin.New()
+			router.POST("/campaigns/:campaignId/adjust", RequireRole(testSecret, auth.RoleAdmin), NewCampaignHandler(db).AdjustContribution)
+
+			rec := record(router, http.MethodPost, "/campaigns/5/adjust", bearer(t, testSecret, "0xadmin", auth.RoleAdmin, time.Minute),
+				`{"contributor_address":" 0xA ","amount":"400","reason":"disputed charge"}`)
+			if rec.Code != http.StatusCreated {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body)
+			}
+
+			var body struct {
+				Adjustment struct {
+					ContributorAddress string `json:"contributor_address"`
+					Amount             string `json:"amount"`
+					PreviousAmount     string `json:"previous_amount"`
+					NewAmount          string `json:"new_amount"`
+					AdjustedBy         string `json:"adjusted_by"`
+					TransactionID      uint   `json:"transaction_id"`
+				} `json:"adjustment"`
+				Transaction struct {
+					Type   string `json:"type"`
+					Amount string `json:"amount"`
+				} `json:"transaction"`
+				Raised        string   `json:"raised_amount"`
+				Share         *float64 `json:"share_percentage"`
+				SharesUpdated bool     `json:"shares_updated"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			adjustment := body.Adjustment
+			if adjustment.ContributorAddress != "0xa" || adjustment.Amount != "400" || adjustment.PreviousAmount != "800" || adjustment.NewAmount != "400" {
+				t.Errorf("adjustment = %+v, want 0xa adjusted by 400 from 800 to 400", adjustment)
+			}
+			if adjustment.AdjustedBy != "0xadmin" || adjustment.TransactionID != 11 {
+				t.Errorf("adjusted by %s with transaction %d, want 0xadmin and 11", adjustment.AdjustedBy, adjustment.TransactionID)
+			}
+			if body.Transaction.Type != "refund" || body.Transaction.Amount != "400" || body.Raised != "600" {
+				t.Errorf("transaction = %+v, raised = %s, want a 400 refund leaving 600", body.Transaction, body.Raised)
+			}
+			recomputed := tt.status == "successful"
+			if body.SharesUpdated != recomputed {
+				t.Errorf("shares_updated = %v, want %v", body.SharesUpdated, recomputed)
+			}
+			switch {
+			case !recomputed && body.Share != nil:
+				t.Errorf("share_percentage = %v, want null", *body.Share)
+			case recomputed && (body.Share == nil || *body.Share != tt.wantShare):
+				t.Errorf("share_percentage = %v, want %v", body.Share, tt.wantShare)
+			}
+		})
+	}
+}
+
+func TestAdjustContributionRejects(t *testing.T) {
+	expectContributions := func(mock sqlmock.Sqlmock, amounts ...string) {
+		mock.ExpectBegin()
+		expectCampaign(mock, "0xcreator", "active", "1000")
+		rows := sqlmock.NewRows([]string{"id", "campaign_id", "contributor_address", "amount"})
+		for i, amount := range amounts {
+			rows.AddRow(i+1, 5, "0xa", amount)
+		}
+		mock.ExpectQuery("SELECT \\* FROM `contributions` WHERE campaign_id = \\? AND contributor_address = \\?").
+			WithArgs(5, "0xa").
+			WillReturnRows(rows)
+		mock.ExpectRollback()
+	}
+
+	tests := []struct {
+		name   string
+		role   string
+		body   string
+		expect func(mock sqlmock.Sqlmock)
+		want   int
+	}{
+		{
+			name:   "more than the contributor put in",
+			role:   auth.RoleAdmin,
+			body:   `{"contributor_address":"0xa","amount":"801","reason":"dispute"}`,
+			expect: func(mock sqlmock.Sqlmock) { expectContributions(mock, "300", "500") },
+			want:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "contributor without contributions",
+			role:   auth.RoleAdmin,
+			body:   `{"contributor_address":"0xa","amount":"1","reason":"dispute"}`,
+			expect: func(mock sqlmock.Sqlmock) { expectContributions(mock) },
+			want:   http.StatusNotFound,
+		},
+		{
+			name: "unknown campaign",
+			role: auth.RoleAdmin,
+			body: `{"contributor_address":"0xa","amount":"1","reason":"dispute"}`,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `campaigns` WHERE campaign_id = \\?").
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			want: http.StatusNotFound,
+		},
+		{name: "zero amount", role: auth.RoleAdmin, body: `{"contributor_address":"0xa","amount":"0","reason":"dispute"}`, want: http.StatusBadRequest},
+		{name: "non-numeric amount", role: auth.RoleAdmin, body: `{"contributor_address":"0xa","amount":"1e18","reason":"dispute"}`, want: http.StatusBadRequest},
+		{name: "missing reason", role: auth.RoleAdmin, body: `{"contributor_address":"0xa","amount":"1"}`, want: http.StatusBadRequest},
+		{name: "non-admin", role: auth.RoleUser, body: `{"contributor_address":"0xa","amount":"1","reason":"dispute"}`, want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			if tt.expect != nil {
+				tt.expect(mock)
+			}
+			router := gin.New()
+			router.POST("/campaigns/:campaignId/adjust", RequireRole(testSecret, auth.RoleAdmin), NewCampaignHandler(db).AdjustContribution)
+
+			rec := record(router, http.MethodPost, "/campaigns/5/adjust", bearer(t, testSecret, "0xadmin", tt.role, time.Minute), tt.body)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want == http.StatusUnprocessableEntity && !strings.Contains(rec.Body.String(), "adjusting 801 wei of 800 wei") {
+				t.Errorf("body = %s, want the adjustment and contribution amounts", rec.Body)
+			}
+		})
+	}
+}
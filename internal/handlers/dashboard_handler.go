@@ -1,12 +1,20 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tunecent/backend/internal/database"
 	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+	"github.com/tunecent/backend/pkg/metrics"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
 )
 
 // DashboardHandler handles dashboard-related endpoints
@@ -27,23 +35,38 @@ func (h *DashboardHandler) GetOverview(c *gin.Context) {
 		return
 	}
 
+	overview, err := h.overview(c.Request.Context(), address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load overview"})
+		return
+	}
+
+	c.JSON(http.StatusOK, overview)
+}
+
+// overview gathers the creator's headline stats
+func (h *DashboardHandler) overview(ctx context.Context, address string) (gin.H, error) {
+	db := h.db.WithContext(ctx)
+
 	// Get total music count
 	var musicCount int64
-	h.db.Model(&models.MusicMetadata{}).
+	if err := db.Model(&models.MusicMetadata{}).
 		Where("creator_address = ? AND is_active = ?", address, true).
-		Count(&musicCount)
+		Count(&musicCount).Error; err != nil {
+		return nil, err
+	}
 
 	// Get total royalties earned
-	var totalEarnings string
 	var royaltySum struct {
 		Total string
 	}
-	h.db.Model(&models.RoyaltyDistribution{}).
+	if err := db.Model(&models.RoyaltyDistribution{}).
 		Select("COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) as total").
 		Joins("JOIN music_metadata ON royalty_distributions.token_id = music_metadata.token_id").
 		Where("music_metadata.creator_address = ?", address).
-		Scan(&royaltySum)
-	totalEarnings = royaltySum.Total
+		Scan(&royaltySum).Error; err != nil {
+		return nil, err
+	}
 
 	// Get total listeners (sum from music metadata)
 	var listenerStats struct {
@@ -51,31 +74,39 @@ func (h *DashboardHandler) GetOverview(c *gin.Context) {
 		TotalViews     uint64
 		TotalPlays     uint64
 	}
-	h.db.Model(&models.MusicMetadata{}).
+	if err := db.Model(&models.MusicMetadata{}).
 		Select("COALESCE(SUM(listener_count), 0) as total_listeners, COALESCE(SUM(view_count), 0) as total_views, COALESCE(SUM(play_count), 0) as total_plays").
 		Where("creator_address = ?", address).
-		Scan(&listenerStats)
+		Scan(&listenerStats).Error; err != nil {
+		return nil, err
+	}
 
 	// Get active campaign count
 	var activeCampaigns int64
-	h.db.Model(&models.Campaign{}).
+	if err := db.Model(&models.Campaign{}).
 		Where("creator_address = ? AND status = ?", address, "active").
-		Count(&activeCampaigns)
+		Count(&activeCampaigns).Error; err != nil {
+		return nil, err
+	}
 
 	// Get successful campaign count
 	var successfulCampaigns int64
-	h.db.Model(&models.Campaign{}).
+	if err := db.Model(&models.Campaign{}).
 		Where("creator_address = ? AND status = ?", address, "successful").
-		Count(&successfulCampaigns)
+		Count(&successfulCampaigns).Error; err != nil {
+		return nil, err
+	}
 
-	// Get user tier and verified status
+	// Get user tier and verified status; a creator without a profile keeps the zero values
 	var user models.User
-	h.db.Where("wallet_address = ?", address).First(&user)
+	if err := db.Where("wallet_address = ?", address).First(&user).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
 
-	c.JSON(http.StatusOK, gin.H{
+	return gin.H{
 		"address":              address,
 		"total_music":          musicCount,
-		"total_earnings":       totalEarnings,
+		"total_earnings":       royaltySum.Total,
 		"total_listeners":      listenerStats.TotalListeners,
 		"total_views":          listenerStats.TotalViews,
 		"total_plays":          listenerStats.TotalPlays,
@@ -84,7 +115,7 @@ func (h *DashboardHandler) GetOverview(c *gin.Context) {
 		"tier":                 user.Tier,
 		"is_verified":          user.IsVerified,
 		"leaderboard_rank":     user.LeaderboardRank,
-	})
+	}, nil
 }
 
 // GetQuickStats returns quick stats for dashboard cards
@@ -96,19 +127,33 @@ func (h *DashboardHandler) GetQuickStats(c *gin.Context) {
 		return
 	}
 
+	stats, err := h.quickStats(c.Request.Context(), address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load quick stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// quickStats gathers the figures shown on the dashboard cards
+func (h *DashboardHandler) quickStats(ctx context.Context, address string) (gin.H, error) {
+	db := h.db.WithContext(ctx)
+
 	// Get today's earnings (last royalty payment)
-	var todayEarnings string
 	var lastRoyalty struct {
 		Amount string
 	}
-	h.db.Model(&models.RoyaltyDistribution{}).
+	if err := db.Model(&models.RoyaltyDistribution{}).
 		Select("amount").
 		Joins("JOIN music_metadata ON royalty_distributions.token_id = music_metadata.token_id").
 		Where("music_metadata.creator_address = ?", address).
 		Order("royalty_distributions.distributed_at DESC").
 		Limit(1).
-		Scan(&lastRoyalty)
-	todayEarnings = lastRoyalty.Amount
+		Scan(&lastRoyalty).Error; err != nil {
+		return nil, err
+	}
+	todayEarnings := lastRoyalty.Amount
 	if todayEarnings == "" {
 		todayEarnings = "0"
 	}
@@ -121,30 +166,41 @@ func (h *DashboardHandler) GetQuickStats(c *gin.Context) {
 
 	// Get trending songs count (where trending_rank > 0)
 	var trendingSongs int64
-	h.db.Model(&models.MusicMetadata{}).
+	if err := db.Model(&models.MusicMetadata{}).
 		Where("creator_address = ? AND trending_rank > ?", address, 0).
-		Count(&trendingSongs)
+		Count(&trendingSongs).Error; err != nil {
+		return nil, err
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"today_earnings":   todayEarnings,
-		"weekly_growth":    weeklyGrowth,
-		"new_listeners":    newListeners,
-		"trending_songs":   trendingSongs,
-	})
+	return gin.H{
+		"today_earnings": todayEarnings,
+		"weekly_growth":  weeklyGrowth,
+		"new_listeners":  newListeners,
+		"trending_songs": trendingSongs,
+	}, nil
 }
 
 // GetTrendingPools returns trending crowdfunding pools
-// GET /api/v1/dashboard/trending-pools?limit=5
+// GET /api/v1/dashboard/trending-pools?limit=5&tz=Asia/Jakarta
 func (h *DashboardHandler) GetTrendingPools(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "5")
-	limit, _ := strconv.Atoi(limitStr)
+	limit, _, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	loc, err := parseTimezone(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	type PoolWithMusic struct {
 		models.Campaign
-		MusicTitle       string  `json:"music_title"`
-		MusicArtist      string  `json:"music_artist"`
-		CreatorName      string  `json:"creator_name"`
-		CreatorVerified  bool    `json:"creator_verified"`
+		MusicTitle        string  `json:"music_title"`
+		MusicArtist       string  `json:"music_artist"`
+		CreatorName       string  `json:"creator_name"`
+		CreatorVerified   bool    `json:"creator_verified"`
 		FundingPercentage float64 `json:"funding_percentage"`
 	}
 
@@ -155,7 +211,7 @@ func (h *DashboardHandler) GetTrendingPools(c *gin.Context) {
 			music_metadata.artist as music_artist,
 			users.display_name as creator_name,
 			users.is_verified as creator_verified,
-			(CAST(campaigns.raised_amount AS DECIMAL(30,0)) / CAST(campaigns.goal_amount AS DECIMAL(30,0)) * 100) as funding_percentage`).
+			`+services.FundingPercentageSQL("campaigns")+` as funding_percentage`).
 		Joins("JOIN music_metadata ON campaigns.token_id = music_metadata.token_id").
 		Joins("JOIN users ON campaigns.creator_address = users.wallet_address").
 		Where("campaigns.status = ? AND campaigns.is_trending = ?", "active", true).
@@ -163,6 +219,12 @@ func (h *DashboardHandler) GetTrendingPools(c *gin.Context) {
 		Limit(limit).
 		Scan(&pools)
 
+	for i := range pools {
+		pools[i].Deadline = pools[i].Deadline.In(loc)
+		pools[i].CreatedAt = pools[i].CreatedAt.In(loc)
+		pools[i].UpdatedAt = pools[i].UpdatedAt.In(loc)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"pools": pools,
 		"total": len(pools),
@@ -170,7 +232,7 @@ func (h *DashboardHandler) GetTrendingPools(c *gin.Context) {
 }
 
 // GetRecentActivities returns recent activities feed
-// GET /api/v1/dashboard/activities?address=0x...&limit=10
+// GET /api/v1/dashboard/activities?address=0x...&limit=10&tz=Asia/Jakarta
 func (h *DashboardHandler) GetRecentActivities(c *gin.Context) {
 	address := c.Query("address")
 	if address == "" {
@@ -178,14 +240,23 @@ func (h *DashboardHandler) GetRecentActivities(c *gin.Context) {
 		return
 	}
 
-	limitStr := c.DefaultQuery("limit", "10")
-	limit, _ := strconv.Atoi(limitStr)
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	loc, err := parseTimezone(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	var activities []models.Activity
-	h.db.Where("user_address = ?", address).
-		Order("created_at DESC").
-		Limit(limit).
-		Find(&activities)
+	activities, err := h.recentActivities(c.Request.Context(), address, limit, offset, loc)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load activities"})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"activities": activities,
@@ -193,6 +264,23 @@ func (h *DashboardHandler) GetRecentActivities(c *gin.Context) {
 	})
 }
 
+// recentActivities returns a page of the user's activities, newest first
+func (h *DashboardHandler) recentActivities(ctx context.Context, address string, limit, offset int, loc *time.Location) ([]models.Activity, error) {
+	activities := []models.Activity{}
+	if err := h.db.WithContext(ctx).Where("user_address = ?", address).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&activities).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range activities {
+		activities[i].CreatedAt = activities[i].CreatedAt.In(loc)
+	}
+	return activities, nil
+}
+
 // GetMusicTrends returns music trends chart data
 // GET /api/v1/dashboard/music-trends?address=0x...&days=30
 func (h *DashboardHandler) GetMusicTrends(c *gin.Context) {
@@ -261,8 +349,11 @@ func (h *DashboardHandler) GetViralPerformance(c *gin.Context) {
 	})
 }
 
-// GetWeeklyProgress returns weekly progress indicators
-// GET /api/v1/dashboard/weekly-progress?address=0x...
+// GetWeeklyProgress returns the week-over-week change in a creator's
+// earnings, works, listeners, plays and views, comparing the latest weekly
+// snapshot with the one before it. Weeks start Monday 00:00 UTC; tz only
+// changes how their bounds are shown.
+// GET /api/v1/dashboard/weekly-progress?address=0x...&tz=Asia/Jakarta
 func (h *DashboardHandler) GetWeeklyProgress(c *gin.Context) {
 	address := c.Query("address")
 	if address == "" {
@@ -270,34 +361,79 @@ func (h *DashboardHandler) GetWeeklyProgress(c *gin.Context) {
 		return
 	}
 
-	// For PoC, return mock weekly progress data
-	// In production, this would calculate actual week-over-week changes
-	c.JSON(http.StatusOK, gin.H{
-		"listeners_growth":  12.5, // percentage
-		"plays_growth":      18.3,
-		"earnings_growth":   25.7,
-		"engagement_growth": 15.2,
-		"week_start":        "2025-10-13",
-		"week_end":          "2025-10-20",
-	})
+	loc, err := parseTimezone(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var snapshots []models.CreatorWeeklySnapshot
+	if err := h.db.WithContext(c.Request.Context()).
+		Where("wallet_address = ?", address).
+		Order("week_start DESC").
+		Limit(2).
+		Find(&snapshots).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load weekly snapshots"})
+		return
+	}
+
+	weekStart := services.WeekStart(time.Now())
+	if len(snapshots) > 0 {
+		weekStart = snapshots[0].WeekStart
+	}
+	response := gin.H{
+		"address":         address,
+		"sufficient_data": len(snapshots) == 2,
+		"week_start":      formatTime(weekStart, loc),
+		"week_end":        formatTime(weekStart.AddDate(0, 0, 7), loc),
+	}
+	if len(snapshots) == 2 {
+		response["progress"] = services.ComputeWeeklyProgress(&snapshots[1], &snapshots[0])
+		response["current"] = snapshots[0]
+		response["previous"] = snapshots[1]
+	} else {
+		response["progress"] = nil
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // GetRoyaltyPulse returns live royalty pulse data
-// GET /api/v1/dashboard/royalty-pulse?address=0x...
+// GET /api/v1/dashboard/royalty-pulse?address=0x...&tz=Asia/Jakarta
 func (h *DashboardHandler) GetRoyaltyPulse(c *gin.Context) {
 	address := c.Query("address")
 
-	// Get recent royalty payments (last 24 hours or last 10)
-	type RoyaltyPulse struct {
-		TokenID   uint64    `json:"token_id"`
-		Title     string    `json:"title"`
-		Amount    string    `json:"amount"`
-		Platform  string    `json:"platform"`
-		PaidAt    string    `json:"paid_at"`
+	loc, err := parseTimezone(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pulse, err := h.royaltyPulse(c.Request.Context(), address, loc)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load royalty pulse"})
+		return
 	}
 
-	var pulseData []RoyaltyPulse
-	query := h.db.Table("royalty_payments").
+	c.JSON(http.StatusOK, pulse)
+}
+
+// royaltyPulseEntry is one recent royalty payment in the pulse feed
+type royaltyPulseEntry struct {
+	TokenID  uint64    `json:"token_id"`
+	Title    string    `json:"title"`
+	Amount   string    `json:"amount"`
+	Platform string    `json:"platform"`
+	PaidAt   time.Time `json:"paid_at"`
+}
+
+// royaltyPulse returns the latest distributed payments and the last 24 hours' total
+func (h *DashboardHandler) royaltyPulse(ctx context.Context, address string, loc *time.Location) (gin.H, error) {
+	db := h.db.WithContext(ctx)
+
+	// Get recent royalty payments (last 24 hours or last 10)
+	pulseData := []royaltyPulseEntry{}
+	query := db.Table("royalty_payments").
 		Select("royalty_payments.token_id, music_metadata.title, royalty_payments.amount, royalty_payments.platform, royalty_payments.paid_at").
 		Joins("JOIN music_metadata ON royalty_payments.token_id = music_metadata.token_id").
 		Where("royalty_payments.is_distributed = ?", true).
@@ -308,19 +444,203 @@ func (h *DashboardHandler) GetRoyaltyPulse(c *gin.Context) {
 		query = query.Where("music_metadata.creator_address = ?", address)
 	}
 
-	query.Scan(&pulseData)
+	if err := query.Scan(&pulseData).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range pulseData {
+		pulseData[i].PaidAt = pulseData[i].PaidAt.In(loc)
+	}
 
 	// Calculate total in pulse period
 	var totalPulse string
-	h.db.Table("royalty_payments").
+	if err := db.Table("royalty_payments").
 		Select("COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) as total").
 		Joins("JOIN music_metadata ON royalty_payments.token_id = music_metadata.token_id").
-		Where("music_metadata.creator_address = ? AND royalty_payments.paid_at >= DATE_SUB(NOW(), INTERVAL 24 HOUR)", address).
-		Scan(&totalPulse)
+		Where("music_metadata.creator_address = ? AND royalty_payments.paid_at >= ?", address, time.Now().UTC().Add(-24*time.Hour)).
+		Scan(&totalPulse).Error; err != nil {
+		return nil, err
+	}
+
+	return gin.H{
+		"pulse_data":    pulseData,
+		"total_24h":     totalPulse,
+		"payment_count": len(pulseData),
+	}, nil
+}
+
+// GetEngagementRatios returns play-to-listener and view-to-play ratios per track
+// and across the creator's catalog, flagging tracks with unusually low retention
+// GET /api/v1/dashboard/engagement-ratios?address=0x...
+func (h *DashboardHandler) GetEngagementRatios(c *gin.Context) {
+	address := c.Query("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address parameter is required"})
+		return
+	}
+
+	type TrackEngagement struct {
+		TokenID        uint64  `json:"token_id"`
+		Title          string  `json:"title"`
+		PlayCount      uint64  `json:"play_count"`
+		ViewCount      uint64  `json:"view_count"`
+		ListenerCount  uint64  `json:"listener_count"`
+		PlayToListener float64 `json:"play_to_listener_ratio" gorm:"-"`
+		ViewToPlay     float64 `json:"view_to_play_ratio" gorm:"-"`
+		LowRetention   bool    `json:"low_retention" gorm:"-"`
+	}
+
+	var tracks []TrackEngagement
+	if err := h.db.Table("music_metadata").
+		Select("token_id, title, play_count, view_count, listener_count").
+		Where("creator_address = ? AND is_active = ? AND deleted_at IS NULL", address, true).
+		Order("play_count DESC").
+		Scan(&tracks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var plays, views, listeners uint64
+	for _, track := range tracks {
+		plays += track.PlayCount
+		views += track.ViewCount
+		listeners += track.ListenerCount
+	}
+	catalogPlayToListener := metrics.Ratio(plays, listeners)
+
+	lowRetention := 0
+	for i := range tracks {
+		tracks[i].PlayToListener = metrics.Ratio(tracks[i].PlayCount, tracks[i].ListenerCount)
+		tracks[i].ViewToPlay = metrics.Ratio(tracks[i].ViewCount, tracks[i].PlayCount)
+		tracks[i].LowRetention = metrics.LowRetention(tracks[i].PlayToListener, catalogPlayToListener, tracks[i].ListenerCount)
+		if tracks[i].LowRetention {
+			lowRetention++
+		}
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"pulse_data":      pulseData,
-		"total_24h":       totalPulse,
-		"payment_count":   len(pulseData),
+		"address": address,
+		"tracks":  tracks,
+		"aggregate": gin.H{
+			"play_count":             plays,
+			"view_count":             views,
+			"listener_count":         listeners,
+			"play_to_listener_ratio": catalogPlayToListener,
+			"view_to_play_ratio":     metrics.Ratio(views, plays),
+			"low_retention_tracks":   lowRetention,
+		},
+		"low_retention_threshold": metrics.LowRetentionFactor,
 	})
 }
+
+// dashboardAllTimeout bounds the combined dashboard; sections still running
+// when it expires are reported as failed
+const dashboardAllTimeout = 5 * time.Second
+
+// dashboardSection reports whether one section of the combined dashboard loaded
+type dashboardSection struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// GetAll returns overview, quick stats, recent activities and royalty pulse in
+// one response, loading the sections concurrently. A section that fails is
+// returned as null with its error flagged under "sections"; the request only
+// fails when every section does.
+// GET /api/v1/dashboard/all?address=0x...&limit=10&tz=Asia/Jakarta
+func (h *DashboardHandler) GetAll(c *gin.Context) {
+	address := c.Query("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address parameter is required"})
+		return
+	}
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	loc, err := parseTimezone(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), dashboardAllTimeout)
+	defer cancel()
+
+	var (
+		overview, stats, pulse                         gin.H
+		activities                                     []models.Activity
+		overviewErr, statsErr, activitiesErr, pulseErr error
+	)
+
+	// Each goroutine keeps its own error and returns nil so one failing
+	// section does not cancel the others
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		overview, overviewErr = h.overview(gctx, address)
+		return nil
+	})
+	g.Go(func() error {
+		stats, statsErr = h.quickStats(gctx, address)
+		return nil
+	})
+	g.Go(func() error {
+		activities, activitiesErr = h.recentActivities(gctx, address, limit, offset, loc)
+		return nil
+	})
+	g.Go(func() error {
+		pulse, pulseErr = h.royaltyPulse(gctx, address, loc)
+		return nil
+	})
+	g.Wait()
+
+	sections := gin.H{
+		"overview":      dashboardSectionStatus(c, "overview", overviewErr),
+		"quick_stats":   dashboardSectionStatus(c, "quick_stats", statsErr),
+		"activities":    dashboardSectionStatus(c, "activities", activitiesErr),
+		"royalty_pulse": dashboardSectionStatus(c, "royalty_pulse", pulseErr),
+	}
+
+	failed := 0
+	for _, sectionErr := range []error{overviewErr, statsErr, activitiesErr, pulseErr} {
+		if sectionErr != nil {
+			failed++
+		}
+	}
+	if failed == len(sections) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load dashboard", "sections": sections})
+		return
+	}
+
+	var activitiesSection gin.H
+	if activitiesErr == nil {
+		activitiesSection = gin.H{"activities": activities, "total": len(activities)}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":       address,
+		"overview":      overview,
+		"quick_stats":   stats,
+		"activities":    activitiesSection,
+		"royalty_pulse": pulse,
+		"sections":      sections,
+		"partial":       failed > 0,
+	})
+}
+
+// dashboardSectionStatus logs a failed section and flags it without exposing
+// the underlying database error
+func dashboardSectionStatus(c *gin.Context, name string, err error) dashboardSection {
+	if err == nil {
+		return dashboardSection{OK: true}
+	}
+
+	slog.WarnContext(c.Request.Context(), "Dashboard section failed", "section", name, "error", err)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return dashboardSection{Error: "timed out"}
+	}
+	return dashboardSection{Error: "failed to load"}
+}
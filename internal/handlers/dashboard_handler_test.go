@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestGetEngagementRatios(t *testing.T) {
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("SELECT token_id, title, play_count, view_count, listener_count FROM `music_metadata` WHERE creator_address = \\? AND is_active = \\?").
+		WithArgs("0xcreator", true).
+		WillReturnRows(sqlmock.NewRows([]string{"token_id", "title", "play_count", "view_count", "listener_count"}).
+			AddRow(1, "Loyal", 900, 300, 100).  // 9 plays per listener
+			AddRow(2, "Skipped", 100, 50, 100). // 1 play per listener, below half the catalog's 4.5
+			AddRow(3, "Unreleased", 0, 40, 0))  // no plays or listeners
+
+	router := gin.New()
+	router.GET("/dashboard/engagement-ratios", NewDashboardHandler(db).GetEngagementRatios)
+
+	rec := record(router, http.MethodGet, "/dashboard/engagement-ratios?address=0xcreator", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+
+	var body struct {
+		Tracks []struct {
+			TokenID        uint64  `json:"token_id"`
+			PlayToListener float64 `json:"play_to_listener_ratio"`
+			ViewToPlay     float64 `json:"view_to_play_ratio"`
+			LowRetention   bool    `json:"low_retention"`
+		} `json:"tracks"`
+		Aggregate struct {
+			PlayToListener float64 `json:"play_to_listener_ratio"`
+			ViewToPlay     float64 `json:"view_to_play_ratio"`
+			LowRetention   int     `json:"low_retention_tracks"`
+		} `json:"aggregate"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		playToListener, viewToPlay float64
+		lowRetention               bool
+	}{
+		{playToListener: 9, viewToPlay: 0.33},
+		{playToListener: 1, viewToPlay: 0.5, lowRetention: true},
+		{playToListener: 0, viewToPlay: 0},
+	}
+	if len(body.Tracks) != len(want) {
+		t.Fatalf("got %d tracks, want %d", len(body.Tracks), len(want))
+	}
+	for i, track := range body.Tracks {
+		if track.PlayToListener != want[i].playToListener || track.ViewToPlay != want[i].viewToPlay || track.LowRetention != want[i].lowRetention {
+			t.Errorf("track %d = %+v, want %+v", track.TokenID, track, want[i])
+		}
+	}
+	if body.Aggregate.PlayToListener != 5 || body.Aggregate.ViewToPlay != 0.39 || body.Aggregate.LowRetention != 1 {
+		t.Errorf("aggregate = %+v, want 5 plays per listener, 0.39 views per play and 1 low-retention track", body.Aggregate)
+	}
+}
+
+func TestGetEngagementRatiosRequiresAddress(t *testing.T) {
+	db, _ := dbtest.New(t)
+	router := gin.New()
+	router.GET("/dashboard/engagement-ratios", NewDashboardHandler(db).GetEngagementRatios)
+
+	if status := serve(router, http.MethodGet, "/dashboard/engagement-ratios", "", ""); status != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+	}
+}
+
+func TestGetAll(t *testing.T) {
+	const address = "0xcreator"
+	errDown := errors.New("connection refused")
+
+	// Each expects a section's queries; a failing section stops at its first
+	expectOverview := func(mock sqlmock.Sqlmock, fail bool) {
+		count := mock.ExpectQuery("SELECT count\\(\\*\\) FROM `music_metadata` WHERE \\(creator_address = \\? AND is_active = \\?\\)").
+			WithArgs(address, true)
+		if fail {
+			count.WillReturnError(errDown)
+			return
+		}
+		count.WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+		mock.ExpectQuery("SELECT COALESCE\\(SUM\\(CAST\\(amount AS DECIMAL\\(30,0\\)\\)\\), 0\\) as total FROM `royalty_distributions`").
+			WithArgs(address).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow("9000"))
+		mock.ExpectQuery("SELECT COALESCE\\(SUM\\(listener_count\\), 0\\) .* FROM `music_metadata`").
+			WithArgs(address).
+			WillReturnRows(sqlmock.NewRows([]string{"total_listeners", "total_views", "total_plays"}).AddRow(10, 20, 30))
+		for _, status := range []string{"active", "successful"} {
+			mock.ExpectQuery("SELECT count\\(\\*\\) FROM `campaigns`").
+				WithArgs(address, status).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		}
+		mock.ExpectQuery("SELECT \\* FROM `users` WHERE wallet_address = \\?").
+			WithArgs(address).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "wallet_address", "tier"}).AddRow(1, address, "gold"))
+	}
+	expectQuickStats := func(mock sqlmock.Sqlmock, fail bool) {
+		last := mock.ExpectQuery("SELECT `amount` FROM `royalty_distributions`").WithArgs(address)
+		if fail {
+			last.WillReturnError(errDown)
+			return
+		}
+		last.WillReturnRows(sqlmock.NewRows([]string{"amount"}).AddRow("150"))
+		mock.ExpectQuery("SELECT count\\(\\*\\) FROM `music_metadata` WHERE \\(creator_address = \\? AND trending_rank > \\?\\)").
+			WithArgs(address, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	}
+	expectActivities := func(mock sqlmock.Sqlmock, fail bool) {
+		activities := mock.ExpectQuery("SELECT \\* FROM `activities` WHERE user_address = \\?").WithArgs(address)
+		if fail {
+			activities.WillReturnError(errDown)
+			return
+		}
+		activities.WillReturnRows(sqlmock.NewRows([]string{"id", "user_address", "activity_type"}).AddRow(1, address, "upload"))
+	}
+	expectPulse := func(mock sqlmock.Sqlmock, fail bool) {
+		recent := mock.ExpectQuery("SELECT royalty_payments.token_id, .* FROM `royalty_payments`").WithArgs(true, address)
+		if fail {
+			recent.WillReturnError(errDown)
+			return
+		}
+		recent.WillReturnRows(sqlmock.NewRows([]string{"token_id", "title", "amount"}).AddRow(7, "Hit", "150"))
+		mock.ExpectQuery("SELECT COALESCE\\(SUM\\(CAST\\(amount AS DECIMAL\\(30,0\\)\\)\\), 0\\) as total FROM `royalty_payments`").
+			WithArgs(address, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow("150"))
+	}
+
+	sections := []string{"overview", "quick_stats", "activities", "royalty_pulse"}
+	tests := []struct {
+		name   string
+		failed map[string]bool
+		want   int
+	}{
+		{name: "every section loads", want: http.StatusOK},
+		{name: "a failing section degrades to null", failed: map[string]bool{"royalty_pulse": true}, want: http.StatusOK},
+		{name: "several failing sections", failed: map[string]bool{"overview": true, "activities": true}, want: http.StatusOK},
+		{name: "every section fails", failed: map[string]bool{"overview": true, "quick_stats": true, "activities": true, "royalty_pulse": true}, want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			// Sections load concurrently, so their queries interleave
+			mock.MatchExpectationsInOrder(false)
+			expectOverview(mock, tt.failed["overview"])
+			expectQuickStats(mock, tt.failed["quick_stats"])
+			expectActivities(mock, tt.failed["activities"])
+			expectPulse(mock, tt.failed["royalty_pulse"])
+
+			router := gin.New()
+			router.GET("/dashboard/all", NewDashboardHandler(db).GetAll)
+
+			rec := record(router, http.MethodGet, "/dashboard/all?address="+address, "", "")
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+
+			var body map[string]json.RawMessage
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			var status map[string]dashboardSection
+			if err := json.Unmarshal(body["sections"], &status); err != nil {
+				t.Fatal(err)
+			}
+			for _, section := range sections {
+				want := dashboardSection{OK: true}
+				if tt.failed[section] {
+					want = dashboardSection{Error: "failed to load"}
+				}
+				if status[section] != want {
+					t.Errorf("sections.%s = %+v, want %+v", section, status[section], want)
+				}
+				if tt.want != http.StatusOK {
+					continue
+				}
+				if loaded := string(body[section]) != "null"; loaded == tt.failed[section] {
+					t.Errorf("%s = %s, want loaded = %v", section, body[section], !tt.failed[section])
+				}
+			}
+			if tt.want == http.StatusOK && string(body["partial"]) != strconv.FormatBool(len(tt.failed) > 0) {
+				t.Errorf("partial = %s, want %v", body["partial"], len(tt.failed) > 0)
+			}
+			if strings.Contains(rec.Body.String(), errDown.Error()) {
+				t.Errorf("response leaks the database error: %s", rec.Body)
+			}
+		})
+	}
+}
+
+func TestGetAllRequiresAddress(t *testing.T) {
+	db, _ := dbtest.New(t)
+	router := gin.New()
+	router.GET("/dashboard/all", NewDashboardHandler(db).GetAll)
+
+	if status := serve(router, http.MethodGet, "/dashboard/all", "", ""); status != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+	}
+}
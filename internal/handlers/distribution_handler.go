@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
 	"github.com/tunecent/backend/internal/services"
+	"gorm.io/gorm"
 )
 
 type DistributionHandler struct {
@@ -28,6 +32,17 @@ func (h *DistributionHandler) SubmitDistribution(c *gin.Context) {
 
 	submission, err := h.distributionService.SubmitDistribution(c.Request.Context(), &req)
 	if err != nil {
+		if respondPlatformError(c, err) {
+			return
+		}
+		var missing *services.MissingMetadataError
+		if errors.As(err, &missing) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":          err.Error(),
+				"missing_fields": missing.Missing,
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -38,6 +53,34 @@ func (h *DistributionHandler) SubmitDistribution(c *gin.Context) {
 	})
 }
 
+// respondPlatformError answers 400 for an empty or unsupported platform
+// selection, naming the unsupported entries, and reports whether it did
+func respondPlatformError(c *gin.Context, err error) bool {
+	var invalid *services.InvalidPlatformsError
+	switch {
+	case errors.As(err, &invalid):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":               err.Error(),
+			"invalid_platforms":   invalid.Invalid,
+			"supported_platforms": services.SupportedPlatformKeys(),
+		})
+		return true
+	case errors.Is(err, services.ErrNoPlatforms):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return true
+	}
+	return false
+}
+
+// GetPlatforms handles GET /api/v1/distribution/platforms
+func (h *DistributionHandler) GetPlatforms(c *gin.Context) {
+	platforms := services.SupportedPlatforms()
+	c.JSON(http.StatusOK, gin.H{
+		"platforms": platforms,
+		"total":     len(platforms),
+	})
+}
+
 // GetDistributionStatus handles GET /api/v1/distribution/:tokenId/status
 func (h *DistributionHandler) GetDistributionStatus(c *gin.Context) {
 	tokenIDStr := c.Param("tokenId")
@@ -56,6 +99,31 @@ func (h *DistributionHandler) GetDistributionStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, status)
 }
 
+// GetTimeline handles GET /api/v1/distribution/:tokenId/timeline
+func (h *DistributionHandler) GetTimeline(c *gin.Context) {
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	events, err := h.distributionService.GetTimeline(c.Request.Context(), tokenID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Distribution not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token_id": tokenID,
+		"events":   events,
+		"total":    len(events),
+	})
+}
+
 // GetPlatformStatus handles GET /api/v1/distribution/:tokenId/platform/:platform
 func (h *DistributionHandler) GetPlatformStatus(c *gin.Context) {
 	tokenIDStr := c.Param("tokenId")
@@ -109,17 +177,127 @@ func (h *DistributionHandler) UpdatePlatformStatus(c *gin.Context) {
 	})
 }
 
+// RetryPlatformDistribution handles POST /api/v1/distribution/:tokenId/platform/:platform/retry
+func (h *DistributionHandler) RetryPlatformDistribution(c *gin.Context) {
+	tokenIDStr := c.Param("tokenId")
+	tokenID, err := strconv.ParseUint(tokenIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	if !h.authorizeTrackManager(c, tokenID) {
+		return
+	}
+
+	platform := c.Param("platform")
+
+	platformDist, err := h.distributionService.RetryPlatformDistribution(c.Request.Context(), tokenID, platform)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrInvalidStatusTransition):
+			status = http.StatusConflict
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Platform distribution queued for retry",
+		"distribution": platformDist,
+	})
+}
+
+// authorizeTrackManager lets users manage only their own tracks' distribution;
+// admin and service tokens may manage any. It responds and returns false
+// otherwise.
+func (h *DistributionHandler) authorizeTrackManager(c *gin.Context, tokenID uint64) bool {
+	claims := authClaims(c)
+	if claims.Role != auth.RoleUser {
+		return true
+	}
+
+	creator, err := h.distributionService.TrackCreator(c.Request.Context(), tokenID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return false
+	}
+	if !strings.EqualFold(creator, claims.Subject) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the track's creator, an admin or a service can manage its distribution"})
+		return false
+	}
+	return true
+}
+
+// AddPlatform handles POST /api/v1/distribution/:tokenId/platform
+func (h *DistributionHandler) AddPlatform(c *gin.Context) {
+	tokenIDStr := c.Param("tokenId")
+	tokenID, err := strconv.ParseUint(tokenIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	var req struct {
+		Platform string `json:"platform" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if strings.TrimSpace(req.Platform) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "platform is required"})
+		return
+	}
+	if !h.authorizeTrackManager(c, tokenID) {
+		return
+	}
+
+	submission, platformDist, err := h.distributionService.AddPlatform(c.Request.Context(), tokenID, req.Platform)
+	if err != nil {
+		if respondPlatformError(c, err) {
+			return
+		}
+		var missing *services.MissingMetadataError
+		if errors.As(err, &missing) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":          err.Error(),
+				"missing_fields": missing.Missing,
+			})
+			return
+		}
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrPlatformAlreadyAdded):
+			status = http.StatusConflict
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":      "Platform added to distribution",
+		"submission":   submission,
+		"distribution": platformDist,
+	})
+}
+
 // ListDistributions handles GET /api/v1/distribution/list
 func (h *DistributionHandler) ListDistributions(c *gin.Context) {
 	userAddress := c.Query("user_address")
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
-
-	limit, _ := strconv.Atoi(limitStr)
-	offset, _ := strconv.Atoi(offsetStr)
-
-	if limit > 100 {
-		limit = 100
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	submissions, total, err := h.distributionService.ListDistributions(c.Request.Context(), userAddress, limit, offset)
@@ -135,3 +313,31 @@ func (h *DistributionHandler) ListDistributions(c *gin.Context) {
 		"offset": offset,
 	})
 }
+
+// ListPendingTracks handles GET /api/v1/distribution/pending-tracks
+func (h *DistributionHandler) ListPendingTracks(c *gin.Context) {
+	address := c.Query("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tracks, total, err := h.distributionService.ListPendingTracks(c.Request.Context(), address, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":   tracks,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
@@ -0,0 +1,584 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/internal/services"
+)
+
+const (
+	trackCreator = "0xcreator"
+	trackSQL     = "SELECT `creator_address` FROM `music_metadata` WHERE token_id = \\?"
+)
+
+// distributionRouter mounts the distribution management routes the way
+// main.go does
+func distributionRouter(h *DistributionHandler) *gin.Engine {
+	router := gin.New()
+	manage := RequireRole(testSecret, auth.RoleUser, auth.RoleAdmin, auth.RoleService)
+	router.POST("/distribution/:tokenId/platform", manage, h.AddPlatform)
+	router.POST("/distribution/:tokenId/platform/:platform/retry", manage, h.RetryPlatformDistribution)
+	return router
+}
+
+// expectTrackCreator answers the creator lookup made for user tokens
+func expectTrackCreator(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(trackSQL).WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"creator_address"}).AddRow(trackCreator))
+}
+
+// expectPlatformRow answers the lookup of a track's platform row
+func expectPlatformRow(mock sqlmock.Sqlmock, platform, status string) {
+	mock.ExpectQuery("SELECT \\* FROM `platform_distributions` WHERE \\(token_id = \\? AND platform = \\?\\)").
+		WithArgs(7, platform).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "platform", "status"}).AddRow(3, 7, platform, status))
+}
+
+// expectRecompute answers recomputeSubmissionStatus for a submission that
+// moves from the given status to processing
+func expectRecompute(mock sqlmock.Sqlmock, from string, platforms [][2]string) {
+	mock.ExpectQuery("SELECT \\* FROM `distribution_submissions` WHERE token_id = \\?").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "status", "created_at"}).AddRow(1, 7, from, time.Now()))
+	rows := sqlmock.NewRows([]string{"id", "token_id", "platform", "status"})
+	for i, p := range platforms {
+		rows.AddRow(i+1, 7, p[0], p[1])
+	}
+	mock.ExpectQuery("SELECT \\* FROM `platform_distributions` WHERE token_id = \\?").WithArgs(7).WillReturnRows(rows)
+	mock.ExpectExec("UPDATE `distribution_submissions` SET `status`=\\?").
+		WithArgs("processing", sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO `distribution_events`").
+		WithArgs(7, "", from, "processing", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestRetryPlatformDistribution(t *testing.T) {
+	const path = "/distribution/7/platform/spotify/retry"
+
+	tests := []struct {
+		name          string
+		authorization func(t *testing.T) string
+		expect        func(mock sqlmock.Sqlmock)
+		want          int
+	}{
+		{
+			name:          "creator retries a failed platform",
+			authorization: func(t *testing.T) string { return bearer(t, testSecret, trackCreator, auth.RoleUser, time.Minute) },
+			expect: func(mock sqlmock.Sqlmock) {
+				expectTrackCreator(mock)
+				expectPlatformRow(mock, "spotify", "failed")
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `platform_distributions` SET .*`status`=\\?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `distribution_events`").
+					WithArgs(7, "spotify", "failed", "pending", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRecompute(mock, "failed", [][2]string{{"spotify", "pending"}, {"tiktok", "live"}})
+				mock.ExpectCommit()
+			},
+			want: http.StatusOK,
+		},
+		{
+			name:          "creator is matched case-insensitively",
+			authorization: func(t *testing.T) string { return bearer(t, testSecret, "0xCREATOR", auth.RoleUser, time.Minute) },
+			expect: func(mock sqlmock.Sqlmock) {
+				expectTrackCreator(mock)
+				expectPlatformRow(mock, "spotify", "live")
+			},
+			want: http.StatusConflict,
+		},
+		{
+			name:          "live platform cannot be retried",
+			authorization: func(t *testing.T) string { return bearer(t, testSecret, trackCreator, auth.RoleUser, time.Minute) },
+			expect: func(mock sqlmock.Sqlmock) {
+				expectTrackCreator(mock)
+				expectPlatformRow(mock, "spotify", "live")
+			},
+			want: http.StatusConflict,
+		},
+		{
+			name:          "pending platform cannot be retried",
+			authorization: func(t *testing.T) string { return bearer(t, testSecret, "admin", auth.RoleAdmin, time.Minute) },
+			expect: func(mock sqlmock.Sqlmock) {
+				expectPlatformRow(mock, "spotify", "pending")
+			},
+			want: http.StatusConflict,
+		},
+		{
+			name:          "unknown platform row",
+			authorization: func(t *testing.T) string { return bearer(t, testSecret, "indexer", auth.RoleService, time.Minute) },
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `platform_distributions`").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name:          "another user's track",
+			authorization: func(t *testing.T) string { return bearer(t, testSecret, "0xsomeoneelse", auth.RoleUser, time.Minute) },
+			expect:        expectTrackCreator,
+			want:          http.StatusForbidden,
+		},
+		{
+			name:          "unknown track",
+			authorization: func(t *testing.T) string { return bearer(t, testSecret, trackCreator, auth.RoleUser, time.Minute) },
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(trackSQL).WillReturnRows(sqlmock.NewRows([]string{"creator_address"}))
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name:          "no token",
+			authorization: func(t *testing.T) string { return "" },
+			expect:        func(sqlmock.Sqlmock) {},
+			want:          http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			tt.expect(mock)
+			router := distributionRouter(NewDistributionHandler(services.NewDistributionService(db)))
+
+			rec := record(router, http.MethodPost, path, tt.authorization(t), "")
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+// expectTikTokReadyTrack answers AddPlatform's track lookup with a track that
+// meets TikTok's requirements, or lacks its audio when incomplete
+func expectTikTokReadyTrack(mock sqlmock.Sqlmock, incomplete bool) {
+	audio := "ipfs://audio"
+	if incomplete {
+		audio = ""
+	}
+	mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "creator_address", "title", "artist", "audio_file_url", "duration"}).
+			AddRow(1, 7, trackCreator, "Song", "Artist", audio, 180))
+}
+
+// expectActiveSubmission answers the locked lookup of the track's active
+// submission with the given platforms JSON
+func expectActiveSubmission(mock sqlmock.Sqlmock, platforms string) {
+	mock.ExpectQuery("SELECT \\* FROM `distribution_submissions` WHERE \\(token_id = \\? AND status NOT IN \\('failed', 'cancelled'\\)\\) .* FOR UPDATE").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "platforms", "status"}).AddRow(1, 7, platforms, "distributed"))
+}
+
+func TestAddPlatform(t *testing.T) {
+	const path = "/distribution/7/platform"
+	admin := func(t *testing.T) string { return bearer(t, testSecret, "admin", auth.RoleAdmin, time.Minute) }
+	creator := func(t *testing.T) string { return bearer(t, testSecret, trackCreator, auth.RoleUser, time.Minute) }
+
+	tests := []struct {
+		name          string
+		authorization func(t *testing.T) string
+		body          string
+		expect        func(mock sqlmock.Sqlmock)
+		want          int
+	}{
+		{
+			name:          "creator adds a platform",
+			authorization: creator,
+			body:          `{"platform":"TikTok"}`,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectTrackCreator(mock)
+				expectTikTokReadyTrack(mock, false)
+				mock.ExpectBegin()
+				expectActiveSubmission(mock, `["spotify"]`)
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `platform_distributions` WHERE \\(token_id = \\? AND platform = \\?\\)").
+					WithArgs(7, "tiktok").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec("UPDATE `distribution_submissions` SET `platforms`=\\?").
+					WithArgs(`["spotify","tiktok"]`, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `platform_distributions`").
+					WillReturnResult(sqlmock.NewResult(4, 1))
+				mock.ExpectExec("INSERT INTO `distribution_events`").
+					WithArgs(7, "tiktok", "", "pending", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRecompute(mock, "distributed", [][2]string{{"spotify", "live"}, {"tiktok", "pending"}})
+				mock.ExpectQuery("SELECT \\* FROM `distribution_submissions` WHERE `distribution_submissions`.`id` = \\?").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "platforms", "status"}).AddRow(1, 7, `["spotify","tiktok"]`, "processing"))
+				mock.ExpectCommit()
+			},
+			want: http.StatusCreated,
+		},
+		{
+			name:          "platform already on the submission",
+			authorization: admin,
+			body:          `{"platform":"spotify"}`,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "title", "artist", "genre", "isrc", "explicit", "cover_image_url", "audio_file_url"}).
+						AddRow(1, 7, "Song", "Artist", "Pop", "USRC17607839", false, "ipfs://cover", "ipfs://audio"))
+				mock.ExpectBegin()
+				expectActiveSubmission(mock, `["Spotify"]`)
+				mock.ExpectRollback()
+			},
+			want: http.StatusConflict,
+		},
+		{
+			name:          "platform row left from an earlier submission",
+			authorization: admin,
+			body:          `{"platform":"tiktok"}`,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectTikTokReadyTrack(mock, false)
+				mock.ExpectBegin()
+				expectActiveSubmission(mock, `["spotify"]`)
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `platform_distributions`").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectRollback()
+			},
+			want: http.StatusConflict,
+		},
+		{
+			name:          "no active submission",
+			authorization: admin,
+			body:          `{"platform":"tiktok"}`,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectTikTokReadyTrack(mock, false)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `distribution_submissions`").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name:          "track lacks the platform's metadata",
+			authorization: admin,
+			body:          `{"platform":"tiktok"}`,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectTikTokReadyTrack(mock, true)
+			},
+			want: http.StatusUnprocessableEntity,
+		},
+		{
+			name:          "unsupported platform",
+			authorization: admin,
+			body:          `{"platform":"myspace"}`,
+			expect:        func(sqlmock.Sqlmock) {},
+			want:          http.StatusBadRequest,
+		},
+		{
+			name:          "missing platform",
+			authorization: creator,
+			body:          `{"platform":"  "}`,
+			expect:        func(sqlmock.Sqlmock) {},
+			want:          http.StatusBadRequest,
+		},
+		{
+			name:          "another user's track",
+			authorization: func(t *testing.T) string { return bearer(t, testSecret, "0xsomeoneelse", auth.RoleUser, time.Minute) },
+			body:          `{"platform":"tiktok"}`,
+			expect:        expectTrackCreator,
+			want:          http.StatusForbidden,
+		},
+		{
+			name:          "no token",
+			authorization: func(t *testing.T) string { return "" },
+			body:          `{"platform":"tiktok"}`,
+			expect:        func(sqlmock.Sqlmock) {},
+			want:          http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			tt.expect(mock)
+			router := distributionRouter(NewDistributionHandler(services.NewDistributionService(db)))
+
+			rec := record(router, http.MethodPost, path, tt.authorization(t), tt.body)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+func TestListPendingTracks(t *testing.T) {
+	// Tracks with a live, pending or failed submission are excluded by the NOT
+	// EXISTS subquery; only a cancelled submission leaves a track pending
+	const pendingSQL = "FROM `music_metadata` WHERE \\(creator_address = \\? AND is_active = \\?\\) AND NOT EXISTS \\(.*SELECT 1 FROM distribution_submissions ds.*ds.token_id = music_metadata.token_id.*ds.deleted_at IS NULL.*ds.status <> \\?.*\\) AND `music_metadata`.`deleted_at` IS NULL"
+
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("(?s)SELECT count\\(\\*\\) "+pendingSQL).
+		WithArgs(trackCreator, true, "cancelled").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("(?s)SELECT \\* "+pendingSQL+" ORDER BY registered_at DESC LIMIT 2 OFFSET 1").
+		WithArgs(trackCreator, true, "cancelled").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "title"}).
+			AddRow(2, 8, "Never submitted").
+			AddRow(3, 9, "Submission cancelled"))
+
+	router := gin.New()
+	router.GET("/distribution/pending-tracks", NewDistributionHandler(services.NewDistributionService(db)).ListPendingTracks)
+
+	rec := record(router, http.MethodGet, "/distribution/pending-tracks?address="+trackCreator+"&limit=2&offset=1", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var body struct {
+		Data []struct {
+			TokenID uint64 `json:"token_id"`
+		} `json:"data"`
+		Total int64 `json:"total"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Total != 3 || len(body.Data) != 2 || body.Data[0].TokenID != 8 || body.Data[1].TokenID != 9 {
+		t.Errorf("got %+v of %d, want tokens 8 and 9 of 3", body.Data, body.Total)
+	}
+}
+
+func TestListPendingTracksRequiresAddress(t *testing.T) {
+	db, _ := dbtest.New(t)
+	router := gin.New()
+	router.GET("/distribution/pending-tracks", NewDistributionHandler(services.NewDistributionService(db)).ListPendingTracks)
+
+	if status := serve(router, http.MethodGet, "/distribution/pending-tracks", "", ""); status != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+	}
+}
+
+func TestSubmitDistributionMissingMetadata(t *testing.T) {
+	tests := []struct {
+		name        string
+		platforms   string
+		want        int
+		wantMissing map[string][]string
+	}{
+		{
+			name:        "ISRC required by the selected platforms",
+			platforms:   `["Spotify","apple-music"]`,
+			want:        http.StatusUnprocessableEntity,
+			wantMissing: map[string][]string{"spotify": {"isrc", "explicit"}, "apple_music": {"isrc", "explicit"}},
+		},
+		{
+			name:        "only the platforms that need a field report it",
+			platforms:   `["spotify","tiktok"]`,
+			want:        http.StatusUnprocessableEntity,
+			wantMissing: map[string][]string{"spotify": {"isrc", "explicit"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "creator_address", "title", "artist", "genre", "cover_image_url", "audio_file_url", "duration"}).
+					AddRow(1, 7, trackCreator, "Song", "Artist", "Pop", "ipfs://cover", "ipfs://audio", 180))
+
+			router := gin.New()
+			router.POST("/distribution/submit", NewDistributionHandler(services.NewDistributionService(db)).SubmitDistribution)
+
+			body := `{"token_id":7,"user_address":"0xcreator","platforms":` + tt.platforms + `}`
+			rec := record(router, http.MethodPost, "/distribution/submit", "", body)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+
+			var resp struct {
+				Missing map[string][]string `json:"missing_fields"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(resp.Missing, tt.wantMissing) {
+				t.Errorf("missing_fields = %v, want %v", resp.Missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestSubmitDistributionRejectsPlatforms(t *testing.T) {
+	tests := []struct {
+		name        string
+		platforms   string
+		wantInvalid []string
+	}{
+		{name: "unsupported platform", platforms: `["spotify","myspace"]`, wantInvalid: []string{"myspace"}},
+		{name: "misspelled platforms", platforms: `["spotfy","Tik Tok","tiktok"]`, wantInvalid: []string{"spotfy", "Tik Tok"}},
+		{name: "no platforms", platforms: `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := dbtest.New(t)
+			router := gin.New()
+			router.POST("/distribution/submit", NewDistributionHandler(services.NewDistributionService(db)).SubmitDistribution)
+
+			body := `{"token_id":7,"user_address":"0xcreator","platforms":` + tt.platforms + `}`
+			rec := record(router, http.MethodPost, "/distribution/submit", "", body)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+
+			var got struct {
+				Invalid []string `json:"invalid_platforms"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Invalid, tt.wantInvalid) {
+				t.Errorf("invalid_platforms = %q, want %q", got.Invalid, tt.wantInvalid)
+			}
+		})
+	}
+}
+
+func TestGetPlatforms(t *testing.T) {
+	router := gin.New()
+	router.GET("/distribution/platforms", NewDistributionHandler(nil).GetPlatforms)
+
+	rec := record(router, http.MethodGet, "/distribution/platforms", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var body struct {
+		Platforms []services.PlatformRequirements `json:"platforms"`
+		Total     int                             `json:"total"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(body.Platforms, services.SupportedPlatforms()) || body.Total != len(body.Platforms) {
+		t.Errorf("platforms = %+v (total %d), want the supported platforms", body.Platforms, body.Total)
+	}
+}
+
+func TestUpdatePlatformStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		from      string
+		to        string
+		wantEvent bool
+	}{
+		{name: "status change appends a timeline event", from: "pending", to: "live", wantEvent: true},
+		{name: "unchanged status is not recorded", from: "processing", to: "processing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			expectPlatformRow(mock, "spotify", tt.from)
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `platform_distributions` SET .*`status`=\\?").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			if tt.wantEvent {
+				mock.ExpectExec("INSERT INTO `distribution_events`").
+					WithArgs(7, "spotify", tt.from, tt.to, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectRecompute(mock, "pending", [][2]string{{"spotify", tt.to}, {"tiktok", "pending"}})
+			} else {
+				// The submission is already processing, so it records nothing either
+				mock.ExpectQuery("SELECT \\* FROM `distribution_submissions` WHERE token_id = \\?").
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "status", "created_at"}).AddRow(1, 7, "processing", time.Now()))
+				mock.ExpectQuery("SELECT \\* FROM `platform_distributions` WHERE token_id = \\?").
+					WithArgs(7).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "platform", "status"}).AddRow(3, 7, "spotify", tt.to))
+			}
+			mock.ExpectCommit()
+
+			router := gin.New()
+			router.PUT("/distribution/:tokenId/platform/:platform", NewDistributionHandler(services.NewDistributionService(db)).UpdatePlatformStatus)
+
+			rec := record(router, http.MethodPut, "/distribution/7/platform/spotify", "", `{"status":"`+tt.to+`"}`)
+			if rec.Code != http.StatusOK {
+				t.Errorf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+		})
+	}
+}
+
+func TestGetDistributionTimeline(t *testing.T) {
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		submissions int
+		events      [][]interface{} // platform, from, to
+		want        int
+	}{
+		{
+			name:        "status changes oldest first",
+			submissions: 1,
+			events: [][]interface{}{
+				{"", "", "pending"},
+				{"spotify", "", "pending"},
+				{"spotify", "pending", "live"},
+				{"", "pending", "distributed"},
+			},
+			want: http.StatusOK,
+		},
+		{name: "submitted without changes yet", submissions: 1, want: http.StatusOK},
+		{name: "track never submitted", want: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT count\\(\\*\\) FROM `distribution_submissions` WHERE token_id = \\?").
+				WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.submissions))
+			if tt.submissions > 0 {
+				rows := sqlmock.NewRows([]string{"id", "token_id", "platform", "from_status", "to_status", "created_at"})
+				for i, e := range tt.events {
+					rows.AddRow(i+1, 7, e[0], e[1], e[2], at.Add(time.Duration(i)*time.Minute))
+				}
+				mock.ExpectQuery("SELECT \\* FROM `distribution_events` WHERE token_id = \\? ORDER BY created_at ASC, id ASC").
+					WithArgs(7).
+					WillReturnRows(rows)
+			}
+
+			router := gin.New()
+			router.GET("/distribution/:tokenId/timeline", NewDistributionHandler(services.NewDistributionService(db)).GetTimeline)
+
+			rec := record(router, http.MethodGet, "/distribution/7/timeline", "", "")
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Events []struct {
+					Platform string `json:"platform"`
+					From     string `json:"from_status"`
+					To       string `json:"to_status"`
+				} `json:"events"`
+				Total int `json:"total"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Events == nil || len(body.Events) != len(tt.events) || body.Total != len(tt.events) {
+				t.Fatalf("got %d events (total %d), want %d", len(body.Events), body.Total, len(tt.events))
+			}
+			for i, e := range tt.events {
+				got := body.Events[i]
+				if got.Platform != e[0] || got.From != e[1] || got.To != e[2] {
+					t.Errorf("event %d = %+v, want %v", i, got, e)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetEarningTokens lists the tracks whose royalty distributions have paid the
+// address, with the total received per track, highest first
+// GET /api/v1/users/:address/earning-tokens
+func (h *UserHandler) GetEarningTokens(c *gin.Context) {
+	address := c.Param("address")
+
+	type EarningToken struct {
+		TokenID           uint64    `json:"token_id"`
+		Title             string    `json:"title"`
+		TotalEarned       string    `json:"total_earned"` // Wei as string
+		DistributionCount int64     `json:"distribution_count"`
+		LastDistributedAt time.Time `json:"last_distributed_at"`
+	}
+
+	tokens := []EarningToken{}
+	if err := h.db.Table("royalty_distributions rd").
+		Select(`rd.token_id, m.title,
+			CAST(COALESCE(SUM(CAST(rd.amount AS DECIMAL(30,0))), 0) AS CHAR) as total_earned,
+			COUNT(*) as distribution_count,
+			MAX(rd.distributed_at) as last_distributed_at`).
+		Joins("JOIN music_metadata m ON m.token_id = rd.token_id").
+		Where("rd.beneficiary = ?", address).
+		Group("rd.token_id, m.title").
+		Order("SUM(CAST(rd.amount AS DECIMAL(30,0))) DESC, rd.token_id ASC").
+		Scan(&tokens).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	total := new(big.Int)
+	for _, token := range tokens {
+		if amount, ok := new(big.Int).SetString(token.TotalEarned, 10); ok {
+			total.Add(total, amount)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":      address,
+		"tokens":       tokens,
+		"total_tokens": len(tokens),
+		"total_earned": total.String(),
+	})
+}
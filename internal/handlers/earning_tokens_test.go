@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestGetEarningTokens(t *testing.T) {
+	last := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	type token struct {
+		id     uint64
+		title  string
+		earned string
+		count  int64
+	}
+
+	tests := []struct {
+		name      string
+		tokens    []token
+		wantTotal string
+	}{
+		{
+			name: "several tokens",
+			tokens: []token{
+				{id: 7, title: "Night Drive", earned: "5000000000000000000000", count: 4},
+				{id: 3, title: "Sunrise", earned: "1200", count: 2},
+				{id: 9, title: "Outro", earned: "300", count: 1},
+			},
+			wantTotal: "5000000000000000001500",
+		},
+		{name: "never paid", wantTotal: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows([]string{"token_id", "title", "total_earned", "distribution_count", "last_distributed_at"})
+			for _, tok := range tt.tokens {
+				rows.AddRow(tok.id, tok.title, tok.earned, tok.count, last)
+			}
+			// One row per token, summed and ordered by the database
+			mock.ExpectQuery("FROM royalty_distributions rd JOIN music_metadata m ON m.token_id = rd.token_id " +
+				"WHERE rd.beneficiary = \\? GROUP BY rd.token_id, m.title " +
+				"ORDER BY SUM\\(CAST\\(rd.amount AS DECIMAL\\(30,0\\)\\)\\) DESC, rd.token_id ASC").
+				WithArgs("0xinvestor").
+				WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/users/:address/earning-tokens", NewUserHandler(db).GetEarningTokens)
+
+			rec := record(router, http.MethodGet, "/users/0xinvestor/earning-tokens", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Tokens []struct {
+					TokenID           uint64    `json:"token_id"`
+					Title             string    `json:"title"`
+					TotalEarned       string    `json:"total_earned"`
+					DistributionCount int64     `json:"distribution_count"`
+					LastDistributedAt time.Time `json:"last_distributed_at"`
+				} `json:"tokens"`
+				TotalTokens int    `json:"total_tokens"`
+				TotalEarned string `json:"total_earned"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Tokens == nil || body.TotalTokens != len(tt.tokens) || body.TotalEarned != tt.wantTotal {
+				t.Fatalf("got %d tokens earning %s, want %d earning %s", body.TotalTokens, body.TotalEarned, len(tt.tokens), tt.wantTotal)
+			}
+			for i, want := range tt.tokens {
+				got := body.Tokens[i]
+				if got.TokenID != want.id || got.Title != want.title || got.TotalEarned != want.earned || got.DistributionCount != want.count || !got.LastDistributedAt.Equal(last) {
+					t.Errorf("token %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/pkg/metrics"
+)
+
+// defaultConcentrationThreshold is the top-track share of earnings, in
+// percent, above which a creator is flagged as relying on one track
+const defaultConcentrationThreshold = 50.0
+
+// GetEarningsConcentration measures how much of the address's royalty
+// earnings come from its top tracks, using the Herfindahl index over
+// earnings by track. is_concentrated is set when the top track's share
+// exceeds threshold percent.
+// GET /api/v1/users/:address/earnings-concentration?threshold=50
+func (h *UserHandler) GetEarningsConcentration(c *gin.Context) {
+	address := c.Param("address")
+
+	threshold := defaultConcentrationThreshold
+	if value := c.Query("threshold"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 || parsed > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "threshold must be a percentage between 0 and 100"})
+			return
+		}
+		threshold = parsed
+	}
+
+	var rows []struct {
+		TokenID uint64
+		Total   string
+	}
+	if err := h.db.Model(&models.RoyaltyDistribution{}).
+		Select("token_id, CAST(COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) AS CHAR) as total").
+		Where("beneficiary = ?", address).
+		Group("token_id").
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	byTrack := make(map[string]*big.Int, len(rows))
+	total := new(big.Int)
+	for _, row := range rows {
+		amount, ok := new(big.Int).SetString(row.Total, 10)
+		if !ok || amount.Sign() <= 0 {
+			continue
+		}
+		byTrack[strconv.FormatUint(row.TokenID, 10)] = amount
+		total.Add(total, amount)
+	}
+
+	concentration := metrics.Concentrate(byTrack, 3)
+	topShare := 0.0
+	if len(concentration.Top) > 0 {
+		topShare = concentration.Top[0].Share
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":         address,
+		"total_earned":    total.String(),
+		"earning_tracks":  len(byTrack),
+		"concentration":   concentration,
+		"top_track_share": topShare,
+		"threshold":       threshold,
+		"is_concentrated": topShare > threshold,
+	})
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestGetEarningsConcentration(t *testing.T) {
+	tests := []struct {
+		name             string
+		query            string
+		earnings         []string // summed earnings of tracks 1, 2, ...
+		wantTotal        string
+		wantTracks       int
+		wantTopShare     float64
+		wantIndex        float64
+		wantConcentrated bool
+	}{
+		{
+			name:             "one hit carries the catalog",
+			earnings:         []string{"800", "100", "100"},
+			wantTotal:        "1000",
+			wantTracks:       3,
+			wantTopShare:     80,
+			wantIndex:        0.66,
+			wantConcentrated: true,
+		},
+		{
+			name:         "earnings spread evenly",
+			earnings:     []string{"250", "250", "250", "250"},
+			wantTotal:    "1000",
+			wantTracks:   4,
+			wantTopShare: 25,
+			wantIndex:    0.25,
+		},
+		{
+			name:             "spread earnings over a lower threshold",
+			query:            "?threshold=20",
+			earnings:         []string{"250", "250", "250", "250"},
+			wantTotal:        "1000",
+			wantTracks:       4,
+			wantTopShare:     25,
+			wantIndex:        0.25,
+			wantConcentrated: true,
+		},
+		{
+			name:         "top share at the threshold is not flagged",
+			earnings:     []string{"500", "500"},
+			wantTotal:    "1000",
+			wantTracks:   2,
+			wantTopShare: 50,
+			wantIndex:    0.5,
+		},
+		{
+			name:             "tracks that earned nothing are left out",
+			earnings:         []string{"600", "0", "400"},
+			wantTotal:        "1000",
+			wantTracks:       2,
+			wantTopShare:     60,
+			wantIndex:        0.52,
+			wantConcentrated: true,
+		},
+		{
+			name:      "no earnings",
+			wantTotal: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows([]string{"token_id", "total"})
+			for i, total := range tt.earnings {
+				rows.AddRow(i+1, total)
+			}
+			mock.ExpectQuery("SELECT token_id, .* as total FROM `royalty_distributions` WHERE beneficiary = \\? GROUP BY `token_id`").
+				WithArgs("0xcreator").
+				WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/users/:address/earnings-concentration", NewUserHandler(db).GetEarningsConcentration)
+
+			rec := record(router, http.MethodGet, "/users/0xcreator/earnings-concentration"+tt.query, "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Total         string `json:"total_earned"`
+				Tracks        int    `json:"earning_tracks"`
+				Concentration struct {
+					Index float64 `json:"index"`
+				} `json:"concentration"`
+				TopShare     float64 `json:"top_track_share"`
+				Concentrated bool    `json:"is_concentrated"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Total != tt.wantTotal || body.Tracks != tt.wantTracks {
+				t.Errorf("earned %s over %d tracks, want %s over %d", body.Total, body.Tracks, tt.wantTotal, tt.wantTracks)
+			}
+			if body.TopShare != tt.wantTopShare || body.Concentration.Index != tt.wantIndex || body.Concentrated != tt.wantConcentrated {
+				t.Errorf("top share %v%%, index %v, concentrated %v, want %v%%, %v, %v", body.TopShare, body.Concentration.Index, body.Concentrated, tt.wantTopShare, tt.wantIndex, tt.wantConcentrated)
+			}
+		})
+	}
+}
+
+func TestGetEarningsConcentrationRejectsThreshold(t *testing.T) {
+	db, _ := dbtest.New(t)
+	router := gin.New()
+	router.GET("/users/:address/earnings-concentration", NewUserHandler(db).GetEarningsConcentration)
+
+	for _, threshold := range []string{"0", "-5", "101", "half"} {
+		t.Run(threshold, func(t *testing.T) {
+			if status := serve(router, http.MethodGet, "/users/0xcreator/earnings-concentration?threshold="+threshold, "", ""); status != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+	"gorm.io/gorm"
+)
+
+// GetFeeEstimate returns the platform fee, after the staking discount, and
+// the estimated gas for distributing an undistributed payment, with the net
+// amount left for its beneficiaries. Gas is omitted, with gas_error set,
+// when the blockchain is not configured or cannot be reached.
+// GET /api/v1/royalties/:paymentId/fee-estimate
+func (h *RoyaltyHandler) GetFeeEstimate(c *gin.Context) {
+	paymentID, err := strconv.ParseUint(c.Param("paymentId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment ID"})
+		return
+	}
+
+	var payment models.RoyaltyPayment
+	if err := h.db.WithContext(c.Request.Context()).First(&payment, paymentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": services.ErrPaymentNotFound.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if payment.IsDistributed {
+		c.JSON(http.StatusConflict, gin.H{"error": services.ErrPaymentAlreadyDistributed.Error()})
+		return
+	}
+	amount, ok := new(big.Int).SetString(payment.Amount, 10)
+	if !ok || amount.Sign() <= 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": services.ErrInvalidPaymentAmount.Error()})
+		return
+	}
+
+	fees := services.ComputeDistributionFees(amount, h.fees.PlatformFeeBasisPoints, h.fees.StakingDiscountBasisPoints)
+	fees.Gas, err = h.feeEstimator.Estimate(c.Request.Context(), "distribute_royalty", common.Address{})
+	if err != nil {
+		fees.GasError = err.Error()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"payment_id": payment.ID,
+		"token_id":   payment.TokenID,
+		"fees":       fees,
+	})
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+)
+
+// GetGenreBreakdown returns how the creator's active tracks spread across
+// genres, with each genre's share of tracks and of plays. Tracks without a
+// genre are grouped as "Unknown".
+// GET /api/v1/users/:address/genre-breakdown
+func (h *UserHandler) GetGenreBreakdown(c *gin.Context) {
+	address := c.Param("address")
+
+	type GenreShare struct {
+		Genre      string  `json:"genre"`
+		TrackCount int64   `json:"track_count"`
+		PlayCount  uint64  `json:"play_count"`
+		TrackShare float64 `json:"track_share"` // percent of the creator's tracks
+		PlayShare  float64 `json:"play_share"`  // percent of the creator's plays
+	}
+
+	var genres []GenreShare
+	if err := h.db.Model(&models.MusicMetadata{}).
+		Select(`COALESCE(NULLIF(TRIM(genre), ''), 'Unknown') as genre,
+			COUNT(*) as track_count,
+			COALESCE(SUM(play_count), 0) as play_count`).
+		Where("creator_address = ? AND is_active = ?", address, true).
+		Group("COALESCE(NULLIF(TRIM(genre), ''), 'Unknown')").
+		Order("track_count DESC, play_count DESC, genre ASC").
+		Scan(&genres).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var totalTracks int64
+	var totalPlays uint64
+	for _, genre := range genres {
+		totalTracks += genre.TrackCount
+		totalPlays += genre.PlayCount
+	}
+	for i := range genres {
+		if totalTracks > 0 {
+			genres[i].TrackShare = math.Round(float64(genres[i].TrackCount)/float64(totalTracks)*10000) / 100
+		}
+		if totalPlays > 0 {
+			genres[i].PlayShare = math.Round(float64(genres[i].PlayCount)/float64(totalPlays)*10000) / 100
+		}
+	}
+	if genres == nil {
+		genres = []GenreShare{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":      address,
+		"total_tracks": totalTracks,
+		"total_plays":  totalPlays,
+		"genres":       genres,
+	})
+}
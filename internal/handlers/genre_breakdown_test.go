@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestGetGenreBreakdown(t *testing.T) {
+	type genre struct {
+		name   string
+		tracks int64
+		plays  uint64
+	}
+	type share struct {
+		Genre      string  `json:"genre"`
+		TrackCount int64   `json:"track_count"`
+		PlayCount  uint64  `json:"play_count"`
+		TrackShare float64 `json:"track_share"`
+		PlayShare  float64 `json:"play_share"`
+	}
+
+	tests := []struct {
+		name       string
+		genres     []genre
+		wantTracks int64
+		wantPlays  uint64
+		want       []share
+	}{
+		{
+			name:       "several genres with untagged tracks as Unknown",
+			genres:     []genre{{"Pop", 2, 600}, {"Unknown", 1, 100}, {"Jazz", 1, 300}},
+			wantTracks: 4,
+			wantPlays:  1000,
+			want: []share{
+				{Genre: "Pop", TrackCount: 2, PlayCount: 600, TrackShare: 50, PlayShare: 60},
+				{Genre: "Unknown", TrackCount: 1, PlayCount: 100, TrackShare: 25, PlayShare: 10},
+				{Genre: "Jazz", TrackCount: 1, PlayCount: 300, TrackShare: 25, PlayShare: 30},
+			},
+		},
+		{
+			name:       "shares are rounded to two decimals",
+			genres:     []genre{{"Rock", 2, 1}, {"Folk", 1, 2}},
+			wantTracks: 3,
+			wantPlays:  3,
+			want: []share{
+				{Genre: "Rock", TrackCount: 2, PlayCount: 1, TrackShare: 66.67, PlayShare: 33.33},
+				{Genre: "Folk", TrackCount: 1, PlayCount: 2, TrackShare: 33.33, PlayShare: 66.67},
+			},
+		},
+		{
+			name:       "no plays yet",
+			genres:     []genre{{"Pop", 1, 0}},
+			wantTracks: 1,
+			want:       []share{{Genre: "Pop", TrackCount: 1, TrackShare: 100}},
+		},
+		{
+			name: "no active tracks",
+			want: []share{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows([]string{"genre", "track_count", "play_count"})
+			for _, g := range tt.genres {
+				rows.AddRow(g.name, g.tracks, g.plays)
+			}
+			// Empty and blank genres are folded into "Unknown" by the grouping itself
+			mock.ExpectQuery("SELECT COALESCE\\(NULLIF\\(TRIM\\(genre\\), ''\\), 'Unknown'\\) as genre,.* FROM `music_metadata` "+
+				"WHERE \\(creator_address = \\? AND is_active = \\?\\) AND `music_metadata`.`deleted_at` IS NULL "+
+				"GROUP BY COALESCE\\(NULLIF\\(TRIM\\(genre\\), ''\\), 'Unknown'\\)").
+				WithArgs("0xcreator", true).
+				WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/users/:address/genre-breakdown", NewUserHandler(db).GetGenreBreakdown)
+
+			rec := record(router, http.MethodGet, "/users/0xcreator/genre-breakdown", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				TotalTracks int64   `json:"total_tracks"`
+				TotalPlays  uint64  `json:"total_plays"`
+				Genres      []share `json:"genres"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.TotalTracks != tt.wantTracks || body.TotalPlays != tt.wantPlays {
+				t.Errorf("totals = %d tracks, %d plays, want %d, %d", body.TotalTracks, body.TotalPlays, tt.wantTracks, tt.wantPlays)
+			}
+			if body.Genres == nil || len(body.Genres) != len(tt.want) {
+				t.Fatalf("genres = %+v, want %+v", body.Genres, tt.want)
+			}
+			for i, want := range tt.want {
+				if body.Genres[i] != want {
+					t.Errorf("genre %d = %+v, want %+v", i, body.Genres[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+)
+
+// privateActivityTypes reveal a user's finances and are never shown outside
+// their own feed
+var privateActivityTypes = []string{"royalty_received", "withdraw"}
+
+// globalActivity is an activity in the platform-wide feed, with the display
+// name of the user it belongs to
+type globalActivity struct {
+	models.Activity
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+// GetGlobalActivities returns recent activities across all users, newest
+// first, optionally narrowed to a comma-separated list of types. Private
+// types are always excluded, and exclude= drops further types. Pass the
+// returned next_cursor to fetch the following page.
+// GET /api/v1/activities/global?types=music_registered,pool_invested&limit=20&cursor=...
+func (h *DashboardHandler) GetGlobalActivities(c *gin.Context) {
+	limit, err := parseNonNegativeQuery(c, "limit", defaultPageSize)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	var cursor *timelineCursor
+	if value := c.Query("cursor"); value != "" {
+		if cursor, err = decodeTimelineCursor(value); err != nil || cursor.Source != timelineSourceActivity {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+			return
+		}
+	}
+
+	excluded := append([]string{}, privateActivityTypes...)
+	excluded = append(excluded, splitQueryList(c.Query("exclude"))...)
+
+	query := h.db.WithContext(c.Request.Context()).Where("type NOT IN ?", excluded)
+	if types := splitQueryList(c.Query("types")); len(types) > 0 {
+		query = query.Where("type IN ?", types)
+	}
+
+	// One extra row tells us whether another page exists
+	var activities []models.Activity
+	if err := cursor.after(query, timelineSourceActivity).
+		Order("created_at DESC, id DESC").
+		Limit(limit + 1).
+		Find(&activities).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load activities"})
+		return
+	}
+
+	hasMore := len(activities) > limit
+	if hasMore {
+		activities = activities[:limit]
+	}
+
+	addresses := make([]string, 0, len(activities))
+	for _, activity := range activities {
+		addresses = append(addresses, activity.UserAddress)
+	}
+	var users []models.User
+	if len(addresses) > 0 {
+		if err := h.db.WithContext(c.Request.Context()).
+			Select("wallet_address", "display_name").
+			Where("wallet_address IN ?", addresses).
+			Find(&users).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load activities"})
+			return
+		}
+	}
+	displayNames := make(map[string]string, len(users))
+	for _, user := range users {
+		displayNames[strings.ToLower(user.WalletAddress)] = user.DisplayName
+	}
+
+	data := make([]globalActivity, len(activities))
+	for i, activity := range activities {
+		data[i] = globalActivity{Activity: activity, DisplayName: displayNames[strings.ToLower(activity.UserAddress)]}
+	}
+
+	var nextCursor string
+	if hasMore && len(activities) > 0 {
+		last := activities[len(activities)-1]
+		nextCursor = timelineCursor{Timestamp: last.CreatedAt, Source: timelineSourceActivity, ID: last.ID}.encode()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":        data,
+		"limit":       limit,
+		"has_more":    hasMore,
+		"next_cursor": nextCursor,
+		"excluded":    excluded,
+	})
+}
+
+// splitQueryList splits a comma-separated query value, dropping blanks
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
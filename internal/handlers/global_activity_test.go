@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestGetGlobalActivities(t *testing.T) {
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cursor := timelineCursor{Timestamp: at, Source: timelineSourceActivity, ID: 9}
+
+	type activity struct {
+		id    uint
+		user  string
+		typ   string
+		title string
+	}
+	type entry struct {
+		ID          uint   `json:"id"`
+		UserAddress string `json:"user_address"`
+		DisplayName string `json:"display_name"`
+	}
+
+	// Three users' activities, newest first; only 0xalice has a display name
+	feed := []activity{
+		{8, "0xAlice", "music_registered", "Registered a track"},
+		{7, "0xbob", "pool_invested", "Invested"},
+		{6, "0xcarol", "music_registered", "Registered a track"},
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		filter   string
+		args     []driver.Value
+		limit    int
+		rows     []activity
+		want     []entry
+		wantMore bool
+	}{
+		{
+			name:   "every user's public activity",
+			filter: "WHERE type NOT IN \\(\\?,\\?\\)",
+			args:   []driver.Value{"royalty_received", "withdraw"},
+			limit:  21,
+			rows:   feed,
+			want:   []entry{{8, "0xAlice", "Alice"}, {7, "0xbob", ""}, {6, "0xcarol", ""}},
+		},
+		{
+			name:   "narrowed to types",
+			query:  "?types=music_registered,%20pool_invested",
+			filter: "WHERE type NOT IN \\(\\?,\\?\\) AND type IN \\(\\?,\\?\\)",
+			args:   []driver.Value{"royalty_received", "withdraw", "music_registered", "pool_invested"},
+			limit:  21,
+			rows:   feed,
+			want:   []entry{{8, "0xAlice", "Alice"}, {7, "0xbob", ""}, {6, "0xcarol", ""}},
+		},
+		{
+			name:   "further types excluded",
+			query:  "?exclude=pool_invested",
+			filter: "WHERE type NOT IN \\(\\?,\\?,\\?\\)",
+			args:   []driver.Value{"royalty_received", "withdraw", "pool_invested"},
+			limit:  21,
+			rows:   []activity{feed[0], feed[2]},
+			want:   []entry{{8, "0xAlice", "Alice"}, {6, "0xcarol", ""}},
+		},
+		{
+			name:     "first page of several",
+			query:    "?limit=2",
+			filter:   "WHERE type NOT IN \\(\\?,\\?\\)",
+			args:     []driver.Value{"royalty_received", "withdraw"},
+			limit:    3,
+			rows:     feed,
+			want:     []entry{{8, "0xAlice", "Alice"}, {7, "0xbob", ""}},
+			wantMore: true,
+		},
+		{
+			name:   "page after a cursor",
+			query:  "?limit=2&cursor=" + cursor.encode(),
+			filter: "WHERE type NOT IN \\(\\?,\\?\\) AND \\(\\(created_at < \\? OR \\(created_at = \\? AND id < \\?\\)\\)\\)",
+			args:   []driver.Value{"royalty_received", "withdraw", at, at, 9},
+			limit:  3,
+			rows:   feed[1:],
+			want:   []entry{{7, "0xbob", ""}, {6, "0xcarol", ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows([]string{"id", "user_address", "type", "title", "created_at"})
+			var addresses []driver.Value
+			for i, a := range tt.rows {
+				rows.AddRow(a.id, a.user, a.typ, a.title, at.Add(-time.Duration(i)*time.Minute))
+				if i < len(tt.want) {
+					addresses = append(addresses, a.user)
+				}
+			}
+			mock.ExpectQuery("SELECT \\* FROM `activities` " + tt.filter + " ORDER BY created_at DESC, id DESC LIMIT " + strconv.Itoa(tt.limit)).
+				WithArgs(tt.args...).
+				WillReturnRows(rows)
+			mock.ExpectQuery("SELECT `wallet_address`,`display_name` FROM `users` WHERE wallet_address IN").
+				WithArgs(addresses...).
+				WillReturnRows(sqlmock.NewRows([]string{"wallet_address", "display_name"}).AddRow("0xalice", "Alice"))
+
+			router := gin.New()
+			router.GET("/activities/global", NewDashboardHandler(db).GetGlobalActivities)
+
+			rec := record(router, http.MethodGet, "/activities/global"+tt.query, "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Data       []entry `json:"data"`
+				HasMore    bool    `json:"has_more"`
+				NextCursor string  `json:"next_cursor"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(body.Data, tt.want) {
+				t.Errorf("data = %+v, want %+v", body.Data, tt.want)
+			}
+			if body.HasMore != tt.wantMore || (body.NextCursor != "") != tt.wantMore {
+				t.Errorf("has_more %v, next_cursor %q, want more = %v", body.HasMore, body.NextCursor, tt.wantMore)
+			}
+		})
+	}
+}
+
+func TestGetGlobalActivitiesRejects(t *testing.T) {
+	notification := timelineCursor{Timestamp: time.Now(), Source: timelineSourceNotification, ID: 1}
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "negative limit", query: "?limit=-1"},
+		{name: "malformed cursor", query: "?cursor=not-a-cursor"},
+		{name: "cursor from another feed", query: "?cursor=" + notification.encode()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := dbtest.New(t)
+			router := gin.New()
+			router.GET("/activities/global", NewDashboardHandler(db).GetGlobalActivities)
+
+			if status := serve(router, http.MethodGet, "/activities/global"+tt.query, "", ""); status != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+			}
+		})
+	}
+}
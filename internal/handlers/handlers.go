@@ -1,23 +1,43 @@
 package handlers
 
 import (
+	"fmt"
+	"math/big"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/config"
 	"github.com/tunecent/backend/internal/database"
 	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
 )
 
 // CampaignHandler handles crowdfunding campaign endpoints
 type CampaignHandler struct {
 	db *database.DB
+
+	onFundingChanged []func()
 }
 
 func NewCampaignHandler(db *database.DB) *CampaignHandler {
 	return &CampaignHandler{db: db}
 }
 
+// OnFundingChanged adds a callback run after a campaign is created or its
+// contributions change, e.g. to invalidate caches that sum funding
+func (h *CampaignHandler) OnFundingChanged(fn func()) {
+	h.onFundingChanged = append(h.onFundingChanged, fn)
+}
+
+func (h *CampaignHandler) fundingChanged() {
+	for _, fn := range h.onFundingChanged {
+		fn()
+	}
+}
+
 func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
 	var req struct {
 		TokenID           uint64 `json:"token_id" binding:"required"`
@@ -33,6 +53,17 @@ func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
 		return
 	}
 
+	goal, ok := new(big.Int).SetString(req.GoalAmount, 10)
+	if !ok || goal.Sign() <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "goal_amount must be a positive integer amount in wei"})
+		return
+	}
+
+	if err := services.ValidateCampaignTerms(int(req.RoyaltyPercentage), req.DurationDays, req.LockupDays); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	// Mock campaign creation - in production, call smart contract
 	campaign := &models.Campaign{
 		CampaignID:        uint64(1), // Mock
@@ -41,6 +72,7 @@ func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
 		GoalAmount:        req.GoalAmount,
 		RaisedAmount:      "0",
 		RoyaltyPercentage: req.RoyaltyPercentage,
+		Deadline:          time.Now().UTC().AddDate(0, 0, req.DurationDays),
 		LockupPeriod:      req.LockupDays,
 		Status:            "active",
 		TxHash:            "0xmock",
@@ -50,37 +82,148 @@ func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create campaign"})
 		return
 	}
+	h.fundingChanged()
 
 	c.JSON(http.StatusCreated, campaign)
 }
 
+// campaignMusic is the track summary embedded in a campaign response
+type campaignMusic struct {
+	TokenID       uint64 `json:"token_id"`
+	Title         string `json:"title"`
+	Artist        string `json:"artist"`
+	CoverImageURL string `json:"cover_image_url,omitempty"`
+}
+
+// campaignCreator is the creator summary embedded in a campaign response
+type campaignCreator struct {
+	Address     string `json:"address"`
+	DisplayName string `json:"display_name,omitempty"`
+	IsVerified  bool   `json:"is_verified"`
+	Tier        string `json:"tier,omitempty"`
+}
+
+// campaignDetail is a campaign with its track, creator and live funding figures.
+// ContributorCount shadows the stored counter with a count of distinct
+// contributors who still have an amount in, so fully refunded ones drop out.
+type campaignDetail struct {
+	models.Campaign
+	FundingPercentage float64          `json:"funding_percentage"`
+	ContributorCount  int64            `json:"contributor_count"`
+	Music             *campaignMusic   `json:"music"`
+	Creator           *campaignCreator `json:"creator"`
+}
+
+// GetCampaign returns a campaign enriched with its track, creator, funding
+// percentage and contributor count, loaded in a single query
+// GET /api/v1/campaigns/:campaignId
 func (h *CampaignHandler) GetCampaign(c *gin.Context) {
-	campaignID, _ := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	campaignID, err := strconv.ParseUint(c.Param("campaignId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid campaign ID"})
+		return
+	}
 
-	var campaign models.Campaign
-	if err := h.db.Where("campaign_id = ?", campaignID).First(&campaign).Error; err != nil {
+	var row struct {
+		models.Campaign
+		HasMusic           bool
+		MusicTitle         string
+		MusicArtist        string
+		MusicCoverImageURL string
+		HasCreator         bool
+		CreatorDisplayName string
+		CreatorIsVerified  bool
+		CreatorTier        string
+		Contributors       int64
+	}
+	result := h.db.Model(&models.Campaign{}).
+		Select(`campaigns.*,
+			m.id IS NOT NULL as has_music,
+			COALESCE(m.title, '') as music_title,
+			COALESCE(m.artist, '') as music_artist,
+			COALESCE(m.cover_image_url, '') as music_cover_image_url,
+			u.id IS NOT NULL as has_creator,
+			COALESCE(NULLIF(u.display_name, ''), u.username, '') as creator_display_name,
+			COALESCE(u.is_verified, false) as creator_is_verified,
+			COALESCE(u.tier, '') as creator_tier,
+			(SELECT COUNT(DISTINCT ct.contributor_address) FROM contributions ct
+				WHERE ct.campaign_id = campaigns.campaign_id AND ct.amount <> '0') as contributors`).
+		Joins("LEFT JOIN music_metadata m ON m.token_id = campaigns.token_id AND m.deleted_at IS NULL").
+		Joins("LEFT JOIN users u ON u.wallet_address = campaigns.creator_address AND u.deleted_at IS NULL").
+		Where("campaigns.campaign_id = ?", campaignID).
+		Limit(1).
+		Scan(&row)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": result.Error.Error()})
+		return
+	}
+	if result.RowsAffected == 0 {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
 		return
 	}
 
-	c.JSON(http.StatusOK, campaign)
+	detail := campaignDetail{
+		Campaign:          row.Campaign,
+		FundingPercentage: services.FundingPercentage(row.RaisedAmount, row.GoalAmount),
+		ContributorCount:  row.Contributors,
+	}
+	if row.HasMusic {
+		detail.Music = &campaignMusic{
+			TokenID:       row.TokenID,
+			Title:         row.MusicTitle,
+			Artist:        row.MusicArtist,
+			CoverImageURL: row.MusicCoverImageURL,
+		}
+	}
+	if row.HasCreator {
+		detail.Creator = &campaignCreator{
+			Address:     row.CreatorAddress,
+			DisplayName: row.CreatorDisplayName,
+			IsVerified:  row.CreatorIsVerified,
+			Tier:        row.CreatorTier,
+		}
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// containsPattern builds a LIKE pattern matching value anywhere, with LIKE
+// wildcards in value matched literally
+func containsPattern(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+	return "%" + strings.ToLower(escaped) + "%"
 }
 
+// ListCampaigns lists campaigns, optionally filtered by status, creator
+// address, or a case-insensitive fragment of the creator's display name or username
+// GET /api/v1/campaigns?status=active&creator_address=0x...&creator_name=...
 func (h *CampaignHandler) ListCampaigns(c *gin.Context) {
 	status := c.Query("status")
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	query := h.db.Model(&models.Campaign{})
 	if status != "" {
-		query = query.Where("status = ?", status)
+		query = query.Where("campaigns.status = ?", status)
+	}
+	if creatorAddress := c.Query("creator_address"); creatorAddress != "" {
+		query = query.Where("campaigns.creator_address = ?", creatorAddress)
+	}
+	if creatorName := strings.TrimSpace(c.Query("creator_name")); creatorName != "" {
+		pattern := containsPattern(creatorName)
+		query = query.
+			Joins("JOIN users ON users.wallet_address = campaigns.creator_address AND users.deleted_at IS NULL").
+			Where("(LOWER(users.display_name) LIKE ? OR LOWER(users.username) LIKE ?)", pattern, pattern)
 	}
 
 	var campaigns []models.Campaign
 	var total int64
 
 	query.Count(&total)
-	query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&campaigns)
+	query.Select("campaigns.*").Order("campaigns.created_at DESC").Limit(limit).Offset(offset).Find(&campaigns)
 
 	c.JSON(http.StatusOK, gin.H{
 		"data":   campaigns,
@@ -103,29 +246,44 @@ func (h *CampaignHandler) Contribute(c *gin.Context) {
 		return
 	}
 
+	var campaign models.Campaign
+	if err := h.db.Select("status").Where("campaign_id = ?", campaignID).First(&campaign).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
+		return
+	}
+	if campaign.Status != "active" {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("campaign is %s and no longer accepts contributions", campaign.Status)})
+		return
+	}
+
 	contribution := &models.Contribution{
 		CampaignID:         campaignID,
 		ContributorAddress: req.ContributorAddress,
 		Amount:             req.Amount,
 		SharePercentage:    0, // Calculate based on total
 		TxHash:             "0xmock",
+		Source:             services.ContributionSourceDirect,
 	}
 
 	if err := h.db.Create(contribution).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record contribution"})
 		return
 	}
+	h.fundingChanged()
 
 	c.JSON(http.StatusCreated, contribution)
 }
 
 // RoyaltyHandler handles royalty endpoints
 type RoyaltyHandler struct {
-	db *database.DB
+	db             *database.DB
+	royaltyService *services.RoyaltyService
+	feeEstimator   *services.FeeEstimator
+	fees           config.FeeConfig
 }
 
-func NewRoyaltyHandler(db *database.DB) *RoyaltyHandler {
-	return &RoyaltyHandler{db: db}
+func NewRoyaltyHandler(db *database.DB, royaltyService *services.RoyaltyService, feeEstimator *services.FeeEstimator, fees config.FeeConfig) *RoyaltyHandler {
+	return &RoyaltyHandler{db: db, royaltyService: royaltyService, feeEstimator: feeEstimator, fees: fees}
 }
 
 func (h *RoyaltyHandler) GetRoyalties(c *gin.Context) {
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestCreateCampaignRejectsInvalidGoal(t *testing.T) {
+	tests := []struct {
+		name string
+		goal string
+	}{
+		{name: "zero goal", goal: "0"},
+		{name: "negative goal", goal: "-5"},
+		{name: "non-numeric goal", goal: "1e18"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := dbtest.New(t)
+			router := gin.New()
+			router.POST("/campaigns", NewCampaignHandler(db).CreateCampaign)
+
+			body := `{"token_id":1,"creator_address":"0xcreator","goal_amount":"` + tt.goal + `","royalty_percentage":3000,"duration_days":30,"lockup_days":90}`
+			if status := serve(router, http.MethodPost, "/campaigns", "", body); status != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestGetCampaignFundingPercentage(t *testing.T) {
+	tests := []struct {
+		name   string
+		raised string
+		goal   string
+		want   float64
+	}{
+		{name: "partly funded", raised: "250", goal: "1000", want: 25},
+		{name: "zero goal reports 0% instead of failing", raised: "250", goal: "0", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("FROM `campaigns` LEFT JOIN music_metadata m .* WHERE campaigns.campaign_id = \\?").
+				WithArgs(9).
+				WillReturnRows(sqlmock.NewRows([]string{"campaign_id", "raised_amount", "goal_amount", "has_music", "has_creator", "contributors"}).
+					AddRow(9, tt.raised, tt.goal, false, false, 2))
+
+			router := gin.New()
+			router.GET("/campaigns/:campaignId", NewCampaignHandler(db).GetCampaign)
+
+			rec := record(router, http.MethodGet, "/campaigns/9", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			var body struct {
+				FundingPercentage float64 `json:"funding_percentage"`
+				ContributorCount  int64   `json:"contributor_count"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.FundingPercentage != tt.want {
+				t.Errorf("funding_percentage = %v, want %v", body.FundingPercentage, tt.want)
+			}
+			if body.ContributorCount != 2 {
+				t.Errorf("contributor_count = %d, want 2", body.ContributorCount)
+			}
+		})
+	}
+}
+
+func TestGetCampaignEnriched(t *testing.T) {
+	columns := []string{"campaign_id", "token_id", "creator_address", "raised_amount", "goal_amount",
+		"has_music", "music_title", "music_artist", "music_cover_image_url",
+		"has_creator", "creator_display_name", "creator_is_verified", "creator_tier", "contributors"}
+
+	tests := []struct {
+		name        string
+		row         []driver.Value
+		wantMusic   *campaignMusic
+		wantCreator *campaignCreator
+	}{
+		{
+			name: "track and creator joined",
+			row: []driver.Value{9, 7, "0xcreator", "600", "1000",
+				true, "Night Drive", "Ada", "ipfs://cover",
+				true, "Ada Lovelace", true, "gold", 3},
+			wantMusic:   &campaignMusic{TokenID: 7, Title: "Night Drive", Artist: "Ada", CoverImageURL: "ipfs://cover"},
+			wantCreator: &campaignCreator{Address: "0xcreator", DisplayName: "Ada Lovelace", IsVerified: true, Tier: "gold"},
+		},
+		{
+			name: "no track or profile",
+			row: []driver.Value{9, 7, "0xcreator", "600", "1000",
+				false, "", "", "",
+				false, "", false, "", 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			// Everything comes back from the one query
+			mock.ExpectQuery("SELECT campaigns.\\*,.* FROM `campaigns` LEFT JOIN music_metadata m .* LEFT JOIN users u .* WHERE campaigns.campaign_id = \\?").
+				WithArgs(9).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(tt.row...))
+
+			router := gin.New()
+			router.GET("/campaigns/:campaignId", NewCampaignHandler(db).GetCampaign)
+
+			rec := record(router, http.MethodGet, "/campaigns/9", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			var body struct {
+				CampaignID        uint64           `json:"campaign_id"`
+				FundingPercentage float64          `json:"funding_percentage"`
+				ContributorCount  int64            `json:"contributor_count"`
+				Music             *campaignMusic   `json:"music"`
+				Creator           *campaignCreator `json:"creator"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.CampaignID != 9 || body.FundingPercentage != 60 || body.ContributorCount != 3 {
+				t.Errorf("campaign %d at %v%% with %d contributors, want 9 at 60%% with 3", body.CampaignID, body.FundingPercentage, body.ContributorCount)
+			}
+			if (body.Music == nil) != (tt.wantMusic == nil) || (body.Music != nil && *body.Music != *tt.wantMusic) {
+				t.Errorf("music = %+v, want %+v", body.Music, tt.wantMusic)
+			}
+			if (body.Creator == nil) != (tt.wantCreator == nil) || (body.Creator != nil && *body.Creator != *tt.wantCreator) {
+				t.Errorf("creator = %+v, want %+v", body.Creator, tt.wantCreator)
+			}
+		})
+	}
+}
+
+func TestGetCampaignNotFound(t *testing.T) {
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("FROM `campaigns` LEFT JOIN music_metadata m .* WHERE campaigns.campaign_id = \\?").
+		WithArgs(404).
+		WillReturnRows(sqlmock.NewRows([]string{"campaign_id"}))
+
+	router := gin.New()
+	router.GET("/campaigns/:campaignId", NewCampaignHandler(db).GetCampaign)
+
+	if status := serve(router, http.MethodGet, "/campaigns/404", "", ""); status != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestCreateCampaignRejectsOutOfRangeTerms(t *testing.T) {
+	tests := []struct {
+		name    string
+		royalty int
+		days    int
+		lockup  int
+	}{
+		{name: "royalty above 100%", royalty: 10001, days: 30, lockup: 90},
+		{name: "royalty beyond uint16", royalty: 70000, days: 30, lockup: 90},
+		{name: "negative duration", royalty: 3000, days: -30, lockup: 90},
+		{name: "negative lockup", royalty: 3000, days: 30, lockup: -90},
+		{name: "duration over the maximum", royalty: 3000, days: 366, lockup: 90},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := dbtest.New(t)
+			router := gin.New()
+			router.POST("/campaigns", NewCampaignHandler(db).CreateCampaign)
+
+			body := fmt.Sprintf(`{"token_id":1,"creator_address":"0xcreator","goal_amount":"1000","royalty_percentage":%d,"duration_days":%d,"lockup_days":%d}`, tt.royalty, tt.days, tt.lockup)
+			if status := serve(router, http.MethodPost, "/campaigns", "", body); status != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestContainsPattern(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "Ada", want: "%ada%"},
+		{value: "100%", want: `%100\%%`},
+		{value: "dj_ada", want: `%dj\_ada%`},
+		{value: `back\slash`, want: `%back\\slash%`},
+	}
+
+	for _, tt := range tests {
+		if got := containsPattern(tt.value); got != tt.want {
+			t.Errorf("containsPattern(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestListCampaignsByCreatorName(t *testing.T) {
+	const nameFilter = "JOIN users ON users.wallet_address = campaigns.creator_address AND users.deleted_at IS NULL " +
+		"WHERE \\(\\(LOWER\\(users.display_name\\) LIKE \\? OR LOWER\\(users.username\\) LIKE \\?\\)\\) AND `campaigns`.`deleted_at` IS NULL"
+
+	tests := []struct {
+		name  string
+		query string
+		args  []driver.Value
+	}{
+		{name: "partial name", query: "?creator_name=ada", args: []driver.Value{"%ada%", "%ada%"}},
+		{name: "name is matched case-insensitively", query: "?creator_name=%20ADA%20", args: []driver.Value{"%ada%", "%ada%"}},
+		{name: "wildcards in the name are literal", query: "?creator_name=dj_", args: []driver.Value{`%dj\_%`, `%dj\_%`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT count\\(\\*\\) FROM `campaigns` " + nameFilter).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			mock.ExpectQuery("SELECT campaigns.\\* FROM `campaigns` " + nameFilter + " ORDER BY campaigns.created_at DESC").
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "creator_address"}).
+					AddRow(1, 11, "0xada").
+					AddRow(2, 12, "0xada"))
+
+			router := gin.New()
+			router.GET("/campaigns", NewCampaignHandler(db).ListCampaigns)
+
+			rec := record(router, http.MethodGet, "/campaigns"+tt.query, "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			var body struct {
+				Data  []json.RawMessage `json:"data"`
+				Total int64             `json:"total"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if len(body.Data) != 2 || body.Total != 2 {
+				t.Errorf("got %d campaigns of %d, want 2 of 2", len(body.Data), body.Total)
+			}
+		})
+	}
+}
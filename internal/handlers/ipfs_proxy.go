@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/services"
+	"github.com/tunecent/backend/pkg/ipfs"
+)
+
+// GetIPFSContent handles GET /api/v1/ipfs/:cid
+// @Summary Fetch IPFS content through the API
+// @Description Proxies a track's metadata, audio or cover, or a user's avatar, from the configured IPFS gateways for clients that cannot reach them. Only CIDs referenced by known records are served, up to the audio upload limit: audio or non-SVG images, and JSON for metadata CIDs.
+// @Tags Music
+// @Produce octet-stream
+// @Param cid path string true "IPFS CID (v0 or base32 v1)"
+// @Success 200 {file} binary "Content, with the gateway's Content-Type"
+// @Failure 400 {object} map[string]interface{} "Invalid CID"
+// @Failure 404 {object} map[string]interface{} "CID not referenced by any known record"
+// @Failure 413 {object} map[string]interface{} "Content too large to proxy"
+// @Failure 415 {object} map[string]interface{} "Content is not audio, a non-SVG image or metadata JSON"
+// @Failure 502 {object} map[string]interface{} "No gateway could serve the content"
+// @Failure 503 {object} map[string]interface{} "No IPFS gateway configured"
+// @Failure 504 {object} map[string]interface{} "Gateways timed out"
+// @Router /ipfs/{cid} [get]
+func (h *MusicHandler) GetIPFSContent(c *gin.Context) {
+	cid := c.Param("cid")
+	etag := `"` + cid + `"`
+
+	ref, err := h.musicService.CheckReferencedCID(c.Request.Context(), cid)
+	if err != nil {
+		respondIPFSProxyError(c, err, h.maxAudioBytes)
+		return
+	}
+
+	// Content behind a CID never changes, so a client holding the ETag can be
+	// answered without going to the gateways
+	if c.GetHeader("If-None-Match") == etag {
+		setIPFSContentHeaders(c, etag)
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	content, err := h.musicService.FetchProxiableContent(c.Request.Context(), cid, ref, h.maxAudioBytes)
+	if err != nil {
+		respondIPFSProxyError(c, err, h.maxAudioBytes)
+		return
+	}
+
+	setIPFSContentHeaders(c, etag)
+	c.Data(http.StatusOK, content.ContentType, content.Data)
+}
+
+func respondIPFSProxyError(c *gin.Context, err error, maxBytes int64) {
+	switch {
+	case errors.Is(err, services.ErrInvalidCID):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrUnknownCID):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ipfs.ErrTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error(), "max_bytes": maxBytes})
+	case errors.Is(err, services.ErrUnproxiableContent):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": services.ErrUnproxiableContent.Error()})
+	case errors.Is(err, ipfs.ErrNoGateways):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case errors.Is(err, context.DeadlineExceeded):
+		c.JSON(http.StatusGatewayTimeout, gin.H{"error": "IPFS gateways timed out"})
+	default:
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to fetch content from IPFS"})
+	}
+}
+
+// setIPFSContentHeaders marks proxied content as cacheable indefinitely and
+// stops browsers from sniffing it or running it as an active document
+func setIPFSContentHeaders(c *gin.Context, etag string) {
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("ETag", etag)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Security-Policy", "sandbox")
+}
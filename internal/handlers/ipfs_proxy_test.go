@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/config"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/internal/services"
+	"github.com/tunecent/backend/pkg/ipfs"
+)
+
+func TestGetIPFSContent(t *testing.T) {
+	const (
+		metadataCID = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+		audioCID    = "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"
+	)
+
+	// The stub gateway serves metadata JSON, an MP3 and, for any other CID, a
+	// page of HTML
+	var fetched []string
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched = append(fetched, r.URL.Path)
+		switch r.URL.Path {
+		case "/ipfs/" + metadataCID:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"title":"Song"}`))
+		case "/ipfs/" + audioCID:
+			w.Header().Set("Content-Type", "audio/mpeg")
+			w.Write([]byte("ID3\x04\x00\x00\x00\x00\x00\x00"))
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<script></script>"))
+		}
+	}))
+	defer gateway.Close()
+	gatewayURL := gateway.URL + "/ipfs/"
+
+	// references answers the CID lookups: the count of tracks whose metadata
+	// is the CID, then of tracks and users whose media URLs point at it
+	references := func(metadata, tracks, users int) func(mock sqlmock.Sqlmock, cid string) {
+		return func(mock sqlmock.Sqlmock, cid string) {
+			mock.ExpectQuery("SELECT count\\(\\*\\) FROM `music_metadata` WHERE ipfs_cid = \\?").
+				WithArgs(cid).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(metadata))
+			if metadata > 0 {
+				return
+			}
+			urls := []interface{}{"ipfs://" + cid, gatewayURL + cid}
+			mock.ExpectQuery("SELECT count\\(\\*\\) FROM `music_metadata` WHERE \\(audio_file_url IN \\(\\?,\\?\\) OR cover_image_url IN \\(\\?,\\?\\)\\)").
+				WithArgs(urls[0], urls[1], urls[0], urls[1]).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tracks))
+			if tracks > 0 {
+				return
+			}
+			mock.ExpectQuery("SELECT count\\(\\*\\) FROM `users` WHERE avatar_url IN \\(\\?,\\?\\)").
+				WithArgs(urls[0], urls[1]).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(users))
+		}
+	}
+
+	tests := []struct {
+		name            string
+		cid             string
+		ifNoneMatch     string
+		expect          func(mock sqlmock.Sqlmock, cid string)
+		want            int
+		wantContentType string
+		wantFetched     bool
+	}{
+		{name: "track metadata", cid: metadataCID, expect: references(1, 0, 0), want: http.StatusOK, wantContentType: "application/json", wantFetched: true},
+		{name: "track audio", cid: audioCID, expect: references(0, 1, 0), want: http.StatusOK, wantContentType: "audio/mpeg", wantFetched: true},
+		{name: "unknown CID", cid: "QmPChd2hVbrJ6bfo3WBcTW4iZnpHm8TEzWkLHmLpXhF68A", expect: references(0, 0, 0), want: http.StatusNotFound},
+		{name: "avatar that turns out to be HTML", cid: "QmPChd2hVbrJ6bfo3WBcTW4iZnpHm8TEzWkLHmLpXhF68A", expect: references(0, 0, 1), want: http.StatusUnsupportedMediaType, wantFetched: true},
+		{name: "client already holds the content", cid: metadataCID, ifNoneMatch: `"` + metadataCID + `"`, expect: references(1, 0, 0), want: http.StatusNotModified},
+		{name: "invalid CID", cid: "not-a-cid", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetched = nil
+			db, mock := dbtest.New(t)
+			if tt.expect != nil {
+				tt.expect(mock, tt.cid)
+			}
+
+			ipfsService := ipfs.NewService(&config.Config{IPFS: config.IPFSConfig{Gateways: []string{gatewayURL}}})
+			h := NewMusicHandler(services.NewMusicService(db, ipfsService, nil, nil), config.UploadConfig{MaxAudioBytes: 1 << 20}, testSecret)
+			router := gin.New()
+			router.GET("/ipfs/:cid", h.GetIPFSContent)
+
+			req := httptest.NewRequest(http.MethodGet, "/ipfs/"+tt.cid, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if (len(fetched) > 0) != tt.wantFetched {
+				t.Errorf("gateway requests = %v, want fetched = %v", fetched, tt.wantFetched)
+			}
+			if tt.want != http.StatusOK && tt.want != http.StatusNotModified {
+				return
+			}
+			if got := rec.Header().Get("Content-Type"); tt.want == http.StatusOK && got != tt.wantContentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != "public, max-age=31536000, immutable" {
+				t.Errorf("Cache-Control = %q, want the immutable policy", got)
+			}
+			if got := rec.Header().Get("ETag"); got != `"`+tt.cid+`"` {
+				t.Errorf("ETag = %q, want the quoted CID", got)
+			}
+		})
+	}
+}
@@ -2,27 +2,40 @@ package handlers
 
 import (
 	"net/http"
-	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tunecent/backend/internal/database"
 	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
 )
 
 // LeaderboardHandler handles leaderboard-related endpoints
 type LeaderboardHandler struct {
-	db *database.DB
+	db                 *database.DB
+	leaderboardService *services.LeaderboardService
 }
 
-func NewLeaderboardHandler(db *database.DB) *LeaderboardHandler {
-	return &LeaderboardHandler{db: db}
+func NewLeaderboardHandler(db *database.DB, leaderboardService *services.LeaderboardService) *LeaderboardHandler {
+	return &LeaderboardHandler{db: db, leaderboardService: leaderboardService}
+}
+
+// movementPeriods maps the period query parameter to a lookback window
+var movementPeriods = map[string]time.Duration{
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 30 * 24 * time.Hour,
 }
 
 // GetTopArtists returns top artists leaderboard
 // GET /api/v1/leaderboard/top-artists?limit=10
 func (h *LeaderboardHandler) GetTopArtists(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "10")
-	limit, _ := strconv.Atoi(limitStr)
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	type LeaderboardEntry struct {
 		Rank            int     `json:"rank"`
@@ -59,11 +72,12 @@ func (h *LeaderboardHandler) GetTopArtists(c *gin.Context) {
 		Group("u.wallet_address").
 		Order("score DESC").
 		Limit(limit).
+		Offset(offset).
 		Scan(&leaderboard)
 
 	// Add rank numbers after fetching
 	for i := range leaderboard {
-		leaderboard[i].Rank = i + 1
+		leaderboard[i].Rank = offset + i + 1
 	}
 
 	// If no results, return empty array
@@ -147,40 +161,92 @@ func (h *LeaderboardHandler) GetUserRank(c *gin.Context) {
 	})
 }
 
-// GetLeaderboardStats returns overall leaderboard statistics
-// GET /api/v1/leaderboard/stats
-func (h *LeaderboardHandler) GetLeaderboardStats(c *gin.Context) {
-	var stats struct {
-		TotalCreators   int64
-		TotalWorks      int64
-		TotalEarnings   string
-		VerifiedCreators int64
+// GetRankMovement returns a creator's current rank, the rank one period ago
+// and the change between them, based on the periodic rank snapshots
+// GET /api/v1/leaderboard/:address/movement?period=week
+func (h *LeaderboardHandler) GetRankMovement(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address parameter is required"})
+		return
 	}
 
-	// Total creators
-	h.db.Model(&models.User{}).
-		Where("role IN (?)", []string{"creator", "both"}).
-		Count(&stats.TotalCreators)
+	period := c.DefaultQuery("period", "week")
+	window, ok := movementPeriods[period]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "period must be one of day, week, month"})
+		return
+	}
 
-	// Total works
-	h.db.Model(&models.MusicMetadata{}).
-		Where("is_active = ?", true).
-		Count(&stats.TotalWorks)
+	movement, err := h.leaderboardService.GetMovement(c.Request.Context(), address, period, window)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, movement)
+}
 
-	// Total earnings
-	h.db.Model(&models.RoyaltyDistribution{}).
-		Select("COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) as total").
-		Scan(&stats.TotalEarnings)
+// GetLeaderboardStats returns overall leaderboard statistics
+// Served from a short-lived cache; ?fresh=true recomputes it
+// GET /api/v1/leaderboard/stats
+func (h *LeaderboardHandler) GetLeaderboardStats(c *gin.Context) {
+	fresh := c.Query("fresh") == "true"
 
-	// Verified creators
-	h.db.Model(&models.User{}).
-		Where("role IN (?) AND is_verified = ?", []string{"creator", "both"}, true).
-		Count(&stats.VerifiedCreators)
+	stats, cached, err := h.leaderboardService.GetStats(c.Request.Context(), fresh)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"total_creators":    stats.TotalCreators,
 		"total_works":       stats.TotalWorks,
 		"total_earnings":    stats.TotalEarnings,
 		"verified_creators": stats.VerifiedCreators,
+		"computed_at":       stats.ComputedAt,
+		"cached":            cached,
 	})
 }
+
+// GetTopInvestors ranks contributors by total invested, pools backed and
+// realized ROI. Pass ?address= to also get that investor's own standing.
+// GET /api/v1/leaderboard/top-investors?limit=20&offset=0&address=0x...
+func (h *LeaderboardHandler) GetTopInvestors(c *gin.Context) {
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	standings, err := h.leaderboardService.RankedInvestors(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	page := []services.InvestorStanding{}
+	if offset < len(standings) {
+		page = standings[offset:min(offset+limit, len(standings))]
+	}
+
+	response := gin.H{
+		"leaderboard": page,
+		"total":       len(standings),
+		"limit":       limit,
+		"offset":      offset,
+	}
+
+	if address := c.Query("address"); address != "" {
+		var own *services.InvestorStanding
+		for i := range standings {
+			if strings.EqualFold(standings[i].WalletAddress, address) {
+				own = &standings[i]
+				break
+			}
+		}
+		response["your_standing"] = own
+	}
+
+	c.JSON(http.StatusOK, response)
+}
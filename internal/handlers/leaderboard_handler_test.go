@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/internal/services"
+)
+
+func TestGetRankMovement(t *testing.T) {
+	now := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "wallet_address", "rank", "snapshot_at"}
+
+	tests := []struct {
+		name     string
+		query    string
+		lookback time.Duration
+	}{
+		{name: "defaults to a week", query: "", lookback: 7 * 24 * time.Hour},
+		{name: "day", query: "?period=day", lookback: 24 * time.Hour},
+		{name: "month", query: "?period=month", lookback: 30 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT \\* FROM `rank_snapshots` WHERE wallet_address = \\?").
+				WithArgs("0xcreator").
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "0xcreator", 8, now))
+			mock.ExpectQuery("SELECT \\* FROM `rank_snapshots` WHERE wallet_address = \\? AND snapshot_at <= \\?").
+				WithArgs("0xcreator", now.Add(-tt.lookback)).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "0xcreator", 5, now.Add(-tt.lookback)))
+
+			router := gin.New()
+			router.GET("/leaderboard/:address/movement", NewLeaderboardHandler(db, services.NewLeaderboardService(db)).GetRankMovement)
+
+			rec := record(router, http.MethodGet, "/leaderboard/0xcreator/movement"+tt.query, "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			var got services.RankMovement
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Delta != -3 || got.Direction != "down" {
+				t.Errorf("movement = %d %s, want -3 down", got.Delta, got.Direction)
+			}
+		})
+	}
+}
+
+func TestGetRankMovementRejectsUnknownPeriod(t *testing.T) {
+	db, _ := dbtest.New(t)
+	router := gin.New()
+	router.GET("/leaderboard/:address/movement", NewLeaderboardHandler(db, services.NewLeaderboardService(db)).GetRankMovement)
+
+	if status := serve(router, http.MethodGet, "/leaderboard/0xcreator/movement?period=year", "", ""); status != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+	}
+}
+
+func TestGetTopInvestors(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantPage []string
+		wantOwn  int // rank of the requested address, 0 when not ranked
+	}{
+		{name: "first page", query: "?limit=2", wantPage: []string{"0xwhale", "0xpaid"}},
+		{name: "own rank beyond the page", query: "?limit=1&address=0xSMALL", wantPage: []string{"0xwhale"}, wantOwn: 3},
+		{name: "unranked address", query: "?address=0xnobody", wantPage: []string{"0xwhale", "0xpaid", "0xsmall"}},
+		{name: "offset past the end", query: "?offset=5", wantPage: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("FROM `contributions` GROUP BY `contributor_address`").
+				WillReturnRows(sqlmock.NewRows([]string{"contributor_address", "total", "pools"}).
+					AddRow("0xsmall", "100", 1).
+					AddRow("0xwhale", "5000", 4).
+					AddRow("0xpaid", "1000", 2))
+			mock.ExpectQuery("FROM royalty_distributions rd").
+				WillReturnRows(sqlmock.NewRows([]string{"beneficiary", "total"}))
+			mock.ExpectQuery("FROM `users`").
+				WillReturnRows(sqlmock.NewRows([]string{"wallet_address", "display_name"}))
+
+			router := gin.New()
+			router.GET("/leaderboard/top-investors", NewLeaderboardHandler(db, services.NewLeaderboardService(db)).GetTopInvestors)
+
+			rec := record(router, http.MethodGet, "/leaderboard/top-investors"+tt.query, "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Leaderboard  []services.InvestorStanding `json:"leaderboard"`
+				Total        int                         `json:"total"`
+				YourStanding *services.InvestorStanding  `json:"your_standing"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Total != 3 || len(body.Leaderboard) != len(tt.wantPage) {
+				t.Fatalf("got %d of %d investors, want %d of 3", len(body.Leaderboard), body.Total, len(tt.wantPage))
+			}
+			for i, address := range tt.wantPage {
+				if body.Leaderboard[i].WalletAddress != address {
+					t.Errorf("position %d = %s, want %s", i, body.Leaderboard[i].WalletAddress, address)
+				}
+			}
+			if tt.wantOwn == 0 && body.YourStanding != nil {
+				t.Errorf("your_standing = %+v, want none", body.YourStanding)
+			}
+			if tt.wantOwn != 0 && (body.YourStanding == nil || body.YourStanding.Rank != tt.wantOwn) {
+				t.Errorf("your_standing = %+v, want rank %d", body.YourStanding, tt.wantOwn)
+			}
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tunecent/backend/internal/services"
@@ -27,14 +32,10 @@ func (h *LedgerHandler) GetSplitHistory(c *gin.Context) {
 		return
 	}
 
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
-
-	limit, _ := strconv.Atoi(limitStr)
-	offset, _ := strconv.Atoi(offsetStr)
-
-	if limit > 100 {
-		limit = 100
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	history, err := h.ledgerService.GetSplitHistory(c.Request.Context(), tokenID, limit, offset)
@@ -46,6 +47,60 @@ func (h *LedgerHandler) GetSplitHistory(c *gin.Context) {
 	c.JSON(http.StatusOK, history)
 }
 
+// splitExportFlushEvery is how many CSV rows are written between flushes
+const splitExportFlushEvery = 500
+
+// ExportSplits handles GET /api/v1/ledger/:tokenId/splits/export?format=csv
+func (h *LedgerHandler) ExportSplits(c *gin.Context) {
+	tokenIDStr := c.Param("tokenId")
+	tokenID, err := strconv.ParseUint(tokenIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	if format := c.DefaultQuery("format", "csv"); format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported format %q: only csv is available", format)})
+		return
+	}
+
+	filename := fmt.Sprintf("tunecent-splits-%d.csv", tokenID)
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// Headers are already sent, so failures past this point can only end the stream early
+	w := csv.NewWriter(c.Writer)
+	w.Write([]string{"split_id", "payment_id", "beneficiary", "amount", "tx_hash", "block", "timestamp"})
+
+	written := 0
+	n, err := h.ledgerService.StreamSplitRows(c.Request.Context(), tokenID, func(row services.SplitExportRow) error {
+		if err := w.Write([]string{
+			strconv.FormatUint(uint64(row.SplitID), 10),
+			strconv.FormatUint(uint64(row.PaymentID), 10),
+			row.Beneficiary,
+			row.Amount,
+			row.TxHash,
+			strconv.FormatUint(row.BlockNumber, 10),
+			row.BlockTimestamp.UTC().Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+		if written++; written%splitExportFlushEvery == 0 {
+			w.Flush()
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	w.Flush()
+	if err == nil {
+		err = w.Error()
+	}
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "Split export aborted", "token_id", tokenID, "rows", n, "error", err)
+	}
+}
+
 // GetContributorBreakdown handles GET /api/v1/ledger/:tokenId/contributors
 func (h *LedgerHandler) GetContributorBreakdown(c *gin.Context) {
 	tokenIDStr := c.Param("tokenId")
@@ -66,7 +121,11 @@ func (h *LedgerHandler) GetContributorBreakdown(c *gin.Context) {
 
 // GetSplitByTxHash handles GET /api/v1/ledger/audit/:txHash
 func (h *LedgerHandler) GetSplitByTxHash(c *gin.Context) {
-	txHash := c.Param("txHash")
+	txHash, err := parseTxHash(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	splitRecord, err := h.ledgerService.GetSplitRecordByTxHash(c.Request.Context(), txHash)
 	if err != nil {
@@ -77,18 +136,72 @@ func (h *LedgerHandler) GetSplitByTxHash(c *gin.Context) {
 	c.JSON(http.StatusOK, splitRecord)
 }
 
+// GetDistributionSplit handles GET /api/v1/ledger/distribution/:id/split
+func (h *LedgerHandler) GetDistributionSplit(c *gin.Context) {
+	distributionID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid distribution ID"})
+		return
+	}
+
+	splitRecord, err := h.ledgerService.GetSplitForDistribution(c.Request.Context(), uint(distributionID))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrDistributionNotFound), errors.Is(err, services.ErrSplitNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, splitRecord)
+}
+
+// GetFairness handles GET /api/v1/ledger/:tokenId/fairness?tolerance=0.01
+func (h *LedgerHandler) GetFairness(c *gin.Context) {
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tolerance := services.DefaultFairnessTolerance
+	if value := c.Query("tolerance"); value != "" {
+		tolerance, err = strconv.ParseFloat(value, 64)
+		if err != nil || tolerance < 0 || tolerance > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "tolerance must be between 0 and 100 percentage points"})
+			return
+		}
+	}
+
+	report, err := h.ledgerService.CheckFairness(c.Request.Context(), tokenID, tolerance, limit, offset)
+	if err != nil {
+		if errors.Is(err, services.ErrTrackNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
 // GetUserLedger handles GET /api/v1/ledger/user/:address
 func (h *LedgerHandler) GetUserLedger(c *gin.Context) {
 	userAddress := c.Param("address")
 
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
-
-	limit, _ := strconv.Atoi(limitStr)
-	offset, _ := strconv.Atoi(offsetStr)
-
-	if limit > 100 {
-		limit = 100
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	distributions, total, err := h.ledgerService.GetUserLedger(c.Request.Context(), userAddress, limit, offset)
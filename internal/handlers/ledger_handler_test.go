@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/internal/services"
+)
+
+func TestExportSplits(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		distributions map[uint]int // distributions per split record
+	}{
+		{name: "no split records"},
+		{name: "one split", distributions: map[uint]int{1: 3}},
+		{name: "several splits", distributions: map[uint]int{1: 2, 2: 4, 3: 1}},
+		{name: "past a flush boundary", distributions: map[uint]int{1: splitExportFlushEvery + 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows([]string{"split_id", "payment_id", "beneficiary", "amount", "tx_hash", "block_number", "block_timestamp"})
+			total := 0
+			for split := uint(1); split <= uint(len(tt.distributions)); split++ {
+				for i := 0; i < tt.distributions[split]; i++ {
+					rows.AddRow(split, split+10, "0xbeneficiary", "100", "0xtx", 42, at)
+					total++
+				}
+			}
+			mock.ExpectQuery("SELECT sr.id as split_id, .* FROM split_records sr JOIN royalty_distributions rd ON rd.payment_id = sr.payment_id WHERE sr.token_id = \\? ORDER BY sr.id ASC, rd.id ASC").
+				WithArgs(7).
+				WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/ledger/:tokenId/splits/export", NewLedgerHandler(services.NewLedgerService(db)).ExportSplits)
+
+			rec := record(router, http.MethodGet, "/ledger/7/splits/export?format=csv", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/csv") {
+				t.Errorf("Content-Type = %q, want text/csv", got)
+			}
+
+			records, err := csv.NewReader(rec.Body).ReadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) == 0 || strings.Join(records[0], ",") != "split_id,payment_id,beneficiary,amount,tx_hash,block,timestamp" {
+				t.Fatalf("header = %v, want the export columns", records)
+			}
+			if got := len(records) - 1; got != total {
+				t.Errorf("got %d rows, want one per distribution (%d)", got, total)
+			}
+			if total > 0 && strings.Join(records[1], ",") != "1,11,0xbeneficiary,100,0xtx,42,2026-03-01T12:00:00Z" {
+				t.Errorf("first row = %v", records[1])
+			}
+		})
+	}
+}
+
+func TestExportSplitsRejects(t *testing.T) {
+	db, _ := dbtest.New(t)
+	router := gin.New()
+	router.GET("/ledger/:tokenId/splits/export", NewLedgerHandler(services.NewLedgerService(db)).ExportSplits)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{name: "invalid token ID", path: "/ledger/abc/splits/export"},
+		{name: "unsupported format", path: "/ledger/7/splits/export?format=xlsx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := serve(router, http.MethodGet, tt.path, "", ""); status != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestGetDistributionSplit(t *testing.T) {
+	tests := []struct {
+		name         string
+		distribution bool
+		split        bool
+		want         int
+	}{
+		{name: "distribution with a split record", distribution: true, split: true, want: http.StatusOK},
+		{name: "payment never split", distribution: true, want: http.StatusNotFound},
+		{name: "unknown distribution", want: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			distributions := sqlmock.NewRows([]string{"id", "payment_id", "token_id", "beneficiary", "amount"})
+			if tt.distribution {
+				distributions.AddRow(22, 11, 7, "0xbob", "300")
+			}
+			mock.ExpectQuery("SELECT \\* FROM `royalty_distributions` WHERE `royalty_distributions`.`id` = \\?").
+				WithArgs(22).
+				WillReturnRows(distributions)
+			if tt.distribution {
+				splits := sqlmock.NewRows([]string{"id", "payment_id", "token_id", "total_amount", "split_count", "tx_hash"})
+				if tt.split {
+					splits.AddRow(5, 11, 7, "1000", 3, "0xsplit")
+				}
+				mock.ExpectQuery("SELECT \\* FROM `split_records` WHERE payment_id = \\? ORDER BY created_at DESC").
+					WithArgs(11).
+					WillReturnRows(splits)
+			}
+			if tt.split {
+				mock.ExpectQuery("SELECT \\* FROM `royalty_distributions` WHERE payment_id = \\? ORDER BY id ASC").
+					WithArgs(11).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "payment_id", "token_id", "beneficiary", "amount"}).
+						AddRow(21, 11, 7, "0xcreator", "600").
+						AddRow(22, 11, 7, "0xbob", "300").
+						AddRow(23, 11, 7, "0xcarol", "100"))
+			}
+
+			router := gin.New()
+			router.GET("/ledger/distribution/:id/split", NewLedgerHandler(services.NewLedgerService(db)).GetDistributionSplit)
+
+			rec := record(router, http.MethodGet, "/ledger/distribution/22/split", "", "")
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			var got services.SplitRecordDetail
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.ID != 5 || got.PaymentID != 11 || got.TxHash != "0xsplit" {
+				t.Errorf("split = %+v, want split 5 of payment 11", got)
+			}
+			// The siblings of the requested distribution come with it
+			if len(got.Distributions) != 3 || got.Distributions[1].ID != 22 {
+				t.Errorf("distributions = %+v, want all three of payment 11", got.Distributions)
+			}
+		})
+	}
+}
@@ -1,39 +1,191 @@
 package handlers
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/config"
+	"github.com/tunecent/backend/internal/middleware"
 	"github.com/tunecent/backend/internal/services"
+	"github.com/tunecent/backend/pkg/audio"
+	"github.com/tunecent/backend/pkg/ipfs"
+	"gorm.io/gorm"
 )
 
 type MusicHandler struct {
-	musicService *services.MusicService
+	musicService  *services.MusicService
+	maxAudioBytes int64
+	uploadURLTTL  time.Duration
+	tokenSecret   string
 }
 
-func NewMusicHandler(musicService *services.MusicService) *MusicHandler {
+func NewMusicHandler(musicService *services.MusicService, uploads config.UploadConfig, tokenSecret string) *MusicHandler {
 	return &MusicHandler{
-		musicService: musicService,
+		musicService:  musicService,
+		maxAudioBytes: uploads.MaxAudioBytes,
+		uploadURLTTL:  uploads.URLTTL,
+		tokenSecret:   tokenSecret,
 	}
 }
 
+// CreateUploadURL handles POST /api/v1/music/upload-url
+// @Summary Create a direct audio upload URL
+// @Description Returns a signed URL the client uploads the audio file to directly, plus an upload token to pass to /music/register together with the resulting CID
+// @Tags Music
+// @Accept json
+// @Produce json
+// @Param request body object true "filename, and creator_address for admins"
+// @Success 201 {object} map[string]interface{} "Upload target and token"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid bearer token"
+// @Failure 403 {object} map[string]interface{} "creator_address is not the caller"
+// @Failure 415 {object} map[string]interface{} "Unsupported audio format"
+// @Failure 503 {object} map[string]interface{} "Direct uploads not configured"
+// @Router /music/upload-url [post]
+func (h *MusicHandler) CreateUploadURL(c *gin.Context) {
+	var req struct {
+		CreatorAddress string `json:"creator_address"`
+		Filename       string `json:"filename" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The upload token is issued to the caller; only admins may name another creator
+	claims := authClaims(c)
+	creator := claims.Subject
+	if req.CreatorAddress != "" && !strings.EqualFold(req.CreatorAddress, claims.Subject) {
+		if claims.Role != auth.RoleAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "upload tokens can only be issued to the caller's own address"})
+			return
+		}
+		creator = req.CreatorAddress
+	}
+
+	if _, ok := audio.FormatForFilename(req.Filename); !ok {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": audio.ErrUnsupportedFormat.Error()})
+		return
+	}
+
+	target, err := h.musicService.CreateUploadURL(c.Request.Context(), req.Filename, h.maxAudioBytes, h.uploadURLTTL)
+	if err != nil {
+		status := http.StatusBadGateway
+		if errors.Is(err, ipfs.ErrNotConfigured) {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, err := auth.IssueToken(h.tokenSecret, creator, auth.RoleUpload, h.uploadURLTTL)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"upload":          target,
+		"upload_token":    token,
+		"creator_address": creator,
+		"max_bytes":       h.maxAudioBytes,
+	})
+}
+
+// uploadedAudio is the audio file for a registration, either sent inline or
+// fetched from IPFS after a direct upload
+type uploadedAudio struct {
+	data        []byte
+	filename    string
+	contentType string
+	cid         string
+}
+
+// readInlineAudio reads the audio_file form part, enforcing the size limit
+func (h *MusicHandler) readInlineAudio(c *gin.Context) (*uploadedAudio, int, error) {
+	file, header, err := c.Request.FormFile("audio_file")
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("Audio file is required")
+	}
+	defer file.Close()
+
+	if header.Size > h.maxAudioBytes {
+		return nil, http.StatusRequestEntityTooLarge, audio.ErrTooLarge
+	}
+
+	// Read at most one byte past the limit so an understated header size is still caught
+	data, err := io.ReadAll(io.LimitReader(file, h.maxAudioBytes+1))
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("Failed to read audio file")
+	}
+
+	return &uploadedAudio{
+		data:        data,
+		filename:    header.Filename,
+		contentType: header.Header.Get("Content-Type"),
+	}, 0, nil
+}
+
+// readUploadedAudio checks the upload token and fetches a directly uploaded
+// file by CID, verifying that it is pinned first
+func (h *MusicHandler) readUploadedAudio(c *gin.Context, cid, creatorAddress string) (*uploadedAudio, int, error) {
+	claims, err := auth.ParseToken(h.tokenSecret, c.PostForm("upload_token"))
+	if err != nil {
+		return nil, http.StatusUnauthorized, errors.New("valid upload_token is required when registering by ipfs_cid")
+	}
+	if claims.Role != auth.RoleUpload || !strings.EqualFold(claims.Subject, creatorAddress) {
+		return nil, http.StatusForbidden, errors.New("upload_token was not issued to this creator")
+	}
+
+	data, filename, err := h.musicService.FetchUploadedAudio(c.Request.Context(), cid, h.maxAudioBytes)
+	if err != nil {
+		switch {
+		case errors.Is(err, ipfs.ErrNotConfigured):
+			return nil, http.StatusServiceUnavailable, err
+		case errors.Is(err, ipfs.ErrNotPinned):
+			return nil, http.StatusNotFound, errors.New("referenced content not found")
+		case errors.Is(err, ipfs.ErrTooLarge):
+			return nil, http.StatusRequestEntityTooLarge, audio.ErrTooLarge
+		default:
+			return nil, http.StatusBadGateway, err
+		}
+	}
+
+	return &uploadedAudio{data: data, filename: filename, cid: cid}, 0, nil
+}
+
 // RegisterMusic handles POST /api/v1/music/register
 // @Summary Register new music NFT
-// @Description Upload and register a new music NFT with metadata and audio file
+// @Description Upload and register a new music NFT with metadata and audio file, or reference a file uploaded via /music/upload-url by its CID
 // @Tags Music
 // @Accept multipart/form-data
 // @Produce json
-// @Param creator_address formData string true "Creator's wallet address"
+// @Param creator_address formData string false "Creator's wallet address; defaults to the caller, and only admins may name another"
 // @Param title formData string true "Music title"
 // @Param artist formData string true "Artist name"
 // @Param genre formData string false "Music genre"
 // @Param description formData string false "Music description"
-// @Param duration formData integer false "Duration in seconds"
-// @Param audio_file formData file true "Audio file"
+// @Param duration formData integer false "Duration in seconds (ignored when it can be read from the file)"
+// @Param isrc formData string false "International Standard Recording Code, e.g. USRC17607839"
+// @Param explicit formData boolean false "Whether the track has explicit content"
+// @Param audio_file formData file false "Audio file (mp3, wav, flac or m4a); required unless ipfs_cid is given"
+// @Param ipfs_cid formData string false "CID of an audio file uploaded directly"
+// @Param upload_token formData string false "Upload token from /music/upload-url; required with ipfs_cid"
 // @Success 201 {object} map[string]interface{} "Music registered successfully"
 // @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid bearer or upload token"
+// @Failure 402 {object} map[string]interface{} "Caller's storage quota exceeded"
+// @Failure 403 {object} map[string]interface{} "creator_address is not the caller"
+// @Failure 404 {object} map[string]interface{} "Referenced CID is not pinned"
+// @Failure 413 {object} map[string]interface{} "Audio file too large"
+// @Failure 415 {object} map[string]interface{} "Unsupported or mislabeled audio file"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /music/register [post]
 func (h *MusicHandler) RegisterMusic(c *gin.Context) {
@@ -51,41 +203,94 @@ func (h *MusicHandler) RegisterMusic(c *gin.Context) {
 	description := c.PostForm("description")
 	durationStr := c.PostForm("duration")
 
-	if creatorAddress == "" || title == "" || artist == "" {
+	if title == "" || artist == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing required fields"})
 		return
 	}
 
+	// Tracks are registered to the caller; only admins may name another creator
+	claims := authClaims(c)
+	if creatorAddress == "" {
+		creatorAddress = claims.Subject
+	}
+	if !ownsAddress(claims, creatorAddress) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "tracks can only be registered to the caller's own address"})
+		return
+	}
+
 	duration, _ := strconv.Atoi(durationStr)
 
-	// Get audio file
-	file, _, err := c.Request.FormFile("audio_file")
+	isrc := services.NormalizeISRC(c.PostForm("isrc"))
+	if isrc != "" && !services.IsValidISRC(isrc) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "isrc must be 12 characters: country, registrant, year and designation code"})
+		return
+	}
+
+	var explicit *bool
+	if value := c.PostForm("explicit"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "explicit must be true or false"})
+			return
+		}
+		explicit = &parsed
+	}
+
+	// Get audio file, inline or by reference to a direct upload
+	var file *uploadedAudio
+	var status int
+	var err error
+	if cid := c.PostForm("ipfs_cid"); cid != "" {
+		file, status, err = h.readUploadedAudio(c, cid, creatorAddress)
+	} else {
+		file, status, err = h.readInlineAudio(c)
+	}
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Audio file is required"})
+		body := gin.H{"error": err.Error()}
+		if status == http.StatusRequestEntityTooLarge {
+			body["max_bytes"] = h.maxAudioBytes
+		}
+		c.JSON(status, body)
 		return
 	}
-	defer file.Close()
 
-	audioData, err := io.ReadAll(file)
+	info, err := audio.Validate(file.filename, file.contentType, file.data, h.maxAudioBytes)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read audio file"})
+		status := http.StatusUnsupportedMediaType
+		if errors.Is(err, audio.ErrTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
+	// Prefer the duration read from the file headers over the client-provided value
+	if info.Duration > 0 {
+		duration = info.Duration
+	}
+
 	// Create request
 	req := &services.RegisterMusicRequest{
-		CreatorAddress: creatorAddress,
-		Title:          title,
-		Artist:         artist,
-		Genre:          genre,
-		Description:    description,
-		AudioData:      audioData,
-		Duration:       duration,
+		CreatorAddress:  creatorAddress,
+		UploaderAddress: claims.Subject,
+		Title:           title,
+		Artist:          artist,
+		Genre:           genre,
+		Description:     description,
+		AudioData:       file.data,
+		AudioCID:        file.cid,
+		Duration:        duration,
+		ISRC:            isrc,
+		Explicit:        explicit,
 	}
 
 	// Register music
 	resp, err := h.musicService.RegisterMusic(c.Request.Context(), req)
 	if err != nil {
+		if errors.Is(err, services.ErrStorageQuotaExceeded) {
+			c.JSON(http.StatusPaymentRequired, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -93,13 +298,103 @@ func (h *MusicHandler) RegisterMusic(c *gin.Context) {
 	c.JSON(http.StatusCreated, resp)
 }
 
+// GetStorageQuota handles GET /api/v1/users/:address/storage
+// @Summary Get a creator's storage usage
+// @Description Audio bytes uploaded by the creator and what is left of the storage quota
+// @Tags Users
+// @Produce json
+// @Param address path string true "Creator wallet address"
+// @Success 200 {object} services.StorageQuota "Used and available bytes"
+// @Router /users/{address}/storage [get]
+func (h *MusicHandler) GetStorageQuota(c *gin.Context) {
+	quota, err := h.musicService.GetStorageQuota(c.Request.Context(), c.Param("address"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, quota)
+}
+
+// CheckFingerprint handles POST /api/v1/music/check-fingerprint
+// @Summary Check whether a track is already registered
+// @Description Fingerprints an uploaded audio file (or takes a precomputed fingerprint_hash) and returns identical or similar registered tracks without creating any record
+// @Tags Music
+// @Accept multipart/form-data
+// @Accept json
+// @Produce json
+// @Param audio_file formData file false "Audio file (mp3, wav, flac or m4a)"
+// @Param fingerprint_hash formData string false "Precomputed fingerprint hash"
+// @Success 200 {object} services.FingerprintCheck "Matching tracks"
+// @Failure 400 {object} map[string]interface{} "Bad request"
+// @Failure 413 {object} map[string]interface{} "Audio file too large"
+// @Failure 415 {object} map[string]interface{} "Unsupported or mislabeled audio file"
+// @Router /music/check-fingerprint [post]
+func (h *MusicHandler) CheckFingerprint(c *gin.Context) {
+	var fingerprintHash string
+
+	if c.ContentType() == "application/json" {
+		var req struct {
+			FingerprintHash string `json:"fingerprint_hash" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		fingerprintHash = req.FingerprintHash
+	} else {
+		if err := c.Request.ParseMultipartForm(50 << 20); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to parse form"})
+			return
+		}
+
+		fingerprintHash = c.PostForm("fingerprint_hash")
+		if fingerprintHash == "" {
+			file, status, err := h.readInlineAudio(c)
+			if err != nil {
+				c.JSON(status, gin.H{"error": err.Error()})
+				return
+			}
+
+			if _, err := audio.Validate(file.filename, file.contentType, file.data, h.maxAudioBytes); err != nil {
+				status := http.StatusUnsupportedMediaType
+				if errors.Is(err, audio.ErrTooLarge) {
+					status = http.StatusRequestEntityTooLarge
+				}
+				c.JSON(status, gin.H{"error": err.Error()})
+				return
+			}
+
+			fingerprintHash, err = h.musicService.GenerateFingerprint(file.data)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+	}
+
+	result, err := h.musicService.CheckFingerprint(c.Request.Context(), fingerprintHash)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrInvalidFingerprint) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // GetMusic handles GET /api/v1/music/:tokenId
 // @Summary Get music by token ID
 // @Description Retrieve music NFT metadata by token ID
 // @Tags Music
 // @Produce json
 // @Param tokenId path integer true "Music Token ID"
+// @Param If-None-Match header string false "ETag from a previous response"
 // @Success 200 {object} map[string]interface{} "Music metadata"
+// @Success 304 "Not modified since the given ETag"
 // @Failure 400 {object} map[string]interface{} "Invalid token ID"
 // @Failure 404 {object} map[string]interface{} "Music not found"
 // @Router /music/{tokenId} [get]
@@ -117,6 +412,11 @@ func (h *MusicHandler) GetMusic(c *gin.Context) {
 		return
 	}
 
+	// Metadata only changes through updates that bump updated_at
+	if middleware.NotModified(c, middleware.ETag("music", music.TokenID, music.UpdatedAt.UnixNano())) {
+		return
+	}
+
 	c.JSON(http.StatusOK, music)
 }
 
@@ -133,15 +433,12 @@ func (h *MusicHandler) GetMusic(c *gin.Context) {
 // @Router /music [get]
 func (h *MusicHandler) ListMusic(c *gin.Context) {
 	// Parse query parameters
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
 	creatorAddress := c.Query("creator")
 
-	limit, _ := strconv.Atoi(limitStr)
-	offset, _ := strconv.Atoi(offsetStr)
-
-	if limit > 100 {
-		limit = 100
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	musics, total, err := h.musicService.ListMusic(c.Request.Context(), limit, offset, creatorAddress)
@@ -184,3 +481,166 @@ func (h *MusicHandler) GetMusicAnalytics(c *gin.Context) {
 
 	c.JSON(http.StatusOK, analytics)
 }
+
+// GetMusicFeatures handles GET /api/v1/music/:tokenId/features
+// @Summary Get music audio features
+// @Description Retrieve the acoustic features (tempo, key, loudness, sample rate) extracted at registration
+// @Tags Music
+// @Produce json
+// @Param tokenId path integer true "Music Token ID"
+// @Success 200 {object} map[string]interface{} "Audio features"
+// @Failure 400 {object} map[string]interface{} "Invalid token ID"
+// @Failure 404 {object} map[string]interface{} "Music not found"
+// @Router /music/{tokenId}/features [get]
+func (h *MusicHandler) GetMusicFeatures(c *gin.Context) {
+	tokenIDStr := c.Param("tokenId")
+	tokenID, err := strconv.ParseUint(tokenIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	features, err := h.musicService.GetFeatures(c.Request.Context(), tokenID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Music not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, features)
+}
+
+// GetMusicProvenance handles GET /api/v1/music/:tokenId/provenance
+// @Summary Get track provenance
+// @Description Registration tx hash, IPFS CID and gateway URL, fingerprint hash, creator and registration time, with the fingerprint verified against the MusicRegistry when blockchain is configured. Fields where the database and the chain disagree are listed in mismatches.
+// @Tags Music
+// @Produce json
+// @Param tokenId path integer true "Music Token ID"
+// @Success 200 {object} services.Provenance "Provenance record"
+// @Failure 400 {object} map[string]interface{} "Invalid token ID"
+// @Failure 404 {object} map[string]interface{} "Music not found"
+// @Router /music/{tokenId}/provenance [get]
+func (h *MusicHandler) GetMusicProvenance(c *gin.Context) {
+	tokenIDStr := c.Param("tokenId")
+	tokenID, err := strconv.ParseUint(tokenIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	provenance, err := h.musicService.GetProvenance(c.Request.Context(), tokenID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Music not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, provenance)
+}
+
+// GetMusicLinks handles GET /api/v1/music/:tokenId/links
+// @Summary Get listen-everywhere links
+// @Description Streaming links for every platform the track is live on, grouped by platform, plus the IPFS audio URL
+// @Tags Music
+// @Produce json
+// @Param tokenId path integer true "Music Token ID"
+// @Success 200 {object} services.ListenLinks "Links grouped by platform"
+// @Failure 400 {object} map[string]interface{} "Invalid token ID"
+// @Failure 404 {object} map[string]interface{} "Music not found"
+// @Router /music/{tokenId}/links [get]
+func (h *MusicHandler) GetMusicLinks(c *gin.Context) {
+	tokenIDStr := c.Param("tokenId")
+	tokenID, err := strconv.ParseUint(tokenIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	links, err := h.musicService.GetListenLinks(c.Request.Context(), tokenID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Music not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+// UpdateCoverArt handles POST /api/v1/music/:tokenId/cover
+// @Summary Set a track's cover art
+// @Description Sets the cover image from an uploaded image file (pinned to IPFS) or from a URL, then re-pins the track metadata with the new image. Only the track's creator may call it.
+// @Tags Music
+// @Accept multipart/form-data,json
+// @Produce json
+// @Param tokenId path integer true "Music Token ID"
+// @Param image formData file false "Cover image (jpeg, png or webp, max 5 MB)"
+// @Param url formData string false "ipfs:// or IPFS gateway URL of the cover image; used when no file is uploaded"
+// @Success 200 {object} map[string]interface{} "Updated music metadata"
+// @Failure 400 {object} map[string]interface{} "Invalid token ID, URL or missing image"
+// @Failure 401 {object} map[string]interface{} "Missing or invalid token"
+// @Failure 403 {object} map[string]interface{} "Caller is not the track's creator"
+// @Failure 404 {object} map[string]interface{} "Music not found"
+// @Failure 413 {object} map[string]interface{} "Image too large"
+// @Failure 415 {object} map[string]interface{} "Unsupported image type"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /music/{tokenId}/cover [post]
+func (h *MusicHandler) UpdateCoverArt(c *gin.Context) {
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	input := &services.CoverArtInput{}
+	if c.ContentType() == "application/json" {
+		var req struct {
+			URL string `json:"url" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "url is required"})
+			return
+		}
+		input.URL = req.URL
+	} else if file, header, err := c.Request.FormFile("image"); err == nil {
+		defer file.Close()
+		if header.Size > services.MaxCoverImageBytes {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": services.ErrImageTooLarge.Error(), "max_bytes": services.MaxCoverImageBytes})
+			return
+		}
+		// Read at most one byte past the limit so an understated header size is still caught
+		input.ImageData, err = io.ReadAll(io.LimitReader(file, services.MaxCoverImageBytes+1))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read image file"})
+			return
+		}
+	} else if input.URL = c.PostForm("url"); input.URL == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "an image file or url is required"})
+		return
+	}
+
+	music, err := h.musicService.UpdateCoverArt(c.Request.Context(), tokenID, authClaims(c).Subject, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Music not found"})
+		case errors.Is(err, services.ErrNotCreator):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrImageTooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error(), "max_bytes": services.MaxCoverImageBytes})
+		case errors.Is(err, services.ErrUnsupportedImage):
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidCoverURL):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, music)
+}
@@ -0,0 +1,844 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/blockchain"
+	"github.com/tunecent/backend/internal/config"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/internal/middleware"
+	"github.com/tunecent/backend/internal/services"
+	"github.com/tunecent/backend/pkg/fingerprint"
+	"github.com/tunecent/backend/pkg/ipfs"
+)
+
+// The upload-url cases stop before the handler reaches the music service:
+// 415 for the unsupported filename means the caller got past the auth checks
+func TestCreateUploadURLAuth(t *testing.T) {
+	h := NewMusicHandler(nil, config.UploadConfig{}, testSecret)
+	router := gin.New()
+	router.POST("/music/upload-url", RequireAuth(testSecret), h.CreateUploadURL)
+
+	tests := []struct {
+		name          string
+		authorization string
+		body          string
+		want          int
+	}{
+		{name: "no token", body: `{"filename":"a.txt"}`, want: http.StatusUnauthorized},
+		{name: "upload token", authorization: bearer(t, testSecret, "0xabc", auth.RoleUpload, time.Hour), body: `{"filename":"a.txt"}`, want: http.StatusForbidden},
+		{name: "user for themselves", authorization: bearer(t, testSecret, "0xabc", auth.RoleUser, time.Hour), body: `{"filename":"a.txt"}`, want: http.StatusUnsupportedMediaType},
+		{name: "user naming their own address", authorization: bearer(t, testSecret, "0xabc", auth.RoleUser, time.Hour), body: `{"creator_address":"0xABC","filename":"a.txt"}`, want: http.StatusUnsupportedMediaType},
+		{name: "user naming another address", authorization: bearer(t, testSecret, "0xabc", auth.RoleUser, time.Hour), body: `{"creator_address":"0xdef","filename":"a.txt"}`, want: http.StatusForbidden},
+		{name: "admin naming another address", authorization: bearer(t, testSecret, "0xadmin", auth.RoleAdmin, time.Hour), body: `{"creator_address":"0xdef","filename":"a.txt"}`, want: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serve(router, http.MethodPost, "/music/upload-url", tt.authorization, tt.body); got != tt.want {
+				t.Errorf("POST /music/upload-url = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// roundTripFunc serves outgoing HTTP requests in tests
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// stubTransport replaces the default transport, which the IPFS client uses,
+// for the rest of the test
+func stubTransport(t *testing.T, fn roundTripFunc) {
+	original := http.DefaultTransport
+	http.DefaultTransport = fn
+	t.Cleanup(func() { http.DefaultTransport = original })
+}
+
+// respond builds a response with the given status and body
+func respond(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+// multipartForm encodes fields as a multipart/form-data body
+func multipartForm(t *testing.T, fields map[string]string) (string, *bytes.Buffer) {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for key, value := range fields {
+		if err := writer.WriteField(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return writer.FormDataContentType(), body
+}
+
+// metadataArgs matches a music_metadata insert by column, checking the given
+// values and accepting anything in the other columns
+func metadataArgs(want map[string]driver.Value) []driver.Value {
+	columns := strings.Split("token_id,creator_address,title,artist,genre,description,ipfs_cid,fingerprint_hash,"+
+		"audio_file_url,cover_image_url,duration,isrc,explicit,is_active,tx_hash,registered_at,play_count,view_count,"+
+		"listener_count,viral_score,trending_rank,tempo,musical_key,loudness,sample_rate,created_at,updated_at,deleted_at", ",")
+	args := make([]driver.Value, len(columns))
+	for i, column := range columns {
+		args[i] = sqlmock.AnyArg()
+		if value, ok := want[column]; ok {
+			args[i] = value
+		}
+	}
+	return args
+}
+
+func TestRegisterMusicByCID(t *testing.T) {
+	const (
+		creator  = "0xabc"
+		audioCID = "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"
+		metaCID  = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+		gateway  = "https://gateway.test/ipfs/"
+	)
+	mp3 := "ID3\x04\x00\x00\x00\x00\x00\x00"
+
+	uploadToken := func(t *testing.T, subject string) string {
+		token, err := auth.IssueToken(testSecret, subject, auth.RoleUpload, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	// pinata answers the pin list with the given rows JSON, accepts metadata
+	// uploads and serves the audio from the gateway
+	pinata := func(pinRows string) roundTripFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			switch {
+			case r.URL.Host == "api.pinata.cloud" && r.URL.Path == "/data/pinList":
+				if r.URL.Query().Get("hashContains") != audioCID || r.Header.Get("pinata_api_key") == "" {
+					return respond(http.StatusBadRequest, "unexpected pin query"), nil
+				}
+				return respond(http.StatusOK, `{"rows":`+pinRows+`}`), nil
+			case r.URL.Host == "api.pinata.cloud" && r.URL.Path == "/pinning/pinFileToIPFS":
+				return respond(http.StatusOK, `{"IpfsHash":"`+metaCID+`"}`), nil
+			case r.URL.String() == gateway+audioCID:
+				return respond(http.StatusOK, mp3), nil
+			}
+			return respond(http.StatusNotFound, ""), nil
+		}
+	}
+	pinned := `[{"ipfs_pin_hash":"` + audioCID + `","size":10,"metadata":{"name":"song.mp3"}}]`
+
+	tests := []struct {
+		name      string
+		pinataKey string
+		pins      string
+		maxBytes  int64
+		quota     int64
+		token     func(t *testing.T) string
+		expect    func(mock sqlmock.Sqlmock)
+		want      int
+	}{
+		{
+			name:      "pinned upload is registered",
+			pinataKey: "key",
+			pins:      pinned,
+			maxBytes:  1 << 20,
+			token:     func(t *testing.T) string { return uploadToken(t, creator) },
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE fingerprint_hash = \\?").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `storage_usages`").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `storage_usages` SET .* WHERE creator_address = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				// The extracted audio features are stored with the track
+				mock.ExpectExec("INSERT INTO `music_metadata` .*`tempo`,`musical_key`,`loudness`,`sample_rate`").
+					WithArgs(metadataArgs(map[string]driver.Value{"tempo": 120.0, "musical_key": "C major", "loudness": -5.0, "sample_rate": 44100})...).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `analytics`").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			want: http.StatusCreated,
+		},
+		{
+			name:      "upload past the storage quota",
+			pinataKey: "key",
+			pins:      pinned,
+			maxBytes:  1 << 20,
+			quota:     int64(len(mp3)) - 1,
+			token:     func(t *testing.T) string { return uploadToken(t, creator) },
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE fingerprint_hash = \\?").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `storage_usages`").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `storage_usages` SET .* WHERE creator_address = \\? AND used_bytes \\+ \\? <= \\?").
+					WithArgs(len(mp3), sqlmock.AnyArg(), creator, len(mp3), len(mp3)-1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			want: http.StatusPaymentRequired,
+		},
+		{
+			name:      "content that is not pinned",
+			pinataKey: "key",
+			pins:      `[]`,
+			maxBytes:  1 << 20,
+			token:     func(t *testing.T) string { return uploadToken(t, creator) },
+			want:      http.StatusNotFound,
+		},
+		{
+			name:      "pinned file over the size limit",
+			pinataKey: "key",
+			pins:      pinned,
+			maxBytes:  5,
+			token:     func(t *testing.T) string { return uploadToken(t, creator) },
+			want:      http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "pin verification not configured",
+			pins:     pinned,
+			maxBytes: 1 << 20,
+			token:    func(t *testing.T) string { return uploadToken(t, creator) },
+			want:     http.StatusServiceUnavailable,
+		},
+		{
+			name:      "upload token of another creator",
+			pinataKey: "key",
+			pins:      pinned,
+			maxBytes:  1 << 20,
+			token:     func(t *testing.T) string { return uploadToken(t, "0xdef") },
+			want:      http.StatusForbidden,
+		},
+		{
+			name:      "missing upload token",
+			pinataKey: "key",
+			pins:      pinned,
+			maxBytes:  1 << 20,
+			token:     func(t *testing.T) string { return "" },
+			want:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubTransport(t, pinata(tt.pins))
+			db, mock := dbtest.New(t)
+			if tt.expect != nil {
+				tt.expect(mock)
+			}
+
+			ipfsService := ipfs.NewService(&config.Config{IPFS: config.IPFSConfig{
+				PinataAPIKey: tt.pinataKey,
+				PinataSecret: tt.pinataKey,
+				Gateways:     []string{gateway},
+			}})
+			musicService := services.NewMusicService(db, ipfsService, fingerprint.NewService(nil), nil)
+			musicService.SetStorageQuota(tt.quota)
+			// main.go invalidates the cached leaderboard stats through this hook
+			registered := false
+			musicService.OnRegistered(func() { registered = true })
+			h := NewMusicHandler(musicService, config.UploadConfig{MaxAudioBytes: tt.maxBytes}, testSecret)
+			router := gin.New()
+			router.POST("/music/register", RequireAuth(testSecret), h.RegisterMusic)
+
+			contentType, body := multipartForm(t, map[string]string{
+				"title":        "Song",
+				"artist":       "Artist",
+				"ipfs_cid":     audioCID,
+				"upload_token": tt.token(t),
+			})
+			req := httptest.NewRequest(http.MethodPost, "/music/register", body)
+			req.Header.Set("Content-Type", contentType)
+			req.Header.Set("Authorization", bearer(t, testSecret, creator, auth.RoleUser, time.Hour))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want == http.StatusCreated && !strings.Contains(rec.Body.String(), `"ipfs_cid":"`+metaCID+`"`) {
+				t.Errorf("response %s does not carry the pinned metadata CID", rec.Body)
+			}
+			if registered != (tt.want == http.StatusCreated) {
+				t.Errorf("registration hook fired = %v, want %v", registered, tt.want == http.StatusCreated)
+			}
+		})
+	}
+}
+
+func TestCheckFingerprint(t *testing.T) {
+	mp3 := []byte("ID3\x04\x00\x00\x00\x00\x00\x00")
+	registered, err := fingerprint.NewService(nil).Generate(mp3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := strings.Repeat("ab", 32)
+
+	// audioForm uploads data as the audio_file field
+	audioForm := func(t *testing.T, filename string, data []byte) (string, *bytes.Buffer) {
+		t.Helper()
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", `form-data; name="audio_file"; filename="`+filename+`"`)
+		header.Set("Content-Type", "audio/mpeg")
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write(data)
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return writer.FormDataContentType(), body
+	}
+
+	catalog := func(hashes ...string) func(mock sqlmock.Sqlmock) {
+		return func(mock sqlmock.Sqlmock) {
+			rows := sqlmock.NewRows([]string{"id", "token_id", "title", "artist", "fingerprint_hash"})
+			for i, hash := range hashes {
+				rows.AddRow(i+1, 7+i, "Song", "Artist", hash)
+			}
+			mock.ExpectQuery("SELECT id, token_id, title, artist, fingerprint_hash FROM `music_metadata`").
+				WillReturnRows(rows)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		body           func(t *testing.T) (string, io.Reader)
+		expect         func(mock sqlmock.Sqlmock)
+		want           int
+		wantRegistered bool
+		wantMatch      uint64
+	}{
+		{
+			name:           "uploaded duplicate",
+			body:           func(t *testing.T) (string, io.Reader) { return audioForm(t, "song.mp3", mp3) },
+			expect:         catalog(other, registered),
+			want:           http.StatusOK,
+			wantRegistered: true,
+			wantMatch:      8,
+		},
+		{
+			name:   "novel upload",
+			body:   func(t *testing.T) (string, io.Reader) { return audioForm(t, "song.mp3", mp3) },
+			expect: catalog(other),
+			want:   http.StatusOK,
+		},
+		{
+			name: "precomputed duplicate",
+			body: func(t *testing.T) (string, io.Reader) {
+				return "application/json", strings.NewReader(`{"fingerprint_hash":"` + registered + `"}`)
+			},
+			expect:         catalog(registered),
+			want:           http.StatusOK,
+			wantRegistered: true,
+			wantMatch:      7,
+		},
+		{
+			name: "malformed fingerprint",
+			body: func(t *testing.T) (string, io.Reader) {
+				return "application/json", strings.NewReader(`{"fingerprint_hash":"not-a-hash"}`)
+			},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "upload that is not audio",
+			body: func(t *testing.T) (string, io.Reader) { return audioForm(t, "song.mp3", []byte("<html></html>")) },
+			want: http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "no audio or fingerprint",
+			body: func(t *testing.T) (string, io.Reader) { return multipartForm(t, map[string]string{"title": "Song"}) },
+			want: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			if tt.expect != nil {
+				tt.expect(mock)
+			}
+
+			musicService := services.NewMusicService(db, nil, fingerprint.NewService(nil), nil)
+			router := gin.New()
+			router.POST("/music/check-fingerprint", NewMusicHandler(musicService, config.UploadConfig{MaxAudioBytes: 1 << 20}, testSecret).CheckFingerprint)
+
+			contentType, body := tt.body(t)
+			req := httptest.NewRequest(http.MethodPost, "/music/check-fingerprint", body)
+			req.Header.Set("Content-Type", contentType)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			var got services.FingerprintCheck
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.IsRegistered != tt.wantRegistered {
+				t.Errorf("is_registered = %v, want %v", got.IsRegistered, tt.wantRegistered)
+			}
+			switch {
+			case tt.wantMatch == 0 && len(got.Matches) != 0:
+				t.Errorf("matches = %+v, want none", got.Matches)
+			case tt.wantMatch != 0 && (len(got.Matches) != 1 || got.Matches[0].TokenID != tt.wantMatch || got.Matches[0].Similarity != 1):
+				t.Errorf("matches = %+v, want token %d with similarity 1", got.Matches, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestGetMusicLinks(t *testing.T) {
+	const audioURL = "https://gateway.test/ipfs/QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"
+
+	tests := []struct {
+		name      string
+		audioURL  string
+		platforms [][]driver.Value // platform, external_id, external_url of live rows
+		want      map[string][]services.ListenLink
+	}{
+		{
+			name:     "live platforms and the IPFS audio",
+			audioURL: audioURL,
+			platforms: [][]driver.Value{
+				{"apple_music", "a1", "https://music.apple.com/a1"},
+				{"spotify", "s1", "https://open.spotify.com/track/s1"},
+				{"spotify", "s2", "https://open.spotify.com/track/s2"},
+			},
+			want: map[string][]services.ListenLink{
+				"apple_music": {{URL: "https://music.apple.com/a1", ExternalID: "a1"}},
+				"spotify":     {{URL: "https://open.spotify.com/track/s1", ExternalID: "s1"}, {URL: "https://open.spotify.com/track/s2", ExternalID: "s2"}},
+				"ipfs":        {{URL: audioURL}},
+			},
+		},
+		{
+			name:     "nothing live yet",
+			audioURL: audioURL,
+			want:     map[string][]services.ListenLink{"ipfs": {{URL: audioURL}}},
+		},
+		{
+			name: "no audio URL",
+			platforms: [][]driver.Value{
+				{"spotify", "s1", "https://open.spotify.com/track/s1"},
+			},
+			want: map[string][]services.ListenLink{
+				"spotify": {{URL: "https://open.spotify.com/track/s1", ExternalID: "s1"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+				WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "title", "artist", "audio_file_url"}).
+					AddRow(1, 7, "Song", "Artist", tt.audioURL))
+			rows := sqlmock.NewRows([]string{"platform", "external_id", "external_url"})
+			for _, row := range tt.platforms {
+				rows.AddRow(row...)
+			}
+			// Pending, failed and link-less distributions are filtered out by the query
+			mock.ExpectQuery("SELECT \\* FROM `platform_distributions` WHERE \\(token_id = \\? AND status = \\? AND external_url <> ''\\) AND `platform_distributions`.`deleted_at` IS NULL ORDER BY platform ASC").
+				WithArgs(7, "live").
+				WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/music/:tokenId/links", NewMusicHandler(services.NewMusicService(db, nil, nil, nil), config.UploadConfig{}, testSecret).GetMusicLinks)
+
+			rec := record(router, http.MethodGet, "/music/7/links", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+			var got services.ListenLinks
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Links, tt.want) {
+				t.Errorf("links = %+v, want %+v", got.Links, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMusicLinksErrors(t *testing.T) {
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+		WithArgs(404).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	router := gin.New()
+	router.GET("/music/:tokenId/links", NewMusicHandler(services.NewMusicService(db, nil, nil, nil), config.UploadConfig{}, testSecret).GetMusicLinks)
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "unknown track", path: "/music/404/links", want: http.StatusNotFound},
+		{name: "invalid token ID", path: "/music/abc/links", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := serve(router, http.MethodGet, tt.path, "", ""); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMusicFeatures(t *testing.T) {
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "duration", "tempo", "musical_key", "loudness", "sample_rate"}).
+			AddRow(1, 7, 215, 120.5, "A minor", -7.25, 48000))
+	mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+		WithArgs(404).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	router := gin.New()
+	router.GET("/music/:tokenId/features", NewMusicHandler(services.NewMusicService(db, nil, nil, nil), config.UploadConfig{}, testSecret).GetMusicFeatures)
+
+	tests := []struct {
+		name string
+		path string
+		want int
+		// features of the registered track; only checked on success
+		wantFeatures services.MusicFeatures
+	}{
+		{
+			name:         "registered track",
+			path:         "/music/7/features",
+			want:         http.StatusOK,
+			wantFeatures: services.MusicFeatures{TokenID: 7, Duration: 215, Tempo: 120.5, MusicalKey: "A minor", Loudness: -7.25, SampleRate: 48000},
+		},
+		{name: "unknown track", path: "/music/404/features", want: http.StatusNotFound},
+		{name: "invalid token ID", path: "/music/abc/features", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := record(router, http.MethodGet, tt.path, "", "")
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+			var got services.MusicFeatures
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.wantFeatures {
+				t.Errorf("features = %+v, want %+v", got, tt.wantFeatures)
+			}
+		})
+	}
+}
+
+func TestGetMusicNotModified(t *testing.T) {
+	updated := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	current := middleware.ETag("music", uint64(7), updated.UnixNano())
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        int
+	}{
+		{name: "first fetch", want: http.StatusOK},
+		{name: "unchanged since the client's copy", ifNoneMatch: current, want: http.StatusNotModified},
+		{name: "updated since the client's copy", ifNoneMatch: middleware.ETag("music", uint64(7), updated.Add(-time.Minute).UnixNano()), want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+				WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "title", "updated_at"}).AddRow(1, 7, "Song", updated))
+
+			musicService := services.NewMusicService(db, nil, fingerprint.NewService(nil), nil)
+			router := gin.New()
+			router.GET("/music/:tokenId", middleware.CacheControl(time.Hour), NewMusicHandler(musicService, config.UploadConfig{}, testSecret).GetMusic)
+
+			req := httptest.NewRequest(http.MethodGet, "/music/7", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if etag := rec.Header().Get("ETag"); etag != current {
+				t.Errorf("ETag = %q, want %q", etag, current)
+			}
+			if cc := rec.Header().Get("Cache-Control"); cc != "public, max-age=3600" {
+				t.Errorf("Cache-Control = %q, want the metadata max age", cc)
+			}
+			if tt.want == http.StatusNotModified && rec.Body.Len() != 0 {
+				t.Errorf("304 body = %q, want none", rec.Body)
+			}
+		})
+	}
+}
+
+func TestGetProvenance(t *testing.T) {
+	const gateway = "https://gateway.test/ipfs/"
+	hash := "0x" + strings.Repeat("ab", 32)
+	registered := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		chain       *blockchain.Service
+		hash        string
+		wantStatus  string
+		wantErrText string
+	}{
+		{name: "blockchain not configured", hash: hash, wantStatus: services.OnChainNotConfigured},
+		{name: "registry cannot be queried", chain: blockchain.NewService(nil), hash: hash, wantStatus: services.OnChainUnavailable, wantErrText: "contract bindings not generated"},
+		{name: "stored hash is malformed", chain: blockchain.NewService(nil), hash: "0xabc", wantStatus: services.OnChainUnavailable, wantErrText: "stored fingerprint hash is not 32 bytes of hex"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+				WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "creator_address", "title", "artist", "ipfs_cid", "fingerprint_hash", "tx_hash", "registered_at"}).
+					AddRow(1, 7, "0xcreator", "Song", "Artist", "bafymeta", tt.hash, "0xtx", registered))
+
+			ipfsService := ipfs.NewService(&config.Config{IPFS: config.IPFSConfig{Gateways: []string{gateway}}})
+			musicService := services.NewMusicService(db, ipfsService, fingerprint.NewService(nil), tt.chain)
+			router := gin.New()
+			router.GET("/music/:tokenId/provenance", NewMusicHandler(musicService, config.UploadConfig{}, testSecret).GetMusicProvenance)
+
+			rec := record(router, http.MethodGet, "/music/7/provenance", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var got services.Provenance
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.TxHash != "0xtx" || got.IPFSCID != "bafymeta" || got.GatewayURL != gateway+"bafymeta" || got.FingerprintHash != tt.hash {
+				t.Errorf("provenance = %+v, want the stored tx hash, CID, gateway URL and fingerprint", got)
+			}
+			if got.CreatorAddress != "0xcreator" || !got.RegisteredAt.Equal(registered) {
+				t.Errorf("creator %s registered %s, want 0xcreator registered %s", got.CreatorAddress, got.RegisteredAt, registered)
+			}
+			if got.OnChain == nil || got.OnChain.Status != tt.wantStatus || got.OnChain.Error != tt.wantErrText {
+				t.Errorf("on_chain = %+v, want status %s with error %q", got.OnChain, tt.wantStatus, tt.wantErrText)
+			}
+			// Nothing was compared against the chain, so nothing can disagree
+			if got.Mismatches == nil || len(got.Mismatches) != 0 {
+				t.Errorf("mismatches = %v, want an empty list", got.Mismatches)
+			}
+		})
+	}
+}
+
+func TestGetProvenanceErrors(t *testing.T) {
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+		WithArgs(404).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	musicService := services.NewMusicService(db, nil, fingerprint.NewService(nil), nil)
+	router := gin.New()
+	router.GET("/music/:tokenId/provenance", NewMusicHandler(musicService, config.UploadConfig{}, testSecret).GetMusicProvenance)
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "unknown track", path: "/music/404/provenance", want: http.StatusNotFound},
+		{name: "invalid token ID", path: "/music/abc/provenance", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := serve(router, http.MethodGet, tt.path, "", ""); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateCoverArt(t *testing.T) {
+	const (
+		creator  = "0xabc"
+		imageCID = "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"
+		metaCID  = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+		gateway  = "https://gateway.test/ipfs/"
+	)
+	png := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
+	// imageForm uploads data as the image field
+	imageForm := func(t *testing.T, data string) (string, io.Reader) {
+		t.Helper()
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("image", "cover.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte(data))
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return writer.FormDataContentType(), body
+	}
+	jsonBody := func(body string) func(t *testing.T) (string, io.Reader) {
+		return func(t *testing.T) (string, io.Reader) { return "application/json", strings.NewReader(body) }
+	}
+
+	tests := []struct {
+		name       string
+		caller     string
+		body       func(t *testing.T) (string, io.Reader)
+		want       int
+		wantCover  string
+		wantPinned []string // files pinned to IPFS, in order
+	}{
+		{
+			name:       "uploaded image",
+			caller:     creator,
+			body:       func(t *testing.T) (string, io.Reader) { return imageForm(t, png) },
+			want:       http.StatusOK,
+			wantCover:  gateway + imageCID,
+			wantPinned: []string{"cover-7.png", "metadata.json"},
+		},
+		{
+			name:       "gateway URL",
+			caller:     creator,
+			body:       jsonBody(`{"url":"` + gateway + imageCID + `"}`),
+			want:       http.StatusOK,
+			wantCover:  gateway + imageCID,
+			wantPinned: []string{"metadata.json"},
+		},
+		{
+			name:   "ipfs URL in a form",
+			caller: creator,
+			body: func(t *testing.T) (string, io.Reader) {
+				return multipartForm(t, map[string]string{"url": "ipfs://" + imageCID})
+			},
+			want:       http.StatusOK,
+			wantCover:  "ipfs://" + imageCID,
+			wantPinned: []string{"metadata.json"},
+		},
+		{
+			name:   "image that is not a supported type",
+			caller: creator,
+			body:   func(t *testing.T) (string, io.Reader) { return imageForm(t, "GIF89a\x01\x00\x01\x00") },
+			want:   http.StatusUnsupportedMediaType,
+		},
+		{
+			name:   "URL on another host",
+			caller: creator,
+			body:   jsonBody(`{"url":"https://example.com/cover.png"}`),
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "another user",
+			caller: "0xdef",
+			body:   jsonBody(`{"url":"ipfs://` + imageCID + `"}`),
+			want:   http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pinned []string
+			stubTransport(t, func(r *http.Request) (*http.Response, error) {
+				if r.URL.String() != "https://api.pinata.cloud/pinning/pinFileToIPFS" {
+					return respond(http.StatusNotFound, ""), nil
+				}
+				if err := r.ParseMultipartForm(1 << 20); err != nil {
+					return respond(http.StatusBadRequest, err.Error()), nil
+				}
+				filename := r.MultipartForm.File["file"][0].Filename
+				pinned = append(pinned, filename)
+				if filename == "metadata.json" {
+					return respond(http.StatusOK, `{"IpfsHash":"`+metaCID+`"}`), nil
+				}
+				return respond(http.StatusOK, `{"IpfsHash":"`+imageCID+`"}`), nil
+			})
+
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+				WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "title", "creator_address"}).AddRow(1, 7, "Song", creator))
+			if tt.want == http.StatusOK {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `music_metadata` SET `cover_image_url`=\\?,`ipfs_cid`=\\?,`updated_at`=\\? WHERE .*`id` = \\?").
+					WithArgs(tt.wantCover, metaCID, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			ipfsService := ipfs.NewService(&config.Config{IPFS: config.IPFSConfig{
+				PinataAPIKey: "key",
+				PinataSecret: "key",
+				Gateways:     []string{gateway},
+			}})
+			musicService := services.NewMusicService(db, ipfsService, nil, nil)
+			router := gin.New()
+			router.POST("/music/:tokenId/cover", RequireAuth(testSecret), NewMusicHandler(musicService, config.UploadConfig{}, testSecret).UpdateCoverArt)
+
+			contentType, body := tt.body(t)
+			req := httptest.NewRequest(http.MethodPost, "/music/7/cover", body)
+			req.Header.Set("Content-Type", contentType)
+			req.Header.Set("Authorization", bearer(t, testSecret, tt.caller, auth.RoleUser, time.Hour))
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if !reflect.DeepEqual(pinned, tt.wantPinned) {
+				t.Errorf("pinned %v, want %v", pinned, tt.wantPinned)
+			}
+			if tt.want == http.StatusOK && !strings.Contains(rec.Body.String(), `"cover_image_url":"`+tt.wantCover+`"`) {
+				t.Errorf("response %s does not carry the cover URL %s", rec.Body, tt.wantCover)
+			}
+		})
+	}
+}
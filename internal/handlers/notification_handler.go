@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -18,7 +19,7 @@ func NewNotificationHandler(notificationService *services.NotificationService) *
 	}
 }
 
-// GetNotifications handles GET /api/v1/notifications
+// GetNotifications handles GET /api/v1/notifications?user_address=0x...&unread_only=true&type=payment&related_id=42
 func (h *NotificationHandler) GetNotifications(c *gin.Context) {
 	userAddress := c.Query("user_address")
 	if userAddress == "" {
@@ -26,19 +27,27 @@ func (h *NotificationHandler) GetNotifications(c *gin.Context) {
 		return
 	}
 
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
-	unreadOnlyStr := c.DefaultQuery("unread_only", "false")
-
-	limit, _ := strconv.Atoi(limitStr)
-	offset, _ := strconv.Atoi(offsetStr)
-	unreadOnly := unreadOnlyStr == "true"
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	if limit > 100 {
-		limit = 100
+	unreadOnlyStr := c.DefaultQuery("unread_only", "false")
+	filter := services.NotificationFilter{
+		UnreadOnly: unreadOnlyStr == "true",
+		Type:       c.Query("type"),
+	}
+	if value := c.Query("related_id"); value != "" {
+		relatedID, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "related_id must be a non-negative integer"})
+			return
+		}
+		filter.RelatedID = &relatedID
 	}
 
-	notifications, total, err := h.notificationService.GetNotifications(c.Request.Context(), userAddress, limit, offset, unreadOnly)
+	notifications, total, err := h.notificationService.GetNotifications(c.Request.Context(), userAddress, limit, offset, filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -72,6 +81,23 @@ func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
 	})
 }
 
+// GetStats handles GET /api/v1/notifications/stats
+func (h *NotificationHandler) GetStats(c *gin.Context) {
+	address := c.Query("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	stats, err := h.notificationService.GetStats(c.Request.Context(), address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
 // MarkAsRead handles PUT /api/v1/notifications/:id/read
 func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
 	notificationIDStr := c.Param("id")
@@ -117,6 +143,39 @@ func (h *NotificationHandler) MarkAllAsRead(c *gin.Context) {
 	})
 }
 
+// MarkTypeAsRead handles PUT /api/v1/notifications/read-by-type?address=0x...&type=payment;
+// address defaults to the caller, and only admins may act for others
+func (h *NotificationHandler) MarkTypeAsRead(c *gin.Context) {
+	userAddress := c.Query("address")
+	if userAddress == "" {
+		userAddress = c.Query("user_address")
+	}
+	claims := authClaims(c)
+	if userAddress == "" {
+		userAddress = claims.Subject
+	}
+	if !ownsAddress(claims, userAddress) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "token does not belong to this address"})
+		return
+	}
+
+	notificationType := c.Query("type")
+	updated, err := h.notificationService.MarkTypeAsRead(c.Request.Context(), userAddress, notificationType)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidNotificationType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"type":    notificationType,
+		"updated": updated,
+	})
+}
+
 // DeleteNotification handles DELETE /api/v1/notifications/:id
 func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
 	notificationIDStr := c.Param("id")
@@ -184,3 +243,34 @@ func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
 		"message": "Preferences updated successfully",
 	})
 }
+
+// GetPreferenceHistory handles GET /api/v1/notifications/preferences/history
+func (h *NotificationHandler) GetPreferenceHistory(c *gin.Context) {
+	userAddress := c.Query("address")
+	if userAddress == "" {
+		userAddress = c.Query("user_address")
+	}
+	if userAddress == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address is required"})
+		return
+	}
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	changes, total, err := h.notificationService.ListPreferenceChanges(c.Request.Context(), userAddress, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":   changes,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/internal/services"
+)
+
+func TestGetNotifications(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		filter string
+		args   []driver.Value
+	}{
+		{name: "all notifications", filter: "WHERE user_address = \\?", args: []driver.Value{"0xuser"}},
+		{name: "by related entity", query: "&related_id=42", filter: "WHERE user_address = \\? AND related_id = \\?", args: []driver.Value{"0xuser", 42}},
+		{name: "by type and related entity", query: "&type=payment&related_id=42", filter: "WHERE user_address = \\? AND type = \\? AND related_id = \\?", args: []driver.Value{"0xuser", "payment", 42}},
+		{name: "unread for a related entity", query: "&unread_only=true&related_id=0", filter: "WHERE user_address = \\? AND is_read = \\? AND related_id = \\?", args: []driver.Value{"0xuser", false, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT count\\(\\*\\) FROM `notifications` " + tt.filter + "$").
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mock.ExpectQuery("SELECT \\* FROM `notifications` " + tt.filter + " ORDER BY created_at DESC LIMIT 20").
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "user_address", "type", "title", "related_id"}).AddRow(3, "0xuser", "payment", "Paid", 42))
+
+			router := gin.New()
+			router.GET("/notifications", NewNotificationHandler(services.NewNotificationService(db)).GetNotifications)
+
+			rec := record(router, http.MethodGet, "/notifications?user_address=0xuser"+tt.query, "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Data  []json.RawMessage `json:"data"`
+				Total int64             `json:"total"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if len(body.Data) != 1 || body.Total != 1 {
+				t.Errorf("got %d notifications of %d, want 1 of 1", len(body.Data), body.Total)
+			}
+		})
+	}
+}
+
+func TestGetNotificationsRejects(t *testing.T) {
+	db, _ := dbtest.New(t)
+	router := gin.New()
+	router.GET("/notifications", NewNotificationHandler(services.NewNotificationService(db)).GetNotifications)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "missing address", query: "?related_id=42"},
+		{name: "non-numeric related ID", query: "?user_address=0xuser&related_id=abc"},
+		{name: "negative related ID", query: "?user_address=0xuser&related_id=-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := serve(router, http.MethodGet, "/notifications"+tt.query, "", ""); status != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestMarkTypeAsRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		role    string
+		query   string
+		address string // whose notifications are updated; none when rejected
+		want    int
+	}{
+		{name: "own notifications", subject: "0xuser", role: auth.RoleUser, query: "?address=0xuser&type=payment", address: "0xuser", want: http.StatusOK},
+		{name: "address defaults to the caller", subject: "0xuser", role: auth.RoleUser, query: "?type=payment", address: "0xuser", want: http.StatusOK},
+		{name: "admin for another user", subject: "0xadmin", role: auth.RoleAdmin, query: "?address=0xuser&type=payment", address: "0xuser", want: http.StatusOK},
+		{name: "another user's notifications", subject: "0xother", role: auth.RoleUser, query: "?address=0xuser&type=payment", want: http.StatusForbidden},
+		{name: "unknown type", subject: "0xuser", role: auth.RoleUser, query: "?type=royalty", want: http.StatusBadRequest},
+		{name: "missing type", subject: "0xuser", role: auth.RoleUser, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			if tt.address != "" {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `notifications` SET `is_read`=\\?,`updated_at`=\\? WHERE user_address = \\? AND type = \\? AND is_read = \\?$").
+					WithArgs(true, sqlmock.AnyArg(), tt.address, "payment", false).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			}
+
+			router := gin.New()
+			router.PUT("/notifications/read-by-type", RequireAuth(testSecret), NewNotificationHandler(services.NewNotificationService(db)).MarkTypeAsRead)
+
+			rec := record(router, http.MethodPut, "/notifications/read-by-type"+tt.query, bearer(t, testSecret, tt.subject, tt.role, time.Minute), "")
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Type    string `json:"type"`
+				Updated int64  `json:"updated"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Type != "payment" || body.Updated != 2 {
+				t.Errorf("type = %s, updated = %d, want payment, 2", body.Type, body.Updated)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Pagination defaults shared by every list endpoint; SetPageSizes overrides
+// them from config at startup
+var (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// SetPageSizes sets the limit used when a list request gives none and the
+// largest limit a request may ask for. It must be called before serving.
+func SetPageSizes(defaultSize, maxSize int) {
+	defaultPageSize = defaultSize
+	maxPageSize = maxSize
+}
+
+// parsePagination reads the limit and offset query parameters.
+// Missing values fall back to the defaults and limit is capped at maxPageSize;
+// non-numeric or negative values are rejected so the caller can return 400.
+func parsePagination(c *gin.Context) (limit, offset int, err error) {
+	limit, err = parseNonNegativeQuery(c, "limit", defaultPageSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	offset, err = parseNonNegativeQuery(c, "offset", 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return limit, offset, nil
+}
+
+func parseNonNegativeQuery(c *gin.Context, key string, defaultValue int) (int, error) {
+	raw, ok := c.GetQuery(key)
+	if !ok || raw == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer", key)
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("%s must be non-negative", key)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// queryContext returns a gin context for a GET request with the given query string
+func queryContext(query string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/?"+query, nil)
+	return c
+}
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantLimit  int
+		wantOffset int
+		wantErr    bool
+	}{
+		{name: "defaults", query: "", wantLimit: 20, wantOffset: 0},
+		{name: "empty values use the defaults", query: "limit=&offset=", wantLimit: 20, wantOffset: 0},
+		{name: "explicit values", query: "limit=5&offset=40", wantLimit: 5, wantOffset: 40},
+		{name: "zero limit", query: "limit=0", wantLimit: 0, wantOffset: 0},
+		{name: "limit at the cap", query: "limit=100", wantLimit: 100, wantOffset: 0},
+		{name: "limit over the cap is capped", query: "limit=1000", wantLimit: 100, wantOffset: 0},
+		{name: "non-numeric limit", query: "limit=ten", wantErr: true},
+		{name: "non-numeric offset", query: "offset=1.5", wantErr: true},
+		{name: "negative limit", query: "limit=-1", wantErr: true},
+		{name: "negative offset", query: "offset=-20", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, offset, err := parsePagination(queryContext(tt.query))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePagination() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if limit != tt.wantLimit || offset != tt.wantOffset {
+				t.Errorf("parsePagination() = %d, %d, want %d, %d", limit, offset, tt.wantLimit, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestSetPageSizes(t *testing.T) {
+	defaultSize, maxSize := defaultPageSize, maxPageSize
+	t.Cleanup(func() { SetPageSizes(defaultSize, maxSize) })
+	SetPageSizes(10, 50)
+
+	tests := []struct {
+		query     string
+		wantLimit int
+	}{
+		{query: "", wantLimit: 10},
+		{query: "limit=50", wantLimit: 50},
+		{query: "limit=51", wantLimit: 50},
+	}
+
+	for _, tt := range tests {
+		limit, _, err := parsePagination(queryContext(tt.query))
+		if err != nil {
+			t.Fatalf("parsePagination(%q) error = %v", tt.query, err)
+		}
+		if limit != tt.wantLimit {
+			t.Errorf("parsePagination(%q) limit = %d, want %d", tt.query, limit, tt.wantLimit)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+)
+
+// GetPaymentDistributions returns every distribution of a royalty payment with
+// their sum and whether it matches the payment amount
+// GET /api/v1/royalties/payment/:paymentId/distributions
+func (h *RoyaltyHandler) GetPaymentDistributions(c *gin.Context) {
+	paymentID, err := strconv.ParseUint(c.Param("paymentId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment ID"})
+		return
+	}
+
+	var payment models.RoyaltyPayment
+	if err := h.db.First(&payment, paymentID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Payment not found"})
+		return
+	}
+
+	var distributions []models.RoyaltyDistribution
+	if err := h.db.Where("payment_id = ?", payment.ID).Order("id ASC").Find(&distributions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	total := new(big.Int)
+	for _, dist := range distributions {
+		if amount, ok := new(big.Int).SetString(dist.Amount, 10); ok {
+			total.Add(total, amount)
+		}
+	}
+
+	paymentAmount, ok := new(big.Int).SetString(payment.Amount, 10)
+	matches := ok && total.Cmp(paymentAmount) == 0
+
+	c.JSON(http.StatusOK, gin.H{
+		"payment":            payment,
+		"distributions":      distributions,
+		"distributed_total":  total.String(),
+		"matches_payment":    matches,
+		"distribution_count": len(distributions),
+	})
+}
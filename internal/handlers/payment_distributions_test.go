@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/config"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestGetPaymentDistributions(t *testing.T) {
+	tests := []struct {
+		name        string
+		amount      string
+		splits      []string
+		wantTotal   string
+		wantMatches bool
+	}{
+		{name: "distributions add up to the payment", amount: "1000", splits: []string{"700", "200", "100"}, wantTotal: "1000", wantMatches: true},
+		{name: "distributions short of the payment", amount: "1000", splits: []string{"700", "200"}, wantTotal: "900"},
+		{name: "undistributed payment", amount: "1000", wantTotal: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT \\* FROM `royalty_payments` WHERE `royalty_payments`.`id` = \\?").
+				WithArgs(9).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "amount"}).AddRow(9, 1, tt.amount))
+			rows := sqlmock.NewRows([]string{"id", "payment_id", "token_id", "beneficiary", "amount"})
+			for i, amount := range tt.splits {
+				rows.AddRow(i+1, 9, 1, "0xbeneficiary", amount)
+			}
+			mock.ExpectQuery("SELECT \\* FROM `royalty_distributions` WHERE payment_id = \\? ORDER BY id ASC").
+				WithArgs(9).
+				WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/royalties/payment/:paymentId/distributions", NewRoyaltyHandler(db, nil, nil, config.FeeConfig{}).GetPaymentDistributions)
+
+			rec := record(router, http.MethodGet, "/royalties/payment/9/distributions", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Distributions []json.RawMessage `json:"distributions"`
+				Total         string            `json:"distributed_total"`
+				Matches       bool              `json:"matches_payment"`
+				Count         int               `json:"distribution_count"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if len(body.Distributions) != len(tt.splits) || body.Count != len(tt.splits) {
+				t.Errorf("got %d distributions (count %d), want %d", len(body.Distributions), body.Count, len(tt.splits))
+			}
+			if body.Total != tt.wantTotal || body.Matches != tt.wantMatches {
+				t.Errorf("total = %s, matches = %v, want %s, %v", body.Total, body.Matches, tt.wantTotal, tt.wantMatches)
+			}
+		})
+	}
+}
+
+func TestGetPaymentDistributionsErrors(t *testing.T) {
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("SELECT \\* FROM `royalty_payments`").
+		WithArgs(404).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	router := gin.New()
+	router.GET("/royalties/payment/:paymentId/distributions", NewRoyaltyHandler(db, nil, nil, config.FeeConfig{}).GetPaymentDistributions)
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "unknown payment", path: "/royalties/payment/404/distributions", want: http.StatusNotFound},
+		{name: "invalid payment ID", path: "/royalties/payment/abc/distributions", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := serve(router, http.MethodGet, tt.path, "", ""); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
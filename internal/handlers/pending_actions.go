@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+	"golang.org/x/sync/errgroup"
+)
+
+// endingSoonWindow is how close to its deadline an active campaign must be
+// to count as ending soon
+const endingSoonWindow = 7 * 24 * time.Hour
+
+// endingSoonCampaign is an active campaign the user created or contributed
+// to whose deadline is near
+type endingSoonCampaign struct {
+	CampaignID   uint64    `json:"campaign_id"`
+	TokenID      uint64    `json:"token_id"`
+	Title        string    `json:"title"`
+	GoalAmount   string    `json:"goal_amount"`
+	RaisedAmount string    `json:"raised_amount"`
+	Deadline     time.Time `json:"deadline"`
+	IsCreator    bool      `json:"is_creator"`
+}
+
+// failedDistribution is a platform a creator's track failed to go live on
+type failedDistribution struct {
+	TokenID   uint64    `json:"token_id"`
+	Title     string    `json:"title"`
+	Platform  string    `json:"platform"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetPendingActions summarises what needs the user's attention: royalties
+// waiting to be distributed, failed platform distributions, unread
+// notifications, campaigns they are in that end within a week, and funds
+// available to reinvest. The sections load concurrently; one that fails is
+// returned as null and flagged under "sections", and the request only fails
+// when every section does.
+// GET /api/v1/users/:address/pending-actions
+func (h *UserHandler) GetPendingActions(c *gin.Context) {
+	address := c.Param("address")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), dashboardAllTimeout)
+	defer cancel()
+
+	var (
+		royalties  gin.H
+		failed     []failedDistribution
+		endingSoon []endingSoonCampaign
+		unread     int64
+		available  string
+
+		royaltiesErr, failedErr, notificationsErr, endingSoonErr, reinvestmentErr error
+	)
+
+	// Each goroutine keeps its own error and returns nil so one failing
+	// section does not cancel the others
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		royalties, royaltiesErr = h.undistributedRoyalties(gctx, address)
+		return nil
+	})
+	g.Go(func() error {
+		failedErr = h.db.WithContext(gctx).Table("platform_distributions pd").
+			Select("pd.token_id, m.title, pd.platform, pd.updated_at").
+			Joins("JOIN music_metadata m ON pd.token_id = m.token_id").
+			Where("m.creator_address = ? AND pd.status = ? AND pd.deleted_at IS NULL", address, "failed").
+			Order("pd.updated_at DESC").
+			Scan(&failed).Error
+		return nil
+	})
+	g.Go(func() error {
+		notificationsErr = h.db.WithContext(gctx).Model(&models.Notification{}).
+			Where("user_address = ? AND is_read = ?", address, false).
+			Count(&unread).Error
+		return nil
+	})
+	g.Go(func() error {
+		now := time.Now()
+		endingSoonErr = h.db.WithContext(gctx).Table("campaigns camp").
+			Select(`camp.campaign_id, camp.token_id, COALESCE(m.title, '') as title,
+				camp.goal_amount, camp.raised_amount, camp.deadline,
+				camp.creator_address = ? as is_creator`, address).
+			Joins("LEFT JOIN music_metadata m ON camp.token_id = m.token_id").
+			Where("camp.status = ? AND camp.deadline BETWEEN ? AND ?", "active", now, now.Add(endingSoonWindow)).
+			Where(`camp.creator_address = ? OR camp.campaign_id IN (
+				SELECT campaign_id FROM contributions WHERE contributor_address = ?)`, address, address).
+			Order("camp.deadline ASC").
+			Scan(&endingSoon).Error
+		return nil
+	})
+	g.Go(func() error {
+		available, reinvestmentErr = services.AvailableReinvestmentFunds(h.db.WithContext(gctx), address)
+		return nil
+	})
+	g.Wait()
+
+	errs := []error{royaltiesErr, failedErr, notificationsErr, endingSoonErr, reinvestmentErr}
+	sections := gin.H{
+		"undistributed_royalties": dashboardSectionStatus(c, "undistributed_royalties", royaltiesErr),
+		"failed_distributions":    dashboardSectionStatus(c, "failed_distributions", failedErr),
+		"unread_notifications":    dashboardSectionStatus(c, "unread_notifications", notificationsErr),
+		"ending_soon_campaigns":   dashboardSectionStatus(c, "ending_soon_campaigns", endingSoonErr),
+		"reinvestment":            dashboardSectionStatus(c, "reinvestment", reinvestmentErr),
+	}
+
+	failedCount, actions := 0, 0
+	for _, sectionErr := range errs {
+		if sectionErr != nil {
+			failedCount++
+		}
+	}
+	if failedCount == len(errs) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load pending actions", "sections": sections})
+		return
+	}
+
+	response := gin.H{
+		"address":  address,
+		"sections": sections,
+		"partial":  failedCount > 0,
+	}
+	if royaltiesErr == nil {
+		response["undistributed_royalties"] = royalties
+		if royalties["payment_count"].(int) > 0 {
+			actions++
+		}
+	} else {
+		response["undistributed_royalties"] = nil
+	}
+	if failedErr == nil {
+		if failed == nil {
+			failed = []failedDistribution{}
+		}
+		response["failed_distributions"] = gin.H{"count": len(failed), "distributions": failed}
+		actions += len(failed)
+	} else {
+		response["failed_distributions"] = nil
+	}
+	if notificationsErr == nil {
+		response["unread_notifications"] = gin.H{"unread_count": unread}
+		if unread > 0 {
+			actions++
+		}
+	} else {
+		response["unread_notifications"] = nil
+	}
+	if endingSoonErr == nil {
+		if endingSoon == nil {
+			endingSoon = []endingSoonCampaign{}
+		}
+		response["ending_soon_campaigns"] = gin.H{"count": len(endingSoon), "campaigns": endingSoon}
+		actions += len(endingSoon)
+	} else {
+		response["ending_soon_campaigns"] = nil
+	}
+	if reinvestmentErr == nil {
+		response["reinvestment"] = gin.H{"available_funds": available}
+		if available != "" && available != "0" {
+			actions++
+		}
+	} else {
+		response["reinvestment"] = nil
+	}
+	response["total_actions"] = actions
+
+	c.JSON(http.StatusOK, response)
+}
+
+// undistributedRoyalties counts and totals the payments on the creator's
+// tracks that have not been split yet, with the creator's pending share
+func (h *UserHandler) undistributedRoyalties(ctx context.Context, address string) (gin.H, error) {
+	db := h.db.WithContext(ctx)
+
+	var payments []models.RoyaltyPayment
+	if err := db.Model(&models.RoyaltyPayment{}).
+		Select("royalty_payments.*").
+		Joins("JOIN music_metadata ON royalty_payments.token_id = music_metadata.token_id").
+		Where("music_metadata.creator_address = ? AND royalty_payments.is_distributed = ?", address, false).
+		Find(&payments).Error; err != nil {
+		return nil, err
+	}
+
+	share, err := services.PendingRoyaltyShare(db, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return gin.H{
+		"payment_count": len(payments),
+		"total_pending": sumPayments(payments).String(),
+		"pending_share": share.String(),
+	}, nil
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestGetPendingActions(t *testing.T) {
+	const address = "0xcreator"
+	errDown := errors.New("connection refused")
+	deadline := time.Now().Add(48 * time.Hour)
+
+	// Each expects a section's queries; a failing section stops at its first
+	expectRoyalties := func(mock sqlmock.Sqlmock, fail bool) {
+		pending := mock.ExpectQuery("SELECT royalty_payments.\\* FROM `royalty_payments` JOIN music_metadata").
+			WithArgs(address, false)
+		if fail {
+			pending.WillReturnError(errDown)
+			return
+		}
+		pending.WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "amount", "is_distributed"}).
+			AddRow(1, 7, "1000", false).
+			AddRow(2, 7, "500", false))
+		// Without a funded campaign the creator's share is the whole payment
+		mock.ExpectQuery("SELECT \\* FROM `royalty_payments` WHERE is_distributed = \\?").
+			WithArgs(false, address, "successful", address).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "amount", "is_distributed"}).
+				AddRow(1, 7, "1000", false).
+				AddRow(2, 7, "500", false))
+		mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "creator_address"}).AddRow(1, 7, address))
+		mock.ExpectQuery("SELECT \\* FROM `campaigns` WHERE \\(token_id = \\? AND status = \\?\\)").
+			WithArgs(7, "successful").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id"}))
+	}
+	expectFailed := func(mock sqlmock.Sqlmock, fail bool) {
+		failed := mock.ExpectQuery("SELECT pd.token_id, m.title, pd.platform, pd.updated_at FROM platform_distributions pd").
+			WithArgs(address, "failed")
+		if fail {
+			failed.WillReturnError(errDown)
+			return
+		}
+		failed.WillReturnRows(sqlmock.NewRows([]string{"token_id", "title", "platform", "updated_at"}).
+			AddRow(7, "Hit", "spotify", time.Now()).
+			AddRow(7, "Hit", "deezer", time.Now()))
+	}
+	expectNotifications := func(mock sqlmock.Sqlmock, fail bool) {
+		unread := mock.ExpectQuery("SELECT count\\(\\*\\) FROM `notifications` WHERE user_address = \\? AND is_read = \\?").
+			WithArgs(address, false)
+		if fail {
+			unread.WillReturnError(errDown)
+			return
+		}
+		unread.WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	}
+	expectEndingSoon := func(mock sqlmock.Sqlmock, fail bool) {
+		ending := mock.ExpectQuery("SELECT camp.campaign_id, .* FROM campaigns camp LEFT JOIN music_metadata m").
+			WithArgs(address, "active", sqlmock.AnyArg(), sqlmock.AnyArg(), address, address)
+		if fail {
+			ending.WillReturnError(errDown)
+			return
+		}
+		ending.WillReturnRows(sqlmock.NewRows([]string{"campaign_id", "token_id", "title", "goal_amount", "raised_amount", "deadline", "is_creator"}).
+			AddRow(4, 7, "Hit", "5000", "1200", deadline, true))
+	}
+	expectReinvestment := func(mock sqlmock.Sqlmock, fail bool) {
+		earned := mock.ExpectQuery("SELECT COALESCE\\(SUM\\(CAST\\(amount AS DECIMAL\\(30,0\\)\\)\\), 0\\) as total FROM `royalty_distributions`").
+			WithArgs(address)
+		if fail {
+			earned.WillReturnError(errDown)
+			return
+		}
+		earned.WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow("9000"))
+		mock.ExpectQuery("SELECT COALESCE\\(SUM\\(CAST\\(amount AS DECIMAL\\(30,0\\)\\)\\), 0\\) as total FROM `reinvestment_histories`").
+			WithArgs(address).
+			WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow("4000"))
+	}
+
+	sections := []string{"undistributed_royalties", "failed_distributions", "unread_notifications", "ending_soon_campaigns", "reinvestment"}
+	tests := []struct {
+		name        string
+		failed      map[string]bool
+		want        int
+		wantActions int
+	}{
+		// One royalties action, two failed platforms, one for the unread
+		// notifications, one ending campaign and one for the funds
+		{name: "every section loads", want: http.StatusOK, wantActions: 6},
+		{name: "a failing section degrades to null", failed: map[string]bool{"failed_distributions": true}, want: http.StatusOK, wantActions: 4},
+		{name: "several failing sections", failed: map[string]bool{"undistributed_royalties": true, "reinvestment": true}, want: http.StatusOK, wantActions: 4},
+		{name: "every section fails", failed: map[string]bool{"undistributed_royalties": true, "failed_distributions": true, "unread_notifications": true, "ending_soon_campaigns": true, "reinvestment": true}, want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			// Sections load concurrently, so their queries interleave
+			mock.MatchExpectationsInOrder(false)
+			expectRoyalties(mock, tt.failed["undistributed_royalties"])
+			expectFailed(mock, tt.failed["failed_distributions"])
+			expectNotifications(mock, tt.failed["unread_notifications"])
+			expectEndingSoon(mock, tt.failed["ending_soon_campaigns"])
+			expectReinvestment(mock, tt.failed["reinvestment"])
+
+			router := gin.New()
+			router.GET("/users/:address/pending-actions", NewUserHandler(db).GetPendingActions)
+
+			rec := record(router, http.MethodGet, "/users/"+address+"/pending-actions", "", "")
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if strings.Contains(rec.Body.String(), errDown.Error()) {
+				t.Errorf("response leaks the database error: %s", rec.Body)
+			}
+
+			var body map[string]json.RawMessage
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			var status map[string]dashboardSection
+			if err := json.Unmarshal(body["sections"], &status); err != nil {
+				t.Fatal(err)
+			}
+			for _, section := range sections {
+				want := dashboardSection{OK: true}
+				if tt.failed[section] {
+					want = dashboardSection{Error: "failed to load"}
+				}
+				if status[section] != want {
+					t.Errorf("sections.%s = %+v, want %+v", section, status[section], want)
+				}
+				if tt.want != http.StatusOK {
+					continue
+				}
+				if loaded := string(body[section]) != "null"; loaded == tt.failed[section] {
+					t.Errorf("%s = %s, want loaded = %v", section, body[section], !tt.failed[section])
+				}
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+			if string(body["partial"]) != strconv.FormatBool(len(tt.failed) > 0) {
+				t.Errorf("partial = %s, want %v", body["partial"], len(tt.failed) > 0)
+			}
+			if string(body["total_actions"]) != strconv.Itoa(tt.wantActions) {
+				t.Errorf("total_actions = %s, want %d", body["total_actions"], tt.wantActions)
+			}
+
+			// Each loaded section reflects what was seeded for it
+			if !tt.failed["undistributed_royalties"] {
+				var royalties struct {
+					Count int    `json:"payment_count"`
+					Total string `json:"total_pending"`
+					Share string `json:"pending_share"`
+				}
+				if err := json.Unmarshal(body["undistributed_royalties"], &royalties); err != nil {
+					t.Fatal(err)
+				}
+				if royalties.Count != 2 || royalties.Total != "1500" || royalties.Share != "1500" {
+					t.Errorf("undistributed_royalties = %+v, want 2 payments totalling 1500, share 1500", royalties)
+				}
+			}
+			if !tt.failed["failed_distributions"] {
+				var failed struct {
+					Count         int                  `json:"count"`
+					Distributions []failedDistribution `json:"distributions"`
+				}
+				if err := json.Unmarshal(body["failed_distributions"], &failed); err != nil {
+					t.Fatal(err)
+				}
+				if failed.Count != 2 || len(failed.Distributions) != 2 || failed.Distributions[0].Platform != "spotify" {
+					t.Errorf("failed_distributions = %+v, want spotify and deezer", failed)
+				}
+			}
+			if !tt.failed["unread_notifications"] {
+				if got := string(body["unread_notifications"]); got != `{"unread_count":3}` {
+					t.Errorf("unread_notifications = %s, want 3 unread", got)
+				}
+			}
+			if !tt.failed["ending_soon_campaigns"] {
+				var ending struct {
+					Count     int                  `json:"count"`
+					Campaigns []endingSoonCampaign `json:"campaigns"`
+				}
+				if err := json.Unmarshal(body["ending_soon_campaigns"], &ending); err != nil {
+					t.Fatal(err)
+				}
+				if ending.Count != 1 || len(ending.Campaigns) != 1 || ending.Campaigns[0].CampaignID != 4 || !ending.Campaigns[0].IsCreator {
+					t.Errorf("ending_soon_campaigns = %+v, want the creator's campaign 4", ending)
+				}
+			}
+			if !tt.failed["reinvestment"] {
+				if got := string(body["reinvestment"]); got != `{"available_funds":"5000"}` {
+					t.Errorf("reinvestment = %s, want 5000 available", got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+)
+
+// sumPayments adds up payment amounts in wei, skipping malformed values
+func sumPayments(payments []models.RoyaltyPayment) *big.Int {
+	total := new(big.Int)
+	for _, payment := range payments {
+		if amount, ok := new(big.Int).SetString(payment.Amount, 10); ok {
+			total.Add(total, amount)
+		}
+	}
+	return total
+}
+
+// GetPendingRoyalties returns the token's payments that have not been
+// distributed yet, with their total
+// GET /api/v1/royalties/token/:tokenId/pending
+func (h *RoyaltyHandler) GetPendingRoyalties(c *gin.Context) {
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	var payments []models.RoyaltyPayment
+	if err := h.db.Where("token_id = ? AND is_distributed = ?", tokenID, false).
+		Order("paid_at ASC").
+		Find(&payments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token_id":      tokenID,
+		"payments":      payments,
+		"payment_count": len(payments),
+		"total_pending": sumPayments(payments).String(),
+	})
+}
+
+// GetPendingRoyalties returns undistributed payments across all of the
+// creator's tracks, totalled overall and per track
+// GET /api/v1/users/:address/pending-royalties
+func (h *UserHandler) GetPendingRoyalties(c *gin.Context) {
+	address := c.Param("address")
+
+	var payments []models.RoyaltyPayment
+	if err := h.db.Model(&models.RoyaltyPayment{}).
+		Select("royalty_payments.*").
+		Joins("JOIN music_metadata ON royalty_payments.token_id = music_metadata.token_id").
+		Where("music_metadata.creator_address = ? AND royalty_payments.is_distributed = ?", address, false).
+		Order("royalty_payments.paid_at ASC").
+		Find(&payments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	type trackPending struct {
+		TokenID      uint64 `json:"token_id"`
+		PaymentCount int    `json:"payment_count"`
+		TotalPending string `json:"total_pending"`
+	}
+
+	byToken := make(map[uint64][]models.RoyaltyPayment)
+	var tokenOrder []uint64
+	for _, payment := range payments {
+		if _, seen := byToken[payment.TokenID]; !seen {
+			tokenOrder = append(tokenOrder, payment.TokenID)
+		}
+		byToken[payment.TokenID] = append(byToken[payment.TokenID], payment)
+	}
+
+	tracks := make([]trackPending, 0, len(tokenOrder))
+	for _, tokenID := range tokenOrder {
+		tracks = append(tracks, trackPending{
+			TokenID:      tokenID,
+			PaymentCount: len(byToken[tokenID]),
+			TotalPending: sumPayments(byToken[tokenID]).String(),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":       address,
+		"payments":      payments,
+		"payment_count": len(payments),
+		"total_pending": sumPayments(payments).String(),
+		"by_track":      tracks,
+	})
+}
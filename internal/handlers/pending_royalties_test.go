@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/config"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/internal/models"
+)
+
+func TestSumPayments(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts []string
+		want    string
+	}{
+		{name: "no payments", want: "0"},
+		{name: "amounts are added", amounts: []string{"700", "300"}, want: "1000"},
+		{name: "amounts beyond int64 are exact", amounts: []string{"20000000000000000000", "1"}, want: "20000000000000000001"},
+		{name: "malformed amounts are skipped", amounts: []string{"500", "", "not-a-number"}, want: "500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payments := make([]models.RoyaltyPayment, 0, len(tt.amounts))
+			for _, amount := range tt.amounts {
+				payments = append(payments, models.RoyaltyPayment{Amount: amount})
+			}
+			if got := sumPayments(payments); got.String() != tt.want {
+				t.Errorf("sumPayments() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPendingRoyalties(t *testing.T) {
+	tests := []struct {
+		name      string
+		amounts   []string
+		wantTotal string
+	}{
+		{name: "undistributed payments are totalled", amounts: []string{"1000", "250"}, wantTotal: "1250"},
+		{name: "nothing pending", wantTotal: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows([]string{"id", "token_id", "amount", "is_distributed"})
+			for i, amount := range tt.amounts {
+				rows.AddRow(i+1, 7, amount, false)
+			}
+			// Distributed payments are filtered out by the query itself
+			mock.ExpectQuery("SELECT \\* FROM `royalty_payments` WHERE token_id = \\? AND is_distributed = \\? ORDER BY paid_at ASC").
+				WithArgs(7, false).
+				WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/royalties/token/:tokenId/pending", NewRoyaltyHandler(db, nil, nil, config.FeeConfig{}).GetPendingRoyalties)
+
+			rec := record(router, http.MethodGet, "/royalties/token/7/pending", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				TokenID  uint64            `json:"token_id"`
+				Payments []json.RawMessage `json:"payments"`
+				Count    int               `json:"payment_count"`
+				Total    string            `json:"total_pending"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.TokenID != 7 || body.Count != len(tt.amounts) || len(body.Payments) != len(tt.amounts) {
+				t.Errorf("token %d with %d payments (count %d), want token 7 with %d", body.TokenID, len(body.Payments), body.Count, len(tt.amounts))
+			}
+			if body.Total != tt.wantTotal {
+				t.Errorf("total_pending = %s, want %s", body.Total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestGetPendingRoyaltiesRejectsInvalidToken(t *testing.T) {
+	db, _ := dbtest.New(t)
+	router := gin.New()
+	router.GET("/royalties/token/:tokenId/pending", NewRoyaltyHandler(db, nil, nil, config.FeeConfig{}).GetPendingRoyalties)
+
+	if status := serve(router, http.MethodGet, "/royalties/token/abc/pending", "", ""); status != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+	}
+}
+
+func TestUserGetPendingRoyalties(t *testing.T) {
+	type track struct {
+		TokenID      uint64 `json:"token_id"`
+		PaymentCount int    `json:"payment_count"`
+		TotalPending string `json:"total_pending"`
+	}
+
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("SELECT royalty_payments.\\* FROM `royalty_payments` JOIN music_metadata ON royalty_payments.token_id = music_metadata.token_id "+
+		"WHERE music_metadata.creator_address = \\? AND royalty_payments.is_distributed = \\? ORDER BY royalty_payments.paid_at ASC").
+		WithArgs("0xcreator", false).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "amount", "is_distributed"}).
+			AddRow(1, 9, "400", false).
+			AddRow(2, 3, "100", false).
+			AddRow(3, 9, "600", false).
+			AddRow(4, 3, "bad", false))
+
+	router := gin.New()
+	router.GET("/users/:address/pending-royalties", NewUserHandler(db).GetPendingRoyalties)
+
+	rec := record(router, http.MethodGet, "/users/0xcreator/pending-royalties", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+
+	var body struct {
+		Address string  `json:"address"`
+		Count   int     `json:"payment_count"`
+		Total   string  `json:"total_pending"`
+		ByTrack []track `json:"by_track"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Address != "0xcreator" || body.Count != 4 || body.Total != "1100" {
+		t.Errorf("address %s, count %d, total %s, want 0xcreator, 4, 1100", body.Address, body.Count, body.Total)
+	}
+	want := []track{
+		{TokenID: 9, PaymentCount: 2, TotalPending: "1000"},
+		{TokenID: 3, PaymentCount: 2, TotalPending: "100"},
+	}
+	if !reflect.DeepEqual(body.ByTrack, want) {
+		t.Errorf("by_track = %+v, want %+v", body.ByTrack, want)
+	}
+}
@@ -1,12 +1,18 @@
 package handlers
 
 import (
+	"fmt"
+	"math"
+	"math/big"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tunecent/backend/internal/database"
 	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+	"github.com/tunecent/backend/pkg/metrics"
 )
 
 // PortfolioHandler handles portfolio-related endpoints
@@ -81,10 +87,11 @@ func (h *PortfolioHandler) GetPortfolio(c *gin.Context) {
 		Where("creator_address = ? AND is_active = ?", address, true).
 		Scan(&musicStats)
 
-	// Calculate portfolio value (mock calculation for PoC)
-	// In production, calculate based on NFT floor prices, pending royalties, etc.
-	portfolioValueETH := 15.5    // Mock value
-	portfolioValueUSD := 38750.0 // Mock value at ~$2500/ETH
+	value, err := h.portfolioValue(address)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
 	// Get user info
 	var user models.User
@@ -100,8 +107,9 @@ func (h *PortfolioHandler) GetPortfolio(c *gin.Context) {
 		"total_invested":        invested.Total,
 		"active_campaigns":      activeCampaigns,
 		"successful_campaigns":  successfulCampaigns,
-		"portfolio_value_eth":   portfolioValueETH,
-		"portfolio_value_usd":   portfolioValueUSD,
+		"portfolio_value_eth":   value.TotalETH,
+		"portfolio_value_usd":   value.TotalUSD,
+		"portfolio_value":       value,
 		"music_stats": gin.H{
 			"total_plays":     musicStats.TotalPlays,
 			"total_views":     musicStats.TotalViews,
@@ -111,6 +119,64 @@ func (h *PortfolioHandler) GetPortfolio(c *gin.Context) {
 	})
 }
 
+// portfolioValue values the user's pending royalty share, the royalties
+// already distributed to them and their investments in active and funded
+// campaigns
+func (h *PortfolioHandler) portfolioValue(address string) (*services.PortfolioValue, error) {
+	pending, err := services.PendingRoyaltyShare(h.db.DB, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var realized struct {
+		Total string
+	}
+	if err := h.db.Model(&models.RoyaltyDistribution{}).
+		Select("CAST(COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) AS CHAR) as total").
+		Where("beneficiary = ?", address).
+		Scan(&realized).Error; err != nil {
+		return nil, fmt.Errorf("failed to sum realized earnings: %w", err)
+	}
+	realizedWei, ok := new(big.Int).SetString(realized.Total, 10)
+	if !ok {
+		realizedWei = new(big.Int)
+	}
+
+	var rows []struct {
+		CampaignID   uint64
+		Invested     string
+		EstimatedROI float64
+		RiskScore    uint8
+	}
+	if err := h.db.Table("contributions").
+		Select(`campaigns.campaign_id,
+			CAST(SUM(CAST(contributions.amount AS DECIMAL(30,0))) AS CHAR) as invested,
+			campaigns.estimated_roi, campaigns.risk_score`).
+		Joins("JOIN campaigns ON campaigns.campaign_id = contributions.campaign_id AND campaigns.deleted_at IS NULL").
+		Where("contributions.contributor_address = ? AND campaigns.status IN ?", address, []string{"active", "successful"}).
+		Group("campaigns.campaign_id, campaigns.estimated_roi, campaigns.risk_score").
+		Order("campaigns.campaign_id ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load active investments: %w", err)
+	}
+
+	investments := make([]services.ActiveInvestment, 0, len(rows))
+	for _, row := range rows {
+		invested, ok := new(big.Int).SetString(row.Invested, 10)
+		if !ok || invested.Sign() <= 0 {
+			continue
+		}
+		investments = append(investments, services.ActiveInvestment{
+			CampaignID:   row.CampaignID,
+			Invested:     invested,
+			EstimatedROI: row.EstimatedROI,
+			RiskScore:    row.RiskScore,
+		})
+	}
+
+	return services.ComputePortfolioValue(pending, realizedWei, investments, services.ETHPriceUSD), nil
+}
+
 // GetGrowthStats returns growth statistics over time
 // GET /api/v1/portfolio/:address/growth?period=month
 func (h *PortfolioHandler) GetGrowthStats(c *gin.Context) {
@@ -299,3 +365,257 @@ func (h *PortfolioHandler) GetPoolsInvested(c *gin.Context) {
 		"total_invested": totalInvested.Total,
 	})
 }
+
+// GetDiversification returns how concentrated a user's investments are
+// by campaign, genre and creator
+// GET /api/v1/portfolio/:address/diversification
+func (h *PortfolioHandler) GetDiversification(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address parameter is required"})
+		return
+	}
+
+	type investmentRow struct {
+		CampaignID     uint64
+		Genre          string
+		CreatorAddress string
+		Amount         string
+	}
+
+	var rows []investmentRow
+	h.db.Table("contributions c").
+		Select("c.campaign_id, COALESCE(m.genre, '') as genre, camp.creator_address, c.amount").
+		Joins("JOIN campaigns camp ON c.campaign_id = camp.campaign_id").
+		Joins("JOIN music_metadata m ON camp.token_id = m.token_id").
+		Where("c.contributor_address = ?", address).
+		Scan(&rows)
+
+	byCampaign := make(map[string]*big.Int)
+	byGenre := make(map[string]*big.Int)
+	byCreator := make(map[string]*big.Int)
+	addTo := func(buckets map[string]*big.Int, key string, amount *big.Int) {
+		if buckets[key] == nil {
+			buckets[key] = new(big.Int)
+		}
+		buckets[key].Add(buckets[key], amount)
+	}
+
+	for _, row := range rows {
+		amount, ok := new(big.Int).SetString(row.Amount, 10)
+		if !ok {
+			continue
+		}
+		genre := row.Genre
+		if genre == "" {
+			genre = "Unknown"
+		}
+		addTo(byCampaign, fmt.Sprintf("%d", row.CampaignID), amount)
+		addTo(byGenre, genre, amount)
+		addTo(byCreator, row.CreatorAddress, amount)
+	}
+
+	campaignConc := metrics.Concentrate(byCampaign, 3)
+	genreConc := metrics.Concentrate(byGenre, 3)
+	creatorConc := metrics.Concentrate(byCreator, 3)
+
+	overall := (campaignConc.Score + genreConc.Score + creatorConc.Score) / 3
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":               address,
+		"total_investments":     len(rows),
+		"diversification_score": math.Round(overall*100) / 100,
+		"by_campaign":           campaignConc,
+		"by_genre":              genreConc,
+		"by_creator":            creatorConc,
+	})
+}
+
+// GetLockups returns the release schedule of a user's contributions: each
+// contribution unlocks its campaign's lockup period after it was made
+// GET /api/v1/portfolio/:address/lockups
+func (h *PortfolioHandler) GetLockups(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address parameter is required"})
+		return
+	}
+
+	type Lockup struct {
+		ContributionID uint      `json:"contribution_id"`
+		CampaignID     uint64    `json:"campaign_id"`
+		MusicTitle     string    `json:"music_title"`
+		Amount         string    `json:"amount"`
+		LockupPeriod   int       `json:"lockup_period"` // days
+		ContributedAt  time.Time `json:"contributed_at"`
+		CreatedAt      time.Time `json:"-"`
+		UnlockAt       time.Time `json:"unlock_at" gorm:"-"`
+		IsUnlocked     bool      `json:"is_unlocked" gorm:"-"`
+		DaysRemaining  int       `json:"days_remaining" gorm:"-"`
+	}
+
+	var lockups []Lockup
+	if err := h.db.Table("contributions").
+		Select(`contributions.id as contribution_id, contributions.campaign_id, contributions.amount,
+			contributions.contributed_at, contributions.created_at,
+			campaigns.lockup_period, COALESCE(music_metadata.title, '') as music_title`).
+		Joins("JOIN campaigns ON contributions.campaign_id = campaigns.campaign_id").
+		Joins("LEFT JOIN music_metadata ON campaigns.token_id = music_metadata.token_id").
+		Where("contributions.contributor_address = ?", address).
+		Scan(&lockups).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now().UTC()
+	locked := 0
+	for i := range lockups {
+		start := lockups[i].ContributedAt
+		if start.IsZero() {
+			start = lockups[i].CreatedAt
+		}
+		lockups[i].UnlockAt = start.UTC().AddDate(0, 0, lockups[i].LockupPeriod)
+		lockups[i].IsUnlocked = !now.Before(lockups[i].UnlockAt)
+		if !lockups[i].IsUnlocked {
+			lockups[i].DaysRemaining = int(math.Ceil(lockups[i].UnlockAt.Sub(now).Hours() / 24))
+			locked++
+		}
+	}
+
+	sort.SliceStable(lockups, func(i, j int) bool {
+		return lockups[i].UnlockAt.Before(lockups[j].UnlockAt)
+	})
+
+	if lockups == nil {
+		lockups = []Lockup{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":  address,
+		"lockups":  lockups,
+		"locked":   locked,
+		"unlocked": len(lockups) - locked,
+	})
+}
+
+// reinvestedPool is a campaign a user has put reinvested royalties into,
+// with how much of their stake came from reinvestment versus fresh funds
+type reinvestedPool struct {
+	CampaignID              uint64    `json:"campaign_id"`
+	MusicTitle              string    `json:"music_title"`
+	MusicArtist             string    `json:"music_artist"`
+	Status                  string    `json:"status"`
+	ReinvestedAmount        string    `json:"reinvested_amount"`
+	DirectAmount            string    `json:"direct_amount"`
+	TotalAmount             string    `json:"total_amount"`
+	ReinvestedPercent       float64   `json:"reinvested_percent"`
+	ReinvestedContributions int       `json:"reinvested_contributions"`
+	DirectContributions     int       `json:"direct_contributions"`
+	LastReinvestedAt        time.Time `json:"last_reinvested_at"`
+
+	reinvested, direct *big.Int
+}
+
+// GetReinvestedPools returns the pools a user has funded with reinvested
+// royalties, most recently reinvested first, with each pool's split between
+// reinvested and direct contributions
+// GET /api/v1/portfolio/:address/reinvested-pools
+func (h *PortfolioHandler) GetReinvestedPools(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address parameter is required"})
+		return
+	}
+
+	type contributionRow struct {
+		CampaignID    uint64
+		MusicTitle    string
+		MusicArtist   string
+		Status        string
+		Amount        string
+		Source        string
+		ContributedAt time.Time
+	}
+
+	// Every contribution to a pool that received at least one reinvestment
+	var rows []contributionRow
+	if err := h.db.WithContext(c.Request.Context()).Table("contributions c").
+		Select(`c.campaign_id, COALESCE(m.title, '') as music_title, COALESCE(m.artist, '') as music_artist,
+			camp.status, c.amount, c.source, c.contributed_at`).
+		Joins("JOIN campaigns camp ON c.campaign_id = camp.campaign_id").
+		Joins("LEFT JOIN music_metadata m ON camp.token_id = m.token_id").
+		Where("c.contributor_address = ?", address).
+		Where(`c.campaign_id IN (SELECT campaign_id FROM contributions
+			WHERE contributor_address = ? AND source = ?)`, address, services.ContributionSourceReinvestment).
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load reinvested pools"})
+		return
+	}
+
+	pools := make(map[uint64]*reinvestedPool)
+	totalReinvested, totalDirect := new(big.Int), new(big.Int)
+	for _, row := range rows {
+		amount, ok := new(big.Int).SetString(row.Amount, 10)
+		if !ok {
+			continue
+		}
+		pool := pools[row.CampaignID]
+		if pool == nil {
+			pool = &reinvestedPool{
+				CampaignID:  row.CampaignID,
+				MusicTitle:  row.MusicTitle,
+				MusicArtist: row.MusicArtist,
+				Status:      row.Status,
+				reinvested:  new(big.Int),
+				direct:      new(big.Int),
+			}
+			pools[row.CampaignID] = pool
+		}
+		if row.Source == services.ContributionSourceReinvestment {
+			pool.reinvested.Add(pool.reinvested, amount)
+			pool.ReinvestedContributions++
+			totalReinvested.Add(totalReinvested, amount)
+			if row.ContributedAt.After(pool.LastReinvestedAt) {
+				pool.LastReinvestedAt = row.ContributedAt
+			}
+		} else {
+			pool.direct.Add(pool.direct, amount)
+			pool.DirectContributions++
+			totalDirect.Add(totalDirect, amount)
+		}
+	}
+
+	data := make([]*reinvestedPool, 0, len(pools))
+	for _, pool := range pools {
+		total := new(big.Int).Add(pool.reinvested, pool.direct)
+		pool.ReinvestedAmount = pool.reinvested.String()
+		pool.DirectAmount = pool.direct.String()
+		pool.TotalAmount = total.String()
+		pool.ReinvestedPercent = reinvestedPercent(pool.reinvested, total)
+		data = append(data, pool)
+	}
+	sort.Slice(data, func(i, j int) bool {
+		if !data[i].LastReinvestedAt.Equal(data[j].LastReinvestedAt) {
+			return data[i].LastReinvestedAt.After(data[j].LastReinvestedAt)
+		}
+		return data[i].CampaignID < data[j].CampaignID
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":            address,
+		"pools":              data,
+		"total_pools":        len(data),
+		"total_reinvested":   totalReinvested.String(),
+		"total_direct":       totalDirect.String(),
+		"reinvested_percent": reinvestedPercent(totalReinvested, new(big.Int).Add(totalReinvested, totalDirect)),
+	})
+}
+
+// reinvestedPercent is reinvested as a percentage of total, to two decimals
+func reinvestedPercent(reinvested, total *big.Int) float64 {
+	if total.Sign() <= 0 {
+		return 0
+	}
+	ratio, _ := new(big.Rat).SetFrac(reinvested, total).Float64()
+	return math.Round(ratio*10000) / 100
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/internal/services"
+)
+
+func TestGetDiversification(t *testing.T) {
+	columns := []string{"campaign_id", "genre", "creator_address", "amount"}
+
+	tests := []struct {
+		name      string
+		rows      [][]driver.Value
+		wantScore float64
+	}{
+		{
+			name:      "no investments",
+			wantScore: 0,
+		},
+		{
+			name:      "single campaign is fully concentrated",
+			rows:      [][]driver.Value{{1, "Pop", "0xa", "1000"}},
+			wantScore: 0,
+		},
+		{
+			name: "several contributions to one campaign are still concentrated",
+			rows: [][]driver.Value{
+				{1, "Pop", "0xa", "600"},
+				{1, "Pop", "0xa", "400"},
+			},
+			wantScore: 0,
+		},
+		{
+			name: "spread across campaigns, genres and creators",
+			rows: [][]driver.Value{
+				{1, "Pop", "0xa", "250"},
+				{2, "Rock", "0xb", "250"},
+				{3, "Jazz", "0xc", "250"},
+				{4, "Folk", "0xd", "250"},
+			},
+			wantScore: 75,
+		},
+		{
+			name: "spread campaigns by one creator in one genre",
+			rows: [][]driver.Value{
+				{1, "Pop", "0xa", "500"},
+				{2, "Pop", "0xa", "500"},
+			},
+			wantScore: 16.67,
+		},
+		{
+			name: "unparsable amounts are skipped",
+			rows: [][]driver.Value{
+				{1, "Pop", "0xa", "1000"},
+				{2, "Rock", "0xb", "oops"},
+			},
+			wantScore: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows(columns)
+			for _, row := range tt.rows {
+				rows.AddRow(row...)
+			}
+			mock.ExpectQuery(`FROM contributions c JOIN campaigns camp .* WHERE c.contributor_address = \?`).
+				WithArgs("0xinvestor").
+				WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/portfolio/:address/diversification", NewPortfolioHandler(db).GetDiversification)
+
+			rec := record(router, http.MethodGet, "/portfolio/0xinvestor/diversification", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Score float64 `json:"diversification_score"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Score != tt.wantScore {
+				t.Errorf("diversification_score = %v, want %v", body.Score, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestGetLockups(t *testing.T) {
+	now := time.Now().UTC()
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("(?s)SELECT contributions.id as contribution_id.*FROM `contributions` JOIN campaigns .* WHERE contributions.contributor_address = \\?").
+		WithArgs("0xinvestor").
+		WillReturnRows(sqlmock.NewRows([]string{"contribution_id", "campaign_id", "amount", "contributed_at", "created_at", "lockup_period", "music_title"}).
+			AddRow(1, 10, "100", daysAgo(2), daysAgo(2), 90, "Long").     // unlocks in 88 days
+			AddRow(2, 11, "200", daysAgo(10), daysAgo(10), 30, "Soon").   // unlocks in 20 days
+			AddRow(3, 12, "300", daysAgo(100), daysAgo(100), 30, "Done"). // unlocked 70 days ago
+			AddRow(4, 13, "400", nil, daysAgo(40), 30, "Legacy"))         // no contribution date, unlocked 10 days ago
+
+	router := gin.New()
+	router.GET("/portfolio/:address/lockups", NewPortfolioHandler(db).GetLockups)
+
+	rec := record(router, http.MethodGet, "/portfolio/0xinvestor/lockups", "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+
+	var body struct {
+		Lockups []struct {
+			ContributionID uint      `json:"contribution_id"`
+			UnlockAt       time.Time `json:"unlock_at"`
+			IsUnlocked     bool      `json:"is_unlocked"`
+			DaysRemaining  int       `json:"days_remaining"`
+		} `json:"lockups"`
+		Locked   int `json:"locked"`
+		Unlocked int `json:"unlocked"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		id            uint
+		unlocked      bool
+		daysRemaining int
+	}{
+		{id: 3, unlocked: true},
+		{id: 4, unlocked: true},
+		{id: 2, daysRemaining: 20},
+		{id: 1, daysRemaining: 88},
+	}
+	if len(body.Lockups) != len(want) {
+		t.Fatalf("got %d lockups, want %d", len(body.Lockups), len(want))
+	}
+	for i, lockup := range body.Lockups {
+		if lockup.ContributionID != want[i].id || lockup.IsUnlocked != want[i].unlocked || lockup.DaysRemaining != want[i].daysRemaining {
+			t.Errorf("lockup %d = %+v, want contribution %d, unlocked %v, %d days remaining", i, lockup, want[i].id, want[i].unlocked, want[i].daysRemaining)
+		}
+	}
+	if body.Locked != 2 || body.Unlocked != 2 {
+		t.Errorf("locked = %d, unlocked = %d, want 2 and 2", body.Locked, body.Unlocked)
+	}
+}
+
+func TestGetReinvestedPools(t *testing.T) {
+	t1 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	t2 := t1.AddDate(0, 1, 0)
+
+	type pool struct {
+		CampaignID              uint64  `json:"campaign_id"`
+		ReinvestedAmount        string  `json:"reinvested_amount"`
+		DirectAmount            string  `json:"direct_amount"`
+		TotalAmount             string  `json:"total_amount"`
+		ReinvestedPercent       float64 `json:"reinvested_percent"`
+		ReinvestedContributions int     `json:"reinvested_contributions"`
+		DirectContributions     int     `json:"direct_contributions"`
+	}
+
+	tests := []struct {
+		name           string
+		rows           [][]driver.Value // campaign_id, amount, source, contributed_at
+		want           []pool
+		wantReinvested string
+		wantDirect     string
+		wantPercent    float64
+	}{
+		{
+			name: "reinvested and direct contributions are told apart",
+			rows: [][]driver.Value{
+				{1, "100", services.ContributionSourceDirect, t1},
+				{1, "200", services.ContributionSourceReinvestment, t1},
+				{2, "500", services.ContributionSourceReinvestment, t1},
+				{1, "100", services.ContributionSourceReinvestment, t2},
+			},
+			want: []pool{
+				{CampaignID: 1, ReinvestedAmount: "300", DirectAmount: "100", TotalAmount: "400", ReinvestedPercent: 75, ReinvestedContributions: 2, DirectContributions: 1},
+				{CampaignID: 2, ReinvestedAmount: "500", DirectAmount: "0", TotalAmount: "500", ReinvestedPercent: 100, ReinvestedContributions: 1},
+			},
+			wantReinvested: "800",
+			wantDirect:     "100",
+			wantPercent:    88.89,
+		},
+		{
+			name:           "no reinvestments",
+			want:           []pool{},
+			wantReinvested: "0",
+			wantDirect:     "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows([]string{"campaign_id", "music_title", "music_artist", "status", "amount", "source", "contributed_at"})
+			for _, r := range tt.rows {
+				rows.AddRow(r[0], "Song", "Artist", "active", r[1], r[2], r[3])
+			}
+			// Only pools with a reinvestment from the address are loaded, but
+			// with all of its contributions to them
+			mock.ExpectQuery("FROM contributions c JOIN campaigns camp .* WHERE c.contributor_address = \\? AND \\(c.campaign_id IN \\(SELECT campaign_id FROM contributions\\s+WHERE contributor_address = \\? AND source = \\?\\)\\)").
+				WithArgs("0xinvestor", "0xinvestor", services.ContributionSourceReinvestment).
+				WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/portfolio/:address/reinvested-pools", NewPortfolioHandler(db).GetReinvestedPools)
+
+			rec := record(router, http.MethodGet, "/portfolio/0xinvestor/reinvested-pools", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Pools           []pool  `json:"pools"`
+				TotalReinvested string  `json:"total_reinvested"`
+				TotalDirect     string  `json:"total_direct"`
+				Percent         float64 `json:"reinvested_percent"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(body.Pools, tt.want) {
+				t.Errorf("pools = %+v, want %+v", body.Pools, tt.want)
+			}
+			if body.TotalReinvested != tt.wantReinvested || body.TotalDirect != tt.wantDirect || body.Percent != tt.wantPercent {
+				t.Errorf("reinvested %s, direct %s (%v%%), want %s, %s (%v%%)", body.TotalReinvested, body.TotalDirect, body.Percent, tt.wantReinvested, tt.wantDirect, tt.wantPercent)
+			}
+		})
+	}
+}
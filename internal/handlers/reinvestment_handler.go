@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -35,6 +37,38 @@ func (h *ReinvestmentHandler) GetSuggestions(c *gin.Context) {
 	c.JSON(http.StatusOK, suggestions)
 }
 
+// DismissSuggestion handles POST /api/v1/reinvest/suggestions/:id/dismiss;
+// user_address defaults to the caller, and only admins may dismiss for others
+func (h *ReinvestmentHandler) DismissSuggestion(c *gin.Context) {
+	claims := authClaims(c)
+	userAddress := c.DefaultQuery("user_address", claims.Subject)
+	if !ownsAddress(claims, userAddress) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "token does not belong to this address"})
+		return
+	}
+
+	suggestionID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid suggestion ID"})
+		return
+	}
+
+	suggestion, err := h.reinvestmentService.DismissSuggestion(c.Request.Context(), uint(suggestionID), userAddress)
+	if err != nil {
+		if errors.Is(err, services.ErrSuggestionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Suggestion dismissed",
+		"suggestion": suggestion,
+	})
+}
+
 // QuickReinvest handles POST /api/v1/reinvest/quick
 func (h *ReinvestmentHandler) QuickReinvest(c *gin.Context) {
 	var req services.QuickReinvestRequest
@@ -45,7 +79,14 @@ func (h *ReinvestmentHandler) QuickReinvest(c *gin.Context) {
 
 	history, err := h.reinvestmentService.QuickReinvest(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch {
+		case errors.Is(err, services.ErrInvalidAmount):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInsufficientFunds):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -55,6 +96,39 @@ func (h *ReinvestmentHandler) QuickReinvest(c *gin.Context) {
 	})
 }
 
+// BatchReinvest handles POST /api/v1/reinvest/batch; the funds reinvested
+// must belong to the caller
+func (h *ReinvestmentHandler) BatchReinvest(c *gin.Context) {
+	var req services.BatchReinvestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !ownsAddress(authClaims(c), req.UserAddress) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "token does not belong to this address"})
+		return
+	}
+	if len(req.Items) > services.MaxBatchReinvestItems {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("at most %d items per batch", services.MaxBatchReinvestItems)})
+		return
+	}
+
+	result, err := h.reinvestmentService.BatchReinvest(c.Request.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidAmount):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInsufficientFunds):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // GetHistory handles GET /api/v1/reinvest/history
 func (h *ReinvestmentHandler) GetHistory(c *gin.Context) {
 	userAddress := c.Query("user_address")
@@ -63,14 +137,10 @@ func (h *ReinvestmentHandler) GetHistory(c *gin.Context) {
 		return
 	}
 
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
-
-	limit, _ := strconv.Atoi(limitStr)
-	offset, _ := strconv.Atoi(offsetStr)
-
-	if limit > 100 {
-		limit = 100
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	history, total, err := h.reinvestmentService.GetReinvestmentHistory(c.Request.Context(), userAddress, limit, offset)
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/internal/services"
+)
+
+func TestDismissSuggestion(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  func(t *testing.T) string
+		path   string
+		expect func(mock sqlmock.Sqlmock)
+		want   int
+	}{
+		{
+			name:  "own open suggestion",
+			token: func(t *testing.T) string { return bearer(t, testSecret, "0xuser", auth.RoleUser, time.Hour) },
+			path:  "/reinvest/suggestions/12/dismiss",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `reinvestment_suggestions` WHERE id = \\? AND user_address = \\?").
+					WithArgs(12, "0xuser").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_address", "is_actioned"}).AddRow(12, "0xuser", false))
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `reinvestment_suggestions` SET `is_actioned`=\\?").
+					WithArgs(true, sqlmock.AnyArg(), 12).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: http.StatusOK,
+		},
+		{
+			name:  "suggestion of another user",
+			token: func(t *testing.T) string { return bearer(t, testSecret, "0xuser", auth.RoleUser, time.Hour) },
+			path:  "/reinvest/suggestions/13/dismiss",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `reinvestment_suggestions` WHERE id = \\? AND user_address = \\?").
+					WithArgs(13, "0xuser").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name:  "naming another user's address",
+			token: func(t *testing.T) string { return bearer(t, testSecret, "0xuser", auth.RoleUser, time.Hour) },
+			path:  "/reinvest/suggestions/12/dismiss?user_address=0xother",
+			want:  http.StatusForbidden,
+		},
+		{
+			name:  "invalid suggestion ID",
+			token: func(t *testing.T) string { return bearer(t, testSecret, "0xuser", auth.RoleUser, time.Hour) },
+			path:  "/reinvest/suggestions/abc/dismiss",
+			want:  http.StatusBadRequest,
+		},
+		{
+			name:  "no token",
+			token: func(t *testing.T) string { return "" },
+			path:  "/reinvest/suggestions/12/dismiss",
+			want:  http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			if tt.expect != nil {
+				tt.expect(mock)
+			}
+			router := gin.New()
+			router.POST("/reinvest/suggestions/:id/dismiss", RequireAuth(testSecret), NewReinvestmentHandler(services.NewReinvestmentService(db)).DismissSuggestion)
+
+			if status := serve(router, http.MethodPost, tt.path, tt.token(t), ""); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchReinvestRejects(t *testing.T) {
+	item := `{"campaign_id":3,"amount":"100","from_source":"royalties"}`
+	tooMany := strings.TrimSuffix(strings.Repeat(item+",", services.MaxBatchReinvestItems+1), ",")
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "another user's funds", body: `{"user_address":"0xother","items":[` + item + `]}`, want: http.StatusForbidden},
+		{name: "too many items", body: `{"user_address":"0xuser","items":[` + tooMany + `]}`, want: http.StatusBadRequest},
+		{name: "no items", body: `{"user_address":"0xuser","items":[]}`, want: http.StatusBadRequest},
+		{name: "invalid amount", body: `{"user_address":"0xuser","items":[{"campaign_id":3,"amount":"abc","from_source":"royalties"}]}`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := dbtest.New(t)
+			router := gin.New()
+			router.POST("/reinvest/batch", RequireAuth(testSecret), NewReinvestmentHandler(services.NewReinvestmentService(db)).BatchReinvest)
+
+			if status := serve(router, http.MethodPost, "/reinvest/batch", bearer(t, testSecret, "0xuser", auth.RoleUser, time.Hour), tt.body); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
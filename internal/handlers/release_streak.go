@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// GetReleaseStreak returns the creator's longest and current runs of
+// consecutive weeks with a release, counted from active tracks' registration dates
+// GET /api/v1/users/:address/streak
+func (h *UserHandler) GetReleaseStreak(c *gin.Context) {
+	address := c.Param("address")
+
+	var releases []time.Time
+	if err := h.db.Model(&models.MusicMetadata{}).
+		Where("creator_address = ? AND is_active = ?", address, true).
+		Pluck("registered_at", &releases).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":        address,
+		"total_releases": len(releases),
+		"streak":         services.ComputeReleaseStreak(releases, time.Now()),
+	})
+}
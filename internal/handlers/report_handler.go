@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database"
+	"github.com/tunecent/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Reporters may file at most reportRateLimit reports per reportRateWindow
+const (
+	reportRateLimit  = 10
+	reportRateWindow = time.Hour
+)
+
+// reportReasons are the accepted report reasons
+var reportReasons = map[string]bool{
+	"copyright": true,
+	"spam":      true,
+	"offensive": true,
+	"fraud":     true,
+	"other":     true,
+}
+
+// ReportHandler handles content reports filed by users
+type ReportHandler struct {
+	db *database.DB
+}
+
+func NewReportHandler(db *database.DB) *ReportHandler {
+	return &ReportHandler{db: db}
+}
+
+// CreateReport files a report against a track or a campaign on behalf of the
+// authenticated caller. Reporters are rate limited and may not report the
+// same target again while their earlier report is open.
+// POST /api/v1/reports
+func (h *ReportHandler) CreateReport(c *gin.Context) {
+	var req struct {
+		TokenID    *uint64 `json:"token_id"`
+		CampaignID *uint64 `json:"campaign_id"`
+		Reason     string  `json:"reason" binding:"required"`
+		Details    string  `json:"details"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if (req.TokenID == nil) == (req.CampaignID == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "exactly one of token_id and campaign_id is required"})
+		return
+	}
+	if !reportReasons[req.Reason] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "reason must be one of copyright, spam, offensive, fraud, other"})
+		return
+	}
+
+	reporter := authClaims(c).Subject
+	db := h.db.WithContext(c.Request.Context())
+
+	var recent int64
+	if err := db.Model(&models.Report{}).
+		Where("reporter_address = ? AND created_at > ?", reporter, time.Now().Add(-reportRateWindow)).
+		Count(&recent).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if recent >= reportRateLimit {
+		c.Header("Retry-After", fmt.Sprintf("%d", int(reportRateWindow.Seconds())))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": fmt.Sprintf("at most %d reports per hour", reportRateLimit)})
+		return
+	}
+
+	target := db.Model(&models.Report{}).Where("reporter_address = ? AND status = ?", reporter, "open")
+	var err error
+	if req.TokenID != nil {
+		err = db.Where("token_id = ?", *req.TokenID).First(&models.MusicMetadata{}).Error
+		target = target.Where("token_id = ?", *req.TokenID)
+	} else {
+		err = db.Where("campaign_id = ?", *req.CampaignID).First(&models.Campaign{}).Error
+		target = target.Where("campaign_id = ?", *req.CampaignID)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Reported content not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var open int64
+	if err := target.Count(&open).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if open > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "You already have an open report for this content"})
+		return
+	}
+
+	report := &models.Report{
+		ReporterAddress: reporter,
+		TokenID:         req.TokenID,
+		CampaignID:      req.CampaignID,
+		Reason:          req.Reason,
+		Details:         req.Details,
+		Status:          "open",
+	}
+	if err := db.Create(report).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to file report"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/internal/models"
+)
+
+func TestCreateReport(t *testing.T) {
+	const reporter = "0xreporter"
+
+	tests := []struct {
+		name     string
+		body     string
+		campaign bool  // whether the report targets a campaign rather than a track
+		recent   int   // reports filed within the rate window
+		found    bool  // whether the reported content exists
+		open     int64 // open reports by the reporter for the same content
+		want     int
+	}{
+		{name: "report a track", body: `{"token_id":7,"reason":"copyright","details":"my song"}`, found: true, want: http.StatusCreated},
+		{name: "report a campaign", body: `{"campaign_id":3,"reason":"fraud"}`, campaign: true, found: true, want: http.StatusCreated},
+		{name: "rate limited", body: `{"token_id":7,"reason":"spam"}`, recent: reportRateLimit, want: http.StatusTooManyRequests},
+		{name: "unknown track", body: `{"token_id":7,"reason":"spam"}`, want: http.StatusNotFound},
+		{name: "already reported", body: `{"token_id":7,"reason":"spam"}`, found: true, open: 1, want: http.StatusConflict},
+		{name: "both targets", body: `{"token_id":7,"campaign_id":3,"reason":"spam"}`, want: http.StatusBadRequest},
+		{name: "no target", body: `{"reason":"spam"}`, want: http.StatusBadRequest},
+		{name: "unknown reason", body: `{"token_id":7,"reason":"boring"}`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			if tt.want != http.StatusBadRequest {
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `reports` WHERE reporter_address = \\? AND created_at > \\?").
+					WithArgs(reporter, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.recent))
+			}
+			if tt.want != http.StatusBadRequest && tt.want != http.StatusTooManyRequests {
+				table, column, id := "music_metadata", "token_id", 7
+				if tt.campaign {
+					table, column, id = "campaigns", "campaign_id", 3
+				}
+				rows := sqlmock.NewRows([]string{"id"})
+				if tt.found {
+					rows.AddRow(1)
+				}
+				mock.ExpectQuery("SELECT \\* FROM `" + table + "` WHERE " + column + " = \\?").
+					WithArgs(id).
+					WillReturnRows(rows)
+				if tt.found {
+					mock.ExpectQuery("SELECT count\\(\\*\\) FROM `reports` WHERE \\(reporter_address = \\? AND status = \\?\\) AND "+column+" = \\?").
+						WithArgs(reporter, "open", id).
+						WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.open))
+				}
+			}
+			if tt.want == http.StatusCreated {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `reports`").WillReturnResult(sqlmock.NewResult(5, 1))
+				mock.ExpectCommit()
+			}
+
+			router := gin.New()
+			router.POST("/reports", RequireAuth(testSecret), NewReportHandler(db).CreateReport)
+
+			rec := record(router, http.MethodPost, "/reports", bearer(t, testSecret, reporter, auth.RoleUser, time.Minute), tt.body)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusCreated {
+				return
+			}
+
+			var report models.Report
+			if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+				t.Fatal(err)
+			}
+			if report.ID != 5 || report.ReporterAddress != reporter || report.Status != "open" {
+				t.Errorf("report = %+v, want open report 5 by %s", report, reporter)
+			}
+		})
+	}
+}
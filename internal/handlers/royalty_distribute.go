@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// DistributePayment splits an undistributed payment between the creator and
+// campaign contributors and records the distributions. Users may only
+// distribute payments for their own tracks.
+// POST /api/v1/royalties/:paymentId/distribute
+func (h *RoyaltyHandler) DistributePayment(c *gin.Context) {
+	paymentID, err := strconv.ParseUint(c.Param("paymentId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid payment ID"})
+		return
+	}
+
+	// Users may only distribute payments for their own tracks; admin and
+	// service tokens may distribute any
+	if claims := authClaims(c); claims.Role == auth.RoleUser {
+		var creators []string
+		if err := h.db.WithContext(c.Request.Context()).Table("royalty_payments p").
+			Joins("JOIN music_metadata m ON m.token_id = p.token_id").
+			Where("p.id = ?", paymentID).
+			Pluck("m.creator_address", &creators).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if len(creators) == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": services.ErrPaymentNotFound.Error()})
+			return
+		}
+		if !strings.EqualFold(creators[0], claims.Subject) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "only the track's creator, an admin or a service can distribute its royalties"})
+			return
+		}
+	}
+
+	result, err := h.royaltyService.DistributePayment(c.Request.Context(), uint(paymentID))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPaymentNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrPaymentAlreadyDistributed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrInvalidPaymentAmount):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	distributed := new(big.Int)
+	for _, dist := range result.Distributions {
+		if amount, ok := new(big.Int).SetString(dist.Amount, 10); ok {
+			distributed.Add(distributed, amount)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":           "Payment distributed successfully",
+		"payment":           result.Payment,
+		"splits":            result.Splits,
+		"distributions":     result.Distributions,
+		"split_record":      result.SplitRecord,
+		"distributed_total": distributed.String(),
+		"reconciled":        distributed.String() == result.Payment.Amount,
+	})
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"math/big"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/pkg/mockdata"
+)
+
+// GetTierProgress returns the creator's tier, computed from their works and
+// royalty earnings with the same thresholds as tier assignment, and the works
+// or earnings still needed for the next tier
+// GET /api/v1/users/:address/tier-progress
+func (h *UserHandler) GetTierProgress(c *gin.Context) {
+	address := c.Param("address")
+
+	var totalWorks int64
+	if err := h.db.Model(&models.MusicMetadata{}).Where("creator_address = ?", address).Count(&totalWorks).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var earnings struct {
+		Total string
+	}
+	if err := h.db.Model(&models.RoyaltyDistribution{}).
+		Select("CAST(COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) AS CHAR) as total").
+		Where("beneficiary = ?", address).
+		Scan(&earnings).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	totalEarnings, ok := new(big.Int).SetString(earnings.Total, 10)
+	if !ok {
+		totalEarnings = new(big.Int)
+	}
+	earningsETH, _ := new(big.Rat).SetFrac(totalEarnings, big.NewInt(1e18)).Float64()
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":        address,
+		"total_earnings": totalEarnings.String(),
+		"progress":       mockdata.GenerateTierProgress(uint(totalWorks), earningsETH),
+		"tiers":          mockdata.CreatorTiers,
+	})
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+)
+
+// GetAvgTimeToFund returns how long the creator's successful campaigns took
+// on average from creation to the contribution that reached their goal.
+// Averages are null when no successful campaign can be measured.
+// GET /api/v1/users/:address/avg-time-to-fund
+func (h *UserHandler) GetAvgTimeToFund(c *gin.Context) {
+	address := c.Param("address")
+	db := h.db.WithContext(c.Request.Context())
+
+	var campaigns []models.Campaign
+	if err := db.Where("creator_address = ? AND status = ?", address, "successful").
+		Order("created_at ASC").
+		Find(&campaigns).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	contributions := make(map[uint64][]models.Contribution, len(campaigns))
+	if len(campaigns) > 0 {
+		campaignIDs := make([]uint64, len(campaigns))
+		for i, campaign := range campaigns {
+			campaignIDs[i] = campaign.CampaignID
+		}
+		var rows []models.Contribution
+		if err := db.Where("campaign_id IN ?", campaignIDs).Find(&rows).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		for _, row := range rows {
+			contributions[row.CampaignID] = append(contributions[row.CampaignID], row)
+		}
+	}
+
+	funded, unresolved := services.ComputeTimeToFund(campaigns, contributions)
+	if funded == nil {
+		funded = []services.TimeToFund{}
+	}
+	if unresolved == nil {
+		unresolved = []uint64{}
+	}
+
+	var avgSeconds *int64
+	var avgDays *float64
+	if len(funded) > 0 {
+		var total int64
+		for _, campaign := range funded {
+			total += campaign.Seconds
+		}
+		seconds := total / int64(len(funded))
+		days := math.Round(float64(seconds)/86400*100) / 100
+		avgSeconds, avgDays = &seconds, &days
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":              address,
+		"successful_campaigns": len(campaigns),
+		"measured_campaigns":   len(funded),
+		"average_seconds":      avgSeconds,
+		"average_days":         avgDays,
+		"campaigns":            funded,
+		"unresolved":           unresolved,
+	})
+}
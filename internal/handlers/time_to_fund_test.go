@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestGetAvgTimeToFund(t *testing.T) {
+	created := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		campaigns    bool
+		wantMeasured int
+		wantSeconds  int64 // averages are null when nothing is measured
+		wantDays     float64
+	}{
+		// One campaign fills in a day, the other in two; a third never
+		// reaches its goal in the indexed contributions
+		{name: "campaigns with known fill times", campaigns: true, wantMeasured: 2, wantSeconds: 36 * 3600, wantDays: 1.5},
+		{name: "no successful campaigns"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows([]string{"id", "campaign_id", "goal_amount", "status", "created_at"})
+			if tt.campaigns {
+				rows.AddRow(1, 1, "1000", "successful", created).
+					AddRow(2, 2, "500", "successful", created).
+					AddRow(3, 3, "800", "successful", created)
+			}
+			mock.ExpectQuery("SELECT \\* FROM `campaigns` WHERE \\(creator_address = \\? AND status = \\?\\) .*ORDER BY created_at ASC").
+				WithArgs("0xcreator", "successful").
+				WillReturnRows(rows)
+			if tt.campaigns {
+				mock.ExpectQuery("SELECT \\* FROM `contributions` WHERE campaign_id IN \\(\\?,\\?,\\?\\)").
+					WithArgs(1, 2, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "amount", "contributed_at"}).
+						AddRow(1, 1, "1000", created.Add(24*time.Hour)).
+						AddRow(2, 2, "200", created.Add(time.Hour)).
+						AddRow(3, 2, "300", created.Add(48*time.Hour)).
+						AddRow(4, 3, "100", created.Add(time.Hour)))
+			}
+
+			router := gin.New()
+			router.GET("/users/:address/avg-time-to-fund", NewUserHandler(db).GetAvgTimeToFund)
+
+			rec := record(router, http.MethodGet, "/users/0xcreator/avg-time-to-fund", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Successful int               `json:"successful_campaigns"`
+				Measured   int               `json:"measured_campaigns"`
+				Seconds    *int64            `json:"average_seconds"`
+				Days       *float64          `json:"average_days"`
+				Campaigns  []json.RawMessage `json:"campaigns"`
+				Unresolved []uint64          `json:"unresolved"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Measured != tt.wantMeasured || len(body.Campaigns) != tt.wantMeasured || body.Campaigns == nil || body.Unresolved == nil {
+				t.Errorf("measured %d of %d campaigns, want %d as non-null lists", body.Measured, body.Successful, tt.wantMeasured)
+			}
+			if tt.wantMeasured == 0 {
+				if body.Seconds != nil || body.Days != nil {
+					t.Errorf("average = %v seconds, %v days, want null", body.Seconds, body.Days)
+				}
+				return
+			}
+			if body.Seconds == nil || *body.Seconds != tt.wantSeconds || body.Days == nil || *body.Days != tt.wantDays {
+				t.Errorf("average = %v seconds, %v days, want %d, %v", body.Seconds, body.Days, tt.wantSeconds, tt.wantDays)
+			}
+			if len(body.Unresolved) != 1 || body.Unresolved[0] != 3 {
+				t.Errorf("unresolved = %v, want [3]", body.Unresolved)
+			}
+		})
+	}
+}
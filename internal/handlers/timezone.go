@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // embed the zone database so tz lookups work in minimal containers
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseTimezone reads the optional tz query parameter (an IANA zone name such
+// as "Asia/Jakarta"). Timestamps default to UTC when it is omitted.
+func parseTimezone(c *gin.Context) (*time.Location, error) {
+	tz := c.Query("tz")
+	if tz == "" || tz == "UTC" {
+		return time.UTC, nil
+	}
+
+	// "Local" would depend on the server's configuration, so it is not accepted
+	if tz == "Local" {
+		return nil, fmt.Errorf("invalid tz %q: use an IANA zone name", tz)
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tz %q: use an IANA zone name", tz)
+	}
+	return loc, nil
+}
+
+// formatTime renders t as RFC3339 in the given location
+func formatTime(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(time.RFC3339)
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimezone(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantZone string
+		wantErr  bool
+	}{
+		{name: "defaults to UTC", query: "", wantZone: "UTC"},
+		{name: "explicit UTC", query: "tz=UTC", wantZone: "UTC"},
+		{name: "named zone", query: "tz=Asia/Jakarta", wantZone: "Asia/Jakarta"},
+		{name: "server local zone is refused", query: "tz=Local", wantErr: true},
+		{name: "unknown zone", query: "tz=Mars/Olympus", wantErr: true},
+		{name: "offset is not a zone name", query: "tz=%2B07:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := parseTimezone(queryContext(tt.query))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTimezone() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && loc.String() != tt.wantZone {
+				t.Errorf("parseTimezone() = %s, want %s", loc, tt.wantZone)
+			}
+		})
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	instant := time.Date(2026, 3, 1, 20, 30, 0, 0, time.UTC)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		loc  *time.Location
+		want string
+	}{
+		{name: "UTC", t: instant, loc: time.UTC, want: "2026-03-01T20:30:00Z"},
+		{name: "UTC to a zone ahead crosses midnight", t: instant, loc: jakarta, want: "2026-03-02T03:30:00+07:00"},
+		{name: "UTC to a zone behind", t: instant, loc: newYork, want: "2026-03-01T15:30:00-05:00"},
+		{name: "zone back to UTC", t: time.Date(2026, 3, 2, 3, 30, 0, 0, jakarta), loc: time.UTC, want: "2026-03-01T20:30:00Z"},
+		{name: "daylight saving is applied", t: time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC), loc: newYork, want: "2026-07-01T08:00:00-04:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatTime(tt.t, tt.loc); got != tt.want {
+				t.Errorf("formatTime() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var txHashPattern = regexp.MustCompile(`^0x[0-9a-f]{64}$`)
+
+// parseTxHash reads the txHash path parameter, accepting it in any case and
+// returning it lower-cased the way hashes are stored
+func parseTxHash(c *gin.Context) (string, error) {
+	txHash := strings.ToLower(strings.TrimSpace(c.Param("txHash")))
+	if !txHashPattern.MatchString(txHash) {
+		return "", fmt.Errorf("invalid tx hash %q: expected 0x followed by 64 hex characters", c.Param("txHash"))
+	}
+	return txHash, nil
+}
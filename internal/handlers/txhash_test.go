@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/internal/services"
+)
+
+func TestParseTxHash(t *testing.T) {
+	valid := "0x" + strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name    string
+		param   string
+		want    string
+		wantErr bool
+	}{
+		{name: "lower case", param: valid, want: valid},
+		{name: "mixed case is lower-cased", param: "0X" + strings.Repeat("AB", 32), want: valid},
+		{name: "surrounding spaces", param: " " + valid + " ", want: valid},
+		{name: "missing prefix", param: strings.Repeat("ab", 32), wantErr: true},
+		{name: "too short", param: valid[:65], wantErr: true},
+		{name: "too long", param: valid + "0", wantErr: true},
+		{name: "not hex", param: "0x" + strings.Repeat("zz", 32), wantErr: true},
+		{name: "empty", param: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(nil)
+			c.Params = gin.Params{{Key: "txHash", Value: tt.param}}
+
+			got, err := parseTxHash(c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTxHash(%q) error = %v, wantErr %v", tt.param, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseTxHash(%q) = %q, want %q", tt.param, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTxHashLookups(t *testing.T) {
+	missing := "0x" + strings.Repeat("0F", 32)
+	stored := strings.ToLower(missing)
+
+	splitByTxHash := func(db *database.DB) gin.HandlerFunc {
+		return NewLedgerHandler(services.NewLedgerService(db)).GetSplitByTxHash
+	}
+	transactionAudit := func(db *database.DB) gin.HandlerFunc { return NewWalletHandler(db, nil).GetTransactionAudit }
+	verifyTransaction := func(db *database.DB) gin.HandlerFunc { return NewWalletHandler(db, nil).VerifyTransaction }
+
+	tests := []struct {
+		name    string
+		handler func(db *database.DB) gin.HandlerFunc
+		hash    string
+		table   string // looked up for a well-formed hash, none when nothing is queried
+		want    int
+	}{
+		{name: "split by malformed hash", handler: splitByTxHash, hash: "0x1234", want: http.StatusBadRequest},
+		{name: "split by missing hash", handler: splitByTxHash, hash: missing, table: "split_records", want: http.StatusNotFound},
+		{name: "audit by malformed hash", handler: transactionAudit, hash: "not-a-hash", want: http.StatusBadRequest},
+		{name: "audit by missing hash", handler: transactionAudit, hash: missing, table: "transactions", want: http.StatusNotFound},
+		{name: "verify malformed hash", handler: verifyTransaction, hash: "0x" + strings.Repeat("a", 63), want: http.StatusBadRequest},
+		{name: "verify well-formed hash", handler: verifyTransaction, hash: missing, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			if tt.table != "" {
+				// Lookups use the lower-cased hash the way it is stored
+				mock.ExpectQuery("SELECT \\* FROM `" + tt.table + "` WHERE tx_hash = \\?").
+					WithArgs(stored).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			}
+
+			router := gin.New()
+			router.GET("/tx/:txHash", tt.handler(db))
+
+			rec := record(router, http.MethodGet, "/tx/"+tt.hash, "", "")
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want == http.StatusOK && !strings.Contains(rec.Body.String(), stored) {
+				t.Errorf("body = %s, want the lower-cased hash %s", rec.Body, stored)
+			}
+		})
+	}
+}
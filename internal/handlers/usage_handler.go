@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/services"
+	"gorm.io/gorm"
+)
+
+type UsageHandler struct {
+	usageService *services.UsageService
+}
+
+func NewUsageHandler(usageService *services.UsageService) *UsageHandler {
+	return &UsageHandler{
+		usageService: usageService,
+	}
+}
+
+// IngestDetections handles POST /api/v1/usage/detect
+func (h *UsageHandler) IngestDetections(c *gin.Context) {
+	var req services.IngestDetectionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.Detections) > services.MaxUsageDetectionBatch {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("at most %d detections per batch", services.MaxUsageDetectionBatch)})
+		return
+	}
+
+	result, err := h.usageService.IngestDetections(c.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAmount) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetTrackUsage handles GET /api/v1/music/:tokenId/usage
+func (h *UsageHandler) GetTrackUsage(c *gin.Context) {
+	tokenID, err := strconv.ParseUint(c.Param("tokenId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	platform := c.Query("platform")
+	var paymentSent *bool
+	if value := c.Query("payment_sent"); value != "" {
+		sent, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "payment_sent must be true or false"})
+			return
+		}
+		paymentSent = &sent
+	}
+
+	detections, total, summary, err := h.usageService.ListDetections(c.Request.Context(), tokenID, platform, paymentSent, limit, offset)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Music not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token_id": tokenID,
+		"summary":  summary,
+		"data":     detections,
+		"total":    total,
+		"limit":    limit,
+		"offset":   offset,
+	})
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/internal/services"
+)
+
+func TestIngestDetectionsRejects(t *testing.T) {
+	detection := `{"token_id":7,"platform":"tiktok","content_id":"v1"}`
+
+	tests := []struct {
+		name string
+		role string
+		body string
+		want int
+	}{
+		{name: "user token", role: auth.RoleUser, body: `{"detections":[` + detection + `]}`, want: http.StatusForbidden},
+		{name: "empty batch", role: auth.RoleService, body: `{"detections":[]}`, want: http.StatusBadRequest},
+		{name: "detection without a content ID", role: auth.RoleService, body: `{"detections":[{"token_id":7,"platform":"tiktok"}]}`, want: http.StatusBadRequest},
+		{name: "payments without an amount", role: auth.RoleService, body: `{"detections":[` + detection + `],"create_payments":true}`, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := dbtest.New(t)
+			router := gin.New()
+			router.POST("/usage/detect", RequireRole(testSecret, auth.RoleService, auth.RoleAdmin), NewUsageHandler(services.NewUsageService(db)).IngestDetections)
+
+			if status := serve(router, http.MethodPost, "/usage/detect", bearer(t, testSecret, "scanner", tt.role, time.Minute), tt.body); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTrackUsage(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		filter string
+		args   []driver.Value
+	}{
+		{name: "all detections", filter: "WHERE token_id = \\?", args: []driver.Value{7}},
+		{name: "by platform", query: "?platform=TikTok", filter: "WHERE token_id = \\? AND platform = \\?", args: []driver.Value{7, "tiktok"}},
+		{name: "by payment status", query: "?payment_sent=false", filter: "WHERE token_id = \\? AND payment_sent = \\?", args: []driver.Value{7, false}},
+		{name: "by platform and payment status", query: "?platform=tiktok&payment_sent=true", filter: "WHERE token_id = \\? AND platform = \\? AND payment_sent = \\?", args: []driver.Value{7, "tiktok", true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT `id` FROM `music_metadata` WHERE token_id = \\?").
+				WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			// The summary covers every detection of the track, whatever the filters
+			mock.ExpectQuery("SELECT COUNT\\(\\*\\) as total_detections, .* FROM `usage_detections` WHERE token_id = \\?$").
+				WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"total_detections", "paid_detections"}).AddRow(5, 2))
+			mock.ExpectQuery("SELECT count\\(\\*\\) FROM `usage_detections` " + tt.filter + "$").
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mock.ExpectQuery("SELECT \\* FROM `usage_detections` " + tt.filter + " ORDER BY detected_at DESC, id DESC LIMIT 20").
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "platform", "content_id", "payment_sent"}).AddRow(3, 7, "tiktok", "v1", true))
+
+			router := gin.New()
+			router.GET("/music/:tokenId/usage", NewUsageHandler(services.NewUsageService(db)).GetTrackUsage)
+
+			rec := record(router, http.MethodGet, "/music/7/usage"+tt.query, "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Summary services.UsageSummary `json:"summary"`
+				Data    []json.RawMessage     `json:"data"`
+				Total   int64                 `json:"total"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Summary.TotalDetections != 5 || body.Summary.PaidDetections != 2 {
+				t.Errorf("summary = %+v, want 5 detections, 2 paid", body.Summary)
+			}
+			if len(body.Data) != 1 || body.Total != 1 {
+				t.Errorf("got %d detections of %d, want 1 of 1", len(body.Data), body.Total)
+			}
+		})
+	}
+}
+
+func TestGetTrackUsageErrors(t *testing.T) {
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("SELECT `id` FROM `music_metadata` WHERE token_id = \\?").
+		WithArgs(404).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	router := gin.New()
+	router.GET("/music/:tokenId/usage", NewUsageHandler(services.NewUsageService(db)).GetTrackUsage)
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "unknown track", path: "/music/404/usage", want: http.StatusNotFound},
+		{name: "invalid token ID", path: "/music/abc/usage", want: http.StatusBadRequest},
+		{name: "invalid payment status", path: "/music/7/usage?payment_sent=maybe", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := serve(router, http.MethodGet, tt.path, "", ""); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Export limits: rows are read exportBatchSize at a time and each section
+// stops after exportMaxRows so a single export stays bounded in size
+const (
+	exportBatchSize = 500
+	exportMaxRows   = 10000
+)
+
+// exportSection streams one table of the user's data as a JSON array
+type exportSection struct {
+	name  string
+	write func(w *exportWriter) (int, error)
+}
+
+type exportWriter struct {
+	c     *gin.Context
+	first bool
+}
+
+func (w *exportWriter) writeRaw(s string) error {
+	_, err := w.c.Writer.WriteString(s)
+	return err
+}
+
+func (w *exportWriter) writeValue(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.c.Writer.Write(data)
+	return err
+}
+
+func (w *exportWriter) writeField(name string, v interface{}) error {
+	prefix := ","
+	if w.first {
+		prefix = ""
+		w.first = false
+	}
+	if err := w.writeRaw(fmt.Sprintf("%s%q:", prefix, name)); err != nil {
+		return err
+	}
+	return w.writeValue(v)
+}
+
+// streamRows pages through query in id order, writing every row into an
+// already-opened JSON array. It returns how many rows were written.
+func streamRows[T any](w *exportWriter, query func() *gorm.DB) (int, error) {
+	written := 0
+	for offset := 0; offset < exportMaxRows; offset += exportBatchSize {
+		var batch []T
+		if err := query().Order("id ASC").Limit(exportBatchSize).Offset(offset).Find(&batch).Error; err != nil {
+			return written, err
+		}
+
+		for _, row := range batch {
+			if written > 0 {
+				if err := w.writeRaw(","); err != nil {
+					return written, err
+				}
+			}
+			if err := w.writeValue(row); err != nil {
+				return written, err
+			}
+			written++
+		}
+		w.c.Writer.Flush()
+
+		if len(batch) < exportBatchSize {
+			break
+		}
+	}
+	return written, nil
+}
+
+// ExportUserData streams every record tied to the address as a JSON download
+// GET /api/v1/users/:address/export
+func (h *UserHandler) ExportUserData(c *gin.Context) {
+	address := c.Param("address")
+
+	var user models.User
+	if err := h.db.Where("wallet_address = ?", address).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := h.db.WithContext(c.Request.Context())
+	byColumn := func(model interface{}, column string) func() *gorm.DB {
+		return func() *gorm.DB {
+			return db.Model(model).Where(column+" = ?", address)
+		}
+	}
+
+	sections := []exportSection{
+		{"music", func(w *exportWriter) (int, error) {
+			return streamRows[models.MusicMetadata](w, byColumn(&models.MusicMetadata{}, "creator_address"))
+		}},
+		{"campaigns", func(w *exportWriter) (int, error) {
+			return streamRows[models.Campaign](w, byColumn(&models.Campaign{}, "creator_address"))
+		}},
+		{"contributions", func(w *exportWriter) (int, error) {
+			return streamRows[models.Contribution](w, byColumn(&models.Contribution{}, "contributor_address"))
+		}},
+		{"transactions", func(w *exportWriter) (int, error) {
+			return streamRows[models.Transaction](w, byColumn(&models.Transaction{}, "user_address"))
+		}},
+		{"notifications", func(w *exportWriter) (int, error) {
+			return streamRows[models.Notification](w, byColumn(&models.Notification{}, "user_address"))
+		}},
+		{"ledger_entries", func(w *exportWriter) (int, error) {
+			return streamRows[models.RoyaltyDistribution](w, byColumn(&models.RoyaltyDistribution{}, "beneficiary"))
+		}},
+	}
+
+	filename := fmt.Sprintf("tunecent-export-%s.json", address)
+	c.Header("Content-Type", "application/json")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// Headers are already sent, so failures past this point can only end the stream early
+	w := &exportWriter{c: c, first: true}
+	err := func() error {
+		if err := w.writeRaw("{"); err != nil {
+			return err
+		}
+		if err := w.writeField("address", address); err != nil {
+			return err
+		}
+		if err := w.writeField("generated_at", time.Now().UTC()); err != nil {
+			return err
+		}
+		if err := w.writeField("profile", user); err != nil {
+			return err
+		}
+
+		counts := make(map[string]int, len(sections))
+		truncated := []string{}
+		for _, section := range sections {
+			if err := w.writeRaw(fmt.Sprintf(",%q:[", section.name)); err != nil {
+				return err
+			}
+			n, err := section.write(w)
+			if err != nil {
+				return fmt.Errorf("failed to export %s: %w", section.name, err)
+			}
+			if err := w.writeRaw("]"); err != nil {
+				return err
+			}
+			counts[section.name] = n
+			if n >= exportMaxRows {
+				truncated = append(truncated, section.name)
+			}
+		}
+
+		if err := w.writeField("counts", counts); err != nil {
+			return err
+		}
+		if err := w.writeField("truncated_sections", truncated); err != nil {
+			return err
+		}
+		return w.writeRaw("}")
+	}()
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "User export aborted", "address", address, "error", err)
+	}
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Timeline sources. Entries are ordered newest first by (timestamp, source
+// rank, id) so ties between an activity and a notification are stable.
+const (
+	timelineSourceActivity     = "activity"
+	timelineSourceNotification = "notification"
+)
+
+var timelineSourceRank = map[string]int{
+	timelineSourceNotification: 0,
+	timelineSourceActivity:     1,
+}
+
+// timelineEntry is one item of the merged activity/notification feed
+type timelineEntry struct {
+	Source    string      `json:"source"`
+	ID        uint        `json:"id"`
+	Timestamp time.Time   `json:"timestamp"`
+	Item      interface{} `json:"item"`
+}
+
+// timelineCursor marks the last entry of a page; the next page starts strictly after it
+type timelineCursor struct {
+	Timestamp time.Time
+	Source    string
+	ID        uint
+}
+
+func (c timelineCursor) encode() string {
+	raw := fmt.Sprintf("%d:%s:%d", c.Timestamp.UnixNano(), c.Source, c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeTimelineCursor(value string) (*timelineCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	if _, ok := timelineSourceRank[parts[1]]; !ok {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	id, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &timelineCursor{Timestamp: time.Unix(0, nanos).UTC(), Source: parts[1], ID: uint(id)}, nil
+}
+
+// after restricts a source's query to rows that sort after the cursor
+func (c *timelineCursor) after(query *gorm.DB, source string) *gorm.DB {
+	if c == nil {
+		return query
+	}
+	rank, cursorRank := timelineSourceRank[source], timelineSourceRank[c.Source]
+	switch {
+	case rank < cursorRank:
+		return query.Where("created_at <= ?", c.Timestamp)
+	case rank > cursorRank:
+		return query.Where("created_at < ?", c.Timestamp)
+	default:
+		return query.Where("(created_at < ? OR (created_at = ? AND id < ?))", c.Timestamp, c.Timestamp, c.ID)
+	}
+}
+
+// timelineBefore reports whether a sorts before b in the newest-first feed
+func timelineBefore(a, b timelineEntry) bool {
+	if !a.Timestamp.Equal(b.Timestamp) {
+		return a.Timestamp.After(b.Timestamp)
+	}
+	if a.Source != b.Source {
+		return timelineSourceRank[a.Source] > timelineSourceRank[b.Source]
+	}
+	return a.ID > b.ID
+}
+
+// GetTimeline merges the user's activities and notifications into one
+// newest-first feed. Each source is read in order and merged in Go; pass the
+// returned next_cursor to fetch the following page.
+// GET /api/v1/users/:address/timeline?limit=20&cursor=...
+func (h *UserHandler) GetTimeline(c *gin.Context) {
+	address := c.Param("address")
+
+	limit, err := parseNonNegativeQuery(c, "limit", defaultPageSize)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	var cursor *timelineCursor
+	if value := c.Query("cursor"); value != "" {
+		if cursor, err = decodeTimelineCursor(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	// One extra row per source tells us whether another page exists
+	db := h.db.WithContext(c.Request.Context())
+	var activities []models.Activity
+	if err := cursor.after(db.Where("user_address = ?", address), timelineSourceActivity).
+		Order("created_at DESC, id DESC").
+		Limit(limit + 1).
+		Find(&activities).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var notifications []models.Notification
+	if err := cursor.after(db.Where("user_address = ?", address), timelineSourceNotification).
+		Order("created_at DESC, id DESC").
+		Limit(limit + 1).
+		Find(&notifications).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	entries := make([]timelineEntry, 0, limit)
+	i, j := 0, 0
+	for len(entries) < limit && (i < len(activities) || j < len(notifications)) {
+		var next timelineEntry
+		var activity *timelineEntry
+		if i < len(activities) {
+			activity = &timelineEntry{Source: timelineSourceActivity, ID: activities[i].ID, Timestamp: activities[i].CreatedAt, Item: activities[i]}
+		}
+		var notification *timelineEntry
+		if j < len(notifications) {
+			notification = &timelineEntry{Source: timelineSourceNotification, ID: notifications[j].ID, Timestamp: notifications[j].CreatedAt, Item: notifications[j]}
+		}
+
+		if notification == nil || (activity != nil && timelineBefore(*activity, *notification)) {
+			next = *activity
+			i++
+		} else {
+			next = *notification
+			j++
+		}
+		entries = append(entries, next)
+	}
+
+	hasMore := i < len(activities) || j < len(notifications)
+	var nextCursor string
+	if hasMore && len(entries) > 0 {
+		last := entries[len(entries)-1]
+		nextCursor = timelineCursor{Timestamp: last.Timestamp, Source: last.Source, ID: last.ID}.encode()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":     address,
+		"data":        entries,
+		"limit":       limit,
+		"has_more":    hasMore,
+		"next_cursor": nextCursor,
+	})
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestTimelineCursor(t *testing.T) {
+	cursor := timelineCursor{Timestamp: time.Date(2026, 1, 1, 12, 0, 0, 500, time.UTC), Source: timelineSourceNotification, ID: 42}
+
+	got, err := decodeTimelineCursor(cursor.encode())
+	if err != nil {
+		t.Fatalf("decodeTimelineCursor() error = %v", err)
+	}
+	if !reflect.DeepEqual(*got, cursor) {
+		t.Errorf("decodeTimelineCursor() = %+v, want %+v", *got, cursor)
+	}
+
+	for _, value := range []string{"not base64!", "MTIz", "MTIzOnBvc3Q6NA", "YWJjOmFjdGl2aXR5OjQ"} {
+		if _, err := decodeTimelineCursor(value); err == nil {
+			t.Errorf("decodeTimelineCursor(%q) error = nil, want an error", value)
+		}
+	}
+}
+
+func TestGetTimeline(t *testing.T) {
+	t1 := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	t2, t3 := t1.Add(time.Hour), t1.Add(2*time.Hour)
+
+	type entry struct {
+		Source string `json:"source"`
+		ID     uint   `json:"id"`
+	}
+
+	tests := []struct {
+		name          string
+		query         string
+		activities    [][]interface{}
+		notifications [][]interface{}
+		want          []entry
+		wantMore      bool
+	}{
+		{
+			name:          "sources are interleaved newest first",
+			activities:    [][]interface{}{{3, t3}, {1, t1}},
+			notifications: [][]interface{}{{2, t2}},
+			want:          []entry{{"activity", 3}, {"notification", 2}, {"activity", 1}},
+		},
+		{
+			name:          "activities sort before notifications at the same time",
+			activities:    [][]interface{}{{5, t1}},
+			notifications: [][]interface{}{{6, t1}, {4, t1}},
+			want:          []entry{{"activity", 5}, {"notification", 6}, {"notification", 4}},
+		},
+		{
+			name:          "a full page reports more to come",
+			query:         "?limit=2",
+			activities:    [][]interface{}{{3, t3}, {1, t1}},
+			notifications: [][]interface{}{{2, t2}},
+			want:          []entry{{"activity", 3}, {"notification", 2}},
+			wantMore:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			activities := sqlmock.NewRows([]string{"id", "user_address", "created_at"})
+			for _, row := range tt.activities {
+				activities.AddRow(row[0], "0xuser", row[1])
+			}
+			mock.ExpectQuery("SELECT \\* FROM `activities` WHERE user_address = \\? ORDER BY created_at DESC, id DESC LIMIT").
+				WithArgs("0xuser").
+				WillReturnRows(activities)
+			notifications := sqlmock.NewRows([]string{"id", "user_address", "created_at"})
+			for _, row := range tt.notifications {
+				notifications.AddRow(row[0], "0xuser", row[1])
+			}
+			mock.ExpectQuery("SELECT \\* FROM `notifications` WHERE user_address = \\? ORDER BY created_at DESC, id DESC LIMIT").
+				WithArgs("0xuser").
+				WillReturnRows(notifications)
+
+			router := gin.New()
+			router.GET("/users/:address/timeline", NewUserHandler(db).GetTimeline)
+
+			rec := record(router, http.MethodGet, "/users/0xuser/timeline"+tt.query, "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Data       []entry `json:"data"`
+				HasMore    bool    `json:"has_more"`
+				NextCursor string  `json:"next_cursor"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(body.Data, tt.want) {
+				t.Errorf("data = %+v, want %+v", body.Data, tt.want)
+			}
+			if body.HasMore != tt.wantMore || (body.NextCursor != "") != tt.wantMore {
+				t.Errorf("has_more = %v, next_cursor = %q, want more = %v", body.HasMore, body.NextCursor, tt.wantMore)
+			}
+		})
+	}
+}
+
+func TestGetTimelineResumesAfterCursor(t *testing.T) {
+	at := time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC)
+	cursor := timelineCursor{Timestamp: at, Source: timelineSourceNotification, ID: 2}.encode()
+
+	db, mock := dbtest.New(t)
+	// Activities rank above notifications at the same time, so any activity
+	// at the cursor's time was already served
+	mock.ExpectQuery("SELECT \\* FROM `activities` WHERE user_address = \\? AND created_at < \\? ORDER BY created_at DESC, id DESC LIMIT").
+		WithArgs("0xuser", at).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, at.Add(-time.Hour)))
+	mock.ExpectQuery("SELECT \\* FROM `notifications` WHERE user_address = \\? AND \\(\\(created_at < \\? OR \\(created_at = \\? AND id < \\?\\)\\)\\) ORDER BY created_at DESC, id DESC LIMIT").
+		WithArgs("0xuser", at, at, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}))
+
+	router := gin.New()
+	router.GET("/users/:address/timeline", NewUserHandler(db).GetTimeline)
+
+	rec := record(router, http.MethodGet, "/users/0xuser/timeline?cursor="+cursor, "", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+
+	if status := serve(router, http.MethodGet, "/users/0xuser/timeline?cursor=bogus", "", ""); status != http.StatusBadRequest {
+		t.Errorf("invalid cursor status = %d, want %d", status, http.StatusBadRequest)
+	}
+}
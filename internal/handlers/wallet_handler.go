@@ -7,15 +7,17 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/tunecent/backend/internal/database"
 	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
 )
 
 // WalletHandler handles wallet and transaction endpoints
 type WalletHandler struct {
-	db *database.DB
+	db      *database.DB
+	savings *services.SavingsCalculator
 }
 
-func NewWalletHandler(db *database.DB) *WalletHandler {
-	return &WalletHandler{db: db}
+func NewWalletHandler(db *database.DB, savings *services.SavingsCalculator) *WalletHandler {
+	return &WalletHandler{db: db, savings: savings}
 }
 
 // GetTransactions returns transaction history for a wallet
@@ -28,8 +30,11 @@ func (h *WalletHandler) GetTransactions(c *gin.Context) {
 	}
 
 	// Query parameters
-	limit := c.DefaultQuery("limit", "20")
-	offset := c.DefaultQuery("offset", "0")
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	txType := c.Query("type") // Optional: filter by type
 
 	var transactions []models.Transaction
@@ -39,7 +44,7 @@ func (h *WalletHandler) GetTransactions(c *gin.Context) {
 		query = query.Where("type = ?", txType)
 	}
 
-	query.Limit(atoi(limit)).Offset(atoi(offset)).Find(&transactions)
+	query.Limit(limit).Offset(offset).Find(&transactions)
 
 	// Get total count
 	var total int64
@@ -52,8 +57,8 @@ func (h *WalletHandler) GetTransactions(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"transactions": transactions,
 		"total":        total,
-		"limit":        atoi(limit),
-		"offset":       atoi(offset),
+		"limit":        limit,
+		"offset":       offset,
 	})
 }
 
@@ -122,13 +127,18 @@ func (h *WalletHandler) SearchTransactions(c *gin.Context) {
 		return
 	}
 
-	limit := c.DefaultQuery("limit", "20")
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	var transactions []models.Transaction
 	h.db.Where("user_address = ? AND (description LIKE ? OR tx_hash LIKE ? OR type LIKE ?)",
 		address, "%"+query+"%", "%"+query+"%", "%"+query+"%").
 		Order("created_at DESC").
-		Limit(atoi(limit)).
+		Limit(limit).
+		Offset(offset).
 		Find(&transactions)
 
 	c.JSON(http.StatusOK, gin.H{
@@ -147,39 +157,47 @@ func (h *WalletHandler) GetSavings(c *gin.Context) {
 		return
 	}
 
-	// For PoC, calculate savings based on staking fee discount
-	// In production, track actual savings from fee reductions
-
-	// Get total royalties received
+	// Savings come from the staking discount on the platform fee, applied to
+	// royalties already distributed (saved) and still pending (estimated)
 	var totalRoyalties struct {
 		Total string
 	}
-	h.db.Model(&models.RoyaltyDistribution{}).
-		Select("COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) as total").
+	if err := h.db.Model(&models.RoyaltyDistribution{}).
+		Select("COALESCE(SUM(CAST(royalty_distributions.amount AS DECIMAL(30,0))), 0) as total").
 		Joins("JOIN music_metadata ON royalty_distributions.token_id = music_metadata.token_id").
 		Where("music_metadata.creator_address = ?", address).
-		Scan(&totalRoyalties)
+		Scan(&totalRoyalties).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
-	// Mock: Assume 10% platform fee normally, but user gets 10% discount from staking
-	// So they save 1% of total royalties
-	// For PoC, use simplified calculation
-	totalSavedUSD := 201.56 // Mock value from Figma design
-	estimatedSavingsUSD := 351.67 // Mock estimated future savings
+	var pendingRoyalties struct {
+		Total string
+	}
+	if err := h.db.Model(&models.RoyaltyPayment{}).
+		Select("COALESCE(SUM(CAST(royalty_payments.amount AS DECIMAL(30,0))), 0) as total").
+		Joins("JOIN music_metadata ON royalty_payments.token_id = music_metadata.token_id").
+		Where("music_metadata.creator_address = ? AND royalty_payments.is_distributed = ?", address, false).
+		Scan(&pendingRoyalties).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"address":            address,
-		"total_saved":        totalSavedUSD,
-		"estimated_savings":  estimatedSavingsUSD,
-		"savings_source":     "Staking fee discount (10%)",
+		"address":           address,
+		"total_royalties":   totalRoyalties.Total,
+		"total_saved":       h.savings.OnWei(totalRoyalties.Total),
+		"estimated_savings": h.savings.OnWei(pendingRoyalties.Total),
+		"savings_source":    h.savings.Source(),
 	})
 }
 
 // GetTransactionAudit returns detailed audit information for a transaction
 // GET /api/v1/audit/transaction/:txHash
 func (h *WalletHandler) GetTransactionAudit(c *gin.Context) {
-	txHash := c.Param("txHash")
-	if txHash == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "txHash parameter is required"})
+	txHash, err := parseTxHash(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -228,9 +246,9 @@ func (h *WalletHandler) GetTransactionAudit(c *gin.Context) {
 // VerifyTransaction verifies a transaction on-chain
 // GET /api/v1/audit/verify/:txHash
 func (h *WalletHandler) VerifyTransaction(c *gin.Context) {
-	txHash := c.Param("txHash")
-	if txHash == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "txHash parameter is required"})
+	txHash, err := parseTxHash(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -269,12 +287,3 @@ func (h *WalletHandler) GetBlockDetails(c *gin.Context) {
 		"explorer_url":  "https://etherscan.io/block/" + blockNumberStr,
 	})
 }
-
-// Helper function to convert string to int
-func atoi(s string) int {
-	i, err := strconv.Atoi(s)
-	if err != nil {
-		return 0
-	}
-	return i
-}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/internal/services"
+	"gorm.io/gorm/clause"
+)
+
+// watchedCampaign is a watchlist entry with its campaign's current funding
+// and deadline
+type watchedCampaign struct {
+	models.Campaign
+	MusicTitle        string    `json:"music_title"`
+	MusicArtist       string    `json:"music_artist"`
+	FundingPercentage float64   `json:"funding_percentage"`
+	RemainingAmount   string    `json:"remaining_amount"` // Wei as string
+	HoursLeft         float64   `json:"hours_left"`       // 0 once the deadline passed
+	WatchedAt         time.Time `json:"watched_at"`
+}
+
+// GetWatchlist returns the campaigns an address is watching, most recently
+// added first, with their current funding and time to deadline
+// GET /api/v1/portfolio/:address/watchlist
+func (h *PortfolioHandler) GetWatchlist(c *gin.Context) {
+	address := c.Param("address")
+
+	campaigns := []watchedCampaign{}
+	if err := h.db.WithContext(c.Request.Context()).Table("watchlists").
+		Select(`campaigns.*,
+			COALESCE(music_metadata.title, '') as music_title,
+			COALESCE(music_metadata.artist, '') as music_artist,
+			`+services.FundingPercentageSQL("campaigns")+` as funding_percentage,
+			watchlists.created_at as watched_at`).
+		Joins("JOIN campaigns ON watchlists.campaign_id = campaigns.campaign_id AND campaigns.deleted_at IS NULL").
+		Joins("LEFT JOIN music_metadata ON campaigns.token_id = music_metadata.token_id").
+		Where("watchlists.user_address = ?", address).
+		Order("watchlists.created_at DESC, watchlists.id DESC").
+		Scan(&campaigns).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	for i := range campaigns {
+		raised, ok := new(big.Int).SetString(campaigns[i].RaisedAmount, 10)
+		if !ok {
+			raised = new(big.Int)
+		}
+		goal, ok := new(big.Int).SetString(campaigns[i].GoalAmount, 10)
+		if !ok {
+			goal = new(big.Int)
+		}
+		remaining := new(big.Int).Sub(goal, raised)
+		if remaining.Sign() < 0 {
+			remaining.SetInt64(0)
+		}
+		campaigns[i].RemainingAmount = remaining.String()
+		campaigns[i].HoursLeft = max(math.Round(campaigns[i].Deadline.Sub(now).Hours()*100)/100, 0)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":   address,
+		"campaigns": campaigns,
+		"total":     len(campaigns),
+	})
+}
+
+// AddToWatchlist adds a campaign to the address's watchlist. Adding one
+// already watched changes nothing and answers 200 instead of 201.
+// POST /api/v1/portfolio/:address/watchlist
+func (h *PortfolioHandler) AddToWatchlist(c *gin.Context) {
+	address := c.Param("address")
+
+	var req struct {
+		CampaignID uint64 `json:"campaign_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	db := h.db.WithContext(c.Request.Context())
+	var exists int64
+	if err := db.Model(&models.Campaign{}).Where("campaign_id = ?", req.CampaignID).Count(&exists).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if exists == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign not found"})
+		return
+	}
+
+	// The unique (user_address, campaign_id) index turns a repeat add into a no-op
+	entry := models.Watchlist{UserAddress: address, CampaignID: req.CampaignID}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entry)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update watchlist"})
+		return
+	}
+
+	added := result.RowsAffected > 0
+	status := http.StatusCreated
+	if !added {
+		status = http.StatusOK
+	}
+	c.JSON(status, gin.H{
+		"address":     address,
+		"campaign_id": req.CampaignID,
+		"added":       added,
+	})
+}
+
+// RemoveFromWatchlist removes a campaign from the address's watchlist
+// DELETE /api/v1/portfolio/:address/watchlist?campaign_id=1
+func (h *PortfolioHandler) RemoveFromWatchlist(c *gin.Context) {
+	address := c.Param("address")
+
+	campaignID, err := strconv.ParseUint(c.Query("campaign_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "campaign_id query parameter is required"})
+		return
+	}
+
+	result := h.db.WithContext(c.Request.Context()).
+		Where("user_address = ? AND campaign_id = ?", address, campaignID).
+		Delete(&models.Watchlist{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update watchlist"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Campaign is not on the watchlist"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":     address,
+		"campaign_id": campaignID,
+		"removed":     true,
+	})
+}
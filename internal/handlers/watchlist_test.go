@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/auth"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestAddToWatchlist(t *testing.T) {
+	tests := []struct {
+		name      string
+		subject   string
+		body      string
+		exists    int     // campaigns matching the ID
+		inserted  []int64 // rows the insert affected; no insert when empty
+		want      int
+		wantAdded bool
+	}{
+		{name: "new campaign", subject: "0xinvestor", body: `{"campaign_id":4}`, exists: 1, inserted: []int64{1}, want: http.StatusCreated, wantAdded: true},
+		// The unique index leaves a repeat add with nothing to insert
+		{name: "duplicate add", subject: "0xinvestor", body: `{"campaign_id":4}`, exists: 1, inserted: []int64{0}, want: http.StatusOK},
+		{name: "unknown campaign", subject: "0xinvestor", body: `{"campaign_id":4}`, want: http.StatusNotFound},
+		{name: "missing campaign ID", subject: "0xinvestor", body: `{}`, want: http.StatusBadRequest},
+		{name: "another user's watchlist", subject: "0xother", body: `{"campaign_id":4}`, want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			if tt.want != http.StatusBadRequest && tt.want != http.StatusForbidden {
+				mock.ExpectQuery("SELECT count\\(\\*\\) FROM `campaigns` WHERE campaign_id = \\?").
+					WithArgs(4).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.exists))
+			}
+			for _, affected := range tt.inserted {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `watchlists` \\(`user_address`,`campaign_id`,`created_at`\\) VALUES \\(\\?,\\?,\\?\\) ON DUPLICATE KEY UPDATE").
+					WithArgs("0xinvestor", 4, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, affected))
+				mock.ExpectCommit()
+			}
+
+			router := gin.New()
+			router.POST("/portfolio/:address/watchlist", RequireOwner(testSecret), NewPortfolioHandler(db).AddToWatchlist)
+
+			rec := record(router, http.MethodPost, "/portfolio/0xinvestor/watchlist", bearer(t, testSecret, tt.subject, auth.RoleUser, time.Minute), tt.body)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.want != http.StatusCreated && tt.want != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				CampaignID uint64 `json:"campaign_id"`
+				Added      bool   `json:"added"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.CampaignID != 4 || body.Added != tt.wantAdded {
+				t.Errorf("campaign %d, added = %v, want 4, %v", body.CampaignID, body.Added, tt.wantAdded)
+			}
+		})
+	}
+}
+
+func TestRemoveFromWatchlist(t *testing.T) {
+	tests := []struct {
+		name     string
+		subject  string
+		query    string
+		affected []int64 // rows the delete affected; no delete when empty
+		want     int
+	}{
+		{name: "watched campaign", subject: "0xinvestor", query: "?campaign_id=4", affected: []int64{1}, want: http.StatusOK},
+		{name: "campaign not on the watchlist", subject: "0xinvestor", query: "?campaign_id=4", affected: []int64{0}, want: http.StatusNotFound},
+		{name: "missing campaign ID", subject: "0xinvestor", want: http.StatusBadRequest},
+		{name: "another user's watchlist", subject: "0xother", query: "?campaign_id=4", want: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			for _, affected := range tt.affected {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `watchlists` WHERE user_address = \\? AND campaign_id = \\?").
+					WithArgs("0xinvestor", 4).
+					WillReturnResult(sqlmock.NewResult(0, affected))
+				mock.ExpectCommit()
+			}
+
+			router := gin.New()
+			router.DELETE("/portfolio/:address/watchlist", RequireOwner(testSecret), NewPortfolioHandler(db).RemoveFromWatchlist)
+
+			if status := serve(router, http.MethodDelete, "/portfolio/0xinvestor/watchlist"+tt.query, bearer(t, testSecret, tt.subject, auth.RoleUser, time.Minute), ""); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetWatchlist(t *testing.T) {
+	now := time.Now()
+	type watched struct {
+		campaignID    uint64
+		goal, raised  string
+		funding       float64
+		deadline      time.Time
+		wantRemaining string
+		wantOpen      bool // deadline still ahead
+	}
+
+	tests := []struct {
+		name     string
+		watching []watched
+	}{
+		{name: "empty watchlist"},
+		{
+			name: "campaigns with current funding",
+			watching: []watched{
+				{campaignID: 4, goal: "1000", raised: "250", funding: 25, deadline: now.Add(48 * time.Hour), wantRemaining: "750", wantOpen: true},
+				{campaignID: 2, goal: "500", raised: "600", funding: 120, deadline: now.Add(-time.Hour), wantRemaining: "0"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows([]string{"campaign_id", "goal_amount", "raised_amount", "funding_percentage", "deadline", "music_title", "watched_at"})
+			for _, w := range tt.watching {
+				rows.AddRow(w.campaignID, w.goal, w.raised, w.funding, w.deadline, "Hit", now)
+			}
+			mock.ExpectQuery("SELECT campaigns.\\*, .* FROM `watchlists` JOIN campaigns .* WHERE watchlists.user_address = \\? ORDER BY watchlists.created_at DESC, watchlists.id DESC").
+				WithArgs("0xinvestor").
+				WillReturnRows(rows)
+
+			router := gin.New()
+			router.GET("/portfolio/:address/watchlist", NewPortfolioHandler(db).GetWatchlist)
+
+			rec := record(router, http.MethodGet, "/portfolio/0xinvestor/watchlist", "", "")
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+			}
+
+			var body struct {
+				Campaigns []struct {
+					CampaignID        uint64  `json:"campaign_id"`
+					FundingPercentage float64 `json:"funding_percentage"`
+					RemainingAmount   string  `json:"remaining_amount"`
+					HoursLeft         float64 `json:"hours_left"`
+				} `json:"campaigns"`
+				Total int `json:"total"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Campaigns == nil || len(body.Campaigns) != len(tt.watching) || body.Total != len(tt.watching) {
+				t.Fatalf("got %d campaigns (total %d), want %d as a list", len(body.Campaigns), body.Total, len(tt.watching))
+			}
+			for i, want := range tt.watching {
+				got := body.Campaigns[i]
+				if got.CampaignID != want.campaignID || got.RemainingAmount != want.wantRemaining || got.FundingPercentage != want.funding {
+					t.Errorf("campaign %d = %+v, want %d with %s remaining at %v%%", i, got, want.campaignID, want.wantRemaining, want.funding)
+				}
+				if open := got.HoursLeft > 0; open != want.wantOpen || got.HoursLeft < 0 {
+					t.Errorf("campaign %d hours_left = %v, want open = %v and never negative", i, got.HoursLeft, want.wantOpen)
+				}
+			}
+		})
+	}
+}
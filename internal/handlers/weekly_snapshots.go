@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tunecent/backend/internal/models"
+)
+
+// GetWeeklySnapshots returns a creator's weekly snapshots of cumulative
+// earnings, works, listeners, plays and views, newest week first
+// GET /api/v1/users/:address/weekly-snapshots?weeks=12
+func (h *UserHandler) GetWeeklySnapshots(c *gin.Context) {
+	address := c.Param("address")
+
+	weeks, err := parseNonNegativeQuery(c, "weeks", 12)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if weeks == 0 || weeks > 104 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "weeks must be between 1 and 104"})
+		return
+	}
+
+	snapshots := []models.CreatorWeeklySnapshot{}
+	if err := h.db.WithContext(c.Request.Context()).
+		Where("wallet_address = ?", address).
+		Order("week_start DESC").
+		Limit(weeks).
+		Find(&snapshots).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"address":   address,
+		"weeks":     weeks,
+		"snapshots": snapshots,
+	})
+}
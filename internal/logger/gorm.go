@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// slowQueryThreshold marks queries slower than this as warnings
+const slowQueryThreshold = 200 * time.Millisecond
+
+// GormLogger routes GORM logs through slog. Every statement is logged at
+// debug, slow statements at warn and failed statements at error, so the
+// slog handler's level decides what is actually written.
+type GormLogger struct {
+	logger *slog.Logger
+}
+
+// NewGormLogger wraps l for use as gorm.Config.Logger
+func NewGormLogger(l *slog.Logger) *GormLogger {
+	return &GormLogger{logger: l}
+}
+
+// LogMode is a no-op: the level is controlled by the slog handler
+func (g *GormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	return g
+}
+
+func (g *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	g.logger.InfoContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (g *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	g.logger.WarnContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (g *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	g.logger.ErrorContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (g *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+
+	level := slog.LevelDebug
+	msg := "sql query"
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		level, msg = slog.LevelError, "sql query failed"
+	case elapsed > slowQueryThreshold:
+		level, msg = slog.LevelWarn, "slow sql query"
+	}
+
+	if !g.logger.Enabled(ctx, level) {
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Duration("elapsed", elapsed),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	g.logger.LogAttrs(ctx, level, msg, attrs...)
+}
@@ -0,0 +1,171 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// decodeLines parses one JSON object per output line
+func decodeLines(t *testing.T, out *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("line %q is not JSON: %v", line, err)
+		}
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    slog.Level
+		wantErr bool
+	}{
+		{input: "", want: slog.LevelInfo},
+		{input: "debug", want: slog.LevelDebug},
+		{input: " INFO ", want: slog.LevelInfo},
+		{input: "warn", want: slog.LevelWarn},
+		{input: "warning", want: slog.LevelWarn},
+		{input: "error", want: slog.LevelError},
+		{input: "verbose", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLevel(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLevel(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNewJSONAtConfiguredLevel(t *testing.T) {
+	var out bytes.Buffer
+	l, err := New(&out, "warn", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l.Info("dropped")
+	l.Warn("kept", "campaign_id", 7)
+	l.Error("also kept")
+
+	lines := decodeLines(t, &out)
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %s", len(lines), out.String())
+	}
+	if lines[0]["level"] != "WARN" || lines[0]["msg"] != "kept" || lines[0]["campaign_id"] != float64(7) {
+		t.Errorf("first line = %v, want the warning with its fields", lines[0])
+	}
+	if lines[1]["level"] != "ERROR" {
+		t.Errorf("second line level = %v, want ERROR", lines[1]["level"])
+	}
+}
+
+func TestNewFormats(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{format: ""},
+		{format: "text"},
+		{format: "JSON"},
+		{format: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		if _, err := New(&bytes.Buffer{}, "info", tt.format); (err != nil) != tt.wantErr {
+			t.Errorf("New(format %q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+		}
+	}
+	if _, err := New(&bytes.Buffer{}, "loud", "json"); err == nil {
+		t.Error("New() with an unknown level succeeded, want an error")
+	}
+}
+
+func TestRequestLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		status    int
+		wantLevel string
+	}{
+		{status: http.StatusOK, wantLevel: "INFO"},
+		{status: http.StatusNotFound, wantLevel: "WARN"},
+		{status: http.StatusBadGateway, wantLevel: "ERROR"},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		l, _ := New(&out, "debug", "json")
+		router := gin.New()
+		router.Use(RequestLogger(l))
+		router.GET("/ping", func(c *gin.Context) { c.Status(tt.status) })
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+		lines := decodeLines(t, &out)
+		if len(lines) != 1 {
+			t.Fatalf("status %d: got %d lines, want 1", tt.status, len(lines))
+		}
+		if lines[0]["level"] != tt.wantLevel || lines[0]["status"] != float64(tt.status) || lines[0]["path"] != "/ping" {
+			t.Errorf("status %d: line = %v, want level %s", tt.status, lines[0], tt.wantLevel)
+		}
+	}
+}
+
+func TestGormLoggerTrace(t *testing.T) {
+	fc := func() (string, int64) { return "SELECT 1", 1 }
+
+	tests := []struct {
+		name      string
+		level     string
+		elapsed   time.Duration
+		err       error
+		wantLevel string // empty when nothing is written
+	}{
+		{name: "queries are debug", level: "debug", wantLevel: "DEBUG"},
+		{name: "queries are hidden at info", level: "info"},
+		{name: "slow queries warn", level: "info", elapsed: time.Second, wantLevel: "WARN"},
+		{name: "failed queries are errors", level: "warn", err: errors.New("deadlock"), wantLevel: "ERROR"},
+		{name: "record not found is not an error", level: "info", err: gorm.ErrRecordNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			l, _ := New(&out, tt.level, "json")
+			NewGormLogger(l).Trace(t.Context(), time.Now().Add(-tt.elapsed), fc, tt.err)
+
+			lines := decodeLines(t, &out)
+			if tt.wantLevel == "" {
+				if len(lines) != 0 {
+					t.Errorf("got %v, want no output", lines)
+				}
+				return
+			}
+			if len(lines) != 1 || lines[0]["level"] != tt.wantLevel || lines[0]["sql"] != "SELECT 1" {
+				t.Errorf("got %v, want one %s line with the SQL", lines, tt.wantLevel)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControl marks successful GET and HEAD responses as cacheable for
+// maxAge; a maxAge of 0 sends no-cache so clients always revalidate. Error
+// responses and responses whose handler already set Cache-Control are left
+// untouched.
+func CacheControl(maxAge time.Duration) gin.HandlerFunc {
+	value := "no-cache"
+	if maxAge > 0 {
+		value = fmt.Sprintf("public, max-age=%d", int64(maxAge/time.Second))
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, value: value}
+		c.Next()
+	}
+}
+
+// cacheControlWriter adds the Cache-Control header once the status is known
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	value string
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	if (code == http.StatusOK || code == http.StatusNotModified) && w.Header().Get("Cache-Control") == "" {
+		w.Header().Set("Cache-Control", w.value)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write covers handlers that write a body without setting a status first
+func (w *cacheControlWriter) Write(data []byte) (int, error) {
+	if !w.Written() {
+		w.WriteHeader(w.Status())
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheControlWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// ETag derives a strong entity tag from the parts identifying a resource
+// version, such as its ID and last update time
+func ETag(parts ...interface{}) string {
+	sum := sha256.Sum256([]byte(fmt.Sprint(parts...)))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// NotModified sets the ETag header and, when the request's If-None-Match
+// matches it, responds 304 Not Modified and returns true; the handler should
+// then return without writing a body
+func NotModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	if !etagMatches(c.GetHeader("If-None-Match"), etag) {
+		return false
+	}
+	c.AbortWithStatus(http.StatusNotModified)
+	return true
+}
+
+// etagMatches applies the weak comparison If-None-Match calls for
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
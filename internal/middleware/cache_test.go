@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCacheControl(t *testing.T) {
+	tests := []struct {
+		name   string
+		maxAge time.Duration
+		method string
+		status int
+		preset string
+		want   string
+	}{
+		{name: "cacheable response", maxAge: time.Hour, status: http.StatusOK, want: "public, max-age=3600"},
+		{name: "sub-second precision is dropped", maxAge: 90500 * time.Millisecond, status: http.StatusOK, want: "public, max-age=90"},
+		{name: "zero max age revalidates", status: http.StatusOK, want: "no-cache"},
+		{name: "not modified keeps the policy", maxAge: time.Minute, status: http.StatusNotModified, want: "public, max-age=60"},
+		{name: "HEAD request", maxAge: time.Minute, method: http.MethodHead, status: http.StatusOK, want: "public, max-age=60"},
+		{name: "error response is not cached", maxAge: time.Hour, status: http.StatusNotFound},
+		{name: "handler's own header wins", maxAge: time.Hour, status: http.StatusOK, preset: "private", want: "private"},
+		{name: "writes are not cached", maxAge: time.Hour, method: http.MethodPost, status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			router := gin.New()
+			router.Use(CacheControl(tt.maxAge))
+			router.Handle(method, "/", func(c *gin.Context) {
+				if tt.preset != "" {
+					c.Header("Cache-Control", tt.preset)
+				}
+				if tt.status == http.StatusNotModified {
+					c.Status(tt.status)
+					return
+				}
+				c.String(tt.status, "body")
+			})
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(method, "/", nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != tt.want {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestETag(t *testing.T) {
+	updated := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC).UnixNano()
+	etag := ETag("music", 7, updated)
+
+	if !strings.HasPrefix(etag, `"`) || !strings.HasSuffix(etag, `"`) || len(etag) != 34 {
+		t.Errorf("ETag() = %s, want a quoted 32-character hash", etag)
+	}
+	if again := ETag("music", 7, updated); again != etag {
+		t.Errorf("ETag() = %s then %s, want the same tag for the same version", etag, again)
+	}
+	if bumped := ETag("music", 7, updated+1); bumped == etag {
+		t.Errorf("ETag() = %s after an update, want a new tag", bumped)
+	}
+	if other := ETag("music", 8, updated); other == etag {
+		t.Errorf("ETag() = %s for another track, want a different tag", other)
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	etag := ETag("music", 7, 1)
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        int
+	}{
+		{name: "no validator", want: http.StatusOK},
+		{name: "matching tag", ifNoneMatch: etag, want: http.StatusNotModified},
+		{name: "weak form of the tag", ifNoneMatch: "W/" + etag, want: http.StatusNotModified},
+		{name: "tag in a list", ifNoneMatch: `"stale", ` + etag, want: http.StatusNotModified},
+		{name: "wildcard", ifNoneMatch: "*", want: http.StatusNotModified},
+		{name: "stale tag", ifNoneMatch: ETag("music", 7, 0), want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", func(c *gin.Context) {
+				if NotModified(c, etag) {
+					return
+				}
+				c.String(http.StatusOK, "body")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if got := rec.Header().Get("ETag"); got != etag {
+				t.Errorf("ETag = %q, want %q", got, etag)
+			}
+			if tt.want == http.StatusNotModified && rec.Body.Len() != 0 {
+				t.Errorf("304 body = %q, want none", rec.Body)
+			}
+		})
+	}
+}
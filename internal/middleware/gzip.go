@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Gzip compresses responses of at least minSize bytes for clients that send
+// Accept-Encoding: gzip. Smaller responses, responses that already carry a
+// Content-Encoding and already-compressed media types are passed through.
+func Gzip(level, minSize int) (gin.HandlerFunc, error) {
+	if _, err := gzip.NewWriterLevel(io.Discard, level); err != nil {
+		return nil, fmt.Errorf("invalid gzip level %d: %w", level, err)
+	}
+
+	pool := &sync.Pool{
+		New: func() interface{} {
+			gz, _ := gzip.NewWriterLevel(io.Discard, level)
+			return gz
+		},
+	}
+
+	return func(c *gin.Context) {
+		if !acceptsGzip(c.Request) || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &gzipWriter{ResponseWriter: c.Writer, pool: pool, minSize: minSize}
+		c.Writer = w
+		defer w.finish()
+
+		c.Next()
+	}, nil
+}
+
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		encoding, params, _ := strings.Cut(part, ";")
+		if strings.TrimSpace(encoding) != "gzip" {
+			continue
+		}
+		// gzip;q=0 explicitly refuses gzip
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// compressedTypes are content types that gain nothing from gzip
+var compressedTypes = []string{"image/", "audio/", "video/", "application/zip", "application/gzip", "application/x-gzip"}
+
+func isCompressible(header http.Header) bool {
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	contentType := header.Get("Content-Type")
+	for _, prefix := range compressedTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// gzipWriter buffers the body until it reaches minSize, then switches to
+// gzip. Bodies that never reach minSize are written uncompressed.
+type gzipWriter struct {
+	gin.ResponseWriter
+	pool    *sync.Pool
+	minSize int
+
+	buf         bytes.Buffer
+	gz          *gzip.Writer
+	passthrough bool
+}
+
+func (w *gzipWriter) Write(data []byte) (int, error) {
+	switch {
+	case w.gz != nil:
+		return w.gz.Write(data)
+	case w.passthrough:
+		return w.ResponseWriter.Write(data)
+	}
+
+	if !isCompressible(w.Header()) {
+		if err := w.startPassthrough(); err != nil {
+			return 0, err
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf.Write(data)
+	if w.buf.Len() >= w.minSize {
+		if err := w.startGzip(); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush is used by streaming handlers; compression starts right away so the
+// stream is not held back waiting for minSize bytes
+func (w *gzipWriter) Flush() {
+	if w.gz == nil && !w.passthrough {
+		var err error
+		if isCompressible(w.Header()) {
+			err = w.startGzip()
+		} else {
+			err = w.startPassthrough()
+		}
+		if err != nil {
+			return
+		}
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *gzipWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return w.ResponseWriter.Hijack()
+}
+
+func (w *gzipWriter) startGzip() error {
+	header := w.Header()
+	header.Set("Content-Encoding", "gzip")
+	header.Add("Vary", "Accept-Encoding")
+	header.Del("Content-Length")
+
+	gz := w.pool.Get().(*gzip.Writer)
+	gz.Reset(w.ResponseWriter)
+	w.gz = gz
+
+	_, err := w.gz.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+func (w *gzipWriter) startPassthrough() error {
+	w.passthrough = true
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+// finish writes any buffered small body as-is or closes the gzip stream
+func (w *gzipWriter) finish() {
+	if w.gz != nil {
+		w.gz.Close()
+		w.gz.Reset(io.Discard)
+		w.pool.Put(w.gz)
+		w.gz = nil
+		return
+	}
+	if w.buf.Len() > 0 {
+		w.ResponseWriter.Write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGzip(t *testing.T) {
+	const minSize = 1024
+	large := strings.Repeat("a", 4*minSize)
+	small := strings.Repeat("a", minSize-1)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		method         string
+		contentType    string
+		encoding       string
+		body           string
+		wantGzip       bool
+	}{
+		{name: "large response is gzipped", acceptEncoding: "gzip", body: large, wantGzip: true},
+		{name: "response at the threshold is gzipped", acceptEncoding: "gzip", body: strings.Repeat("a", minSize), wantGzip: true},
+		{name: "small response is not", acceptEncoding: "gzip", body: small},
+		{name: "gzip among other encodings", acceptEncoding: "br, gzip;q=0.8", body: large, wantGzip: true},
+		{name: "client without gzip", body: large},
+		{name: "client refusing gzip", acceptEncoding: "gzip;q=0", body: large},
+		{name: "already-compressed media type", acceptEncoding: "gzip", contentType: "image/png", body: large},
+		{name: "already-encoded response", acceptEncoding: "gzip", encoding: "br", body: large},
+		{name: "HEAD request", acceptEncoding: "gzip", method: http.MethodHead, body: large},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gz, err := Gzip(gzip.BestSpeed, minSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			router := gin.New()
+			router.Use(gz)
+			router.Handle(http.MethodGet, "/", func(c *gin.Context) {
+				if tt.encoding != "" {
+					c.Header("Content-Encoding", tt.encoding)
+				}
+				contentType := tt.contentType
+				if contentType == "" {
+					contentType = "application/json"
+				}
+				c.Data(http.StatusOK, contentType, []byte(tt.body))
+			})
+			router.Handle(http.MethodHead, "/", func(c *gin.Context) {
+				c.Data(http.StatusOK, "application/json", []byte(tt.body))
+			})
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "/", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			gzipped := rec.Header().Get("Content-Encoding") == "gzip"
+			if gzipped != tt.wantGzip {
+				t.Fatalf("Content-Encoding = %q, want gzip %v", rec.Header().Get("Content-Encoding"), tt.wantGzip)
+			}
+			if method == http.MethodHead {
+				return
+			}
+
+			var body io.Reader = rec.Body
+			if gzipped {
+				reader, err := gzip.NewReader(rec.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body = reader
+			}
+			got, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.body {
+				t.Errorf("body is %d bytes, want the original %d", len(got), len(tt.body))
+			}
+		})
+	}
+}
+
+func TestGzipRejectsInvalidLevel(t *testing.T) {
+	if _, err := Gzip(42, 1024); err == nil {
+		t.Error("Gzip(42) error = nil, want an invalid level error")
+	}
+}
@@ -42,6 +42,8 @@ type MusicMetadata struct {
 	AudioFileURL      string         `json:"audio_file_url,omitempty"`
 	CoverImageURL     string         `json:"cover_image_url,omitempty"`
 	Duration          int            `json:"duration,omitempty"` // in seconds
+	ISRC              string         `gorm:"column:isrc;size:12" json:"isrc,omitempty"`
+	Explicit          *bool          `json:"explicit"` // nil = not declared
 	IsActive          bool           `gorm:"default:true" json:"is_active"`
 	TxHash            string         `json:"tx_hash,omitempty"`
 	RegisteredAt      time.Time      `json:"registered_at"`
@@ -51,6 +53,11 @@ type MusicMetadata struct {
 	ListenerCount     uint64         `gorm:"default:0" json:"listener_count"`
 	ViralScore        float64        `gorm:"type:decimal(5,2);default:0" json:"viral_score"`
 	TrendingRank      int            `gorm:"default:0" json:"trending_rank"` // 0 = not trending
+	// Acoustic features extracted at registration
+	Tempo             float64        `gorm:"type:decimal(6,2);default:0" json:"tempo"` // BPM
+	MusicalKey        string         `json:"musical_key,omitempty"`
+	Loudness          float64        `gorm:"type:decimal(6,2);default:0" json:"loudness"` // dB
+	SampleRate        int            `gorm:"default:0" json:"sample_rate"` // Hz
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
 	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
@@ -73,6 +80,7 @@ type Campaign struct {
 	// PoC additions for pool stats and trending
 	RiskScore         uint8          `gorm:"default:50" json:"risk_score"` // 0-100, lower = safer
 	IsTrending        bool           `gorm:"default:false" json:"is_trending"`
+	TrendingOverride  *bool          `json:"trending_override"` // nil = set by scoring job
 	EstimatedROI      float64        `gorm:"type:decimal(10,2);default:150" json:"estimated_roi"`
 	ContributorCount  uint           `gorm:"default:0" json:"contributor_count"`
 	CreatedAt         time.Time      `json:"created_at"`
@@ -88,6 +96,7 @@ type Contribution struct {
 	Amount            string         `gorm:"not null" json:"amount"` // Wei as string
 	SharePercentage   float64        `json:"share_percentage"`
 	TxHash            string         `json:"tx_hash,omitempty"`
+	Source            string         `gorm:"size:16;not null;default:'direct';index" json:"source"` // direct or reinvestment
 	ContributedAt     time.Time      `json:"contributed_at"`
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
@@ -122,15 +131,15 @@ type RoyaltyDistribution struct {
 
 // UsageDetection stores detected music usage events (mock for PoC)
 type UsageDetection struct {
-	ID           uint      `gorm:"primarykey" json:"id"`
-	TokenID      uint64    `gorm:"not null;index" json:"token_id"`
-	Platform     string    `gorm:"not null" json:"platform"`
-	ContentID    string    `json:"content_id,omitempty"` // e.g., TikTok video ID
-	ContentURL   string    `json:"content_url,omitempty"`
-	DetectedAt   time.Time `json:"detected_at"`
-	PaymentSent  bool      `gorm:"default:false" json:"payment_sent"`
-	PaymentTxHash string   `json:"payment_tx_hash,omitempty"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID            uint      `gorm:"primarykey" json:"id"`
+	TokenID       uint64    `gorm:"not null;index" json:"token_id"`
+	Platform      string    `gorm:"size:64;not null;uniqueIndex:idx_usage_platform_content,priority:1" json:"platform"`
+	ContentID     string    `gorm:"size:191;uniqueIndex:idx_usage_platform_content,priority:2" json:"content_id,omitempty"` // e.g., TikTok video ID
+	ContentURL    string    `json:"content_url,omitempty"`
+	DetectedAt    time.Time `json:"detected_at"`
+	PaymentSent   bool      `gorm:"default:false" json:"payment_sent"`
+	PaymentTxHash string    `json:"payment_tx_hash,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 // Analytics stores aggregated analytics data
@@ -169,6 +178,31 @@ type Transaction struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// ContributionAdjustment records a manual partial refund an admin made
+// against a contributor's contributions to a campaign, e.g. to settle a
+// dispute. Amounts are wei; previous and new amounts are the contributor's
+// campaign total around the adjustment.
+type ContributionAdjustment struct {
+	ID                 uint      `gorm:"primarykey" json:"id"`
+	CampaignID         uint64    `gorm:"not null;index" json:"campaign_id"`
+	ContributorAddress string    `gorm:"size:191;not null;index" json:"contributor_address"`
+	Amount             string    `gorm:"not null" json:"amount"`
+	Reason             string    `gorm:"type:text;not null" json:"reason"`
+	PreviousAmount     string    `gorm:"not null" json:"previous_amount"`
+	NewAmount          string    `gorm:"not null" json:"new_amount"`
+	AdjustedBy         string    `gorm:"size:191;not null" json:"adjusted_by"`
+	TransactionID      uint      `gorm:"not null" json:"transaction_id"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// Watchlist is a campaign an investor saved to keep an eye on
+type Watchlist struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	UserAddress string    `gorm:"size:191;not null;uniqueIndex:idx_watchlists_user_campaign" json:"user_address"`
+	CampaignID  uint64    `gorm:"not null;uniqueIndex:idx_watchlists_user_campaign;index" json:"campaign_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 // Activity represents a user activity feed entry
 type Activity struct {
 	ID          uint      `gorm:"primarykey" json:"id"`
@@ -208,18 +242,30 @@ type PlatformDistribution struct {
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+// DistributionEvent records one status change of a track's distribution,
+// either of the submission (Platform empty) or of a single platform
+type DistributionEvent struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	TokenID    uint64    `gorm:"not null;index:idx_distribution_events_token_created" json:"token_id"`
+	Platform   string    `gorm:"size:64" json:"platform,omitempty"`
+	FromStatus string    `gorm:"size:32" json:"from_status,omitempty"` // empty when the record was created
+	ToStatus   string    `gorm:"size:32;not null" json:"to_status"`
+	CreatedAt  time.Time `gorm:"index:idx_distribution_events_token_created" json:"created_at"`
+}
+
 // Notification represents user notifications
 type Notification struct {
-	ID          uint      `gorm:"primarykey" json:"id"`
-	UserAddress string    `gorm:"not null;index" json:"user_address"`
-	Type        string    `gorm:"not null" json:"type"` // payment, contribution, milestone, alert
-	Title       string    `gorm:"not null" json:"title"`
-	Message     string    `gorm:"type:text" json:"message"`
-	IsRead      bool      `gorm:"default:false" json:"is_read"`
-	RelatedID   uint64    `json:"related_id,omitempty"` // token_id, campaign_id, etc.
-	TxHash      string    `json:"tx_hash,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID            uint      `gorm:"primarykey" json:"id"`
+	UserAddress   string    `gorm:"not null;index" json:"user_address"`
+	Type          string    `gorm:"not null" json:"type"` // payment, contribution, milestone, alert
+	Title         string    `gorm:"not null" json:"title"`
+	Message       string    `gorm:"type:text" json:"message"`
+	IsRead        bool      `gorm:"default:false" json:"is_read"`
+	RelatedID     uint64    `json:"related_id,omitempty"` // token_id, campaign_id, etc.
+	TxHash        string    `json:"tx_hash,omitempty"`
+	OutboxEventID *uint     `gorm:"uniqueIndex" json:"-"` // set when delivered from the outbox, so redelivery is a no-op
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 // NotificationPreference stores user notification preferences
@@ -235,6 +281,16 @@ type NotificationPreference struct {
 	UpdatedAt            time.Time `json:"updated_at"`
 }
 
+// PreferenceChangeLog records one notification preference a user changed
+type PreferenceChangeLog struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	UserAddress string    `gorm:"size:191;not null;index:idx_preference_changes_user_changed" json:"user_address"`
+	Field       string    `gorm:"size:64;not null" json:"field"`
+	OldValue    bool      `json:"old_value"`
+	NewValue    bool      `json:"new_value"`
+	ChangedAt   time.Time `gorm:"not null;index:idx_preference_changes_user_changed" json:"changed_at"`
+}
+
 // SplitRecord tracks royalty split records for audit
 type SplitRecord struct {
 	ID             uint      `gorm:"primarykey" json:"id"`
@@ -272,3 +328,74 @@ type ReinvestmentHistory struct {
 	SuggestionID    *uint     `json:"suggestion_id,omitempty"`
 	CreatedAt       time.Time `json:"created_at"`
 }
+
+// RankSnapshot records a creator's leaderboard position at a point in time
+type RankSnapshot struct {
+	ID            uint      `gorm:"primarykey" json:"id"`
+	WalletAddress string    `gorm:"not null;index:idx_rank_snapshots_address_time" json:"wallet_address"`
+	Rank          int       `gorm:"not null" json:"rank"`
+	Score         float64   `gorm:"type:decimal(20,2)" json:"score"`
+	SnapshotAt    time.Time `gorm:"not null;index:idx_rank_snapshots_address_time" json:"snapshot_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// CreatorWeeklySnapshot records a creator's cumulative totals for one week,
+// starting Monday 00:00 UTC. The snapshot job keeps the current week's row
+// up to date, so a finished week holds its closing totals.
+type CreatorWeeklySnapshot struct {
+	ID             uint      `gorm:"primarykey" json:"id"`
+	WalletAddress  string    `gorm:"not null;uniqueIndex:idx_creator_weekly_snapshots_week" json:"wallet_address"`
+	WeekStart      time.Time `gorm:"not null;uniqueIndex:idx_creator_weekly_snapshots_week" json:"week_start"`
+	TotalEarnings  string    `gorm:"default:'0'" json:"total_earnings"` // Wei as string
+	TotalWorks     uint      `gorm:"default:0" json:"total_works"`
+	TotalListeners uint64    `gorm:"default:0" json:"total_listeners"`
+	TotalPlays     uint64    `gorm:"default:0" json:"total_plays"`
+	TotalViews     uint64    `gorm:"default:0" json:"total_views"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// OutboxEvent is a notification or webhook recorded in the same transaction as
+// the change that caused it and delivered afterwards by the outbox worker
+type OutboxEvent struct {
+	ID            uint       `gorm:"primarykey" json:"id"`
+	EventKey      string     `gorm:"uniqueIndex;size:191;not null" json:"event_key"` // idempotency key, also sent to webhook receivers
+	Channel       string     `gorm:"not null" json:"channel"`                        // notification, webhook
+	EventType     string     `gorm:"not null" json:"event_type"`
+	Payload       string     `gorm:"type:text;not null" json:"payload"` // JSON
+	Status        string     `gorm:"type:enum('pending','done','failed','skipped');default:'pending';index:idx_outbox_events_status_next" json:"status"`
+	Attempts      int        `gorm:"default:0" json:"attempts"`
+	Replays       int        `gorm:"not null;default:0" json:"replays"` // manual webhook replays, capped apart from attempts
+	LastError     string     `gorm:"type:text" json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_outbox_events_status_next" json:"next_attempt_at"`
+	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// StorageUsage tracks the audio bytes a creator has uploaded, for the storage quota
+type StorageUsage struct {
+	ID             uint      `gorm:"primarykey" json:"id"`
+	CreatorAddress string    `gorm:"uniqueIndex;size:191;not null" json:"creator_address"`
+	UsedBytes      int64     `gorm:"not null;default:0" json:"used_bytes"`
+	UploadCount    uint      `gorm:"not null;default:0" json:"upload_count"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Report is a user's complaint about a track or campaign, reviewed by admins.
+// Exactly one of TokenID and CampaignID is set.
+type Report struct {
+	ID              uint       `gorm:"primarykey" json:"id"`
+	ReporterAddress string     `gorm:"size:191;not null;index:idx_reports_reporter_created" json:"reporter_address"`
+	TokenID         *uint64    `gorm:"index" json:"token_id,omitempty"`
+	CampaignID      *uint64    `gorm:"index" json:"campaign_id,omitempty"`
+	Reason          string     `gorm:"size:32;not null" json:"reason"` // copyright, spam, offensive, fraud, other
+	Details         string     `gorm:"type:text" json:"details,omitempty"`
+	Status          string     `gorm:"type:enum('open','dismissed','actioned');default:'open';index" json:"status"`
+	ResolvedBy      string     `json:"resolved_by,omitempty"`
+	ResolutionNote  string     `gorm:"type:text" json:"resolution_note,omitempty"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt       time.Time  `gorm:"index:idx_reports_reporter_created" json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
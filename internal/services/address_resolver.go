@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrENSNotConfigured is returned when an ENS name is given but no blockchain client is available
+var ErrENSNotConfigured = errors.New("ENS resolution unavailable: blockchain client not configured")
+
+// ENSResolver resolves ENS names to addresses (implemented by blockchain.Client)
+type ENSResolver interface {
+	ResolveENS(ctx context.Context, name string) (common.Address, error)
+}
+
+// AddressResolver turns user-supplied address inputs into hex addresses,
+// resolving ENS names through the blockchain client and caching the result
+type AddressResolver struct {
+	ens ENSResolver
+	ttl time.Duration
+	now func() time.Time
+
+	mu    sync.RWMutex
+	cache map[string]resolvedAddress
+}
+
+type resolvedAddress struct {
+	address   string
+	expiresAt time.Time
+}
+
+// NewAddressResolver creates a resolver; ens may be nil when blockchain is not configured
+func NewAddressResolver(ens ENSResolver, ttl time.Duration) *AddressResolver {
+	return &AddressResolver{
+		ens:   ens,
+		ttl:   ttl,
+		now:   time.Now,
+		cache: make(map[string]resolvedAddress),
+	}
+}
+
+// IsENSName reports whether the input looks like an ENS name rather than a hex address
+func IsENSName(input string) bool {
+	return strings.HasSuffix(strings.ToLower(strings.TrimSpace(input)), ".eth")
+}
+
+// Resolve returns the hex address for input. Non-ENS inputs are returned unchanged.
+func (r *AddressResolver) Resolve(ctx context.Context, input string) (string, error) {
+	if !IsENSName(input) {
+		return input, nil
+	}
+	if r.ens == nil {
+		return "", ErrENSNotConfigured
+	}
+
+	name := strings.ToLower(strings.TrimSpace(input))
+
+	r.mu.RLock()
+	entry, ok := r.cache[name]
+	r.mu.RUnlock()
+	if ok && r.now().Before(entry.expiresAt) {
+		return entry.address, nil
+	}
+
+	addr, err := r.ens.ResolveENS(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve ENS name %s: %w", name, err)
+	}
+
+	resolved := addr.Hex()
+	r.mu.Lock()
+	r.cache[name] = resolvedAddress{address: resolved, expiresAt: r.now().Add(r.ttl)}
+	r.mu.Unlock()
+
+	return resolved, nil
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// fakeENS resolves names from a fixed table and counts lookups
+type fakeENS struct {
+	names   map[string]common.Address
+	lookups int
+}
+
+func (f *fakeENS) ResolveENS(_ context.Context, name string) (common.Address, error) {
+	f.lookups++
+	addr, ok := f.names[name]
+	if !ok {
+		return common.Address{}, errors.New("no resolver set")
+	}
+	return addr, nil
+}
+
+func TestIsENSName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "vitalik.eth", want: true},
+		{input: " Artist.ETH ", want: true},
+		{input: "sub.artist.eth", want: true},
+		{input: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", want: false},
+		{input: "artist.xyz", want: false},
+		{input: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsENSName(tt.input); got != tt.want {
+			t.Errorf("IsENSName(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestAddressResolverResolve(t *testing.T) {
+	artist := common.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+
+	tests := []struct {
+		name      string
+		ens       ENSResolver
+		input     string
+		want      string
+		wantErr   bool
+		wantErrIs error
+	}{
+		{name: "hex address is returned unchanged", ens: &fakeENS{}, input: "0xabc", want: "0xabc"},
+		{name: "ens name is resolved", ens: &fakeENS{names: map[string]common.Address{"artist.eth": artist}}, input: "artist.eth", want: artist.Hex()},
+		{name: "ens name is normalised before lookup", ens: &fakeENS{names: map[string]common.Address{"artist.eth": artist}}, input: " Artist.ETH", want: artist.Hex()},
+		{name: "unknown ens name", ens: &fakeENS{}, input: "nobody.eth", wantErr: true},
+		{name: "no blockchain client", input: "artist.eth", wantErr: true, wantErrIs: ErrENSNotConfigured},
+		{name: "hex address without a blockchain client", input: "0xabc", want: "0xabc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAddressResolver(tt.ens, time.Minute).Resolve(t.Context(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Fatalf("Resolve(%q) error = %v, want %v", tt.input, err, tt.wantErrIs)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddressResolverCache(t *testing.T) {
+	ens := &fakeENS{names: map[string]common.Address{"artist.eth": common.HexToAddress("0x01")}}
+	resolver := NewAddressResolver(ens, time.Minute)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	resolver.now = func() time.Time { return now }
+
+	steps := []struct {
+		name        string
+		advance     time.Duration
+		wantLookups int
+	}{
+		{name: "first resolution looks the name up", wantLookups: 1},
+		{name: "within the TTL the cache answers", advance: 59 * time.Second, wantLookups: 1},
+		{name: "after the TTL the name is looked up again", advance: time.Second, wantLookups: 2},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		if _, err := resolver.Resolve(t.Context(), "artist.eth"); err != nil {
+			t.Fatalf("%s: Resolve() error = %v", step.name, err)
+		}
+		if ens.lookups != step.wantLookups {
+			t.Errorf("%s: lookups = %d, want %d", step.name, ens.lookups, step.wantLookups)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"errors"
+	"math/big"
+)
+
+// DefaultPayoutPerPlayWei is the assumed royalty per play when the caller
+// gives none: about $0.004, a typical streaming payout, at ETHPriceUSD
+var DefaultPayoutPerPlayWei = big.NewInt(int64(0.004 / ETHPriceUSD * 1e18))
+
+// ErrNoInvestorShare is returned for break-even on a campaign that pays investors nothing
+var ErrNoInvestorShare = errors.New("campaign has no royalty share for investors")
+
+// BreakEven is how far a track's royalties must go before its campaign's
+// contributors have their principal back. Amounts are wei.
+type BreakEven struct {
+	Principal          string  `json:"principal"`
+	RoyaltyBasisPoints uint16  `json:"royalty_basis_points"`
+	PayoutPerPlay      string  `json:"payout_per_play"`
+	RoyaltiesNeeded    string  `json:"royalties_needed"` // total track royalties at break-even
+	PlaysNeeded        string  `json:"plays_needed"`
+	RoyaltiesReceived  string  `json:"royalties_received"`
+	InvestorsRecovered string  `json:"investors_recovered"`
+	RemainingRoyalties string  `json:"remaining_royalties"`
+	RemainingPlays     string  `json:"remaining_plays"`
+	RecoveredPercent   float64 `json:"recovered_percent"`
+	BrokenEven         bool    `json:"broken_even"`
+}
+
+// ceilQuo returns a/b rounded up; b must be positive
+func ceilQuo(a, b *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+	if r.Sign() > 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
+
+// ComputeBreakEven finds the total royalties, and plays at payoutPerPlay,
+// after which the investor pool (royaltyBps of every payment) equals the
+// principal, and how much of that received already covers
+func ComputeBreakEven(principal *big.Int, royaltyBps uint16, payoutPerPlay, received *big.Int) (*BreakEven, error) {
+	if royaltyBps == 0 {
+		return nil, ErrNoInvestorShare
+	}
+	if payoutPerPlay.Sign() <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	needed := ceilQuo(new(big.Int).Mul(principal, big.NewInt(10000)), big.NewInt(int64(royaltyBps)))
+	recovered := BasisPointsOf(received, royaltyBps)
+	remaining := new(big.Int).Sub(needed, received)
+	if remaining.Sign() < 0 {
+		remaining.SetInt64(0)
+	}
+
+	percent := 100.0
+	if principal.Sign() > 0 {
+		ratio, _ := new(big.Rat).SetFrac(recovered, principal).Float64()
+		percent = min(ratio*100, 100)
+	}
+
+	return &BreakEven{
+		Principal:          principal.String(),
+		RoyaltyBasisPoints: royaltyBps,
+		PayoutPerPlay:      payoutPerPlay.String(),
+		RoyaltiesNeeded:    needed.String(),
+		PlaysNeeded:        ceilQuo(needed, payoutPerPlay).String(),
+		RoyaltiesReceived:  received.String(),
+		InvestorsRecovered: recovered.String(),
+		RemainingRoyalties: remaining.String(),
+		RemainingPlays:     ceilQuo(remaining, payoutPerPlay).String(),
+		RecoveredPercent:   percent,
+		BrokenEven:         recovered.Cmp(principal) >= 0,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestComputeBreakEven(t *testing.T) {
+	// A 1000 wei campaign paying investors 30% needs 3334 wei of royalties,
+	// 477 plays at 7 wei a play
+	tests := []struct {
+		name          string
+		principal     int64
+		bps           uint16
+		payout        int64
+		received      int64
+		wantNeeded    string
+		wantPlays     string
+		wantRecovered string
+		wantRemaining string
+		wantRemPlays  string
+		wantPercent   float64
+		wantBroken    bool
+	}{
+		{
+			name: "nothing received yet", principal: 1000, bps: 3000, payout: 7,
+			wantNeeded: "3334", wantPlays: "477", wantRecovered: "0", wantRemaining: "3334", wantRemPlays: "477",
+		},
+		{
+			name: "part of the way", principal: 1000, bps: 3000, payout: 7, received: 2000,
+			wantNeeded: "3334", wantPlays: "477", wantRecovered: "600", wantRemaining: "1334", wantRemPlays: "191", wantPercent: 60,
+		},
+		{
+			name: "exactly at break-even", principal: 1000, bps: 3000, payout: 7, received: 3334,
+			wantNeeded: "3334", wantPlays: "477", wantRecovered: "1000", wantRemaining: "0", wantRemPlays: "0", wantPercent: 100, wantBroken: true,
+		},
+		{
+			name: "past break-even is capped at 100%", principal: 1000, bps: 3000, payout: 7, received: 5000,
+			wantNeeded: "3334", wantPlays: "477", wantRecovered: "1500", wantRemaining: "0", wantRemPlays: "0", wantPercent: 100, wantBroken: true,
+		},
+		{
+			name: "whole royalty to investors", principal: 1000, bps: 10000, payout: 10, received: 250,
+			wantNeeded: "1000", wantPlays: "100", wantRecovered: "250", wantRemaining: "750", wantRemPlays: "75", wantPercent: 25,
+		},
+		{
+			name: "nothing raised", bps: 3000, payout: 7,
+			wantNeeded: "0", wantPlays: "0", wantRecovered: "0", wantRemaining: "0", wantRemPlays: "0", wantPercent: 100, wantBroken: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ComputeBreakEven(big.NewInt(tt.principal), tt.bps, big.NewInt(tt.payout), big.NewInt(tt.received))
+			if err != nil {
+				t.Fatalf("ComputeBreakEven() error = %v", err)
+			}
+			if got.RoyaltiesNeeded != tt.wantNeeded || got.PlaysNeeded != tt.wantPlays {
+				t.Errorf("needed %s royalties, %s plays, want %s, %s", got.RoyaltiesNeeded, got.PlaysNeeded, tt.wantNeeded, tt.wantPlays)
+			}
+			if got.InvestorsRecovered != tt.wantRecovered || got.RemainingRoyalties != tt.wantRemaining || got.RemainingPlays != tt.wantRemPlays {
+				t.Errorf("recovered %s, remaining %s royalties, %s plays, want %s, %s, %s", got.InvestorsRecovered, got.RemainingRoyalties, got.RemainingPlays, tt.wantRecovered, tt.wantRemaining, tt.wantRemPlays)
+			}
+			if got.RecoveredPercent != tt.wantPercent || got.BrokenEven != tt.wantBroken {
+				t.Errorf("recovered %v%%, broken even %v, want %v%%, %v", got.RecoveredPercent, got.BrokenEven, tt.wantPercent, tt.wantBroken)
+			}
+		})
+	}
+}
+
+func TestComputeBreakEvenRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		bps     uint16
+		payout  int64
+		wantErr error
+	}{
+		{name: "no investor share", bps: 0, payout: 7, wantErr: ErrNoInvestorShare},
+		{name: "zero payout per play", bps: 3000, payout: 0, wantErr: ErrInvalidAmount},
+		{name: "negative payout per play", bps: 3000, payout: -1, wantErr: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ComputeBreakEven(big.NewInt(1000), tt.bps, big.NewInt(tt.payout), new(big.Int))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ComputeBreakEven() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// FundingPercentageSQL returns the SQL expression computing raised/goal*100
+// for the campaigns table (or alias) given. A zero goal yields 0 instead of
+// a division error.
+func FundingPercentageSQL(table string) string {
+	return fmt.Sprintf("COALESCE(CAST(%[1]s.raised_amount AS DECIMAL(30,0)) / NULLIF(CAST(%[1]s.goal_amount AS DECIMAL(30,0)), 0) * 100, 0)", table)
+}
+
+// FundingPercentage computes raised/goal*100 from Wei strings, returning 0
+// when the goal is zero or either amount is not a valid integer
+func FundingPercentage(raised, goal string) float64 {
+	raisedInt, ok := new(big.Int).SetString(raised, 10)
+	if !ok {
+		return 0
+	}
+	goalInt, ok := new(big.Int).SetString(goal, 10)
+	if !ok || goalInt.Sign() <= 0 {
+		return 0
+	}
+
+	pct := new(big.Float).Quo(new(big.Float).SetInt(raisedInt), new(big.Float).SetInt(goalInt))
+	pct.Mul(pct, big.NewFloat(100))
+	result, _ := pct.Float64()
+	return result
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFundingPercentage(t *testing.T) {
+	tests := []struct {
+		name   string
+		raised string
+		goal   string
+		want   float64
+	}{
+		{name: "half funded", raised: "500", goal: "1000", want: 50},
+		{name: "fully funded", raised: "1000", goal: "1000", want: 100},
+		{name: "overfunded", raised: "1500", goal: "1000", want: 150},
+		{name: "nothing raised", raised: "0", goal: "1000", want: 0},
+		{name: "zero goal", raised: "500", goal: "0", want: 0},
+		{name: "zero goal and nothing raised", raised: "0", goal: "0", want: 0},
+		{name: "negative goal", raised: "500", goal: "-1000", want: 0},
+		{name: "unparsable goal", raised: "500", goal: "", want: 0},
+		{name: "unparsable raised", raised: "lots", goal: "1000", want: 0},
+		{name: "wei beyond int64", raised: "15000000000000000000", goal: "60000000000000000000", want: 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FundingPercentage(tt.raised, tt.goal); got != tt.want {
+				t.Errorf("FundingPercentage(%q, %q) = %v, want %v", tt.raised, tt.goal, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFundingPercentageSQL(t *testing.T) {
+	got := FundingPercentageSQL("camp")
+
+	for _, want := range []string{
+		"CAST(camp.raised_amount AS DECIMAL(30,0))",
+		"NULLIF(CAST(camp.goal_amount AS DECIMAL(30,0)), 0)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FundingPercentageSQL() = %s, want it to contain %s", got, want)
+		}
+	}
+	if !strings.HasPrefix(got, "COALESCE(") || !strings.HasSuffix(got, ", 0)") {
+		t.Errorf("FundingPercentageSQL() = %s, want a zero-goal division to fall back to 0", got)
+	}
+}
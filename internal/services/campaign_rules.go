@@ -0,0 +1,26 @@
+package services
+
+import "fmt"
+
+// Bounds for campaign terms. Royalty percentages are basis points (10000 = 100%).
+const (
+	MinRoyaltyBasisPoints   = 1
+	MaxRoyaltyBasisPoints   = 10000
+	MaxCampaignDurationDays = 365
+	MaxLockupDays           = 1825
+)
+
+// ValidateCampaignTerms checks the royalty share, funding duration and lockup
+// period shared by campaign creation and update
+func ValidateCampaignTerms(royaltyBasisPoints, durationDays, lockupDays int) error {
+	if royaltyBasisPoints < MinRoyaltyBasisPoints || royaltyBasisPoints > MaxRoyaltyBasisPoints {
+		return fmt.Errorf("royalty_percentage must be between %d and %d basis points", MinRoyaltyBasisPoints, MaxRoyaltyBasisPoints)
+	}
+	if durationDays <= 0 || durationDays > MaxCampaignDurationDays {
+		return fmt.Errorf("duration_days must be between 1 and %d", MaxCampaignDurationDays)
+	}
+	if lockupDays <= 0 || lockupDays > MaxLockupDays {
+		return fmt.Errorf("lockup_days must be between 1 and %d", MaxLockupDays)
+	}
+	return nil
+}
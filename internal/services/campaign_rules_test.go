@@ -0,0 +1,33 @@
+package services
+
+import "testing"
+
+func TestValidateCampaignTerms(t *testing.T) {
+	tests := []struct {
+		name         string
+		royalty      int
+		durationDays int
+		lockupDays   int
+		wantErr      bool
+	}{
+		{name: "typical terms", royalty: 3000, durationDays: 30, lockupDays: 90},
+		{name: "lowest bounds", royalty: MinRoyaltyBasisPoints, durationDays: 1, lockupDays: 1},
+		{name: "highest bounds", royalty: MaxRoyaltyBasisPoints, durationDays: MaxCampaignDurationDays, lockupDays: MaxLockupDays},
+		{name: "zero royalty", royalty: 0, durationDays: 30, lockupDays: 90, wantErr: true},
+		{name: "royalty above 100%", royalty: MaxRoyaltyBasisPoints + 1, durationDays: 30, lockupDays: 90, wantErr: true},
+		{name: "zero duration", royalty: 3000, durationDays: 0, lockupDays: 90, wantErr: true},
+		{name: "negative duration", royalty: 3000, durationDays: -7, lockupDays: 90, wantErr: true},
+		{name: "duration over the maximum", royalty: 3000, durationDays: MaxCampaignDurationDays + 1, lockupDays: 90, wantErr: true},
+		{name: "negative lockup", royalty: 3000, durationDays: 30, lockupDays: -1, wantErr: true},
+		{name: "lockup over the maximum", royalty: 3000, durationDays: 30, lockupDays: MaxLockupDays + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCampaignTerms(tt.royalty, tt.durationDays, tt.lockupDays)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCampaignTerms(%d, %d, %d) error = %v, wantErr %v", tt.royalty, tt.durationDays, tt.lockupDays, err, tt.wantErr)
+			}
+		})
+	}
+}
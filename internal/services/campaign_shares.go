@@ -0,0 +1,55 @@
+package services
+
+import (
+	"math"
+	"math/big"
+	"sort"
+)
+
+// ContributorShare is a contributor's cumulative stake in a campaign
+type ContributorShare struct {
+	ContributorAddress string  `json:"contributor_address"`
+	Amount             string  `json:"amount"`           // Wei as string
+	SharePercentage    float64 `json:"share_percentage"` // of the total raised
+}
+
+// ContributionShare returns amount as a percentage of total, rounded to 6
+// decimals, or 0 when total is zero
+func ContributionShare(amount, total *big.Int) float64 {
+	if total.Sign() <= 0 {
+		return 0
+	}
+	ratio, _ := new(big.Rat).SetFrac(new(big.Int).Mul(amount, big.NewInt(100)), total).Float64()
+	return math.Round(ratio*1e6) / 1e6
+}
+
+// ContributorShares turns per-contributor cumulative amounts into percentage
+// shares of their sum, largest first. Shares are rounded to 6 decimals.
+// Contributors with nothing left, e.g. after a full refund, get no share.
+func ContributorShares(amounts map[string]*big.Int) ([]ContributorShare, *big.Int) {
+	total := new(big.Int)
+	for _, amount := range amounts {
+		total.Add(total, amount)
+	}
+
+	shares := make([]ContributorShare, 0, len(amounts))
+	for address, amount := range amounts {
+		if amount.Sign() <= 0 {
+			continue
+		}
+		shares = append(shares, ContributorShare{
+			ContributorAddress: address,
+			Amount:             amount.String(),
+			SharePercentage:    ContributionShare(amount, total),
+		})
+	}
+
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].SharePercentage != shares[j].SharePercentage {
+			return shares[i].SharePercentage > shares[j].SharePercentage
+		}
+		return shares[i].ContributorAddress < shares[j].ContributorAddress
+	})
+
+	return shares, total
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestContributionShare(t *testing.T) {
+	tests := []struct {
+		name          string
+		amount, total int64
+		want          float64
+	}{
+		{name: "whole", amount: 500, total: 500, want: 100},
+		{name: "half", amount: 250, total: 500, want: 50},
+		{name: "rounded to six decimals", amount: 1, total: 3, want: 33.333333},
+		{name: "rounded up", amount: 2, total: 3, want: 66.666667},
+		{name: "nothing", amount: 0, total: 500, want: 0},
+		{name: "zero total", amount: 100, total: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContributionShare(big.NewInt(tt.amount), big.NewInt(tt.total)); got != tt.want {
+				t.Errorf("ContributionShare(%d, %d) = %v, want %v", tt.amount, tt.total, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContributorSharesSumToHundred(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts map[string]int64
+	}{
+		{name: "single contributor", amounts: map[string]int64{"0xa": 42}},
+		{name: "thirds", amounts: map[string]int64{"0xa": 1, "0xb": 1, "0xc": 1}},
+		{name: "uneven", amounts: map[string]int64{"0xa": 7, "0xb": 13, "0xc": 29, "0xd": 1}},
+		{name: "wei-sized", amounts: map[string]int64{"0xa": 1e18, "0xb": 333333333333333333, "0xc": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amounts := make(map[string]*big.Int, len(tt.amounts))
+			want := new(big.Int)
+			for address, amount := range tt.amounts {
+				amounts[address] = big.NewInt(amount)
+				want.Add(want, amounts[address])
+			}
+
+			shares, total := ContributorShares(amounts)
+			if total.Cmp(want) != 0 {
+				t.Errorf("total = %s, want %s", total, want)
+			}
+			if len(shares) != len(tt.amounts) {
+				t.Fatalf("got %d shares, want %d", len(shares), len(tt.amounts))
+			}
+
+			sum := 0.0
+			for i, share := range shares {
+				sum += share.SharePercentage
+				if i > 0 && share.SharePercentage > shares[i-1].SharePercentage {
+					t.Errorf("share %d (%v) is larger than the one before it (%v)", i, share.SharePercentage, shares[i-1].SharePercentage)
+				}
+			}
+			if math.Abs(sum-100) > 0.01 {
+				t.Errorf("shares sum to %v, want 100", sum)
+			}
+		})
+	}
+}
+
+func TestContributorSharesSkipsEmptyAmounts(t *testing.T) {
+	// 0xb was fully refunded, so only 0xa and 0xc share what is left
+	shares, total := ContributorShares(map[string]*big.Int{
+		"0xa": big.NewInt(300),
+		"0xb": big.NewInt(0),
+		"0xc": big.NewInt(100),
+	})
+
+	if total.Cmp(big.NewInt(400)) != 0 {
+		t.Errorf("total = %s, want 400", total)
+	}
+	want := []ContributorShare{
+		{ContributorAddress: "0xa", Amount: "300", SharePercentage: 75},
+		{ContributorAddress: "0xc", Amount: "100", SharePercentage: 25},
+	}
+	if !reflect.DeepEqual(shares, want) {
+		t.Errorf("shares = %+v, want %+v", shares, want)
+	}
+}
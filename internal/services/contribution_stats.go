@@ -0,0 +1,50 @@
+package services
+
+import (
+	"math/big"
+	"sort"
+)
+
+// ContributionStats summarizes a campaign's contribution amounts, all in wei.
+// Average and an even-count median are rounded down.
+type ContributionStats struct {
+	Count   int    `json:"count"`
+	Total   string `json:"total"`
+	Average string `json:"average"`
+	Median  string `json:"median"`
+	Min     string `json:"min"`
+	Max     string `json:"max"`
+}
+
+// ComputeContributionStats summarizes amounts; with no amounts every figure is zero
+func ComputeContributionStats(amounts []*big.Int) ContributionStats {
+	if len(amounts) == 0 {
+		return ContributionStats{Total: "0", Average: "0", Median: "0", Min: "0", Max: "0"}
+	}
+
+	sorted := make([]*big.Int, len(amounts))
+	copy(sorted, amounts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+
+	total := new(big.Int)
+	for _, amount := range sorted {
+		total.Add(total, amount)
+	}
+	count := big.NewInt(int64(len(sorted)))
+
+	mid := len(sorted) / 2
+	median := new(big.Int).Set(sorted[mid])
+	if len(sorted)%2 == 0 {
+		median.Add(median, sorted[mid-1])
+		median.Quo(median, big.NewInt(2))
+	}
+
+	return ContributionStats{
+		Count:   len(sorted),
+		Total:   total.String(),
+		Average: new(big.Int).Quo(total, count).String(),
+		Median:  median.String(),
+		Min:     sorted[0].String(),
+		Max:     sorted[len(sorted)-1].String(),
+	}
+}
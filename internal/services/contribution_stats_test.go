@@ -0,0 +1,60 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestComputeContributionStats(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts []string
+		want    ContributionStats
+	}{
+		{
+			name: "no contributions",
+			want: ContributionStats{Total: "0", Average: "0", Median: "0", Min: "0", Max: "0"},
+		},
+		{
+			name:    "single contribution",
+			amounts: []string{"250"},
+			want:    ContributionStats{Count: 1, Total: "250", Average: "250", Median: "250", Min: "250", Max: "250"},
+		},
+		{
+			name:    "odd count takes the middle amount",
+			amounts: []string{"900", "100", "300", "500", "200"},
+			want:    ContributionStats{Count: 5, Total: "2000", Average: "400", Median: "300", Min: "100", Max: "900"},
+		},
+		{
+			name:    "even count averages the two middle amounts",
+			amounts: []string{"400", "100", "1000", "200"},
+			want:    ContributionStats{Count: 4, Total: "1700", Average: "425", Median: "300", Min: "100", Max: "1000"},
+		},
+		{
+			name:    "integer division truncates",
+			amounts: []string{"1", "2"},
+			want:    ContributionStats{Count: 2, Total: "3", Average: "1", Median: "1", Min: "1", Max: "2"},
+		},
+		{
+			name:    "amounts beyond int64",
+			amounts: []string{"3000000000000000000000", "1000000000000000000000"},
+			want:    ContributionStats{Count: 2, Total: "4000000000000000000000", Average: "2000000000000000000000", Median: "2000000000000000000000", Min: "1000000000000000000000", Max: "3000000000000000000000"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amounts := make([]*big.Int, len(tt.amounts))
+			for i, s := range tt.amounts {
+				amounts[i], _ = new(big.Int).SetString(s, 10)
+			}
+
+			if got := ComputeContributionStats(amounts); got != tt.want {
+				t.Errorf("ComputeContributionStats() = %+v, want %+v", got, tt.want)
+			}
+			if len(amounts) > 1 && amounts[0].String() != tt.amounts[0] {
+				t.Errorf("input reordered: first amount = %s, want %s", amounts[0], tt.amounts[0])
+			}
+		})
+	}
+}
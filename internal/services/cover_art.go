@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/pkg/ipfs"
+)
+
+// MaxCoverImageBytes caps the size of an uploaded cover image
+const MaxCoverImageBytes = 5 << 20
+
+// coverImageTypes maps the accepted image MIME types, as sniffed from the
+// file contents, to the extension the file is pinned under
+var coverImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+var (
+	// ErrNotCreator is returned when someone other than the track's creator tries to change it
+	ErrNotCreator = errors.New("only the track's creator can change it")
+	// ErrUnsupportedImage is returned for cover images that are not JPEG, PNG or WebP
+	ErrUnsupportedImage = errors.New("cover image must be a JPEG, PNG or WebP file")
+	// ErrImageTooLarge is returned for cover images over MaxCoverImageBytes
+	ErrImageTooLarge = errors.New("cover image exceeds maximum allowed size")
+	// ErrInvalidCoverURL is returned for cover URLs that are not IPFS content
+	ErrInvalidCoverURL = errors.New("cover url must be an ipfs:// URL or a URL on the configured IPFS gateway")
+)
+
+// CoverArtInput is either an image file to pin or a URL of an image hosted elsewhere
+type CoverArtInput struct {
+	ImageData []byte
+	URL       string
+}
+
+// DetectCoverImage checks the size and contents of a cover image and returns
+// its MIME type
+func DetectCoverImage(data []byte) (string, error) {
+	if len(data) > MaxCoverImageBytes {
+		return "", fmt.Errorf("%w: %d bytes (max %d)", ErrImageTooLarge, len(data), MaxCoverImageBytes)
+	}
+	contentType := http.DetectContentType(data)
+	if _, ok := coverImageTypes[contentType]; !ok {
+		return "", fmt.Errorf("%w: detected %s", ErrUnsupportedImage, contentType)
+	}
+	return contentType, nil
+}
+
+// validateCoverURL accepts only URLs of an IPFS CID, as ipfs:// or on a
+// configured gateway, so covers cannot point at arbitrary hosts
+func (s *MusicService) validateCoverURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if _, ok := s.ipfs.CIDFromURL(raw); !ok {
+		return "", ErrInvalidCoverURL
+	}
+	return raw, nil
+}
+
+// UpdateCoverArt sets a track's cover image and re-pins its metadata so the
+// IPFS record points at the new image. Only the creator may change it. As at
+// registration, IPFS failures fall back to mock CIDs for local development.
+func (s *MusicService) UpdateCoverArt(ctx context.Context, tokenID uint64, caller string, input *CoverArtInput) (*models.MusicMetadata, error) {
+	music, err := s.GetMusic(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(music.CreatorAddress, caller) {
+		return nil, ErrNotCreator
+	}
+
+	var coverURL string
+	if len(input.ImageData) > 0 {
+		contentType, err := DetectCoverImage(input.ImageData)
+		if err != nil {
+			return nil, err
+		}
+		filename := fmt.Sprintf("cover-%d%s", tokenID, coverImageTypes[contentType])
+		imageCID, err := s.ipfs.UploadFile(input.ImageData, filename)
+		if err != nil {
+			imageCID = fmt.Sprintf("QmMOCK%x", time.Now().UnixNano())
+			slog.WarnContext(ctx, "Cover image upload failed (using mock CID)", "token_id", tokenID, "error", err)
+		}
+		coverURL = s.ipfs.GetURL(imageCID)
+	} else {
+		coverURL, err = s.validateCoverURL(input.URL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	metadata := ipfs.MusicMetadata{
+		Title:           music.Title,
+		Artist:          music.Artist,
+		Genre:           music.Genre,
+		Description:     music.Description,
+		Duration:        music.Duration,
+		FingerprintHash: music.FingerprintHash,
+		Creator:         music.CreatorAddress,
+		Image:           coverURL,
+		Timestamp:       time.Now().Unix(),
+	}
+	metadataCID, err := s.ipfs.UploadJSON(metadata)
+	if err != nil {
+		metadataCID = fmt.Sprintf("QmMOCK%x", time.Now().UnixNano())
+		slog.WarnContext(ctx, "Metadata re-pin failed (using mock CID)", "token_id", tokenID, "error", err)
+	}
+
+	if err := s.db.WithContext(ctx).Model(music).Updates(map[string]interface{}{
+		"cover_image_url": coverURL,
+		"ipfs_cid":        metadataCID,
+	}).Error; err != nil {
+		return nil, fmt.Errorf("failed to update cover image: %w", err)
+	}
+
+	return music, nil
+}
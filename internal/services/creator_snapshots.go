@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/tunecent/backend/internal/database"
+	"github.com/tunecent/backend/internal/models"
+	"gorm.io/gorm/clause"
+)
+
+// CreatorSnapshotInterval is how often the snapshot job refreshes the current
+// week's creator totals. Running more often than weekly means a missed tick
+// still leaves every week with a snapshot.
+const CreatorSnapshotInterval = 24 * time.Hour
+
+// WeekStart returns the Monday 00:00 UTC that begins the week containing t
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+}
+
+type CreatorSnapshotService struct {
+	db *database.DB
+}
+
+func NewCreatorSnapshotService(db *database.DB) *CreatorSnapshotService {
+	return &CreatorSnapshotService{db: db}
+}
+
+// Run refreshes the weekly snapshots every interval until ctx is cancelled
+func (s *CreatorSnapshotService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if count, err := s.SnapshotWeek(ctx, time.Now()); err != nil {
+			slog.ErrorContext(ctx, "Creator weekly snapshot failed", "error", err)
+		} else {
+			slog.InfoContext(ctx, "Creator weekly snapshot recorded", "creators", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SnapshotWeek stores every creator's cumulative earnings, active works and
+// their listeners, plays and views as the snapshot for the week containing
+// at, replacing an earlier snapshot of that week. It returns how many
+// creators were recorded.
+func (s *CreatorSnapshotService) SnapshotWeek(ctx context.Context, at time.Time) (int, error) {
+	db := s.db.WithContext(ctx)
+
+	var creators []string
+	if err := db.Model(&models.User{}).
+		Where("role IN (?)", []string{"creator", "both"}).
+		Pluck("wallet_address", &creators).Error; err != nil {
+		return 0, fmt.Errorf("failed to load creators: %w", err)
+	}
+	if len(creators) == 0 {
+		return 0, nil
+	}
+
+	type earningsRow struct {
+		Beneficiary string
+		Total       string
+	}
+	var earnings []earningsRow
+	if err := db.Model(&models.RoyaltyDistribution{}).
+		Select("beneficiary, CAST(COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) AS CHAR) as total").
+		Where("beneficiary IN ?", creators).
+		Group("beneficiary").
+		Scan(&earnings).Error; err != nil {
+		return 0, fmt.Errorf("failed to sum creator earnings: %w", err)
+	}
+	earningsBy := make(map[string]string, len(earnings))
+	for _, row := range earnings {
+		earningsBy[strings.ToLower(row.Beneficiary)] = row.Total
+	}
+
+	type worksRow struct {
+		CreatorAddress string
+		Works          uint
+		Listeners      uint64
+		Plays          uint64
+		Views          uint64
+	}
+	var works []worksRow
+	if err := db.Model(&models.MusicMetadata{}).
+		Select(`creator_address, COUNT(*) as works,
+			COALESCE(SUM(listener_count), 0) as listeners,
+			COALESCE(SUM(play_count), 0) as plays,
+			COALESCE(SUM(view_count), 0) as views`).
+		Where("creator_address IN ? AND is_active = ?", creators, true).
+		Group("creator_address").
+		Scan(&works).Error; err != nil {
+		return 0, fmt.Errorf("failed to total creator works: %w", err)
+	}
+	worksBy := make(map[string]worksRow, len(works))
+	for _, row := range works {
+		worksBy[strings.ToLower(row.CreatorAddress)] = row
+	}
+
+	weekStart := WeekStart(at)
+	snapshots := make([]models.CreatorWeeklySnapshot, len(creators))
+	for i, address := range creators {
+		key := strings.ToLower(address)
+		total := earningsBy[key]
+		if total == "" {
+			total = "0"
+		}
+		row := worksBy[key]
+		snapshots[i] = models.CreatorWeeklySnapshot{
+			WalletAddress:  address,
+			WeekStart:      weekStart,
+			TotalEarnings:  total,
+			TotalWorks:     row.Works,
+			TotalListeners: row.Listeners,
+			TotalPlays:     row.Plays,
+			TotalViews:     row.Views,
+		}
+	}
+
+	if err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "wallet_address"}, {Name: "week_start"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"total_earnings", "total_works", "total_listeners", "total_plays", "total_views", "updated_at",
+		}),
+	}).CreateInBatches(snapshots, 500).Error; err != nil {
+		return 0, fmt.Errorf("failed to save creator weekly snapshots: %w", err)
+	}
+
+	return len(snapshots), nil
+}
+
+// WeeklyProgress is the change in a creator's totals between two weekly
+// snapshots. Growth percentages are nil when the earlier total was zero.
+type WeeklyProgress struct {
+	EarningsDelta    string   `json:"earnings_delta"` // wei
+	WorksDelta       int64    `json:"works_delta"`
+	ListenersDelta   int64    `json:"listeners_delta"`
+	PlaysDelta       int64    `json:"plays_delta"`
+	ViewsDelta       int64    `json:"views_delta"`
+	EarningsGrowth   *float64 `json:"earnings_growth"`
+	ListenersGrowth  *float64 `json:"listeners_growth"`
+	PlaysGrowth      *float64 `json:"plays_growth"`
+	EngagementGrowth *float64 `json:"engagement_growth"` // views
+}
+
+// ComputeWeeklyProgress compares the current week's snapshot with the
+// previous one
+func ComputeWeeklyProgress(previous, current *models.CreatorWeeklySnapshot) WeeklyProgress {
+	prevEarnings, ok := new(big.Int).SetString(previous.TotalEarnings, 10)
+	if !ok {
+		prevEarnings = new(big.Int)
+	}
+	curEarnings, ok := new(big.Int).SetString(current.TotalEarnings, 10)
+	if !ok {
+		curEarnings = new(big.Int)
+	}
+	earningsDelta := new(big.Int).Sub(curEarnings, prevEarnings)
+
+	progress := WeeklyProgress{
+		EarningsDelta:    earningsDelta.String(),
+		WorksDelta:       int64(current.TotalWorks) - int64(previous.TotalWorks),
+		ListenersDelta:   int64(current.TotalListeners) - int64(previous.TotalListeners),
+		PlaysDelta:       int64(current.TotalPlays) - int64(previous.TotalPlays),
+		ViewsDelta:       int64(current.TotalViews) - int64(previous.TotalViews),
+		ListenersGrowth:  growthPercent(previous.TotalListeners, current.TotalListeners),
+		PlaysGrowth:      growthPercent(previous.TotalPlays, current.TotalPlays),
+		EngagementGrowth: growthPercent(previous.TotalViews, current.TotalViews),
+	}
+	if prevEarnings.Sign() > 0 {
+		ratio, _ := new(big.Rat).SetFrac(earningsDelta, prevEarnings).Float64()
+		growth := math.Round(ratio*10000) / 100
+		progress.EarningsGrowth = &growth
+	}
+	return progress
+}
+
+func growthPercent(previous, current uint64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	growth := math.Round((float64(current)-float64(previous))/float64(previous)*10000) / 100
+	return &growth
+}
+
+// AudienceGrowth is the change in a creator's total listeners across their
+// catalog over a window, measured between two weekly snapshots. Growth
+// figures are nil when there are too few snapshots to measure.
+type AudienceGrowth struct {
+	WindowDays     int        `json:"window_days"`
+	From           *time.Time `json:"from"` // when the starting total was recorded
+	To             *time.Time `json:"to"`
+	CoveredDays    float64    `json:"covered_days"`
+	StartListeners uint64     `json:"start_listeners"`
+	EndListeners   uint64     `json:"end_listeners"`
+	AbsoluteGrowth *int64     `json:"absolute_growth"`
+	GrowthPercent  *float64   `json:"growth_percent"` // also nil when the start was zero
+	DailyGrowth    *float64   `json:"daily_growth"`   // listeners per day
+	SufficientData bool       `json:"sufficient_data"`
+	PartialWindow  bool       `json:"partial_window"` // the first snapshot falls inside the window
+	Note           string     `json:"note,omitempty"`
+}
+
+// snapshotAsOf is when a weekly snapshot's totals were taken: the end of its
+// week, or now for the week still in progress
+func snapshotAsOf(snapshot models.CreatorWeeklySnapshot, now time.Time) time.Time {
+	weekEnd := snapshot.WeekStart.AddDate(0, 0, 7)
+	if now.Before(weekEnd) {
+		return now
+	}
+	return weekEnd
+}
+
+// ComputeAudienceGrowth measures listener growth over the days before now
+// from weekly snapshots in ascending week order. The starting total is the
+// latest snapshot taken by the window's start; when every snapshot is newer
+// the earliest one is used and the window is reported as partial.
+func ComputeAudienceGrowth(snapshots []models.CreatorWeeklySnapshot, days int, now time.Time) AudienceGrowth {
+	growth := AudienceGrowth{WindowDays: days}
+	switch len(snapshots) {
+	case 0:
+		growth.Note = "no weekly snapshots recorded yet"
+		return growth
+	case 1:
+		growth.EndListeners = snapshots[0].TotalListeners
+		growth.Note = "at least two weekly snapshots are needed to measure growth"
+		return growth
+	}
+
+	windowStart := now.AddDate(0, 0, -days)
+	start := 0
+	for i, snapshot := range snapshots[:len(snapshots)-1] {
+		if snapshotAsOf(snapshot, now).After(windowStart) {
+			break
+		}
+		start = i
+	}
+	first, last := snapshots[start], snapshots[len(snapshots)-1]
+
+	from, to := snapshotAsOf(first, now), snapshotAsOf(last, now)
+	absolute := int64(last.TotalListeners) - int64(first.TotalListeners)
+	growth.From, growth.To = &from, &to
+	growth.CoveredDays = math.Round(to.Sub(from).Hours()/24*100) / 100
+	growth.StartListeners = first.TotalListeners
+	growth.EndListeners = last.TotalListeners
+	growth.AbsoluteGrowth = &absolute
+	growth.GrowthPercent = growthPercent(first.TotalListeners, last.TotalListeners)
+	growth.SufficientData = true
+	growth.PartialWindow = from.After(windowStart)
+	if growth.CoveredDays > 0 {
+		daily := math.Round(float64(absolute)/growth.CoveredDays*100) / 100
+		growth.DailyGrowth = &daily
+	}
+	if growth.PartialWindow {
+		growth.Note = "snapshots cover only part of the window"
+	}
+	return growth
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tunecent/backend/internal/models"
+)
+
+func TestComputeWeeklyProgress(t *testing.T) {
+	pct := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name     string
+		previous models.CreatorWeeklySnapshot
+		current  models.CreatorWeeklySnapshot
+		want     WeeklyProgress
+	}{
+		{
+			name:     "growth on every total",
+			previous: models.CreatorWeeklySnapshot{TotalEarnings: "1000", TotalWorks: 2, TotalListeners: 100, TotalPlays: 400, TotalViews: 50},
+			current:  models.CreatorWeeklySnapshot{TotalEarnings: "1500", TotalWorks: 3, TotalListeners: 150, TotalPlays: 500, TotalViews: 75},
+			want: WeeklyProgress{
+				EarningsDelta: "500", WorksDelta: 1, ListenersDelta: 50, PlaysDelta: 100, ViewsDelta: 25,
+				EarningsGrowth: pct(50), ListenersGrowth: pct(50), PlaysGrowth: pct(25), EngagementGrowth: pct(50),
+			},
+		},
+		{
+			name:     "declines are negative",
+			previous: models.CreatorWeeklySnapshot{TotalEarnings: "1000", TotalWorks: 3, TotalListeners: 200, TotalPlays: 400, TotalViews: 80},
+			current:  models.CreatorWeeklySnapshot{TotalEarnings: "750", TotalWorks: 2, TotalListeners: 150, TotalPlays: 300, TotalViews: 60},
+			want: WeeklyProgress{
+				EarningsDelta: "-250", WorksDelta: -1, ListenersDelta: -50, PlaysDelta: -100, ViewsDelta: -20,
+				EarningsGrowth: pct(-25), ListenersGrowth: pct(-25), PlaysGrowth: pct(-25), EngagementGrowth: pct(-25),
+			},
+		},
+		{
+			name:     "growth is nil when the previous total was zero",
+			previous: models.CreatorWeeklySnapshot{TotalEarnings: "0"},
+			current:  models.CreatorWeeklySnapshot{TotalEarnings: "10", TotalWorks: 1, TotalListeners: 5, TotalPlays: 7, TotalViews: 3},
+			want: WeeklyProgress{
+				EarningsDelta: "10", WorksDelta: 1, ListenersDelta: 5, PlaysDelta: 7, ViewsDelta: 3,
+			},
+		},
+		{
+			name:     "growth is rounded to two decimals",
+			previous: models.CreatorWeeklySnapshot{TotalEarnings: "3", TotalListeners: 3, TotalPlays: 3, TotalViews: 3},
+			current:  models.CreatorWeeklySnapshot{TotalEarnings: "4", TotalListeners: 4, TotalPlays: 4, TotalViews: 4},
+			want: WeeklyProgress{
+				EarningsDelta: "1", ListenersDelta: 1, PlaysDelta: 1, ViewsDelta: 1,
+				EarningsGrowth: pct(33.33), ListenersGrowth: pct(33.33), PlaysGrowth: pct(33.33), EngagementGrowth: pct(33.33),
+			},
+		},
+		{
+			name:     "unparsable earnings count as zero",
+			previous: models.CreatorWeeklySnapshot{TotalEarnings: ""},
+			current:  models.CreatorWeeklySnapshot{TotalEarnings: "not-a-number"},
+			want:     WeeklyProgress{EarningsDelta: "0"},
+		},
+		{
+			name:     "earnings beyond int64 are exact",
+			previous: models.CreatorWeeklySnapshot{TotalEarnings: "20000000000000000000"},
+			current:  models.CreatorWeeklySnapshot{TotalEarnings: "30000000000000000000"},
+			want:     WeeklyProgress{EarningsDelta: "10000000000000000000", EarningsGrowth: pct(50)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeWeeklyProgress(&tt.previous, &tt.current)
+
+			if got.EarningsDelta != tt.want.EarningsDelta {
+				t.Errorf("EarningsDelta = %s, want %s", got.EarningsDelta, tt.want.EarningsDelta)
+			}
+			deltas := []struct {
+				field     string
+				got, want int64
+			}{
+				{"WorksDelta", got.WorksDelta, tt.want.WorksDelta},
+				{"ListenersDelta", got.ListenersDelta, tt.want.ListenersDelta},
+				{"PlaysDelta", got.PlaysDelta, tt.want.PlaysDelta},
+				{"ViewsDelta", got.ViewsDelta, tt.want.ViewsDelta},
+			}
+			for _, d := range deltas {
+				if d.got != d.want {
+					t.Errorf("%s = %d, want %d", d.field, d.got, d.want)
+				}
+			}
+			growths := []struct {
+				field     string
+				got, want *float64
+			}{
+				{"EarningsGrowth", got.EarningsGrowth, tt.want.EarningsGrowth},
+				{"ListenersGrowth", got.ListenersGrowth, tt.want.ListenersGrowth},
+				{"PlaysGrowth", got.PlaysGrowth, tt.want.PlaysGrowth},
+				{"EngagementGrowth", got.EngagementGrowth, tt.want.EngagementGrowth},
+			}
+			for _, g := range growths {
+				switch {
+				case g.want == nil && g.got != nil:
+					t.Errorf("%s = %v, want nil", g.field, *g.got)
+				case g.want != nil && g.got == nil:
+					t.Errorf("%s = nil, want %v", g.field, *g.want)
+				case g.want != nil && *g.got != *g.want:
+					t.Errorf("%s = %v, want %v", g.field, *g.got, *g.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{name: "start of Monday", t: monday, want: monday},
+		{name: "midweek", t: time.Date(2026, 3, 18, 12, 30, 0, 0, time.UTC), want: monday},
+		{name: "last moment of Sunday", t: time.Date(2026, 3, 22, 23, 59, 59, 0, time.UTC), want: monday},
+		{name: "week spanning a month boundary", t: time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC), want: time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC)},
+		{name: "converted to UTC first", t: time.Date(2026, 3, 16, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*3600)), want: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WeekStart(tt.t); !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("WeekStart(%s) = %s, want %s", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeAudienceGrowth(t *testing.T) {
+	// Wednesday of the week starting Monday 16 March
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)
+	// weeks gives consecutive weekly snapshots ending with the current week
+	weeks := func(listeners ...uint64) []models.CreatorWeeklySnapshot {
+		snapshots := make([]models.CreatorWeeklySnapshot, len(listeners))
+		for i, count := range listeners {
+			snapshots[i] = models.CreatorWeeklySnapshot{
+				WeekStart:      WeekStart(now).AddDate(0, 0, 7*(i-len(listeners)+1)),
+				TotalListeners: count,
+			}
+		}
+		return snapshots
+	}
+	pct := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name         string
+		snapshots    []models.CreatorWeeklySnapshot
+		days         int
+		wantStart    uint64
+		wantEnd      uint64
+		wantAbsolute int64
+		wantPercent  *float64
+		wantDaily    float64
+		wantCovered  float64
+		wantPartial  bool
+		wantNote     string
+		insufficient bool
+	}{
+		{
+			// Measured from the week that closed on 16 February to now
+			name:         "growth over the window",
+			snapshots:    weeks(1000, 1100, 1200, 1300, 1500, 1610),
+			days:         30,
+			wantStart:    1000,
+			wantEnd:      1610,
+			wantAbsolute: 610,
+			wantPercent:  pct(61),
+			wantDaily:    20,
+			wantCovered:  30.5,
+		},
+		{
+			// Starts from the last week that closed by 11 March
+			name:         "short window starts from the latest earlier snapshot",
+			snapshots:    weeks(1000, 1100, 1200, 1300, 1500, 1610),
+			days:         7,
+			wantStart:    1300,
+			wantEnd:      1610,
+			wantAbsolute: 310,
+			wantPercent:  pct(23.85),
+			wantDaily:    32.63,
+			wantCovered:  9.5,
+		},
+		{
+			name:         "snapshots cover part of the window",
+			snapshots:    weeks(1000, 1100, 1200, 1300, 1500, 1610),
+			days:         90,
+			wantStart:    1000,
+			wantEnd:      1610,
+			wantAbsolute: 610,
+			wantPercent:  pct(61),
+			wantDaily:    20,
+			wantCovered:  30.5,
+			wantPartial:  true,
+			wantNote:     "snapshots cover only part of the window",
+		},
+		{
+			name:         "shrinking audience",
+			snapshots:    weeks(1000, 900),
+			days:         30,
+			wantStart:    1000,
+			wantEnd:      900,
+			wantAbsolute: -100,
+			wantPercent:  pct(-10),
+			wantDaily:    -40,
+			wantCovered:  2.5,
+			wantPartial:  true,
+			wantNote:     "snapshots cover only part of the window",
+		},
+		{
+			name:         "growth from no listeners has no percentage",
+			snapshots:    weeks(0, 0, 0, 0, 0, 54),
+			days:         30,
+			wantEnd:      54,
+			wantAbsolute: 54,
+			wantDaily:    1.77,
+			wantCovered:  30.5,
+		},
+		{
+			name:         "single snapshot",
+			snapshots:    weeks(1610),
+			days:         30,
+			wantEnd:      1610,
+			wantNote:     "at least two weekly snapshots are needed to measure growth",
+			insufficient: true,
+		},
+		{
+			name:         "no snapshots",
+			days:         30,
+			wantNote:     "no weekly snapshots recorded yet",
+			insufficient: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeAudienceGrowth(tt.snapshots, tt.days, now)
+
+			if got.WindowDays != tt.days || got.Note != tt.wantNote || got.PartialWindow != tt.wantPartial {
+				t.Errorf("window %d, partial %v, note %q, want %d, %v, %q", got.WindowDays, got.PartialWindow, got.Note, tt.days, tt.wantPartial, tt.wantNote)
+			}
+			if got.StartListeners != tt.wantStart || got.EndListeners != tt.wantEnd {
+				t.Errorf("listeners %d to %d, want %d to %d", got.StartListeners, got.EndListeners, tt.wantStart, tt.wantEnd)
+			}
+			if tt.insufficient {
+				if got.SufficientData || got.AbsoluteGrowth != nil || got.GrowthPercent != nil || got.DailyGrowth != nil || got.From != nil {
+					t.Errorf("growth = %+v, want no figures without enough snapshots", got)
+				}
+				return
+			}
+
+			if !got.SufficientData || got.CoveredDays != tt.wantCovered || !got.To.Equal(now) {
+				t.Errorf("sufficient %v, covered %v days to %v, want true, %v days to now", got.SufficientData, got.CoveredDays, got.To, tt.wantCovered)
+			}
+			if got.AbsoluteGrowth == nil || *got.AbsoluteGrowth != tt.wantAbsolute || got.DailyGrowth == nil || *got.DailyGrowth != tt.wantDaily {
+				t.Errorf("absolute %v, daily %v, want %d, %v", got.AbsoluteGrowth, got.DailyGrowth, tt.wantAbsolute, tt.wantDaily)
+			}
+			switch {
+			case tt.wantPercent == nil && got.GrowthPercent != nil:
+				t.Errorf("percent = %v, want nil", *got.GrowthPercent)
+			case tt.wantPercent != nil && (got.GrowthPercent == nil || *got.GrowthPercent != *tt.wantPercent):
+				t.Errorf("percent = %v, want %v", got.GrowthPercent, *tt.wantPercent)
+			}
+		})
+	}
+}
@@ -3,13 +3,23 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/tunecent/backend/internal/database"
 	"github.com/tunecent/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrInvalidStatusTransition is returned when a distribution is not in a state that allows the requested change
+var ErrInvalidStatusTransition = errors.New("invalid distribution status transition")
+
+// ErrPlatformAlreadyAdded is returned when a platform is already part of a track's distribution
+var ErrPlatformAlreadyAdded = errors.New("platform already added to distribution")
+
 type DistributionService struct {
 	db *database.DB
 }
@@ -40,12 +50,22 @@ type PlatformStatus struct {
 }
 
 func (s *DistributionService) SubmitDistribution(ctx context.Context, req *SubmitDistributionRequest) (*models.DistributionSubmission, error) {
+	platforms, err := NormalizePlatforms(req.Platforms)
+	if err != nil {
+		return nil, err
+	}
+	req.Platforms = platforms
+
 	// Check if music exists
 	var music models.MusicMetadata
 	if err := s.db.Where("token_id = ?", req.TokenID).First(&music).Error; err != nil {
 		return nil, fmt.Errorf("music not found: %w", err)
 	}
 
+	if err := ValidateForPlatforms(&music, req.Platforms); err != nil {
+		return nil, err
+	}
+
 	// Check if already submitted
 	var existing models.DistributionSubmission
 	if err := s.db.Where("token_id = ? AND status NOT IN ('failed', 'cancelled')", req.TokenID).First(&existing).Error; err == nil {
@@ -67,18 +87,32 @@ func (s *DistributionService) SubmitDistribution(ctx context.Context, req *Submi
 		SubmittedAt: time.Now(),
 	}
 
-	if err := s.db.Create(submission).Error; err != nil {
-		return nil, fmt.Errorf("failed to create distribution submission: %w", err)
-	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(submission).Error; err != nil {
+			return fmt.Errorf("failed to create distribution submission: %w", err)
+		}
+		if err := recordDistributionEvent(tx, req.TokenID, "", "", submission.Status); err != nil {
+			return err
+		}
 
-	// Create platform distribution records
-	for _, platform := range req.Platforms {
-		platformDist := &models.PlatformDistribution{
-			TokenID:  req.TokenID,
-			Platform: platform,
-			Status:   "pending",
+		// Create platform distribution records
+		for _, platform := range req.Platforms {
+			platformDist := &models.PlatformDistribution{
+				TokenID:  req.TokenID,
+				Platform: platform,
+				Status:   "pending",
+			}
+			if err := tx.Create(platformDist).Error; err != nil {
+				return fmt.Errorf("failed to create platform distribution: %w", err)
+			}
+			if err := recordDistributionEvent(tx, req.TokenID, platform, "", platformDist.Status); err != nil {
+				return err
+			}
 		}
-		s.db.Create(platformDist)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return submission, nil
@@ -115,6 +149,15 @@ func (s *DistributionService) GetDistributionStatus(ctx context.Context, tokenID
 	}, nil
 }
 
+// TrackCreator returns the creator address of a track
+func (s *DistributionService) TrackCreator(ctx context.Context, tokenID uint64) (string, error) {
+	var music models.MusicMetadata
+	if err := s.db.WithContext(ctx).Select("creator_address").Where("token_id = ?", tokenID).First(&music).Error; err != nil {
+		return "", fmt.Errorf("track not found: %w", err)
+	}
+	return music.CreatorAddress, nil
+}
+
 func (s *DistributionService) GetPlatformStatus(ctx context.Context, tokenID uint64, platform string) (*models.PlatformDistribution, error) {
 	var platformDist models.PlatformDistribution
 	if err := s.db.Where("token_id = ? AND platform = ?", tokenID, platform).First(&platformDist).Error; err != nil {
@@ -129,6 +172,7 @@ func (s *DistributionService) UpdatePlatformStatus(ctx context.Context, tokenID
 		return fmt.Errorf("platform distribution not found: %w", err)
 	}
 
+	previous := platformDist.Status
 	platformDist.Status = status
 	platformDist.ExternalID = externalID
 	platformDist.ExternalURL = externalURL
@@ -138,7 +182,217 @@ func (s *DistributionService) UpdatePlatformStatus(ctx context.Context, tokenID
 		platformDist.DistributedAt = &now
 	}
 
-	return s.db.Save(&platformDist).Error
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&platformDist).Error; err != nil {
+			return err
+		}
+		if err := recordDistributionEvent(tx, tokenID, platform, previous, status); err != nil {
+			return err
+		}
+		return recomputeSubmissionStatus(tx, tokenID)
+	})
+}
+
+// RetryPlatformDistribution resets a failed platform distribution to pending
+// and recomputes the parent submission status
+func (s *DistributionService) RetryPlatformDistribution(ctx context.Context, tokenID uint64, platform string) (*models.PlatformDistribution, error) {
+	var platformDist models.PlatformDistribution
+	if err := s.db.Where("token_id = ? AND platform = ?", tokenID, platform).First(&platformDist).Error; err != nil {
+		return nil, fmt.Errorf("platform distribution not found: %w", err)
+	}
+
+	if platformDist.Status != "failed" {
+		return nil, fmt.Errorf("%w: only failed distributions can be retried (current status: %s)", ErrInvalidStatusTransition, platformDist.Status)
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		platformDist.Status = "pending"
+		platformDist.DistributedAt = nil
+		if err := tx.Save(&platformDist).Error; err != nil {
+			return err
+		}
+		if err := recordDistributionEvent(tx, tokenID, platform, "failed", platformDist.Status); err != nil {
+			return err
+		}
+		return recomputeSubmissionStatus(tx, tokenID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retry distribution: %w", err)
+	}
+
+	// In production, re-enqueue the platform delivery job here
+
+	return &platformDist, nil
+}
+
+// AddPlatform extends the track's active submission with another platform,
+// recording a pending platform row and moving the submission back to
+// processing until the new platform goes live
+func (s *DistributionService) AddPlatform(ctx context.Context, tokenID uint64, platform string) (*models.DistributionSubmission, *models.PlatformDistribution, error) {
+	key, ok := NormalizePlatform(platform)
+	if !ok {
+		return nil, nil, &InvalidPlatformsError{Invalid: []string{platform}}
+	}
+	platform = key
+
+	var music models.MusicMetadata
+	if err := s.db.WithContext(ctx).Where("token_id = ?", tokenID).First(&music).Error; err != nil {
+		return nil, nil, fmt.Errorf("music not found: %w", err)
+	}
+	if err := ValidateForPlatforms(&music, []string{platform}); err != nil {
+		return nil, nil, err
+	}
+
+	var submission models.DistributionSubmission
+	var platformDist *models.PlatformDistribution
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_id = ? AND status NOT IN ('failed', 'cancelled')", tokenID).
+			Order("created_at DESC").
+			First(&submission).Error; err != nil {
+			return fmt.Errorf("distribution not found: %w", err)
+		}
+
+		var platforms []string
+		if submission.Platforms != "" {
+			if err := json.Unmarshal([]byte(submission.Platforms), &platforms); err != nil {
+				return fmt.Errorf("failed to decode platforms: %w", err)
+			}
+		}
+		for _, existing := range platforms {
+			if strings.EqualFold(existing, platform) {
+				return fmt.Errorf("%w: %s", ErrPlatformAlreadyAdded, platform)
+			}
+		}
+
+		var rows int64
+		if err := tx.Model(&models.PlatformDistribution{}).
+			Where("token_id = ? AND platform = ?", tokenID, platform).
+			Count(&rows).Error; err != nil {
+			return err
+		}
+		if rows > 0 {
+			return fmt.Errorf("%w: %s", ErrPlatformAlreadyAdded, platform)
+		}
+
+		platformsJSON, err := json.Marshal(append(platforms, platform))
+		if err != nil {
+			return fmt.Errorf("failed to encode platforms: %w", err)
+		}
+		if err := tx.Model(&submission).Update("platforms", string(platformsJSON)).Error; err != nil {
+			return err
+		}
+
+		platformDist = &models.PlatformDistribution{
+			TokenID:  tokenID,
+			Platform: platform,
+			Status:   "pending",
+		}
+		if err := tx.Create(platformDist).Error; err != nil {
+			return fmt.Errorf("failed to create platform distribution: %w", err)
+		}
+		if err := recordDistributionEvent(tx, tokenID, platform, "", platformDist.Status); err != nil {
+			return err
+		}
+
+		if err := recomputeSubmissionStatus(tx, tokenID); err != nil {
+			return err
+		}
+		return tx.First(&submission, submission.ID).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// In production, enqueue the platform delivery job here
+
+	return &submission, platformDist, nil
+}
+
+// recomputeSubmissionStatus derives the latest submission's status from its platform rows:
+// distributed once every platform is live, failed when every platform failed, otherwise processing
+func recomputeSubmissionStatus(tx *gorm.DB, tokenID uint64) error {
+	var submission models.DistributionSubmission
+	if err := tx.Where("token_id = ?", tokenID).Order("created_at DESC").First(&submission).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	var platformDists []models.PlatformDistribution
+	if err := tx.Where("token_id = ?", tokenID).Find(&platformDists).Error; err != nil {
+		return err
+	}
+	if len(platformDists) == 0 {
+		return nil
+	}
+
+	live, failed := 0, 0
+	for _, pd := range platformDists {
+		switch pd.Status {
+		case "live":
+			live++
+		case "failed":
+			failed++
+		}
+	}
+
+	status := "processing"
+	switch {
+	case live == len(platformDists):
+		status = "distributed"
+	case failed == len(platformDists):
+		status = "failed"
+	}
+
+	if status == submission.Status {
+		return nil
+	}
+	previous := submission.Status
+	if err := tx.Model(&submission).Update("status", status).Error; err != nil {
+		return err
+	}
+	return recordDistributionEvent(tx, tokenID, "", previous, status)
+}
+
+// recordDistributionEvent appends a status change to the track's distribution
+// timeline; platform is empty for changes of the submission itself. Updates
+// that leave the status unchanged are not recorded.
+func recordDistributionEvent(tx *gorm.DB, tokenID uint64, platform, from, to string) error {
+	if from == to {
+		return nil
+	}
+	event := &models.DistributionEvent{
+		TokenID:    tokenID,
+		Platform:   platform,
+		FromStatus: from,
+		ToStatus:   to,
+	}
+	if err := tx.Create(event).Error; err != nil {
+		return fmt.Errorf("failed to record distribution event: %w", err)
+	}
+	return nil
+}
+
+// GetTimeline returns every status change of the track's distribution,
+// oldest first
+func (s *DistributionService) GetTimeline(ctx context.Context, tokenID uint64) ([]models.DistributionEvent, error) {
+	db := s.db.WithContext(ctx)
+
+	var submissions int64
+	if err := db.Model(&models.DistributionSubmission{}).Where("token_id = ?", tokenID).Count(&submissions).Error; err != nil {
+		return nil, fmt.Errorf("failed to load distribution: %w", err)
+	}
+	if submissions == 0 {
+		return nil, fmt.Errorf("distribution not found: %w", gorm.ErrRecordNotFound)
+	}
+
+	events := []models.DistributionEvent{}
+	if err := db.Where("token_id = ?", tokenID).Order("created_at ASC, id ASC").Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to load distribution timeline: %w", err)
+	}
+	return events, nil
 }
 
 func (s *DistributionService) ListDistributions(ctx context.Context, userAddress string, limit, offset int) ([]*models.DistributionSubmission, int64, error) {
@@ -155,3 +409,28 @@ func (s *DistributionService) ListDistributions(ctx context.Context, userAddress
 
 	return submissions, total, nil
 }
+
+// ListPendingTracks returns the creator's active tracks that have never been
+// submitted for distribution, or whose every submission was withdrawn
+func (s *DistributionService) ListPendingTracks(ctx context.Context, creatorAddress string, limit, offset int) ([]*models.MusicMetadata, int64, error) {
+	var tracks []*models.MusicMetadata
+	var total int64
+
+	query := s.db.WithContext(ctx).Model(&models.MusicMetadata{}).
+		Where("creator_address = ? AND is_active = ?", creatorAddress, true).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM distribution_submissions ds
+			WHERE ds.token_id = music_metadata.token_id
+				AND ds.deleted_at IS NULL
+				AND ds.status <> ?
+		)`, "cancelled")
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count pending tracks: %w", err)
+	}
+	if err := query.Order("registered_at DESC").Limit(limit).Offset(offset).Find(&tracks).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list pending tracks: %w", err)
+	}
+
+	return tracks, total, nil
+}
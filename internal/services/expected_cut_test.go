@@ -0,0 +1,122 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestExpectedCut(t *testing.T) {
+	// Campaign 3 pays 30% to contributors: 0xalice put in 60% of it, 0xbob 40%
+	tests := []struct {
+		name        string
+		address     string
+		funded      bool
+		pending     bool
+		wantRole    string
+		wantReason  string
+		wantShare   float64
+		wantNext    string
+		wantPending string
+	}{
+		{
+			name:        "contributor",
+			address:     "0xALICE",
+			funded:      true,
+			pending:     true,
+			wantRole:    CutRoleContributor,
+			wantShare:   18,
+			wantNext:    "180",
+			wantPending: "270",
+		},
+		{
+			name:        "creator",
+			address:     "0xcreator",
+			funded:      true,
+			pending:     true,
+			wantRole:    CutRoleCreator,
+			wantShare:   70,
+			wantNext:    "700",
+			wantPending: "1050",
+		},
+		{
+			name:        "non-contributor",
+			address:     "0xcarol",
+			funded:      true,
+			pending:     true,
+			wantRole:    CutRoleNone,
+			wantReason:  "the address did not contribute to the track's funded campaign",
+			wantNext:    "0",
+			wantPending: "0",
+		},
+		{
+			name:        "no funded campaign",
+			address:     "0xalice",
+			pending:     true,
+			wantRole:    CutRoleNone,
+			wantReason:  "the track has no funded campaign, so only its creator receives royalties",
+			wantNext:    "0",
+			wantPending: "0",
+		},
+		{
+			name:        "nothing pending",
+			address:     "0xalice",
+			funded:      true,
+			wantRole:    CutRoleContributor,
+			wantReason:  "no payments are pending distribution",
+			wantShare:   18,
+			wantNext:    "0",
+			wantPending: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT \\* FROM `music_metadata` WHERE token_id = \\?").
+				WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "token_id", "creator_address"}).AddRow(1, 7, "0xcreator"))
+			campaigns := sqlmock.NewRows([]string{"id", "campaign_id", "token_id", "royalty_percentage", "status"})
+			if tt.funded {
+				campaigns.AddRow(1, 3, 7, 3000, "successful")
+			}
+			mock.ExpectQuery("SELECT \\* FROM `campaigns` WHERE \\(token_id = \\? AND status = \\?\\) .* ORDER BY created_at DESC").
+				WithArgs(7, "successful").
+				WillReturnRows(campaigns)
+			if tt.funded {
+				mock.ExpectQuery("SELECT `contributor_address`,`amount` FROM `contributions` WHERE campaign_id = \\?").
+					WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"contributor_address", "amount"}).
+						AddRow("0xAlice", "400").
+						AddRow("0xbob", "400").
+						AddRow("0xalice", "200"))
+			}
+			payments := sqlmock.NewRows([]string{"id", "token_id", "amount", "is_distributed"})
+			if tt.pending {
+				payments.AddRow(11, 7, "1000", false).AddRow(12, 7, "500", false)
+			}
+			mock.ExpectQuery("SELECT \\* FROM `royalty_payments` WHERE token_id = \\? AND is_distributed = \\? ORDER BY paid_at ASC, id ASC").
+				WithArgs(7, false).
+				WillReturnRows(payments)
+
+			got, err := NewRoyaltyService(db).ExpectedCut(t.Context(), 7, tt.address)
+			if err != nil {
+				t.Fatalf("ExpectedCut() error = %v", err)
+			}
+
+			if got.Role != tt.wantRole || got.Eligible != (tt.wantRole != CutRoleNone) || got.Reason != tt.wantReason {
+				t.Errorf("role %s (eligible %v, %q), want %s (%q)", got.Role, got.Eligible, got.Reason, tt.wantRole, tt.wantReason)
+			}
+			if got.SharePercentage != tt.wantShare || got.ExpectedFromNext != tt.wantNext || got.ExpectedFromPending != tt.wantPending {
+				t.Errorf("share %v%%, next %s, pending %s, want %v%%, %s, %s", got.SharePercentage, got.ExpectedFromNext, got.ExpectedFromPending, tt.wantShare, tt.wantNext, tt.wantPending)
+			}
+			if tt.pending && (got.PendingPayments != 2 || got.NextPaymentID == nil || *got.NextPaymentID != 11 || got.NextPaymentAmount != "1000") {
+				t.Errorf("next payment %v of %s among %d, want payment 11 of 1000 among 2", got.NextPaymentID, got.NextPaymentAmount, got.PendingPayments)
+			}
+			if !tt.pending && (got.PendingPayments != 0 || got.NextPaymentID != nil) {
+				t.Errorf("next payment %v among %d, want none", got.NextPaymentID, got.PendingPayments)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrBlockchainNotConfigured is returned when an on-chain lookup is requested without a blockchain client
+var ErrBlockchainNotConfigured = errors.New("blockchain not configured")
+
+// ErrUnknownAction is returned for fee estimates of actions the platform does not perform on-chain
+var ErrUnknownAction = errors.New("unknown on-chain action")
+
+// GasPriceCacheTTL is how long a suggested gas price is reused
+const GasPriceCacheTTL = 15 * time.Second
+
+// ETHPriceUSD is the mock ETH price, matching the wallet endpoints, used to
+// value fees and portfolios in USD until a price oracle is wired in
+const ETHPriceUSD = 2500.0
+
+// GasOracle prices transactions (implemented by ethclient.Client)
+type GasOracle interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+}
+
+// Contract names used to look up the target of an on-chain action
+const (
+	ContractMusicRegistry      = "music_registry"
+	ContractCrowdfundingPool   = "crowdfunding_pool"
+	ContractRoyaltyDistributor = "royalty_distributor"
+)
+
+// onChainAction describes a contract call a user can be asked to pay for.
+// DefaultGas is used when the node cannot estimate the call, which happens
+// whenever arguments are required that the estimate does not have.
+type onChainAction struct {
+	Contract   string
+	Signature  string
+	DefaultGas uint64
+}
+
+var onChainActions = map[string]onChainAction{
+	"register_music":     {ContractMusicRegistry, "registerMusic(string,bytes32,string,string)", 250000},
+	"create_campaign":    {ContractCrowdfundingPool, "createCampaign(uint256,uint256,uint16,uint256,uint256)", 300000},
+	"contribute":         {ContractCrowdfundingPool, "contribute(uint256)", 120000},
+	"distribute_royalty": {ContractRoyaltyDistributor, "distributeRoyalty(uint256)", 200000},
+}
+
+// FeeActions lists the actions FeeEstimator can price
+func FeeActions() []string {
+	return []string{"register_music", "create_campaign", "contribute", "distribute_royalty"}
+}
+
+// FeeEstimate is the expected cost of an on-chain action
+type FeeEstimate struct {
+	Action         string  `json:"action"`
+	Contract       string  `json:"contract"`
+	GasLimit       uint64  `json:"gas_limit"`
+	GasLimitSource string  `json:"gas_limit_source"` // estimated or default
+	GasPriceWei    string  `json:"gas_price_wei"`
+	FeeWei         string  `json:"fee_wei"`
+	FeeETH         float64 `json:"fee_eth"`
+	FeeUSD         float64 `json:"fee_usd"`
+	ETHPriceUSD    float64 `json:"eth_price_usd"`
+}
+
+// FeeEstimator estimates transaction fees, caching the gas price briefly
+type FeeEstimator struct {
+	oracle    GasOracle
+	contracts map[string]common.Address
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu         sync.Mutex
+	gasPrice   *big.Int
+	gasPriceAt time.Time
+}
+
+// NewFeeEstimator creates an estimator; oracle may be nil when blockchain is not configured
+func NewFeeEstimator(oracle GasOracle, contracts map[string]common.Address, ttl time.Duration) *FeeEstimator {
+	return &FeeEstimator{
+		oracle:    oracle,
+		contracts: contracts,
+		ttl:       ttl,
+		now:       time.Now,
+	}
+}
+
+// Estimate prices action for the optional sender from
+func (e *FeeEstimator) Estimate(ctx context.Context, actionName string, from common.Address) (*FeeEstimate, error) {
+	action, ok := onChainActions[actionName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAction, actionName)
+	}
+	if e.oracle == nil {
+		return nil, ErrBlockchainNotConfigured
+	}
+
+	gasPrice, err := e.suggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	to := e.contracts[action.Contract]
+	gasLimit, source := action.DefaultGas, "default"
+	estimated, err := e.oracle.EstimateGas(ctx, ethereum.CallMsg{
+		From:     from,
+		To:       &to,
+		GasPrice: gasPrice,
+		Data:     crypto.Keccak256([]byte(action.Signature))[:4],
+	})
+	if err == nil && estimated > 0 {
+		gasLimit, source = estimated, "estimated"
+	}
+
+	fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
+	feeETH, _ := new(big.Rat).SetFrac(fee, big.NewInt(1e18)).Float64()
+
+	return &FeeEstimate{
+		Action:         actionName,
+		Contract:       to.Hex(),
+		GasLimit:       gasLimit,
+		GasLimitSource: source,
+		GasPriceWei:    gasPrice.String(),
+		FeeWei:         fee.String(),
+		FeeETH:         feeETH,
+		FeeUSD:         feeETH * ETHPriceUSD,
+		ETHPriceUSD:    ETHPriceUSD,
+	}, nil
+}
+
+// GasPrice returns the suggested gas price, reusing it for the cache TTL
+func (e *FeeEstimator) GasPrice(ctx context.Context) (*big.Int, error) {
+	if e.oracle == nil {
+		return nil, ErrBlockchainNotConfigured
+	}
+	return e.suggestGasPrice(ctx)
+}
+
+func (e *FeeEstimator) suggestGasPrice(ctx context.Context) (*big.Int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.gasPrice != nil && e.now().Sub(e.gasPriceAt) < e.ttl {
+		return e.gasPrice, nil
+	}
+
+	price, err := e.oracle.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	e.gasPrice = price
+	e.gasPriceAt = e.now()
+	return price, nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// fakeOracle is a GasOracle returning fixed answers and counting gas price lookups
+type fakeOracle struct {
+	gasPrice    *big.Int
+	gasPriceErr error
+	gas         uint64
+	gasErr      error
+	priceCalls  int
+	lastMsg     ethereum.CallMsg
+}
+
+func (o *fakeOracle) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	o.priceCalls++
+	return o.gasPrice, o.gasPriceErr
+}
+
+func (o *fakeOracle) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	o.lastMsg = msg
+	return o.gas, o.gasErr
+}
+
+func TestFeeEstimatorEstimate(t *testing.T) {
+	registry := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	tests := []struct {
+		name       string
+		action     string
+		oracle     *fakeOracle
+		wantErr    bool
+		wantErrIs  error
+		wantGas    uint64
+		wantSource string
+		wantFeeWei string
+		wantFeeETH float64
+	}{
+		{
+			name:       "estimated gas at the suggested price",
+			action:     "register_music",
+			oracle:     &fakeOracle{gasPrice: big.NewInt(20e9), gas: 200000},
+			wantGas:    200000,
+			wantSource: "estimated",
+			wantFeeWei: "4000000000000000",
+			wantFeeETH: 0.004,
+		},
+		{
+			name:       "default gas when the node cannot estimate",
+			action:     "register_music",
+			oracle:     &fakeOracle{gasPrice: big.NewInt(20e9), gasErr: errors.New("execution reverted")},
+			wantGas:    250000,
+			wantSource: "default",
+			wantFeeWei: "5000000000000000",
+			wantFeeETH: 0.005,
+		},
+		{
+			name:       "zero estimate falls back to the default",
+			action:     "contribute",
+			oracle:     &fakeOracle{gasPrice: big.NewInt(1e9)},
+			wantGas:    120000,
+			wantSource: "default",
+			wantFeeWei: "120000000000000",
+			wantFeeETH: 0.00012,
+		},
+		{name: "unknown action", action: "mint", oracle: &fakeOracle{gasPrice: big.NewInt(1)}, wantErr: true, wantErrIs: ErrUnknownAction},
+		{name: "gas price lookup fails", action: "contribute", oracle: &fakeOracle{gasPriceErr: errors.New("rpc down")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimator := NewFeeEstimator(tt.oracle, map[string]common.Address{ContractMusicRegistry: registry}, GasPriceCacheTTL)
+
+			got, err := estimator.Estimate(t.Context(), tt.action, common.Address{})
+			if tt.wantErr {
+				if err == nil || (tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs)) {
+					t.Fatalf("Estimate() error = %v, want %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Estimate() error = %v", err)
+			}
+
+			if got.GasLimit != tt.wantGas || got.GasLimitSource != tt.wantSource {
+				t.Errorf("gas limit = %d (%s), want %d (%s)", got.GasLimit, got.GasLimitSource, tt.wantGas, tt.wantSource)
+			}
+			if got.FeeWei != tt.wantFeeWei || got.FeeETH != tt.wantFeeETH {
+				t.Errorf("fee = %s wei (%v ETH), want %s wei (%v ETH)", got.FeeWei, got.FeeETH, tt.wantFeeWei, tt.wantFeeETH)
+			}
+			if got.FeeUSD != tt.wantFeeETH*ETHPriceUSD {
+				t.Errorf("fee = $%v, want $%v", got.FeeUSD, tt.wantFeeETH*ETHPriceUSD)
+			}
+			if got.GasPriceWei != tt.oracle.gasPrice.String() {
+				t.Errorf("gas price = %s, want %s", got.GasPriceWei, tt.oracle.gasPrice)
+			}
+			if tt.action == "register_music" && (got.Contract != registry.Hex() || *tt.oracle.lastMsg.To != registry) {
+				t.Errorf("contract = %s, estimated against %s, want %s", got.Contract, tt.oracle.lastMsg.To.Hex(), registry.Hex())
+			}
+		})
+	}
+}
+
+func TestFeeEstimatorNotConfigured(t *testing.T) {
+	estimator := NewFeeEstimator(nil, nil, GasPriceCacheTTL)
+
+	if _, err := estimator.Estimate(t.Context(), "register_music", common.Address{}); !errors.Is(err, ErrBlockchainNotConfigured) {
+		t.Errorf("Estimate() error = %v, want %v", err, ErrBlockchainNotConfigured)
+	}
+	if _, err := estimator.GasPrice(t.Context()); !errors.Is(err, ErrBlockchainNotConfigured) {
+		t.Errorf("GasPrice() error = %v, want %v", err, ErrBlockchainNotConfigured)
+	}
+	// Unknown actions are reported before the missing client
+	if _, err := estimator.Estimate(t.Context(), "mint", common.Address{}); !errors.Is(err, ErrUnknownAction) {
+		t.Errorf("Estimate() error = %v, want %v", err, ErrUnknownAction)
+	}
+}
+
+func TestFeeEstimatorCachesGasPrice(t *testing.T) {
+	oracle := &fakeOracle{gasPrice: big.NewInt(30e9)}
+	estimator := NewFeeEstimator(oracle, nil, GasPriceCacheTTL)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	estimator.now = func() time.Time { return now }
+
+	steps := []struct {
+		name      string
+		advance   time.Duration
+		wantCalls int
+	}{
+		{name: "first lookup asks the node", wantCalls: 1},
+		{name: "within the TTL the price is reused", advance: GasPriceCacheTTL - time.Second, wantCalls: 1},
+		{name: "at the TTL the price is refreshed", advance: time.Second, wantCalls: 2},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		price, err := estimator.GasPrice(t.Context())
+		if err != nil {
+			t.Fatalf("%s: GasPrice() error = %v", step.name, err)
+		}
+		if price.Cmp(oracle.gasPrice) != 0 || oracle.priceCalls != step.wantCalls {
+			t.Errorf("%s: price %s after %d node calls, want %s after %d", step.name, price, oracle.priceCalls, oracle.gasPrice, step.wantCalls)
+		}
+	}
+}
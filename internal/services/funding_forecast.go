@@ -0,0 +1,140 @@
+package services
+
+import (
+	"math/big"
+	"time"
+)
+
+// FundingRateWindow is how far back contributions are read to estimate a
+// campaign's current funding rate
+const FundingRateWindow = 14 * 24 * time.Hour
+
+// minFundingRateSpan keeps a burst of contributions in a brand-new campaign
+// from being extrapolated over a few minutes
+const minFundingRateSpan = 24 * time.Hour
+
+// Funding forecast outcomes
+const (
+	ForecastFunded  = "funded"   // goal already reached
+	ForecastOnTrack = "on_track" // the current rate reaches the goal by the deadline
+	ForecastBehind  = "behind"   // the current rate reaches the goal only after the deadline
+	ForecastStalled = "stalled"  // no recent contributions: never at the current rate
+	ForecastClosed  = "closed"   // the campaign is no longer accepting contributions
+)
+
+// ContributionPoint is one contribution's amount and time
+type ContributionPoint struct {
+	Amount *big.Int
+	At     time.Time
+}
+
+// FundingForecast projects when a campaign reaches its goal at its recent funding rate
+type FundingForecast struct {
+	Status              string     `json:"status"`
+	Confidence          string     `json:"confidence"`
+	WillMeetGoal        bool       `json:"will_meet_goal"`
+	RaisedAmount        string     `json:"raised_amount"`
+	GoalAmount          string     `json:"goal_amount"`
+	RemainingAmount     string     `json:"remaining_amount"`
+	RateWeiPerDay       string     `json:"rate_wei_per_day"`
+	RateWindowDays      float64    `json:"rate_window_days"`
+	RecentContributions int        `json:"recent_contributions"`
+	Deadline            time.Time  `json:"deadline"`
+	EstimatedCompletion *time.Time `json:"estimated_completion"` // nil when the goal is never reached at the current rate
+}
+
+// ForecastFunding estimates the funding rate from the contributions made in
+// the FundingRateWindow before now (or since the campaign started, if later)
+// and extrapolates the remaining amount. active is false for campaigns that
+// no longer accept contributions.
+func ForecastFunding(raised, goal *big.Int, contributions []ContributionPoint, startedAt, deadline, now time.Time, active bool) *FundingForecast {
+	remaining := new(big.Int).Sub(goal, raised)
+	if remaining.Sign() < 0 {
+		remaining.SetInt64(0)
+	}
+
+	windowStart := now.Add(-FundingRateWindow)
+	if startedAt.After(windowStart) {
+		windowStart = startedAt
+	}
+	span := now.Sub(windowStart)
+	if span < minFundingRateSpan {
+		span = minFundingRateSpan
+	}
+
+	recent := new(big.Int)
+	count := 0
+	for _, contribution := range contributions {
+		if contribution.At.Before(windowStart) || contribution.At.After(now) {
+			continue
+		}
+		recent.Add(recent, contribution.Amount)
+		count++
+	}
+
+	day := big.NewInt(int64(24 * time.Hour / time.Second))
+	ratePerDay := new(big.Int).Mul(recent, day)
+	ratePerDay.Quo(ratePerDay, big.NewInt(int64(span/time.Second)))
+
+	forecast := &FundingForecast{
+		RaisedAmount:        raised.String(),
+		GoalAmount:          goal.String(),
+		RemainingAmount:     remaining.String(),
+		RateWeiPerDay:       ratePerDay.String(),
+		RateWindowDays:      span.Hours() / 24,
+		RecentContributions: count,
+		Deadline:            deadline,
+		Confidence:          fundingForecastConfidence(count, span),
+	}
+
+	switch {
+	case remaining.Sign() == 0:
+		forecast.Status = ForecastFunded
+		forecast.WillMeetGoal = true
+		forecast.Confidence = ConfidenceHigh
+		return forecast
+	case !active || !now.Before(deadline):
+		forecast.Status = ForecastClosed
+		return forecast
+	case recent.Sign() == 0:
+		forecast.Status = ForecastStalled
+		forecast.Confidence = ConfidenceNone
+		return forecast
+	}
+
+	// seconds needed = remaining * span / recent, rounded up
+	seconds := new(big.Int).Mul(remaining, big.NewInt(int64(span/time.Second)))
+	seconds.Add(seconds, new(big.Int).Sub(recent, big.NewInt(1)))
+	seconds.Quo(seconds, recent)
+
+	maxSeconds := big.NewInt(int64(100 * 365 * 24 * time.Hour / time.Second))
+	if seconds.Cmp(maxSeconds) > 0 {
+		// Too far out to be meaningful
+		forecast.Status = ForecastBehind
+		return forecast
+	}
+
+	completion := now.Add(time.Duration(seconds.Int64()) * time.Second)
+	forecast.EstimatedCompletion = &completion
+	forecast.WillMeetGoal = !completion.After(deadline)
+	if forecast.WillMeetGoal {
+		forecast.Status = ForecastOnTrack
+	} else {
+		forecast.Status = ForecastBehind
+	}
+	return forecast
+}
+
+// fundingForecastConfidence rates a rate built from count contributions over span
+func fundingForecastConfidence(count int, span time.Duration) string {
+	switch {
+	case count == 0:
+		return ConfidenceNone
+	case count < 3 || span < 3*24*time.Hour:
+		return ConfidenceLow
+	case count < 10 || span < FundingRateWindow:
+		return ConfidenceMedium
+	default:
+		return ConfidenceHigh
+	}
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestForecastFunding(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	// every n days back from now, one contribution of amount
+	every := func(n, times int, amount int64) []ContributionPoint {
+		points := make([]ContributionPoint, times)
+		for i := range points {
+			points[i] = ContributionPoint{Amount: big.NewInt(amount), At: now.Add(-time.Duration(i*n) * day)}
+		}
+		return points
+	}
+	at := func(d time.Duration) *time.Time {
+		completion := now.Add(d)
+		return &completion
+	}
+
+	tests := []struct {
+		name           string
+		raised         int64
+		goal           int64
+		contributions  []ContributionPoint
+		startedAt      time.Time
+		deadline       time.Time
+		active         bool
+		wantStatus     string
+		wantConfidence string
+		wantRate       string
+		wantMeet       bool
+		wantCompletion *time.Time
+	}{
+		{
+			// 700 over the 14-day window is 50 a day, so the 700 left takes 14 days
+			name:           "on track at the recent rate",
+			raised:         300,
+			goal:           1000,
+			contributions:  every(2, 7, 100),
+			startedAt:      now.Add(-30 * day),
+			deadline:       now.Add(20 * day),
+			active:         true,
+			wantStatus:     ForecastOnTrack,
+			wantConfidence: ConfidenceMedium,
+			wantRate:       "50",
+			wantMeet:       true,
+			wantCompletion: at(14 * day),
+		},
+		{
+			name:           "behind when the rate finishes after the deadline",
+			raised:         300,
+			goal:           1000,
+			contributions:  every(2, 7, 100),
+			startedAt:      now.Add(-30 * day),
+			deadline:       now.Add(10 * day),
+			active:         true,
+			wantStatus:     ForecastBehind,
+			wantConfidence: ConfidenceMedium,
+			wantRate:       "50",
+			wantCompletion: at(14 * day),
+		},
+		{
+			name:           "contributions before the window are ignored",
+			raised:         300,
+			goal:           1000,
+			contributions:  append(every(1, 10, 70), ContributionPoint{Amount: big.NewInt(5000), At: now.Add(-20 * day)}),
+			startedAt:      now.Add(-30 * day),
+			deadline:       now.Add(20 * day),
+			active:         true,
+			wantStatus:     ForecastOnTrack,
+			wantConfidence: ConfidenceHigh,
+			wantRate:       "50",
+			wantMeet:       true,
+			wantCompletion: at(14 * day),
+		},
+		{
+			// A single contribution two hours in is spread over a full day
+			name:           "new campaign uses the minimum span",
+			raised:         7,
+			goal:           8,
+			contributions:  []ContributionPoint{{Amount: big.NewInt(7), At: now.Add(-time.Hour)}},
+			startedAt:      now.Add(-2 * time.Hour),
+			deadline:       now.Add(30 * day),
+			active:         true,
+			wantStatus:     ForecastOnTrack,
+			wantConfidence: ConfidenceLow,
+			wantRate:       "7",
+			wantMeet:       true,
+			wantCompletion: at(12343 * time.Second), // a seventh of a day, rounded up
+		},
+		{
+			name:           "no recent contributions never funds",
+			raised:         300,
+			goal:           1000,
+			contributions:  []ContributionPoint{{Amount: big.NewInt(300), At: now.Add(-20 * day)}},
+			startedAt:      now.Add(-30 * day),
+			deadline:       now.Add(20 * day),
+			active:         true,
+			wantStatus:     ForecastStalled,
+			wantConfidence: ConfidenceNone,
+			wantRate:       "0",
+		},
+		{
+			name:           "goal already reached",
+			raised:         1200,
+			goal:           1000,
+			startedAt:      now.Add(-30 * day),
+			deadline:       now.Add(20 * day),
+			active:         true,
+			wantStatus:     ForecastFunded,
+			wantConfidence: ConfidenceHigh,
+			wantRate:       "0",
+			wantMeet:       true,
+		},
+		{
+			name:           "inactive campaign",
+			raised:         300,
+			goal:           1000,
+			contributions:  every(2, 7, 100),
+			startedAt:      now.Add(-30 * day),
+			deadline:       now.Add(20 * day),
+			wantStatus:     ForecastClosed,
+			wantConfidence: ConfidenceMedium,
+			wantRate:       "50",
+		},
+		{
+			name:           "past the deadline",
+			raised:         300,
+			goal:           1000,
+			contributions:  every(2, 7, 100),
+			startedAt:      now.Add(-30 * day),
+			deadline:       now.Add(-time.Hour),
+			active:         true,
+			wantStatus:     ForecastClosed,
+			wantConfidence: ConfidenceMedium,
+			wantRate:       "50",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ForecastFunding(big.NewInt(tt.raised), big.NewInt(tt.goal), tt.contributions, tt.startedAt, tt.deadline, now, tt.active)
+
+			if got.Status != tt.wantStatus || got.Confidence != tt.wantConfidence {
+				t.Errorf("status %s (%s confidence), want %s (%s)", got.Status, got.Confidence, tt.wantStatus, tt.wantConfidence)
+			}
+			if got.RateWeiPerDay != tt.wantRate || got.WillMeetGoal != tt.wantMeet {
+				t.Errorf("rate %s/day, will meet goal %v, want %s, %v", got.RateWeiPerDay, got.WillMeetGoal, tt.wantRate, tt.wantMeet)
+			}
+			if (got.EstimatedCompletion == nil) != (tt.wantCompletion == nil) ||
+				(got.EstimatedCompletion != nil && !got.EstimatedCompletion.Equal(*tt.wantCompletion)) {
+				t.Errorf("estimated completion = %v, want %v", got.EstimatedCompletion, tt.wantCompletion)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// InvestorStanding is an investor's position on the top-investors leaderboard.
+// Investors are ranked by total invested, then by number of pools backed,
+// then by realized ROI; remaining ties go to the lower address so the order
+// is stable.
+type InvestorStanding struct {
+	Rank          int     `json:"rank"`
+	WalletAddress string  `json:"wallet_address"`
+	DisplayName   string  `json:"display_name,omitempty"`
+	TotalInvested string  `json:"total_invested"` // Wei as string
+	PoolCount     int64   `json:"pool_count"`
+	TotalReturned string  `json:"total_returned"` // royalties received as a contributor, in wei
+	RealizedROI   float64 `json:"realized_roi"`   // percent of total invested
+
+	invested *big.Int
+}
+
+// RankInvestors orders standings and assigns 1-based ranks in place
+func RankInvestors(standings []InvestorStanding) {
+	sort.Slice(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if cmp := a.invested.Cmp(b.invested); cmp != 0 {
+			return cmp > 0
+		}
+		if a.PoolCount != b.PoolCount {
+			return a.PoolCount > b.PoolCount
+		}
+		if a.RealizedROI != b.RealizedROI {
+			return a.RealizedROI > b.RealizedROI
+		}
+		return a.WalletAddress < b.WalletAddress
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+}
+
+// RealizedROI is returned as a percentage of invested, 0 when nothing was invested
+func RealizedROI(returned, invested *big.Int) float64 {
+	if invested.Sign() <= 0 {
+		return 0
+	}
+	roi, _ := new(big.Rat).SetFrac(new(big.Int).Mul(returned, big.NewInt(100)), invested).Float64()
+	return roi
+}
+
+// RankedInvestors computes the full top-investors ranking. Contribution
+// amounts are summed with big.Int so large wei totals compare exactly.
+func (s *LeaderboardService) RankedInvestors(ctx context.Context) ([]InvestorStanding, error) {
+	db := s.db.WithContext(ctx)
+
+	var invested []struct {
+		ContributorAddress string
+		Total              string
+		Pools              int64
+	}
+	if err := db.Table("contributions").
+		Select(`contributor_address,
+			COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) as total,
+			COUNT(DISTINCT campaign_id) as pools`).
+		Group("contributor_address").
+		Scan(&invested).Error; err != nil {
+		return nil, fmt.Errorf("failed to sum contributions: %w", err)
+	}
+
+	// Royalties a user received on tracks they did not create are investment returns
+	var returned []struct {
+		Beneficiary string
+		Total       string
+	}
+	if err := db.Table("royalty_distributions rd").
+		Select("rd.beneficiary, COALESCE(SUM(CAST(rd.amount AS DECIMAL(30,0))), 0) as total").
+		Joins("JOIN music_metadata m ON rd.token_id = m.token_id").
+		Where("rd.beneficiary <> m.creator_address").
+		Group("rd.beneficiary").
+		Scan(&returned).Error; err != nil {
+		return nil, fmt.Errorf("failed to sum investor returns: %w", err)
+	}
+	returnedBy := make(map[string]*big.Int, len(returned))
+	for _, row := range returned {
+		if total, ok := new(big.Int).SetString(row.Total, 10); ok {
+			returnedBy[row.Beneficiary] = total
+		}
+	}
+
+	var names []struct {
+		WalletAddress string
+		DisplayName   string
+	}
+	if err := db.Table("users").
+		Select("wallet_address, COALESCE(NULLIF(display_name, ''), username, '') as display_name").
+		Where("deleted_at IS NULL").
+		Scan(&names).Error; err != nil {
+		return nil, fmt.Errorf("failed to load investor names: %w", err)
+	}
+	nameOf := make(map[string]string, len(names))
+	for _, row := range names {
+		nameOf[row.WalletAddress] = row.DisplayName
+	}
+
+	standings := make([]InvestorStanding, 0, len(invested))
+	for _, row := range invested {
+		total, ok := new(big.Int).SetString(row.Total, 10)
+		if !ok || total.Sign() <= 0 {
+			continue
+		}
+		back := returnedBy[row.ContributorAddress]
+		if back == nil {
+			back = new(big.Int)
+		}
+		standings = append(standings, InvestorStanding{
+			WalletAddress: row.ContributorAddress,
+			DisplayName:   nameOf[row.ContributorAddress],
+			TotalInvested: total.String(),
+			PoolCount:     row.Pools,
+			TotalReturned: back.String(),
+			RealizedROI:   RealizedROI(back, total),
+			invested:      total,
+		})
+	}
+
+	RankInvestors(standings)
+	return standings, nil
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestRealizedROI(t *testing.T) {
+	tests := []struct {
+		name     string
+		returned int64
+		invested int64
+		want     float64
+	}{
+		{name: "nothing returned", returned: 0, invested: 1000, want: 0},
+		{name: "partial return", returned: 250, invested: 1000, want: 25},
+		{name: "more than invested", returned: 1500, invested: 1000, want: 150},
+		{name: "fractional percent", returned: 1, invested: 3, want: 100.0 / 3},
+		{name: "nothing invested", returned: 500, invested: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RealizedROI(big.NewInt(tt.returned), big.NewInt(tt.invested)); got != tt.want {
+				t.Errorf("RealizedROI(%d, %d) = %v, want %v", tt.returned, tt.invested, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankInvestors(t *testing.T) {
+	standing := func(address, invested string, pools int64, roi float64) InvestorStanding {
+		total, _ := new(big.Int).SetString(invested, 10)
+		return InvestorStanding{WalletAddress: address, TotalInvested: invested, PoolCount: pools, RealizedROI: roi, invested: total}
+	}
+
+	tests := []struct {
+		name      string
+		standings []InvestorStanding
+		want      []string
+	}{
+		{
+			name: "by total invested",
+			standings: []InvestorStanding{
+				standing("0xa", "100", 5, 90),
+				standing("0xb", "300", 1, 0),
+				standing("0xc", "200", 2, 10),
+			},
+			want: []string{"0xb", "0xc", "0xa"},
+		},
+		{
+			name: "amounts beyond int64 compare exactly",
+			standings: []InvestorStanding{
+				standing("0xa", "9223372036854775807", 1, 0),
+				standing("0xb", "20000000000000000000", 1, 0),
+			},
+			want: []string{"0xb", "0xa"},
+		},
+		{
+			name: "equal totals go to more pools, then higher ROI, then the lower address",
+			standings: []InvestorStanding{
+				standing("0xd", "500", 2, 10),
+				standing("0xc", "500", 2, 10),
+				standing("0xb", "500", 2, 40),
+				standing("0xa", "500", 3, 0),
+			},
+			want: []string{"0xa", "0xb", "0xc", "0xd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			RankInvestors(tt.standings)
+			for i, address := range tt.want {
+				if got := tt.standings[i]; got.WalletAddress != address || got.Rank != i+1 {
+					t.Errorf("position %d = %s ranked %d, want %s ranked %d", i, got.WalletAddress, got.Rank, address, i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestRankedInvestors(t *testing.T) {
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("SELECT contributor_address,.* FROM `contributions` GROUP BY `contributor_address`").
+		WillReturnRows(sqlmock.NewRows([]string{"contributor_address", "total", "pools"}).
+			AddRow("0xsmall", "100", 1).
+			AddRow("0xwhale", "5000000000000000000000", 4).
+			AddRow("0xpaid", "1000", 2).
+			AddRow("0xrefunded", "0", 1))
+	mock.ExpectQuery("SELECT rd.beneficiary, .* FROM royalty_distributions rd JOIN music_metadata m .* WHERE rd.beneficiary <> m.creator_address GROUP BY `rd`.`beneficiary`").
+		WillReturnRows(sqlmock.NewRows([]string{"beneficiary", "total"}).AddRow("0xpaid", "250"))
+	mock.ExpectQuery("SELECT wallet_address, .* FROM `users` WHERE deleted_at IS NULL").
+		WillReturnRows(sqlmock.NewRows([]string{"wallet_address", "display_name"}).AddRow("0xwhale", "Whale"))
+
+	got, err := NewLeaderboardService(db).RankedInvestors(t.Context())
+	if err != nil {
+		t.Fatalf("RankedInvestors() error = %v", err)
+	}
+
+	// Investors with nothing left invested are not ranked
+	want := []struct {
+		address  string
+		name     string
+		returned string
+		roi      float64
+	}{
+		{address: "0xwhale", name: "Whale", returned: "0"},
+		{address: "0xpaid", returned: "250", roi: 25},
+		{address: "0xsmall", returned: "0"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d investors, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		s := got[i]
+		if s.Rank != i+1 || s.WalletAddress != w.address || s.DisplayName != w.name || s.TotalReturned != w.returned || s.RealizedROI != w.roi {
+			t.Errorf("rank %d = %+v, want %s (%q) returned %s at %v%%", i+1, s, w.address, w.name, w.returned, w.roi)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/pkg/ipfs"
+)
+
+// IPFSProxyTimeout bounds a proxied fetch across all gateways
+const IPFSProxyTimeout = 30 * time.Second
+
+var (
+	// ErrInvalidCID is returned for strings that are not a CIDv0 or base32 CIDv1
+	ErrInvalidCID = errors.New("invalid IPFS CID")
+	// ErrUnknownCID is returned for CIDs no track or profile refers to
+	ErrUnknownCID = errors.New("CID is not referenced by any known record")
+	// ErrUnproxiableContent is returned for content that is not audio, a raster
+	// image or, for metadata CIDs, JSON
+	ErrUnproxiableContent = errors.New("only audio, non-SVG images and track metadata are served through the proxy")
+)
+
+// CIDReference is the kind of record that refers to a CID, which decides what
+// content the proxy serves for it
+type CIDReference int
+
+const (
+	// CIDReferenceMedia is a track's audio or cover, or a user's avatar
+	CIDReferenceMedia CIDReference = iota + 1
+	// CIDReferenceMetadata is a track's metadata JSON
+	CIDReferenceMetadata
+)
+
+// IPFSContent is proxied IPFS content and its MIME type
+type IPFSContent struct {
+	Data        []byte
+	ContentType string
+}
+
+// CheckReferencedCID reports what refers to cid: a track's metadata, or a
+// track's audio or cover or a user's avatar. It fails with ErrUnknownCID for
+// anything else, so the IPFS proxy cannot be used as an open proxy. Stored
+// URLs must point at the CID exactly, either as ipfs:// or on a configured
+// gateway.
+func (s *MusicService) CheckReferencedCID(ctx context.Context, cid string) (CIDReference, error) {
+	if !ipfs.ValidCID(cid) {
+		return 0, ErrInvalidCID
+	}
+
+	var metadata int64
+	if err := s.db.WithContext(ctx).Model(&models.MusicMetadata{}).
+		Where("ipfs_cid = ?", cid).
+		Count(&metadata).Error; err != nil {
+		return 0, fmt.Errorf("failed to look up CID: %w", err)
+	}
+	if metadata > 0 {
+		return CIDReferenceMetadata, nil
+	}
+
+	urls := s.ipfs.URLsFor(cid)
+	var tracks int64
+	if err := s.db.WithContext(ctx).Model(&models.MusicMetadata{}).
+		Where("audio_file_url IN ? OR cover_image_url IN ?", urls, urls).
+		Count(&tracks).Error; err != nil {
+		return 0, fmt.Errorf("failed to look up CID: %w", err)
+	}
+	if tracks > 0 {
+		return CIDReferenceMedia, nil
+	}
+
+	var users int64
+	if err := s.db.WithContext(ctx).Model(&models.User{}).
+		Where("avatar_url IN ?", urls).
+		Count(&users).Error; err != nil {
+		return 0, fmt.Errorf("failed to look up CID: %w", err)
+	}
+	if users == 0 {
+		return 0, ErrUnknownCID
+	}
+	return CIDReferenceMedia, nil
+}
+
+// FetchProxiableContent fetches cid through the configured gateways on behalf
+// of clients that cannot reach them; callers check it with CheckReferencedCID
+// first and pass on the reference. Content over maxBytes fails with
+// ipfs.ErrTooLarge, and content the reference does not allow with
+// ErrUnproxiableContent.
+func (s *MusicService) FetchProxiableContent(ctx context.Context, cid string, ref CIDReference, maxBytes int64) (*IPFSContent, error) {
+	ctx, cancel := context.WithTimeout(ctx, IPFSProxyTimeout)
+	defer cancel()
+
+	data, contentType, err := s.ipfs.FetchContent(ctx, cid, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	if !proxiableContentType(contentType, ref) {
+		return nil, fmt.Errorf("%w: got %s", ErrUnproxiableContent, contentType)
+	}
+	return &IPFSContent{Data: data, ContentType: contentType}, nil
+}
+
+// proxiableContentType accepts audio/* and image/* except SVG, which can
+// carry scripts, and JSON for metadata CIDs only
+func proxiableContentType(contentType string, ref CIDReference) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case strings.HasPrefix(mediaType, "audio/"):
+		return true
+	case strings.HasPrefix(mediaType, "image/"):
+		return mediaType != "image/svg+xml"
+	case mediaType == "application/json":
+		return ref == CIDReferenceMetadata
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package services
+
+import "testing"
+
+func TestProxiableContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		ref         CIDReference
+		want        bool
+	}{
+		{contentType: "audio/mpeg", ref: CIDReferenceMedia, want: true},
+		{contentType: "audio/flac", ref: CIDReferenceMedia, want: true},
+		{contentType: "image/png", ref: CIDReferenceMedia, want: true},
+		{contentType: "image/jpeg; charset=binary", ref: CIDReferenceMedia, want: true},
+		{contentType: "IMAGE/WEBP", ref: CIDReferenceMedia, want: true},
+		{contentType: "image/svg+xml", ref: CIDReferenceMedia, want: false},
+		{contentType: "image/svg+xml; charset=utf-8", ref: CIDReferenceMedia, want: false},
+		{contentType: "Image/SVG+XML", ref: CIDReferenceMedia, want: false},
+		{contentType: "text/html; charset=utf-8", ref: CIDReferenceMedia, want: false},
+		{contentType: "application/json", ref: CIDReferenceMedia, want: false},
+		{contentType: "application/octet-stream", ref: CIDReferenceMedia, want: false},
+		{contentType: "video/mp4", ref: CIDReferenceMedia, want: false},
+		{contentType: "", ref: CIDReferenceMedia, want: false},
+		{contentType: "audio", ref: CIDReferenceMedia, want: false},
+		{contentType: "application/json", ref: CIDReferenceMetadata, want: true},
+		{contentType: "application/json; charset=utf-8", ref: CIDReferenceMetadata, want: true},
+		{contentType: "audio/mpeg", ref: CIDReferenceMetadata, want: true},
+		{contentType: "image/svg+xml", ref: CIDReferenceMetadata, want: false},
+		{contentType: "text/html; charset=utf-8", ref: CIDReferenceMetadata, want: false},
+		{contentType: "text/plain; charset=utf-8", ref: CIDReferenceMetadata, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := proxiableContentType(tt.contentType, tt.ref); got != tt.want {
+			t.Errorf("proxiableContentType(%q, %d) = %v, want %v", tt.contentType, tt.ref, got, tt.want)
+		}
+	}
+}
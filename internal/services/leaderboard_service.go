@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/tunecent/backend/internal/database"
+	"github.com/tunecent/backend/internal/models"
+)
+
+// RankSnapshotInterval is how often the leaderboard job records creator ranks
+const RankSnapshotInterval = 24 * time.Hour
+
+// CreatorScoreSQL is the leaderboard score over users u, music m, royalty
+// distributions rd and campaigns c (see LeaderboardHandler.GetTopArtists)
+const CreatorScoreSQL = `(COUNT(DISTINCT m.token_id) * 100 +
+	COALESCE(SUM(CAST(rd.amount AS DECIMAL(30,0))) / 1e18, 0) * 10 +
+	COUNT(DISTINCT c.campaign_id) * 50)`
+
+// LeaderboardStatsTTL is how long GetStats serves cached platform totals
+// before recomputing them
+const LeaderboardStatsTTL = time.Minute
+
+type LeaderboardService struct {
+	db *database.DB
+
+	statsMu       sync.RWMutex
+	stats         *LeaderboardStats
+	statsCachedAt time.Time
+	statsVersion  uint64 // bumped on invalidation so in-flight results are not cached
+}
+
+func NewLeaderboardService(db *database.DB) *LeaderboardService {
+	return &LeaderboardService{db: db}
+}
+
+// LeaderboardStats are the platform-wide totals shown beside the leaderboard
+type LeaderboardStats struct {
+	TotalCreators    int64     `json:"total_creators"`
+	TotalWorks       int64     `json:"total_works"`
+	TotalEarnings    string    `json:"total_earnings"`
+	VerifiedCreators int64     `json:"verified_creators"`
+	ComputedAt       time.Time `json:"computed_at"`
+}
+
+// GetStats returns the cached leaderboard totals while they are younger than
+// LeaderboardStatsTTL, recomputing them otherwise or when fresh is set. The
+// second result reports whether the cache was used.
+func (s *LeaderboardService) GetStats(ctx context.Context, fresh bool) (*LeaderboardStats, bool, error) {
+	s.statsMu.RLock()
+	stats, cachedAt, version := s.stats, s.statsCachedAt, s.statsVersion
+	s.statsMu.RUnlock()
+	if !fresh && stats != nil && time.Since(cachedAt) < LeaderboardStatsTTL {
+		return stats, true, nil
+	}
+
+	stats, err := s.computeStats(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.statsMu.Lock()
+	if s.statsVersion == version {
+		s.stats = stats
+		s.statsCachedAt = stats.ComputedAt
+	}
+	s.statsMu.Unlock()
+
+	return stats, false, nil
+}
+
+// InvalidateStats drops the cached totals; writers that change them (music
+// registration, campaign creation and contributions, royalty distribution,
+// usage ingestion, creator verification) call it
+func (s *LeaderboardService) InvalidateStats() {
+	s.statsMu.Lock()
+	s.stats = nil
+	s.statsVersion++
+	s.statsMu.Unlock()
+}
+
+func (s *LeaderboardService) computeStats(ctx context.Context) (*LeaderboardStats, error) {
+	db := s.db.WithContext(ctx)
+	creatorRoles := []string{"creator", "both"}
+	stats := &LeaderboardStats{ComputedAt: time.Now()}
+
+	if err := db.Model(&models.User{}).
+		Where("role IN (?)", creatorRoles).
+		Count(&stats.TotalCreators).Error; err != nil {
+		return nil, fmt.Errorf("failed to count creators: %w", err)
+	}
+
+	if err := db.Model(&models.MusicMetadata{}).
+		Where("is_active = ?", true).
+		Count(&stats.TotalWorks).Error; err != nil {
+		return nil, fmt.Errorf("failed to count works: %w", err)
+	}
+
+	if err := db.Model(&models.RoyaltyDistribution{}).
+		Select("COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) as total").
+		Scan(&stats.TotalEarnings).Error; err != nil {
+		return nil, fmt.Errorf("failed to sum earnings: %w", err)
+	}
+
+	if err := db.Model(&models.User{}).
+		Where("role IN (?) AND is_verified = ?", creatorRoles, true).
+		Count(&stats.VerifiedCreators).Error; err != nil {
+		return nil, fmt.Errorf("failed to count verified creators: %w", err)
+	}
+
+	return stats, nil
+}
+
+// RankMovement compares a creator's current and previous rank
+type RankMovement struct {
+	Address      string     `json:"address"`
+	Period       string     `json:"period"`
+	CurrentRank  *int       `json:"current_rank"`
+	PreviousRank *int       `json:"previous_rank"`
+	Delta        int        `json:"delta"`     // positive = climbed
+	Direction    string     `json:"direction"` // up, down, same or new
+	CurrentAt    *time.Time `json:"current_at,omitempty"`
+	PreviousAt   *time.Time `json:"previous_at,omitempty"`
+}
+
+// ComputeMovement returns the rank delta and direction. A creator without a
+// previous rank is "new" with a zero delta.
+func ComputeMovement(current, previous *int) (int, string) {
+	if current == nil || previous == nil {
+		return 0, "new"
+	}
+
+	delta := *previous - *current
+	switch {
+	case delta > 0:
+		return delta, "up"
+	case delta < 0:
+		return delta, "down"
+	default:
+		return 0, "same"
+	}
+}
+
+// Run records rank snapshots every interval until ctx is cancelled
+func (s *LeaderboardService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if count, err := s.SnapshotRanks(ctx); err != nil {
+			slog.ErrorContext(ctx, "Rank snapshot failed", "error", err)
+		} else {
+			slog.InfoContext(ctx, "Rank snapshot recorded", "creators", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SnapshotRanks stores every creator's current rank and returns how many were recorded
+func (s *LeaderboardService) SnapshotRanks(ctx context.Context) (int, error) {
+	type scoreRow struct {
+		WalletAddress string
+		Score         float64
+	}
+
+	var rows []scoreRow
+	if err := s.db.WithContext(ctx).Table("users u").
+		Select("u.wallet_address, "+CreatorScoreSQL+" as score").
+		Joins("LEFT JOIN music_metadata m ON u.wallet_address = m.creator_address").
+		Joins("LEFT JOIN royalty_distributions rd ON m.token_id = rd.token_id AND rd.beneficiary = u.wallet_address").
+		Joins("LEFT JOIN campaigns c ON u.wallet_address = c.creator_address").
+		Where("u.role IN (?)", []string{"creator", "both"}).
+		Group("u.wallet_address").
+		Order("score DESC, u.wallet_address ASC").
+		Scan(&rows).Error; err != nil {
+		return 0, fmt.Errorf("failed to compute creator scores: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+	snapshots := make([]models.RankSnapshot, len(rows))
+	for i, row := range rows {
+		snapshots[i] = models.RankSnapshot{
+			WalletAddress: row.WalletAddress,
+			Rank:          i + 1,
+			Score:         row.Score,
+			SnapshotAt:    now,
+		}
+	}
+
+	if err := s.db.WithContext(ctx).CreateInBatches(snapshots, 500).Error; err != nil {
+		return 0, fmt.Errorf("failed to save rank snapshots: %w", err)
+	}
+
+	return len(snapshots), nil
+}
+
+// GetMovement compares the latest snapshot with the latest one taken at least
+// period ago
+func (s *LeaderboardService) GetMovement(ctx context.Context, address, periodName string, period time.Duration) (*RankMovement, error) {
+	movement := &RankMovement{Address: address, Period: periodName}
+
+	var current models.RankSnapshot
+	result := s.db.WithContext(ctx).
+		Where("wallet_address = ?", address).
+		Order("snapshot_at DESC").
+		Limit(1).
+		Find(&current)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to load current rank: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		movement.CurrentRank = &current.Rank
+		movement.CurrentAt = &current.SnapshotAt
+
+		var previous models.RankSnapshot
+		result = s.db.WithContext(ctx).
+			Where("wallet_address = ? AND snapshot_at <= ?", address, current.SnapshotAt.Add(-period)).
+			Order("snapshot_at DESC").
+			Limit(1).
+			Find(&previous)
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to load previous rank: %w", result.Error)
+		}
+		if result.RowsAffected > 0 {
+			movement.PreviousRank = &previous.Rank
+			movement.PreviousAt = &previous.SnapshotAt
+		}
+	}
+
+	movement.Delta, movement.Direction = ComputeMovement(movement.CurrentRank, movement.PreviousRank)
+	return movement, nil
+}
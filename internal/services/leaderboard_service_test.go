@@ -0,0 +1,163 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestComputeMovement(t *testing.T) {
+	rank := func(v int) *int { return &v }
+
+	tests := []struct {
+		name          string
+		current       *int
+		previous      *int
+		wantDelta     int
+		wantDirection string
+	}{
+		{name: "climbed", current: rank(3), previous: rank(10), wantDelta: 7, wantDirection: "up"},
+		{name: "dropped", current: rank(10), previous: rank(3), wantDelta: -7, wantDirection: "down"},
+		{name: "held", current: rank(5), previous: rank(5), wantDelta: 0, wantDirection: "same"},
+		{name: "no previous snapshot", current: rank(5), wantDelta: 0, wantDirection: "new"},
+		{name: "never ranked", wantDelta: 0, wantDirection: "new"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta, direction := ComputeMovement(tt.current, tt.previous)
+			if delta != tt.wantDelta || direction != tt.wantDirection {
+				t.Errorf("ComputeMovement() = %d, %q, want %d, %q", delta, direction, tt.wantDelta, tt.wantDirection)
+			}
+		})
+	}
+}
+
+func TestGetMovement(t *testing.T) {
+	const address = "0xcreator"
+	now := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	weekAgo := now.Add(-7 * 24 * time.Hour)
+	snapshotColumns := []string{"id", "wallet_address", "rank", "snapshot_at"}
+
+	t.Run("two snapshots", func(t *testing.T) {
+		db, mock := dbtest.New(t)
+		mock.ExpectQuery("SELECT \\* FROM `rank_snapshots` WHERE wallet_address = \\? ORDER BY snapshot_at DESC LIMIT 1").
+			WithArgs(address).
+			WillReturnRows(sqlmock.NewRows(snapshotColumns).AddRow(2, address, 4, now))
+		mock.ExpectQuery("SELECT \\* FROM `rank_snapshots` WHERE wallet_address = \\? AND snapshot_at <= \\? ORDER BY snapshot_at DESC LIMIT 1").
+			WithArgs(address, weekAgo).
+			WillReturnRows(sqlmock.NewRows(snapshotColumns).AddRow(1, address, 9, weekAgo))
+
+		got, err := NewLeaderboardService(db).GetMovement(t.Context(), address, "week", 7*24*time.Hour)
+		if err != nil {
+			t.Fatalf("GetMovement() error = %v", err)
+		}
+		if *got.CurrentRank != 4 || *got.PreviousRank != 9 || got.Delta != 5 || got.Direction != "up" {
+			t.Errorf("GetMovement() = rank %d from %d, delta %d %s, want rank 4 from 9, delta 5 up", *got.CurrentRank, *got.PreviousRank, got.Delta, got.Direction)
+		}
+		if !got.PreviousAt.Equal(weekAgo) {
+			t.Errorf("PreviousAt = %s, want %s", got.PreviousAt, weekAgo)
+		}
+	})
+
+	t.Run("no snapshot a period ago", func(t *testing.T) {
+		db, mock := dbtest.New(t)
+		mock.ExpectQuery("SELECT \\* FROM `rank_snapshots` WHERE wallet_address = \\?").
+			WithArgs(address).
+			WillReturnRows(sqlmock.NewRows(snapshotColumns).AddRow(1, address, 4, now))
+		mock.ExpectQuery("SELECT \\* FROM `rank_snapshots` WHERE wallet_address = \\? AND snapshot_at <= \\?").
+			WithArgs(address, weekAgo).
+			WillReturnRows(sqlmock.NewRows(snapshotColumns))
+
+		got, err := NewLeaderboardService(db).GetMovement(t.Context(), address, "week", 7*24*time.Hour)
+		if err != nil {
+			t.Fatalf("GetMovement() error = %v", err)
+		}
+		if *got.CurrentRank != 4 || got.PreviousRank != nil || got.Direction != "new" {
+			t.Errorf("GetMovement() = %+v, want current rank 4, no previous rank, direction new", got)
+		}
+	})
+
+	t.Run("creator never snapshotted", func(t *testing.T) {
+		db, mock := dbtest.New(t)
+		mock.ExpectQuery("SELECT \\* FROM `rank_snapshots` WHERE wallet_address = \\?").
+			WithArgs(address).
+			WillReturnRows(sqlmock.NewRows(snapshotColumns))
+
+		got, err := NewLeaderboardService(db).GetMovement(t.Context(), address, "week", 7*24*time.Hour)
+		if err != nil {
+			t.Fatalf("GetMovement() error = %v", err)
+		}
+		if got.CurrentRank != nil || got.PreviousRank != nil || got.Delta != 0 || got.Direction != "new" {
+			t.Errorf("GetMovement() = %+v, want no ranks, delta 0, direction new", got)
+		}
+	})
+}
+
+// expectStatsQueries answers the four aggregates behind the leaderboard stats
+func expectStatsQueries(mock sqlmock.Sqlmock, creators int) {
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `users` WHERE role IN \\(\\?,\\?\\) AND `users`.`deleted_at` IS NULL").
+		WithArgs("creator", "both").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(creators))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `music_metadata` WHERE is_active = \\?").
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+	mock.ExpectQuery("SELECT COALESCE\\(SUM\\(CAST\\(amount AS DECIMAL\\(30,0\\)\\)\\), 0\\) as total FROM `royalty_distributions`").
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow("5000"))
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `users` WHERE \\(role IN \\(\\?,\\?\\) AND is_verified = \\?\\)").
+		WithArgs("creator", "both", true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+}
+
+func TestLeaderboardStatsCache(t *testing.T) {
+	db, mock := dbtest.New(t)
+	s := NewLeaderboardService(db)
+
+	check := func(step string, fresh, wantCached bool, wantCreators int64) {
+		t.Helper()
+		stats, cached, err := s.GetStats(t.Context(), fresh)
+		if err != nil {
+			t.Fatalf("%s: GetStats() error = %v", step, err)
+		}
+		if cached != wantCached || stats.TotalCreators != wantCreators {
+			t.Errorf("%s: cached = %v with %d creators, want cached = %v with %d", step, cached, stats.TotalCreators, wantCached, wantCreators)
+		}
+	}
+
+	expectStatsQueries(mock, 5)
+	check("first call", false, false, 5)
+
+	// sqlmock fails the test on any query not expected here
+	check("second call within the TTL", false, true, 5)
+
+	expectStatsQueries(mock, 6)
+	check("fresh bypass", true, false, 6)
+	check("call after the bypass", false, true, 6)
+
+	s.InvalidateStats()
+	expectStatsQueries(mock, 7)
+	check("call after a relevant write", false, false, 7)
+	check("call after recomputing", false, true, 7)
+}
+
+func TestLeaderboardStatsExpire(t *testing.T) {
+	db, mock := dbtest.New(t)
+	s := NewLeaderboardService(db)
+
+	expectStatsQueries(mock, 5)
+	if _, _, err := s.GetStats(t.Context(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	// Age the cached entry past the TTL
+	s.statsMu.Lock()
+	s.statsCachedAt = s.statsCachedAt.Add(-LeaderboardStatsTTL)
+	s.statsMu.Unlock()
+
+	expectStatsQueries(mock, 5)
+	if _, cached, err := s.GetStats(t.Context(), false); err != nil || cached {
+		t.Errorf("GetStats() after the TTL = cached %v, error %v, want a recomputation", cached, err)
+	}
+}
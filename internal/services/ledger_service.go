@@ -2,11 +2,18 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/tunecent/backend/internal/database"
 	"github.com/tunecent/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrDistributionNotFound = errors.New("royalty distribution not found")
+	ErrSplitNotFound        = errors.New("no split record for the distribution's payment")
 )
 
 type LedgerService struct {
@@ -156,8 +163,43 @@ func (s *LedgerService) GetSplitRecordByTxHash(ctx context.Context, txHash strin
 		return nil, fmt.Errorf("split record not found: %w", err)
 	}
 
+	return s.splitRecordDetail(ctx, &splitRecord)
+}
+
+// GetSplitForDistribution returns the split record of the payment a royalty
+// distribution belongs to, with all of that payment's distributions
+func (s *LedgerService) GetSplitForDistribution(ctx context.Context, distributionID uint) (*SplitRecordDetail, error) {
+	var distribution models.RoyaltyDistribution
+	if err := s.db.WithContext(ctx).First(&distribution, distributionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDistributionNotFound
+		}
+		return nil, fmt.Errorf("failed to load distribution: %w", err)
+	}
+
+	var splitRecord models.SplitRecord
+	if err := s.db.WithContext(ctx).
+		Where("payment_id = ?", distribution.PaymentID).
+		Order("created_at DESC").
+		First(&splitRecord).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSplitNotFound
+		}
+		return nil, fmt.Errorf("failed to load split record: %w", err)
+	}
+
+	return s.splitRecordDetail(ctx, &splitRecord)
+}
+
+// splitRecordDetail loads the distributions of a split record's payment
+func (s *LedgerService) splitRecordDetail(ctx context.Context, splitRecord *models.SplitRecord) (*SplitRecordDetail, error) {
 	var distributions []models.RoyaltyDistribution
-	s.db.Where("payment_id = ?", splitRecord.PaymentID).Find(&distributions)
+	if err := s.db.WithContext(ctx).
+		Where("payment_id = ?", splitRecord.PaymentID).
+		Order("id ASC").
+		Find(&distributions).Error; err != nil {
+		return nil, fmt.Errorf("failed to load distributions: %w", err)
+	}
 
 	return &SplitRecordDetail{
 		ID:             splitRecord.ID,
@@ -182,3 +224,48 @@ func (s *LedgerService) GetUserLedger(ctx context.Context, userAddress string, l
 
 	return distributions, total, nil
 }
+
+// SplitExportRow is one distribution of a split record, flattened for export
+type SplitExportRow struct {
+	SplitID        uint
+	PaymentID      uint
+	Beneficiary    string
+	Amount         string
+	TxHash         string
+	BlockNumber    uint64
+	BlockTimestamp time.Time
+}
+
+// StreamSplitRows calls fn for every distribution of the track's split
+// records, in split then distribution order, reading them through a database
+// cursor so the export is never held in memory. It returns how many rows were
+// passed to fn.
+func (s *LedgerService) StreamSplitRows(ctx context.Context, tokenID uint64, fn func(SplitExportRow) error) (int, error) {
+	rows, err := s.db.WithContext(ctx).Table("split_records sr").
+		Select(`sr.id as split_id, sr.payment_id, rd.beneficiary, rd.amount,
+			sr.tx_hash, sr.block_number, sr.block_timestamp`).
+		Joins("JOIN royalty_distributions rd ON rd.payment_id = sr.payment_id").
+		Where("sr.token_id = ?", tokenID).
+		Order("sr.id ASC, rd.id ASC").
+		Rows()
+	if err != nil {
+		return 0, fmt.Errorf("failed to query split records: %w", err)
+	}
+	defer rows.Close()
+
+	written := 0
+	for rows.Next() {
+		var row SplitExportRow
+		if err := s.db.ScanRows(rows, &row); err != nil {
+			return written, fmt.Errorf("failed to read split record: %w", err)
+		}
+		if err := fn(row); err != nil {
+			return written, err
+		}
+		written++
+	}
+	if err := rows.Err(); err != nil {
+		return written, fmt.Errorf("failed to read split records: %w", err)
+	}
+	return written, nil
+}
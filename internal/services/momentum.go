@@ -0,0 +1,53 @@
+package services
+
+import "math"
+
+// Momentum directions
+const (
+	MomentumPositive = "positive"
+	MomentumNegative = "negative"
+	MomentumFlat     = "flat"
+)
+
+// Momentum is the change in a track's growth between two consecutive
+// periods: whether plays and views are accelerating or slowing down
+type Momentum struct {
+	Direction      string  `json:"direction"`
+	Magnitude      float64 `json:"magnitude"`       // change in daily growth, plays+views per day
+	RelativeChange float64 `json:"relative_change"` // percent change in daily growth versus the earlier period
+	PreviousGrowth float64 `json:"previous_growth"` // plays+views per day in the earlier period
+	RecentGrowth   float64 `json:"recent_growth"`   // plays+views per day in the latest period
+	SufficientData bool    `json:"sufficient_data"`
+}
+
+// ComputeMomentum takes cumulative totals at the start of the earlier
+// period, between the periods and now, and compares the daily growth of the
+// two periods. Without all three snapshots it reports zero, flat momentum.
+func ComputeMomentum(snapshots []uint64, periodDays int) Momentum {
+	if len(snapshots) < 3 || periodDays <= 0 {
+		return Momentum{Direction: MomentumFlat}
+	}
+
+	n := len(snapshots)
+	previous := (float64(snapshots[n-2]) - float64(snapshots[n-3])) / float64(periodDays)
+	recent := (float64(snapshots[n-1]) - float64(snapshots[n-2])) / float64(periodDays)
+	change := recent - previous
+
+	momentum := Momentum{
+		Direction:      MomentumFlat,
+		Magnitude:      math.Round(math.Abs(change)*100) / 100,
+		PreviousGrowth: math.Round(previous*100) / 100,
+		RecentGrowth:   math.Round(recent*100) / 100,
+		SufficientData: true,
+	}
+	switch {
+	case change > 0:
+		momentum.Direction = MomentumPositive
+	case change < 0:
+		momentum.Direction = MomentumNegative
+	}
+	if previous != 0 {
+		momentum.RelativeChange = math.Round(change/math.Abs(previous)*10000) / 100
+	}
+	return momentum
+}
@@ -0,0 +1,67 @@
+package services
+
+import "testing"
+
+func TestComputeMomentum(t *testing.T) {
+	tests := []struct {
+		name       string
+		snapshots  []uint64
+		periodDays int
+		want       Momentum
+	}{
+		{
+			name:       "accelerating growth",
+			snapshots:  []uint64{1000, 1700, 3100},
+			periodDays: 7,
+			want:       Momentum{Direction: MomentumPositive, Magnitude: 100, RelativeChange: 100, PreviousGrowth: 100, RecentGrowth: 200, SufficientData: true},
+		},
+		{
+			name:       "slowing growth",
+			snapshots:  []uint64{1000, 2400, 3100},
+			periodDays: 7,
+			want:       Momentum{Direction: MomentumNegative, Magnitude: 100, RelativeChange: -50, PreviousGrowth: 200, RecentGrowth: 100, SufficientData: true},
+		},
+		{
+			name:       "steady growth",
+			snapshots:  []uint64{1000, 1700, 2400},
+			periodDays: 7,
+			want:       Momentum{Direction: MomentumFlat, PreviousGrowth: 100, RecentGrowth: 100, SufficientData: true},
+		},
+		{
+			name:       "only the last two periods count",
+			snapshots:  []uint64{0, 5000, 5000, 5070},
+			periodDays: 7,
+			want:       Momentum{Direction: MomentumPositive, Magnitude: 10, RecentGrowth: 10, SufficientData: true},
+		},
+		{
+			name:       "totals that went down",
+			snapshots:  []uint64{1000, 1700, 1000},
+			periodDays: 7,
+			want:       Momentum{Direction: MomentumNegative, Magnitude: 200, RelativeChange: -200, PreviousGrowth: 100, RecentGrowth: -100, SufficientData: true},
+		},
+		{
+			name:       "two snapshots are not enough",
+			snapshots:  []uint64{1000, 1700},
+			periodDays: 7,
+			want:       Momentum{Direction: MomentumFlat},
+		},
+		{
+			name:       "no snapshots",
+			periodDays: 7,
+			want:       Momentum{Direction: MomentumFlat},
+		},
+		{
+			name:      "no period length",
+			snapshots: []uint64{1000, 1700, 3100},
+			want:      Momentum{Direction: MomentumFlat},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputeMomentum(tt.snapshots, tt.periodDays); got != tt.want {
+				t.Errorf("ComputeMomentum() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
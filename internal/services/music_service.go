@@ -2,21 +2,40 @@ package services
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/tunecent/backend/internal/blockchain"
 	"github.com/tunecent/backend/internal/database"
 	"github.com/tunecent/backend/internal/models"
+	"github.com/tunecent/backend/pkg/audio"
 	"github.com/tunecent/backend/pkg/fingerprint"
 	"github.com/tunecent/backend/pkg/ipfs"
+	"gorm.io/gorm"
 )
 
+// ErrInvalidFingerprint is returned for a fingerprint hash in the wrong format
+var ErrInvalidFingerprint = errors.New("invalid fingerprint hash")
+
 type MusicService struct {
 	db          *database.DB
 	ipfs        *ipfs.Service
 	fingerprint *fingerprint.Service
 	blockchain  *blockchain.Service
+
+	onRegistered []func()
+	storageQuota int64 // bytes per creator; 0 = unlimited
+}
+
+// OnRegistered adds a callback run after each successful registration, e.g.
+// to invalidate caches that count tracks
+func (s *MusicService) OnRegistered(fn func()) {
+	s.onRegistered = append(s.onRegistered, fn)
 }
 
 func NewMusicService(db *database.DB, ipfsService *ipfs.Service, fpService *fingerprint.Service, bcService *blockchain.Service) *MusicService {
@@ -28,14 +47,19 @@ func NewMusicService(db *database.DB, ipfsService *ipfs.Service, fpService *fing
 	}
 }
 
+
 type RegisterMusicRequest struct {
-	CreatorAddress string `json:"creator_address" binding:"required"`
-	Title          string `json:"title" binding:"required"`
-	Artist         string `json:"artist" binding:"required"`
-	Genre          string `json:"genre"`
-	Description    string `json:"description"`
-	AudioData      []byte `json:"-"` // Binary audio data
-	Duration       int    `json:"duration"`
+	CreatorAddress  string `json:"creator_address" binding:"required"`
+	UploaderAddress string `json:"-"` // authenticated caller, charged for the storage
+	Title           string `json:"title" binding:"required"`
+	Artist          string `json:"artist" binding:"required"`
+	Genre           string `json:"genre"`
+	Description     string `json:"description"`
+	AudioData       []byte `json:"-"` // Binary audio data
+	AudioCID        string `json:"-"` // Set when the audio was uploaded directly to IPFS
+	Duration        int    `json:"duration"`
+	ISRC            string `json:"isrc"`
+	Explicit        *bool  `json:"explicit"`
 }
 
 type RegisterMusicResponse struct {
@@ -54,12 +78,26 @@ func (s *MusicService) RegisterMusic(ctx context.Context, req *RegisterMusicRequ
 		return nil, fmt.Errorf("failed to generate fingerprint: %w", err)
 	}
 
+	// Extract acoustic features; registration continues without them on failure
+	features, err := s.fingerprint.ExtractFeatures(req.AudioData)
+	if err != nil {
+		slog.WarnContext(ctx, "Feature extraction failed, continuing without features",
+			"creator", req.CreatorAddress, "fingerprint", fingerprintHash, "error", err)
+		features = &fingerprint.AudioFeatures{}
+	}
+
 	// Step 2: Check if fingerprint already exists
 	var existingMusic models.MusicMetadata
 	if err := s.db.Where("fingerprint_hash = ?", fingerprintHash).First(&existingMusic).Error; err == nil {
 		return nil, fmt.Errorf("music already registered with token ID: %d", existingMusic.TokenID)
 	}
 
+	// Count the audio against the uploader's storage quota; released again if registration fails
+	audioBytes := int64(len(req.AudioData))
+	if err := s.reserveStorage(ctx, req.UploaderAddress, audioBytes); err != nil {
+		return nil, err
+	}
+
 	// Step 3: Upload metadata to IPFS (optional for local dev)
 	var ipfsCID string
 
@@ -80,7 +118,8 @@ func (s *MusicService) RegisterMusic(ctx context.Context, req *RegisterMusicRequ
 		// For local development without IPFS credentials, use a mock CID
 		ipfsCID = fmt.Sprintf("QmMOCK%x", time.Now().UnixNano())
 		// Don't return error, just log it
-		fmt.Printf("IPFS upload failed (using mock CID): %v\n", err)
+		slog.WarnContext(ctx, "IPFS upload failed, using mock CID",
+			"creator", req.CreatorAddress, "fingerprint", fingerprintHash, "cid", ipfsCID, "error", err)
 	}
 
 	// Step 4: In production, call smart contract to register music
@@ -94,6 +133,11 @@ func (s *MusicService) RegisterMusic(ctx context.Context, req *RegisterMusicRequ
 	// txHash := tx.Hash
 
 	// Step 5: Save to database
+	var audioFileURL string
+	if req.AudioCID != "" {
+		audioFileURL = s.ipfs.GetURL(req.AudioCID)
+	}
+
 	musicMetadata := &models.MusicMetadata{
 		TokenID:         tokenID,
 		CreatorAddress:  req.CreatorAddress,
@@ -103,13 +147,23 @@ func (s *MusicService) RegisterMusic(ctx context.Context, req *RegisterMusicRequ
 		Description:     req.Description,
 		IPFSCID:         ipfsCID,
 		FingerprintHash: fingerprintHash,
+		AudioFileURL:    audioFileURL,
 		Duration:        req.Duration,
+		ISRC:            req.ISRC,
+		Explicit:        req.Explicit,
+		Tempo:           features.Tempo,
+		MusicalKey:      features.Key,
+		Loudness:        features.Loudness,
+		SampleRate:      features.SampleRate,
 		IsActive:        true,
 		TxHash:          txHash,
 		RegisteredAt:    time.Now(),
 	}
 
 	if err := s.db.Create(musicMetadata).Error; err != nil {
+		if releaseErr := s.releaseStorage(ctx, req.UploaderAddress, audioBytes); releaseErr != nil {
+			slog.ErrorContext(ctx, "Failed to release storage reservation", "uploader", req.UploaderAddress, "error", releaseErr)
+		}
 		return nil, fmt.Errorf("failed to save to database: %w", err)
 	}
 
@@ -124,6 +178,10 @@ func (s *MusicService) RegisterMusic(ctx context.Context, req *RegisterMusicRequ
 	}
 	s.db.Create(analytics)
 
+	for _, fn := range s.onRegistered {
+		fn()
+	}
+
 	return &RegisterMusicResponse{
 		TokenID:         tokenID,
 		IPFSCID:         ipfsCID,
@@ -134,6 +192,114 @@ func (s *MusicService) RegisterMusic(ctx context.Context, req *RegisterMusicRequ
 	}, nil
 }
 
+// SimilarityThreshold is the minimum fingerprint similarity reported as a match
+const SimilarityThreshold = 0.85
+
+// maxFingerprintMatches caps the similar tracks returned by CheckFingerprint
+const maxFingerprintMatches = 5
+
+// FingerprintMatch is a registered track whose fingerprint matches a checked one
+type FingerprintMatch struct {
+	TokenID    uint64  `json:"token_id"`
+	Title      string  `json:"title"`
+	Artist     string  `json:"artist"`
+	Similarity float64 `json:"similarity"`
+}
+
+// FingerprintCheck is the result of checking a fingerprint against the catalog
+type FingerprintCheck struct {
+	FingerprintHash string             `json:"fingerprint_hash"`
+	IsRegistered    bool               `json:"is_registered"`
+	Matches         []FingerprintMatch `json:"matches"`
+}
+
+// GenerateFingerprint fingerprints audio without storing anything
+func (s *MusicService) GenerateFingerprint(audioData []byte) (string, error) {
+	return s.fingerprint.Generate(audioData)
+}
+
+// CheckFingerprint reports registered tracks whose fingerprint is identical or
+// similar (at least SimilarityThreshold) to the given one. Nothing is written.
+func (s *MusicService) CheckFingerprint(ctx context.Context, fingerprintHash string) (*FingerprintCheck, error) {
+	if !s.fingerprint.Validate(fingerprintHash) {
+		return nil, ErrInvalidFingerprint
+	}
+
+	type candidate struct {
+		ID              uint
+		TokenID         uint64
+		Title           string
+		Artist          string
+		FingerprintHash string
+	}
+
+	matches := []FingerprintMatch{}
+	var batch []candidate
+	err := s.db.WithContext(ctx).Model(&models.MusicMetadata{}).
+		Select("id, token_id, title, artist, fingerprint_hash").
+		FindInBatches(&batch, 1000, func(tx *gorm.DB, _ int) error {
+			for _, cand := range batch {
+				similarity := s.fingerprint.Compare(fingerprintHash, cand.FingerprintHash)
+				if similarity >= SimilarityThreshold {
+					matches = append(matches, FingerprintMatch{
+						TokenID:    cand.TokenID,
+						Title:      cand.Title,
+						Artist:     cand.Artist,
+						Similarity: similarity,
+					})
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan fingerprints: %w", err)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Similarity > matches[j].Similarity
+	})
+	if len(matches) > maxFingerprintMatches {
+		matches = matches[:maxFingerprintMatches]
+	}
+
+	return &FingerprintCheck{
+		FingerprintHash: fingerprintHash,
+		IsRegistered:    len(matches) > 0 && matches[0].Similarity == 1,
+		Matches:         matches,
+	}, nil
+}
+
+// CreateUploadURL returns a signed direct upload target for an audio file
+func (s *MusicService) CreateUploadURL(ctx context.Context, filename string, maxBytes int64, ttl time.Duration) (*ipfs.UploadTarget, error) {
+	return s.ipfs.CreateUploadURL(ctx, filename, maxBytes, audio.ContentTypes(), ttl)
+}
+
+// FetchUploadedAudio verifies that cid is pinned and downloads it so it can be
+// validated and fingerprinted. The returned filename carries the file extension.
+func (s *MusicService) FetchUploadedAudio(ctx context.Context, cid string, maxBytes int64) ([]byte, string, error) {
+	pin, err := s.ipfs.VerifyPin(ctx, cid)
+	if err != nil {
+		return nil, "", err
+	}
+	if pin.Size > maxBytes {
+		return nil, "", ipfs.ErrTooLarge
+	}
+
+	data, err := s.ipfs.FetchFile(ctx, cid, maxBytes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filename := pin.Name
+	if _, ok := audio.FormatForFilename(filename); !ok {
+		if format, detected := audio.Detect(data); detected {
+			filename = cid + "." + string(format)
+		}
+	}
+
+	return data, filename, nil
+}
+
 func (s *MusicService) GetMusic(ctx context.Context, tokenID uint64) (*models.MusicMetadata, error) {
 	var music models.MusicMetadata
 	if err := s.db.Where("token_id = ?", tokenID).First(&music).Error; err != nil {
@@ -165,6 +331,81 @@ func (s *MusicService) ListMusic(ctx context.Context, limit, offset int, creator
 	return musics, total, nil
 }
 
+// MusicFeatures is the acoustic feature set stored for a track
+type MusicFeatures struct {
+	TokenID    uint64  `json:"token_id"`
+	Duration   int     `json:"duration"`
+	Tempo      float64 `json:"tempo"`
+	MusicalKey string  `json:"musical_key"`
+	Loudness   float64 `json:"loudness"`
+	SampleRate int     `json:"sample_rate"`
+}
+
+func (s *MusicService) GetFeatures(ctx context.Context, tokenID uint64) (*MusicFeatures, error) {
+	music, err := s.GetMusic(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MusicFeatures{
+		TokenID:    music.TokenID,
+		Duration:   music.Duration,
+		Tempo:      music.Tempo,
+		MusicalKey: music.MusicalKey,
+		Loudness:   music.Loudness,
+		SampleRate: music.SampleRate,
+	}, nil
+}
+
+// ListenLink is one place a track can be streamed
+type ListenLink struct {
+	URL        string `json:"url"`
+	ExternalID string `json:"external_id,omitempty"`
+}
+
+// ListenLinks groups a track's streaming links by platform
+type ListenLinks struct {
+	TokenID uint64                  `json:"token_id"`
+	Title   string                  `json:"title"`
+	Artist  string                  `json:"artist"`
+	Links   map[string][]ListenLink `json:"links"`
+}
+
+// GetListenLinks returns the external URLs of every platform the track is
+// live on, plus its IPFS audio under the "ipfs" key
+func (s *MusicService) GetListenLinks(ctx context.Context, tokenID uint64) (*ListenLinks, error) {
+	music, err := s.GetMusic(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	var platforms []models.PlatformDistribution
+	if err := s.db.WithContext(ctx).
+		Where("token_id = ? AND status = ? AND external_url <> ''", tokenID, "live").
+		Order("platform ASC").
+		Find(&platforms).Error; err != nil {
+		return nil, fmt.Errorf("failed to load platform distributions: %w", err)
+	}
+
+	links := make(map[string][]ListenLink)
+	for _, platform := range platforms {
+		links[platform.Platform] = append(links[platform.Platform], ListenLink{
+			URL:        platform.ExternalURL,
+			ExternalID: platform.ExternalID,
+		})
+	}
+	if music.AudioFileURL != "" {
+		links["ipfs"] = []ListenLink{{URL: music.AudioFileURL}}
+	}
+
+	return &ListenLinks{
+		TokenID: music.TokenID,
+		Title:   music.Title,
+		Artist:  music.Artist,
+		Links:   links,
+	}, nil
+}
+
 func (s *MusicService) GetAnalytics(ctx context.Context, tokenID uint64) (*models.Analytics, error) {
 	var analytics models.Analytics
 	if err := s.db.Where("token_id = ?", tokenID).First(&analytics).Error; err != nil {
@@ -180,3 +421,100 @@ func (s *MusicService) VerifyFingerprint(ctx context.Context, fingerprintHash st
 	}
 	return &music, nil
 }
+
+// On-chain verification outcomes reported in a track's provenance
+const (
+	OnChainVerified      = "verified"
+	OnChainMismatch      = "mismatch"
+	OnChainNotFound      = "not_found"
+	OnChainUnavailable   = "unavailable"    // the chain could not be queried
+	OnChainNotConfigured = "not_configured" // no blockchain client
+)
+
+// OnChainVerification is what the MusicRegistry reports for a track's fingerprint
+type OnChainVerification struct {
+	Status  string  `json:"status"`
+	TokenID *uint64 `json:"token_id,omitempty"`
+	Creator string  `json:"creator,omitempty"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// Provenance is the off-chain registration record of a track together with
+// its on-chain verification. Mismatches lists every field where the two
+// disagree.
+type Provenance struct {
+	TokenID         uint64               `json:"token_id"`
+	Title           string               `json:"title"`
+	Artist          string               `json:"artist"`
+	CreatorAddress  string               `json:"creator_address"`
+	RegisteredAt    time.Time            `json:"registered_at"`
+	TxHash          string               `json:"tx_hash"`
+	IPFSCID         string               `json:"ipfs_cid"`
+	GatewayURL      string               `json:"gateway_url"`
+	FingerprintHash string               `json:"fingerprint_hash"`
+	OnChain         *OnChainVerification `json:"on_chain"`
+	Mismatches      []string             `json:"mismatches"`
+}
+
+// GetProvenance assembles a track's provenance record, verifying its
+// fingerprint against the MusicRegistry when blockchain is configured
+func (s *MusicService) GetProvenance(ctx context.Context, tokenID uint64) (*Provenance, error) {
+	music, err := s.GetMusic(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	provenance := &Provenance{
+		TokenID:         music.TokenID,
+		Title:           music.Title,
+		Artist:          music.Artist,
+		CreatorAddress:  music.CreatorAddress,
+		RegisteredAt:    music.RegisteredAt,
+		TxHash:          music.TxHash,
+		IPFSCID:         music.IPFSCID,
+		GatewayURL:      s.ipfs.GetURL(music.IPFSCID),
+		FingerprintHash: music.FingerprintHash,
+		OnChain:         &OnChainVerification{Status: OnChainNotConfigured},
+		Mismatches:      []string{},
+	}
+	if s.blockchain == nil {
+		return provenance, nil
+	}
+
+	var hash [32]byte
+	decoded, err := hex.DecodeString(strings.TrimPrefix(music.FingerprintHash, "0x"))
+	if err != nil || len(decoded) != len(hash) {
+		provenance.OnChain = &OnChainVerification{Status: OnChainUnavailable, Error: "stored fingerprint hash is not 32 bytes of hex"}
+		return provenance, nil
+	}
+	copy(hash[:], decoded)
+
+	exists, chainTokenID, creator, err := s.blockchain.VerifyFingerprint(ctx, hash)
+	if err != nil {
+		provenance.OnChain = &OnChainVerification{Status: OnChainUnavailable, Error: err.Error()}
+		return provenance, nil
+	}
+	if !exists {
+		provenance.OnChain = &OnChainVerification{Status: OnChainNotFound}
+		provenance.Mismatches = append(provenance.Mismatches, "fingerprint_hash")
+		return provenance, nil
+	}
+
+	verification := &OnChainVerification{Status: OnChainVerified, Creator: creator.Hex()}
+	if chainTokenID != nil && chainTokenID.IsUint64() {
+		id := chainTokenID.Uint64()
+		verification.TokenID = &id
+	}
+	if verification.TokenID == nil || *verification.TokenID != music.TokenID {
+		provenance.Mismatches = append(provenance.Mismatches, "token_id")
+	}
+	if !strings.EqualFold(verification.Creator, music.CreatorAddress) {
+		provenance.Mismatches = append(provenance.Mismatches, "creator_address")
+	}
+	if len(provenance.Mismatches) > 0 {
+		verification.Status = OnChainMismatch
+	}
+	provenance.OnChain = verification
+
+	return provenance, nil
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tunecent/backend/internal/models"
+)
+
+// MaxNearDuplicateScan caps how many tracks FindNearDuplicates compares
+// pairwise, bounding the work at about two million comparisons
+const MaxNearDuplicateScan = 2000
+
+// ErrInvalidThreshold is returned for similarity thresholds outside (0, 1]
+var ErrInvalidThreshold = errors.New("threshold must be greater than 0 and at most 1")
+
+// DuplicateTrack is a track in a near-duplicate cluster
+type DuplicateTrack struct {
+	TokenID         uint64    `json:"token_id"`
+	Title           string    `json:"title"`
+	Artist          string    `json:"artist"`
+	CreatorAddress  string    `json:"creator_address"`
+	FingerprintHash string    `json:"fingerprint_hash"`
+	RegisteredAt    time.Time `json:"registered_at"`
+}
+
+// DuplicatePair is two tracks whose fingerprints are at least as similar as
+// the threshold
+type DuplicatePair struct {
+	TokenA     uint64  `json:"token_a"`
+	TokenB     uint64  `json:"token_b"`
+	Similarity float64 `json:"similarity"`
+}
+
+// DuplicateCluster is a group of tracks linked by similar pairs. Tracks can
+// be in the same cluster through a chain of pairs without being similar to
+// each other directly.
+type DuplicateCluster struct {
+	Tracks        []DuplicateTrack `json:"tracks"`
+	Pairs         []DuplicatePair  `json:"pairs"`
+	MaxSimilarity float64          `json:"max_similarity"`
+}
+
+// NearDuplicateReport lists the clusters found among the scanned tracks.
+// Truncated is set when the catalog is larger than MaxNearDuplicateScan and
+// only the most recently registered tracks were compared.
+type NearDuplicateReport struct {
+	Threshold float64            `json:"threshold"`
+	Algorithm string             `json:"algorithm"`
+	Scanned   int                `json:"scanned"`
+	Truncated bool               `json:"truncated"`
+	Clusters  []DuplicateCluster `json:"clusters"`
+}
+
+// SimilarPair is two item indexes whose similarity met the threshold
+type SimilarPair struct {
+	I, J       int
+	Similarity float64
+}
+
+// ClusterBySimilarity compares every pair of n items and groups those
+// linked by a similarity of at least threshold. It returns the clusters of
+// two or more items, each listing item indexes in ascending order, largest
+// cluster first, and the qualifying pairs of each cluster.
+func ClusterBySimilarity(n int, similarity func(i, j int) float64, threshold float64) ([][]int, [][]SimilarPair) {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	var pairs []SimilarPair
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			score := similarity(i, j)
+			if score < threshold {
+				continue
+			}
+			pairs = append(pairs, SimilarPair{I: i, J: j, Similarity: score})
+			if ri, rj := find(i), find(j); ri != rj {
+				parent[rj] = ri
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	for i := 0; i < n; i++ {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+	var roots []int
+	for root, group := range members {
+		if len(group) > 1 {
+			roots = append(roots, root)
+		}
+	}
+	sort.Slice(roots, func(a, b int) bool {
+		ga, gb := members[roots[a]], members[roots[b]]
+		if len(ga) != len(gb) {
+			return len(ga) > len(gb)
+		}
+		return ga[0] < gb[0]
+	})
+
+	clusterOf := make(map[int]int, len(roots))
+	clusters := make([][]int, len(roots))
+	for c, root := range roots {
+		clusterOf[root] = c
+		clusters[c] = members[root]
+	}
+	clusterPairs := make([][]SimilarPair, len(roots))
+	for _, pair := range pairs {
+		c := clusterOf[find(pair.I)]
+		clusterPairs[c] = append(clusterPairs[c], pair)
+	}
+	return clusters, clusterPairs
+}
+
+// FindNearDuplicates clusters registered tracks whose fingerprints the
+// configured algorithm rates at least threshold similar. With the SHA256 mock
+// only identical fingerprints match, and those cannot be registered twice,
+// so clusters appear once a fuzzy algorithm is configured.
+func (s *MusicService) FindNearDuplicates(ctx context.Context, threshold float64) (*NearDuplicateReport, error) {
+	if threshold <= 0 || threshold > 1 {
+		return nil, ErrInvalidThreshold
+	}
+
+	var total int64
+	if err := s.db.WithContext(ctx).Model(&models.MusicMetadata{}).Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count tracks: %w", err)
+	}
+
+	var tracks []DuplicateTrack
+	if err := s.db.WithContext(ctx).Model(&models.MusicMetadata{}).
+		Select("token_id, title, artist, creator_address, fingerprint_hash, registered_at").
+		Order("registered_at DESC, token_id DESC").
+		Limit(MaxNearDuplicateScan).
+		Scan(&tracks).Error; err != nil {
+		return nil, fmt.Errorf("failed to load fingerprints: %w", err)
+	}
+
+	indexes, pairs := ClusterBySimilarity(len(tracks), func(i, j int) float64 {
+		return s.fingerprint.Compare(tracks[i].FingerprintHash, tracks[j].FingerprintHash)
+	}, threshold)
+
+	report := &NearDuplicateReport{
+		Threshold: threshold,
+		Algorithm: s.fingerprint.Algorithm(),
+		Scanned:   len(tracks),
+		Truncated: total > int64(len(tracks)),
+		Clusters:  make([]DuplicateCluster, len(indexes)),
+	}
+	for c, group := range indexes {
+		cluster := DuplicateCluster{
+			Tracks: make([]DuplicateTrack, len(group)),
+			Pairs:  make([]DuplicatePair, len(pairs[c])),
+		}
+		for k, i := range group {
+			cluster.Tracks[k] = tracks[i]
+		}
+		for k, pair := range pairs[c] {
+			cluster.Pairs[k] = DuplicatePair{
+				TokenA:     tracks[pair.I].TokenID,
+				TokenB:     tracks[pair.J].TokenID,
+				Similarity: pair.Similarity,
+			}
+			cluster.MaxSimilarity = max(cluster.MaxSimilarity, pair.Similarity)
+		}
+		report.Clusters[c] = cluster
+	}
+	return report, nil
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/tunecent/backend/internal/database/dbtest"
+	"github.com/tunecent/backend/pkg/fingerprint"
+)
+
+// hammingAlgorithm rates equal-length fingerprints by the share of
+// positions that match, standing in for a fuzzy acoustic algorithm
+type hammingAlgorithm struct{}
+
+func (hammingAlgorithm) Name() string                     { return "hamming" }
+func (hammingAlgorithm) Generate([]byte) (string, error)  { return "", nil }
+func (hammingAlgorithm) Validate(fingerprint string) bool { return fingerprint != "" }
+
+func (hammingAlgorithm) Compare(fp1, fp2 string) float64 {
+	if len(fp1) != len(fp2) || len(fp1) == 0 {
+		return 0
+	}
+	same := 0
+	for i := range fp1 {
+		if fp1[i] == fp2[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(fp1))
+}
+
+func TestClusterBySimilarity(t *testing.T) {
+	tests := []struct {
+		name         string
+		fingerprints []string
+		threshold    float64
+		want         [][]int
+		wantPairs    []int // qualifying pairs per cluster
+	}{
+		{
+			name:         "similar fingerprints cluster apart from the rest",
+			fingerprints: []string{"aaaaaaaaaa", "zzzzzzzzzz", "aaaaaaaaab", "mmmmmmmmmm", "zzzzzzzzzy"},
+			threshold:    0.9,
+			want:         [][]int{{0, 2}, {1, 4}},
+			wantPairs:    []int{1, 1},
+		},
+		{
+			name:         "clusters chain through an intermediate track",
+			fingerprints: []string{"aaaaaaaaaa", "aaaaaaaabb", "aaaaaabbbb", "qqqqqqqqqq"},
+			threshold:    0.8,
+			want:         [][]int{{0, 1, 2}},
+			wantPairs:    []int{2},
+		},
+		{
+			name:         "largest cluster first",
+			fingerprints: []string{"zzzzzzzzzz", "aaaaaaaaaa", "zzzzzzzzzy", "aaaaaaaaab", "aaaaaaaabb"},
+			threshold:    0.8,
+			want:         [][]int{{1, 3, 4}, {0, 2}},
+			wantPairs:    []int{3, 1},
+		},
+		{
+			name:         "a threshold of one needs identical fingerprints",
+			fingerprints: []string{"aaaaaaaaaa", "aaaaaaaaab", "aaaaaaaaaa"},
+			threshold:    1,
+			want:         [][]int{{0, 2}},
+			wantPairs:    []int{1},
+		},
+		{
+			name:         "nothing similar enough",
+			fingerprints: []string{"aaaaaaaaaa", "aaaaabbbbb"},
+			threshold:    0.9,
+		},
+		{name: "no tracks", threshold: 0.9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, pairs := ClusterBySimilarity(len(tt.fingerprints), func(i, j int) float64 {
+				return hammingAlgorithm{}.Compare(tt.fingerprints[i], tt.fingerprints[j])
+			}, tt.threshold)
+
+			if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Fatalf("clusters = %v, want %v", got, tt.want)
+			}
+			for c, cluster := range pairs {
+				if len(cluster) != tt.wantPairs[c] {
+					t.Errorf("cluster %d has %d pairs, want %d", c, len(cluster), tt.wantPairs[c])
+				}
+				for _, pair := range cluster {
+					if pair.Similarity < tt.threshold {
+						t.Errorf("pair %+v is below the threshold %v", pair, tt.threshold)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFindNearDuplicates(t *testing.T) {
+	db, mock := dbtest.New(t)
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM `music_metadata`").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT token_id, title, artist, creator_address, fingerprint_hash, registered_at FROM `music_metadata` .*ORDER BY registered_at DESC, token_id DESC LIMIT").
+		WillReturnRows(sqlmock.NewRows([]string{"token_id", "title", "artist", "creator_address", "fingerprint_hash"}).
+			AddRow(9, "Hit (remaster)", "A", "0xb", "aaaaaaaaab").
+			AddRow(8, "Other", "B", "0xc", "qqqqqqqqqq").
+			AddRow(7, "Hit", "A", "0xa", "aaaaaaaaaa"))
+
+	service := NewMusicService(db, nil, fingerprint.NewService(hammingAlgorithm{}), nil)
+	got, err := service.FindNearDuplicates(t.Context(), 0.9)
+	if err != nil {
+		t.Fatalf("FindNearDuplicates() error = %v", err)
+	}
+
+	if got.Algorithm != "hamming" || got.Scanned != 3 || got.Truncated {
+		t.Errorf("algorithm %s, scanned %d, truncated %v, want hamming, 3, false", got.Algorithm, got.Scanned, got.Truncated)
+	}
+	if len(got.Clusters) != 1 {
+		t.Fatalf("got %d clusters, want 1", len(got.Clusters))
+	}
+	cluster := got.Clusters[0]
+	if len(cluster.Tracks) != 2 || cluster.Tracks[0].TokenID != 9 || cluster.Tracks[1].TokenID != 7 {
+		t.Errorf("tracks = %+v, want tokens 9 and 7", cluster.Tracks)
+	}
+	if want := []DuplicatePair{{TokenA: 9, TokenB: 7, Similarity: 0.9}}; !reflect.DeepEqual(cluster.Pairs, want) || cluster.MaxSimilarity != 0.9 {
+		t.Errorf("pairs = %+v, max %v, want %+v, max 0.9", cluster.Pairs, cluster.MaxSimilarity, want)
+	}
+}
+
+func TestFindNearDuplicatesRejectsThreshold(t *testing.T) {
+	for _, threshold := range []float64{0, -0.5, 1.01} {
+		db, _ := dbtest.New(t)
+		service := NewMusicService(db, nil, fingerprint.NewService(hammingAlgorithm{}), nil)
+		if _, err := service.FindNearDuplicates(t.Context(), threshold); !errors.Is(err, ErrInvalidThreshold) {
+			t.Errorf("FindNearDuplicates(%v) error = %v, want %v", threshold, err, ErrInvalidThreshold)
+		}
+	}
+}
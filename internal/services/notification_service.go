@@ -2,10 +2,15 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/tunecent/backend/internal/database"
 	"github.com/tunecent/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type NotificationService struct {
@@ -43,15 +48,28 @@ func (s *NotificationService) CreateNotification(ctx context.Context, req *Creat
 	return notification, nil
 }
 
-func (s *NotificationService) GetNotifications(ctx context.Context, userAddress string, limit, offset int, unreadOnly bool) ([]*models.Notification, int64, error) {
+// NotificationFilter narrows a notification listing; zero values match everything
+type NotificationFilter struct {
+	UnreadOnly bool
+	Type       string
+	RelatedID  *uint64 // token or campaign ID the notification is about
+}
+
+func (s *NotificationService) GetNotifications(ctx context.Context, userAddress string, limit, offset int, filter NotificationFilter) ([]*models.Notification, int64, error) {
 	var notifications []*models.Notification
 	var total int64
 
 	query := s.db.Model(&models.Notification{}).Where("user_address = ?", userAddress)
 
-	if unreadOnly {
+	if filter.UnreadOnly {
 		query = query.Where("is_read = ?", false)
 	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.RelatedID != nil {
+		query = query.Where("related_id = ?", *filter.RelatedID)
+	}
 
 	query.Count(&total)
 	query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&notifications)
@@ -68,6 +86,72 @@ func (s *NotificationService) GetUnreadCount(ctx context.Context, userAddress st
 	return count, err
 }
 
+// NotificationStats summarises a user's notifications by type and read state
+type NotificationStats struct {
+	UserAddress  string           `json:"user_address"`
+	Total        int64            `json:"total"`
+	ByType       map[string]int64 `json:"by_type"`
+	Read         int64            `json:"read"`
+	Unread       int64            `json:"unread"`
+	OldestUnread *time.Time       `json:"oldest_unread_at"`
+}
+
+// notificationTypes are always reported, even with a zero count
+var notificationTypes = []string{"payment", "contribution", "milestone", "alert"}
+
+// ErrInvalidNotificationType is returned for a type outside notificationTypes
+var ErrInvalidNotificationType = errors.New("type must be one of payment, contribution, milestone or alert")
+
+func (s *NotificationService) GetStats(ctx context.Context, userAddress string) (*NotificationStats, error) {
+	db := s.db.WithContext(ctx)
+	stats := &NotificationStats{
+		UserAddress: userAddress,
+		ByType:      make(map[string]int64, len(notificationTypes)),
+	}
+	for _, t := range notificationTypes {
+		stats.ByType[t] = 0
+	}
+
+	var byType []struct {
+		Type  string
+		Count int64
+	}
+	if err := db.Model(&models.Notification{}).
+		Select("type, COUNT(*) as count").
+		Where("user_address = ?", userAddress).
+		Group("type").
+		Scan(&byType).Error; err != nil {
+		return nil, fmt.Errorf("failed to count notifications by type: %w", err)
+	}
+	for _, row := range byType {
+		stats.ByType[row.Type] = row.Count
+		stats.Total += row.Count
+	}
+
+	var byState []struct {
+		IsRead       bool
+		Count        int64
+		OldestUnread *time.Time
+	}
+	if err := db.Model(&models.Notification{}).
+		Select("is_read, COUNT(*) as count, MIN(created_at) as oldest_unread").
+		Where("user_address = ?", userAddress).
+		Group("is_read").
+		Scan(&byState).Error; err != nil {
+		return nil, fmt.Errorf("failed to count notifications by read state: %w", err)
+	}
+	for _, row := range byState {
+		if row.IsRead {
+			stats.Read = row.Count
+			continue
+		}
+		stats.Unread = row.Count
+		stats.OldestUnread = row.OldestUnread
+	}
+
+	return stats, nil
+}
+
 func (s *NotificationService) MarkAsRead(ctx context.Context, notificationID uint, userAddress string) error {
 	result := s.db.Model(&models.Notification{}).
 		Where("id = ? AND user_address = ?", notificationID, userAddress).
@@ -90,6 +174,19 @@ func (s *NotificationService) MarkAllAsRead(ctx context.Context, userAddress str
 		Update("is_read", true).Error
 }
 
+// MarkTypeAsRead marks the user's unread notifications of one type as read
+// and returns how many changed
+func (s *NotificationService) MarkTypeAsRead(ctx context.Context, userAddress, notificationType string) (int64, error) {
+	if !slices.Contains(notificationTypes, notificationType) {
+		return 0, ErrInvalidNotificationType
+	}
+
+	result := s.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_address = ? AND type = ? AND is_read = ?", userAddress, notificationType, false).
+		Update("is_read", true)
+	return result.RowsAffected, result.Error
+}
+
 func (s *NotificationService) DeleteNotification(ctx context.Context, notificationID uint, userAddress string) error {
 	result := s.db.Where("id = ? AND user_address = ?", notificationID, userAddress).
 		Delete(&models.Notification{})
@@ -105,55 +202,102 @@ func (s *NotificationService) DeleteNotification(ctx context.Context, notificati
 	return nil
 }
 
+// defaultPreferences are the preferences of a user who never changed them
+func defaultPreferences(userAddress string) models.NotificationPreference {
+	return models.NotificationPreference{
+		UserAddress:        userAddress,
+		EmailNotifications: true,
+		RoyaltyAlerts:      true,
+		ContributionAlerts: true,
+		MilestoneAlerts:    true,
+		MarketingEmails:    false,
+	}
+}
+
 func (s *NotificationService) GetPreferences(ctx context.Context, userAddress string) (*models.NotificationPreference, error) {
 	var prefs models.NotificationPreference
 	err := s.db.Where("user_address = ?", userAddress).First(&prefs).Error
 
 	if err != nil {
 		// Create default preferences if not exists
-		prefs = models.NotificationPreference{
-			UserAddress:        userAddress,
-			EmailNotifications: true,
-			RoyaltyAlerts:      true,
-			ContributionAlerts: true,
-			MilestoneAlerts:    true,
-			MarketingEmails:    false,
-		}
+		prefs = defaultPreferences(userAddress)
 		s.db.Create(&prefs)
 	}
 
 	return &prefs, nil
 }
 
+// UpdatePreferences applies the given preference values and logs every value
+// that actually changed, in the same transaction, for the preference history
 func (s *NotificationService) UpdatePreferences(ctx context.Context, userAddress string, prefs map[string]bool) error {
-	var existing models.NotificationPreference
-	err := s.db.Where("user_address = ?", userAddress).First(&existing).Error
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.NotificationPreference
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_address = ?", userAddress).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Create with the defaults first so the log diffs against them
+			existing = defaultPreferences(userAddress)
+			err = tx.Create(&existing).Error
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load preferences: %w", err)
+		}
 
-	if err != nil {
-		// Create if not exists
-		existing = models.NotificationPreference{
-			UserAddress: userAddress,
+		fields := []struct {
+			name  string
+			value *bool
+		}{
+			{"email_notifications", &existing.EmailNotifications},
+			{"royalty_alerts", &existing.RoyaltyAlerts},
+			{"contribution_alerts", &existing.ContributionAlerts},
+			{"milestone_alerts", &existing.MilestoneAlerts},
+			{"marketing_emails", &existing.MarketingEmails},
 		}
-	}
 
-	// Update preferences
-	if val, ok := prefs["email_notifications"]; ok {
-		existing.EmailNotifications = val
-	}
-	if val, ok := prefs["royalty_alerts"]; ok {
-		existing.RoyaltyAlerts = val
-	}
-	if val, ok := prefs["contribution_alerts"]; ok {
-		existing.ContributionAlerts = val
-	}
-	if val, ok := prefs["milestone_alerts"]; ok {
-		existing.MilestoneAlerts = val
+		now := time.Now()
+		var changes []models.PreferenceChangeLog
+		for _, field := range fields {
+			val, ok := prefs[field.name]
+			if !ok || val == *field.value {
+				continue
+			}
+			changes = append(changes, models.PreferenceChangeLog{
+				UserAddress: userAddress,
+				Field:       field.name,
+				OldValue:    *field.value,
+				NewValue:    val,
+				ChangedAt:   now,
+			})
+			*field.value = val
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+
+		if err := tx.Save(&existing).Error; err != nil {
+			return fmt.Errorf("failed to update preferences: %w", err)
+		}
+		if err := tx.Create(&changes).Error; err != nil {
+			return fmt.Errorf("failed to log preference changes: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListPreferenceChanges returns the user's preference changes, newest first
+func (s *NotificationService) ListPreferenceChanges(ctx context.Context, userAddress string, limit, offset int) ([]models.PreferenceChangeLog, int64, error) {
+	query := s.db.WithContext(ctx).Model(&models.PreferenceChangeLog{}).Where("user_address = ?", userAddress)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count preference changes: %w", err)
 	}
-	if val, ok := prefs["marketing_emails"]; ok {
-		existing.MarketingEmails = val
+
+	changes := []models.PreferenceChangeLog{}
+	if err := query.Order("changed_at DESC, id DESC").Limit(limit).Offset(offset).Find(&changes).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list preference changes: %w", err)
 	}
 
-	return s.db.Save(&existing).Error
+	return changes, total, nil
 }
 
 // Helper function to create common notification types
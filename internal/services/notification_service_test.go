@@ -0,0 +1,209 @@
+package services
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestNotificationGetStats(t *testing.T) {
+	oldest := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		byType     *sqlmock.Rows
+		byState    *sqlmock.Rows
+		wantByType map[string]int64
+		wantTotal  int64
+		wantRead   int64
+		wantUnread int64
+		wantOldest *time.Time
+	}{
+		{
+			name: "mixed types and read states",
+			byType: sqlmock.NewRows([]string{"type", "count"}).
+				AddRow("payment", 4).
+				AddRow("milestone", 1),
+			byState: sqlmock.NewRows([]string{"is_read", "count", "oldest_unread"}).
+				AddRow(true, 3, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)).
+				AddRow(false, 2, oldest),
+			wantByType: map[string]int64{"payment": 4, "contribution": 0, "milestone": 1, "alert": 0},
+			wantTotal:  5,
+			wantRead:   3,
+			wantUnread: 2,
+			wantOldest: &oldest,
+		},
+		{
+			name:       "everything read",
+			byType:     sqlmock.NewRows([]string{"type", "count"}).AddRow("alert", 2),
+			byState:    sqlmock.NewRows([]string{"is_read", "count", "oldest_unread"}).AddRow(true, 2, oldest),
+			wantByType: map[string]int64{"payment": 0, "contribution": 0, "milestone": 0, "alert": 2},
+			wantTotal:  2,
+			wantRead:   2,
+		},
+		{
+			name:       "no notifications",
+			byType:     sqlmock.NewRows([]string{"type", "count"}),
+			byState:    sqlmock.NewRows([]string{"is_read", "count", "oldest_unread"}),
+			wantByType: map[string]int64{"payment": 0, "contribution": 0, "milestone": 0, "alert": 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT type, COUNT\\(\\*\\) as count FROM `notifications` WHERE user_address = \\? GROUP BY `type`").
+				WithArgs("0xuser").
+				WillReturnRows(tt.byType)
+			mock.ExpectQuery("SELECT is_read, COUNT\\(\\*\\) as count, MIN\\(created_at\\) as oldest_unread FROM `notifications` WHERE user_address = \\? GROUP BY `is_read`").
+				WithArgs("0xuser").
+				WillReturnRows(tt.byState)
+
+			got, err := NewNotificationService(db).GetStats(t.Context(), "0xuser")
+			if err != nil {
+				t.Fatalf("GetStats() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.ByType, tt.wantByType) {
+				t.Errorf("ByType = %v, want %v", got.ByType, tt.wantByType)
+			}
+			if got.Total != tt.wantTotal || got.Read != tt.wantRead || got.Unread != tt.wantUnread {
+				t.Errorf("total, read, unread = %d, %d, %d, want %d, %d, %d", got.Total, got.Read, got.Unread, tt.wantTotal, tt.wantRead, tt.wantUnread)
+			}
+			switch {
+			case tt.wantOldest == nil && got.OldestUnread != nil:
+				t.Errorf("OldestUnread = %s, want nil", got.OldestUnread)
+			case tt.wantOldest != nil && (got.OldestUnread == nil || !got.OldestUnread.Equal(*tt.wantOldest)):
+				t.Errorf("OldestUnread = %v, want %s", got.OldestUnread, tt.wantOldest)
+			}
+		})
+	}
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	prefColumns := []string{"id", "user_address", "email_notifications", "royalty_alerts", "contribution_alerts", "milestone_alerts", "marketing_emails"}
+
+	type change struct {
+		field    string
+		old, new bool
+	}
+
+	tests := []struct {
+		name        string
+		stored      bool // whether the user has saved preferences before
+		prefs       map[string]bool
+		wantChanges []change
+	}{
+		{
+			name:        "changed values are logged",
+			stored:      true,
+			prefs:       map[string]bool{"email_notifications": true, "royalty_alerts": false, "marketing_emails": true},
+			wantChanges: []change{{"royalty_alerts", true, false}, {"marketing_emails", false, true}},
+		},
+		{
+			name:   "unchanged values are not logged",
+			stored: true,
+			prefs:  map[string]bool{"email_notifications": true, "marketing_emails": false},
+		},
+		{
+			name:        "first change is diffed against the defaults",
+			prefs:       map[string]bool{"milestone_alerts": true, "contribution_alerts": false},
+			wantChanges: []change{{"contribution_alerts", true, false}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows(prefColumns)
+			if tt.stored {
+				rows.AddRow(3, "0xuser", true, true, true, true, false)
+			}
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT \\* FROM `notification_preferences` WHERE user_address = \\? ORDER BY `notification_preferences`.`id` LIMIT 1 FOR UPDATE").
+				WithArgs("0xuser").
+				WillReturnRows(rows)
+			if !tt.stored {
+				mock.ExpectExec("INSERT INTO `notification_preferences`").WillReturnResult(sqlmock.NewResult(3, 1))
+			}
+			if len(tt.wantChanges) > 0 {
+				mock.ExpectExec("UPDATE `notification_preferences` SET .* WHERE `id` = \\?").WillReturnResult(sqlmock.NewResult(0, 1))
+				// Every change goes into one insert inside the same transaction
+				var args []driver.Value
+				for _, c := range tt.wantChanges {
+					args = append(args, "0xuser", c.field, c.old, c.new, sqlmock.AnyArg())
+				}
+				mock.ExpectExec("INSERT INTO `preference_change_logs` \\(`user_address`,`field`,`old_value`,`new_value`,`changed_at`\\) VALUES " +
+					strings.TrimSuffix(strings.Repeat("\\(\\?,\\?,\\?,\\?,\\?\\),", len(tt.wantChanges)), ",")).
+					WithArgs(args...).
+					WillReturnResult(sqlmock.NewResult(1, int64(len(tt.wantChanges))))
+			}
+			mock.ExpectCommit()
+
+			if err := NewNotificationService(db).UpdatePreferences(t.Context(), "0xuser", tt.prefs); err != nil {
+				t.Fatalf("UpdatePreferences() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdatePreferencesRollsBackWhenLoggingFails(t *testing.T) {
+	db, mock := dbtest.New(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `notification_preferences`").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_address", "marketing_emails"}).AddRow(3, "0xuser", false))
+	mock.ExpectExec("UPDATE `notification_preferences`").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO `preference_change_logs`").WillReturnError(errors.New("disk full"))
+	mock.ExpectRollback()
+
+	if err := NewNotificationService(db).UpdatePreferences(t.Context(), "0xuser", map[string]bool{"marketing_emails": true}); err == nil {
+		t.Error("UpdatePreferences() error = nil, want the logging failure")
+	}
+}
+
+func TestMarkTypeAsRead(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      string
+		affected int64
+	}{
+		{name: "unread notifications of the type", typ: "payment", affected: 3},
+		{name: "nothing unread of the type", typ: "milestone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			// Only the requested type is matched, so other types stay unread
+			mock.ExpectBegin()
+			mock.ExpectExec("UPDATE `notifications` SET `is_read`=\\?,`updated_at`=\\? WHERE user_address = \\? AND type = \\? AND is_read = \\?$").
+				WithArgs(true, sqlmock.AnyArg(), "0xuser", tt.typ, false).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			mock.ExpectCommit()
+
+			got, err := NewNotificationService(db).MarkTypeAsRead(t.Context(), "0xuser", tt.typ)
+			if err != nil {
+				t.Fatalf("MarkTypeAsRead() error = %v", err)
+			}
+			if got != tt.affected {
+				t.Errorf("MarkTypeAsRead() = %d, want %d", got, tt.affected)
+			}
+		})
+	}
+}
+
+func TestMarkTypeAsReadRejectsUnknownType(t *testing.T) {
+	for _, typ := range []string{"", "Payment", "royalty"} {
+		t.Run(typ, func(t *testing.T) {
+			db, _ := dbtest.New(t)
+			if _, err := NewNotificationService(db).MarkTypeAsRead(t.Context(), "0xuser", typ); !errors.Is(err, ErrInvalidNotificationType) {
+				t.Errorf("MarkTypeAsRead(%q) error = %v, want %v", typ, err, ErrInvalidNotificationType)
+			}
+		})
+	}
+}
@@ -0,0 +1,396 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/tunecent/backend/internal/config"
+	"github.com/tunecent/backend/internal/database"
+	"github.com/tunecent/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Outbox channels
+const (
+	OutboxChannelNotification = "notification"
+	OutboxChannelWebhook      = "webhook"
+)
+
+// Outbox event statuses
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusDone    = "done"
+	OutboxStatusFailed  = "failed"
+	OutboxStatusSkipped = "skipped" // webhook events while no webhook URL is configured
+)
+
+// Outbox worker tuning: events are polled every OutboxPollInterval, retried
+// with exponential backoff capped at outboxMaxBackoff, and marked failed
+// after MaxOutboxAttempts
+const (
+	OutboxPollInterval = 5 * time.Second
+	MaxOutboxAttempts  = 10
+	outboxBatchSize    = 50
+	outboxBaseBackoff  = 5 * time.Second
+	outboxMaxBackoff   = time.Hour
+	outboxClaimMargin  = time.Minute // added to the webhook timeout while a delivery is in flight
+)
+
+// MaxWebhookReplays caps the manual replays of a webhook event, counted apart
+// from the worker's attempts, so a receiver that keeps failing cannot be
+// retried forever
+const MaxWebhookReplays = 5
+
+var (
+	// ErrNotWebhookEvent is returned when replaying an outbox event that is not a webhook
+	ErrNotWebhookEvent = errors.New("outbox event is not a webhook")
+	// ErrWebhookNotReplayable is returned when replaying a webhook that has not failed or been skipped
+	ErrWebhookNotReplayable = errors.New("only failed or skipped webhooks can be replayed")
+	// ErrReplayLimitReached is returned once a webhook has used up its manual replays
+	ErrReplayLimitReached = errors.New("webhook replay limit reached")
+	// ErrWebhookNotConfigured is returned when replaying while no webhook URL is configured
+	ErrWebhookNotConfigured = errors.New("webhook URL not configured")
+)
+
+// Webhook request headers; receivers dedupe on the event ID
+const (
+	WebhookSignatureHeader = "X-TuneCent-Signature"
+	WebhookEventIDHeader   = "X-TuneCent-Event-ID"
+	WebhookEventTypeHeader = "X-TuneCent-Event-Type"
+)
+
+// WebhookPayload is the JSON body POSTed for webhook events
+type WebhookPayload struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// EnqueueNotification records a notification in tx; it is created once tx
+// commits and the outbox worker picks it up. key must be unique per
+// notification so retried writers do not enqueue it twice.
+func EnqueueNotification(tx *gorm.DB, key string, req *CreateNotificationRequest) error {
+	return enqueueOutbox(tx, key, OutboxChannelNotification, "notification."+req.Type, req)
+}
+
+// EnqueueWebhook records a webhook event in tx for delivery after commit
+func EnqueueWebhook(tx *gorm.DB, key, eventType string, data interface{}) error {
+	return enqueueOutbox(tx, key, OutboxChannelWebhook, eventType, data)
+}
+
+func enqueueOutbox(tx *gorm.DB, key, channel, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	event := &models.OutboxEvent{
+		EventKey:      key,
+		Channel:       channel,
+		EventType:     eventType,
+		Payload:       string(payload),
+		Status:        OutboxStatusPending,
+		NextAttemptAt: time.Now(),
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(event).Error; err != nil {
+		return fmt.Errorf("failed to enqueue %s event: %w", eventType, err)
+	}
+	return nil
+}
+
+// SignWebhook returns the signature header value for a webhook body
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// OutboxBackoff is the delay before retrying an event that has failed attempts times
+func OutboxBackoff(attempts int) time.Duration {
+	backoff := outboxBaseBackoff
+	for i := 1; i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > outboxMaxBackoff {
+		backoff = outboxMaxBackoff
+	}
+	return backoff
+}
+
+// OutboxWorker delivers outbox events at least once: an event is only marked
+// done after delivery succeeds, so a crash in between causes a redelivery
+// that downstream dedup (notification outbox_event_id, webhook event ID)
+// absorbs
+type OutboxWorker struct {
+	db      *database.DB
+	webhook config.WebhookConfig
+	client  *http.Client
+}
+
+func NewOutboxWorker(db *database.DB, webhook config.WebhookConfig) *OutboxWorker {
+	return &OutboxWorker{
+		db:      db,
+		webhook: webhook,
+		client:  &http.Client{Timeout: webhook.Timeout},
+	}
+}
+
+// Run delivers due events every interval until ctx is cancelled
+func (w *OutboxWorker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := w.ProcessDue(ctx); err != nil {
+			slog.ErrorContext(ctx, "Outbox delivery failed", "error", err)
+		} else if n > 0 {
+			slog.DebugContext(ctx, "Outbox events processed", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue attempts delivery of up to one batch of due events and returns
+// how many were attempted
+func (w *OutboxWorker) ProcessDue(ctx context.Context) (int, error) {
+	var ids []uint
+	if err := w.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("status = ? AND next_attempt_at <= ?", OutboxStatusPending, time.Now()).
+		Order("id ASC").
+		Limit(outboxBatchSize).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, fmt.Errorf("failed to load outbox events: %w", err)
+	}
+
+	for _, id := range ids {
+		if err := w.process(ctx, id); err != nil {
+			return 0, err
+		}
+	}
+	return len(ids), nil
+}
+
+// process locks one event so concurrent workers skip it. Notifications are
+// delivered and recorded in that transaction. Webhooks are only claimed
+// there, by pushing next_attempt_at past the delivery timeout, so the POST
+// runs without holding the row lock; the outcome is then recorded in a
+// transaction of its own. A worker that dies mid-delivery leaves the claim to
+// expire and the event is redelivered.
+func (w *OutboxWorker) process(ctx context.Context, id uint) error {
+	var event models.OutboxEvent
+	claimed := false
+	err := w.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("id = ? AND status = ?", id, OutboxStatusPending).
+			First(&event).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil // taken by another worker or already handled
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case event.Channel == OutboxChannelNotification:
+			return recordOutboxAttempt(tx, &event, OutboxStatusDone, deliverNotification(tx, &event))
+		case event.Channel != OutboxChannelWebhook:
+			return recordOutboxAttempt(tx, &event, OutboxStatusFailed, fmt.Errorf("unknown outbox channel %q", event.Channel))
+		case w.webhook.URL == "":
+			return recordOutboxAttempt(tx, &event, OutboxStatusSkipped, nil)
+		}
+
+		claimed = true
+		return w.claimWebhook(tx, &event, time.Now())
+	})
+	if err != nil || !claimed {
+		return err
+	}
+
+	deliveryErr := w.DeliverWebhook(ctx, &event)
+	return w.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return recordOutboxAttempt(tx, &event, OutboxStatusDone, deliveryErr)
+	})
+}
+
+// claimWebhook marks a webhook event in flight until its delivery times out
+func (w *OutboxWorker) claimWebhook(tx *gorm.DB, event *models.OutboxEvent, now time.Time) error {
+	event.NextAttemptAt = now.Add(w.webhook.Timeout + outboxClaimMargin)
+	if err := tx.Model(event).Update("next_attempt_at", event.NextAttemptAt).Error; err != nil {
+		return fmt.Errorf("failed to claim outbox event %d: %w", event.ID, err)
+	}
+	return nil
+}
+
+// recordOutboxAttempt stores the result of a delivery attempt, scheduling a
+// retry with backoff or giving up after MaxOutboxAttempts. The update only
+// applies while the event is still pending with the attempts it was loaded
+// with, so an attempt whose claim expired and was taken over is not recorded
+// twice.
+func recordOutboxAttempt(tx *gorm.DB, event *models.OutboxEvent, status string, deliveryErr error) error {
+	attempts := event.Attempts
+	updates := outboxAttemptUpdates(event, status, deliveryErr, time.Now())
+	result := tx.Model(&models.OutboxEvent{}).
+		Where("id = ? AND status = ? AND attempts = ?", event.ID, OutboxStatusPending, attempts).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update outbox event %d: %w", event.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		slog.WarnContext(tx.Statement.Context, "Outbox attempt not recorded, event was taken over", "event_id", event.ID)
+	}
+	return nil
+}
+
+// outboxAttemptUpdates counts an attempt on event and returns the columns to
+// store for it
+func outboxAttemptUpdates(event *models.OutboxEvent, status string, deliveryErr error, now time.Time) map[string]interface{} {
+	event.Attempts++
+	updates := map[string]interface{}{"attempts": event.Attempts}
+
+	switch {
+	case deliveryErr == nil:
+		updates["status"] = status
+		updates["last_error"] = ""
+		updates["processed_at"] = now
+	case status == OutboxStatusFailed || event.Attempts >= MaxOutboxAttempts:
+		updates["status"] = OutboxStatusFailed
+		updates["last_error"] = deliveryErr.Error()
+	default:
+		updates["last_error"] = deliveryErr.Error()
+		updates["next_attempt_at"] = now.Add(OutboxBackoff(event.Attempts))
+	}
+	return updates
+}
+
+func deliverNotification(tx *gorm.DB, event *models.OutboxEvent) error {
+	var req CreateNotificationRequest
+	if err := json.Unmarshal([]byte(event.Payload), &req); err != nil {
+		return fmt.Errorf("invalid notification payload: %w", err)
+	}
+
+	eventID := event.ID
+	notification := &models.Notification{
+		UserAddress:   req.UserAddress,
+		Type:          req.Type,
+		Title:         req.Title,
+		Message:       req.Message,
+		RelatedID:     req.RelatedID,
+		TxHash:        req.TxHash,
+		OutboxEventID: &eventID,
+	}
+	// A notification already created for this event means an earlier attempt got through
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(notification).Error
+}
+
+// ReplayWebhook resends a failed or skipped webhook event once, signed with
+// the current secret. The outcome is recorded on the event, which is never
+// handed back to the worker: a failed replay stays failed until replayed
+// again, up to MaxWebhookReplays times.
+func (w *OutboxWorker) ReplayWebhook(ctx context.Context, id uint) (*models.OutboxEvent, error) {
+	if w.webhook.URL == "" {
+		return nil, ErrWebhookNotConfigured
+	}
+
+	var event models.OutboxEvent
+	err := w.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&event, id).Error; err != nil {
+			return err
+		}
+		if err := checkWebhookReplayable(&event); err != nil {
+			return err
+		}
+
+		event.Attempts++
+		event.Replays++
+		updates := map[string]interface{}{"attempts": event.Attempts, "replays": event.Replays}
+		if deliveryErr := w.DeliverWebhook(ctx, &event); deliveryErr != nil {
+			event.Status = OutboxStatusFailed
+			event.LastError = deliveryErr.Error()
+		} else {
+			now := time.Now()
+			event.Status = OutboxStatusDone
+			event.LastError = ""
+			event.ProcessedAt = &now
+			updates["processed_at"] = now
+		}
+		updates["status"] = event.Status
+		updates["last_error"] = event.LastError
+
+		if err := tx.Model(&event).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update outbox event %d: %w", event.ID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// checkWebhookReplayable allows replaying failed or skipped webhook events
+// that have replays left
+func checkWebhookReplayable(event *models.OutboxEvent) error {
+	if event.Channel != OutboxChannelWebhook {
+		return ErrNotWebhookEvent
+	}
+	if event.Status != OutboxStatusFailed && event.Status != OutboxStatusSkipped {
+		return fmt.Errorf("%w (status: %s)", ErrWebhookNotReplayable, event.Status)
+	}
+	if event.Replays >= MaxWebhookReplays {
+		return fmt.Errorf("%w after %d replays", ErrReplayLimitReached, event.Replays)
+	}
+	return nil
+}
+
+// DeliverWebhook signs and POSTs a webhook event; non-2xx responses are errors
+func (w *OutboxWorker) DeliverWebhook(ctx context.Context, event *models.OutboxEvent) error {
+	body, err := json.Marshal(WebhookPayload{
+		ID:        event.EventKey,
+		Type:      event.EventType,
+		CreatedAt: event.CreatedAt,
+		Data:      json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventIDHeader, event.EventKey)
+	req.Header.Set(WebhookEventTypeHeader, event.EventType)
+	if w.webhook.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhook(w.webhook.Secret, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
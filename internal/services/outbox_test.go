@@ -0,0 +1,197 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tunecent/backend/internal/config"
+	"github.com/tunecent/backend/internal/models"
+)
+
+func TestOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: 5 * time.Second},
+		{attempts: 1, want: 5 * time.Second},
+		{attempts: 2, want: 10 * time.Second},
+		{attempts: 3, want: 20 * time.Second},
+		{attempts: 9, want: 1280 * time.Second},
+		{attempts: 10, want: 2560 * time.Second},
+		{attempts: 11, want: time.Hour},
+		{attempts: 1000, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := OutboxBackoff(tt.attempts); got != tt.want {
+			t.Errorf("OutboxBackoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestOutboxAttemptUpdates(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	deliveryErr := errors.New("connection refused")
+
+	tests := []struct {
+		name        string
+		attempts    int // before this attempt
+		status      string
+		deliveryErr error
+		want        map[string]interface{}
+	}{
+		{
+			name:   "success marks the event done",
+			status: OutboxStatusDone,
+			want:   map[string]interface{}{"attempts": 1, "status": OutboxStatusDone, "last_error": "", "processed_at": now},
+		},
+		{
+			name:   "skipped webhooks keep their status",
+			status: OutboxStatusSkipped,
+			want:   map[string]interface{}{"attempts": 1, "status": OutboxStatusSkipped, "last_error": "", "processed_at": now},
+		},
+		{
+			name:        "first failure is retried after the base backoff",
+			status:      OutboxStatusDone,
+			deliveryErr: deliveryErr,
+			want:        map[string]interface{}{"attempts": 1, "last_error": "connection refused", "next_attempt_at": now.Add(5 * time.Second)},
+		},
+		{
+			name:        "later failures back off exponentially",
+			attempts:    3,
+			status:      OutboxStatusDone,
+			deliveryErr: deliveryErr,
+			want:        map[string]interface{}{"attempts": 4, "last_error": "connection refused", "next_attempt_at": now.Add(40 * time.Second)},
+		},
+		{
+			name:        "last allowed attempt fails the event",
+			attempts:    MaxOutboxAttempts - 1,
+			status:      OutboxStatusDone,
+			deliveryErr: deliveryErr,
+			want:        map[string]interface{}{"attempts": MaxOutboxAttempts, "status": OutboxStatusFailed, "last_error": "connection refused"},
+		},
+		{
+			name:        "permanent failures are not retried",
+			status:      OutboxStatusFailed,
+			deliveryErr: deliveryErr,
+			want:        map[string]interface{}{"attempts": 1, "status": OutboxStatusFailed, "last_error": "connection refused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &models.OutboxEvent{Attempts: tt.attempts}
+			got := outboxAttemptUpdates(event, tt.status, tt.deliveryErr, now)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("updates = %v, want %v", got, tt.want)
+			}
+			for column, want := range tt.want {
+				if got[column] != want {
+					t.Errorf("%s = %v, want %v", column, got[column], want)
+				}
+			}
+			if event.Attempts != tt.attempts+1 {
+				t.Errorf("event.Attempts = %d, want %d", event.Attempts, tt.attempts+1)
+			}
+		})
+	}
+}
+
+func TestDeliverWebhook(t *testing.T) {
+	event := &models.OutboxEvent{
+		EventKey:  "campaign.funded:42",
+		EventType: "campaign.funded",
+		Payload:   `{"campaign_id":42}`,
+		CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name          string
+		secret        string
+		status        int
+		wantErr       bool
+		wantSignature bool
+	}{
+		{name: "2xx is delivered", secret: "s3cret", status: http.StatusNoContent, wantSignature: true},
+		{name: "no secret sends no signature", status: http.StatusOK},
+		{name: "non-2xx is an error to retry", secret: "s3cret", status: http.StatusBadGateway, wantErr: true, wantSignature: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			worker := NewOutboxWorker(nil, config.WebhookConfig{URL: server.URL, Secret: tt.secret, Timeout: time.Second})
+			err := worker.DeliverWebhook(t.Context(), event)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeliverWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if id := got.Header.Get(WebhookEventIDHeader); id != event.EventKey {
+				t.Errorf("%s = %q, want %q", WebhookEventIDHeader, id, event.EventKey)
+			}
+			if typ := got.Header.Get(WebhookEventTypeHeader); typ != event.EventType {
+				t.Errorf("%s = %q, want %q", WebhookEventTypeHeader, typ, event.EventType)
+			}
+			signature := got.Header.Get(WebhookSignatureHeader)
+			switch {
+			case tt.wantSignature && signature != SignWebhook(tt.secret, body):
+				t.Errorf("%s = %q, want the body's signature", WebhookSignatureHeader, signature)
+			case !tt.wantSignature && signature != "":
+				t.Errorf("%s = %q, want none", WebhookSignatureHeader, signature)
+			}
+
+			var payload WebhookPayload
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatalf("body is not a webhook payload: %v", err)
+			}
+			if payload.ID != event.EventKey || payload.Type != event.EventType || string(payload.Data) != event.Payload {
+				t.Errorf("payload = %+v, want the event's key, type and data", payload)
+			}
+		})
+	}
+}
+
+func TestCheckWebhookReplayable(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   models.OutboxEvent
+		wantErr error
+	}{
+		{name: "failed webhook", event: models.OutboxEvent{Channel: OutboxChannelWebhook, Status: OutboxStatusFailed, Attempts: MaxOutboxAttempts}},
+		{name: "skipped webhook", event: models.OutboxEvent{Channel: OutboxChannelWebhook, Status: OutboxStatusSkipped, Attempts: 1}},
+		{name: "last replay left", event: models.OutboxEvent{Channel: OutboxChannelWebhook, Status: OutboxStatusFailed, Attempts: MaxOutboxAttempts + MaxWebhookReplays - 1, Replays: MaxWebhookReplays - 1}},
+		{name: "skipped webhook with replays left", event: models.OutboxEvent{Channel: OutboxChannelWebhook, Status: OutboxStatusSkipped, Attempts: MaxOutboxAttempts + MaxWebhookReplays}},
+		{name: "notification", event: models.OutboxEvent{Channel: OutboxChannelNotification, Status: OutboxStatusFailed}, wantErr: ErrNotWebhookEvent},
+		{name: "pending webhook", event: models.OutboxEvent{Channel: OutboxChannelWebhook, Status: OutboxStatusPending}, wantErr: ErrWebhookNotReplayable},
+		{name: "delivered webhook", event: models.OutboxEvent{Channel: OutboxChannelWebhook, Status: OutboxStatusDone}, wantErr: ErrWebhookNotReplayable},
+		{name: "replays used up", event: models.OutboxEvent{Channel: OutboxChannelWebhook, Status: OutboxStatusFailed, Attempts: MaxOutboxAttempts + MaxWebhookReplays, Replays: MaxWebhookReplays}, wantErr: ErrReplayLimitReached},
+		{name: "skipped webhook with replays used up", event: models.OutboxEvent{Channel: OutboxChannelWebhook, Status: OutboxStatusSkipped, Attempts: MaxWebhookReplays + 1, Replays: MaxWebhookReplays}, wantErr: ErrReplayLimitReached},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkWebhookReplayable(&tt.event)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("checkWebhookReplayable() = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkWebhookReplayable() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
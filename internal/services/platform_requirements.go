@@ -0,0 +1,235 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/tunecent/backend/internal/models"
+)
+
+// Track metadata fields a platform can require
+const (
+	FieldTitle      = "title"
+	FieldArtist     = "artist"
+	FieldGenre      = "genre"
+	FieldISRC       = "isrc"
+	FieldExplicit   = "explicit"
+	FieldCoverImage = "cover_image_url"
+	FieldAudioFile  = "audio_file_url"
+	FieldDuration   = "duration"
+)
+
+// PlatformRequirements describes what a distribution platform needs before
+// it accepts a track
+type PlatformRequirements struct {
+	Platform           string   `json:"platform"`
+	DisplayName        string   `json:"display_name"`
+	RequiredFields     []string `json:"required_fields"`
+	CoverArtMinPixels  int      `json:"cover_art_min_pixels,omitempty"` // square artwork edge length
+	MaxDurationSeconds int      `json:"max_duration_seconds,omitempty"`
+}
+
+// platformRequirements is the catalogue of supported distribution platforms
+var platformRequirements = []PlatformRequirements{
+	{
+		Platform:          "spotify",
+		DisplayName:       "Spotify",
+		RequiredFields:    []string{FieldTitle, FieldArtist, FieldGenre, FieldISRC, FieldExplicit, FieldCoverImage, FieldAudioFile},
+		CoverArtMinPixels: 640,
+	},
+	{
+		Platform:          "apple_music",
+		DisplayName:       "Apple Music",
+		RequiredFields:    []string{FieldTitle, FieldArtist, FieldGenre, FieldISRC, FieldExplicit, FieldCoverImage, FieldAudioFile},
+		CoverArtMinPixels: 3000,
+	},
+	{
+		Platform:          "youtube_music",
+		DisplayName:       "YouTube Music",
+		RequiredFields:    []string{FieldTitle, FieldArtist, FieldExplicit, FieldCoverImage, FieldAudioFile},
+		CoverArtMinPixels: 1400,
+	},
+	{
+		Platform:           "tiktok",
+		DisplayName:        "TikTok",
+		RequiredFields:     []string{FieldTitle, FieldArtist, FieldAudioFile, FieldDuration},
+		MaxDurationSeconds: 600,
+	},
+}
+
+// SupportedPlatforms returns the requirements of every supported platform
+func SupportedPlatforms() []PlatformRequirements {
+	return platformRequirements
+}
+
+// SupportedPlatformKeys returns the key of every supported platform
+func SupportedPlatformKeys() []string {
+	keys := make([]string, len(platformRequirements))
+	for i, req := range platformRequirements {
+		keys[i] = req.Platform
+	}
+	return keys
+}
+
+// RequirementsFor returns the requirements of a supported platform
+func RequirementsFor(platform string) (PlatformRequirements, bool) {
+	for _, req := range platformRequirements {
+		if req.Platform == platform {
+			return req, true
+		}
+	}
+	return PlatformRequirements{}, false
+}
+
+var isrcPattern = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{3}[0-9]{7}$`)
+
+// NormalizeISRC upper-cases an ISRC and strips the dashes it is often written with
+func NormalizeISRC(isrc string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(isrc), "-", ""))
+}
+
+// IsValidISRC reports whether a normalized ISRC is well formed
+func IsValidISRC(isrc string) bool {
+	return isrcPattern.MatchString(isrc)
+}
+
+// trackHasField reports whether the track provides a required field
+func trackHasField(music *models.MusicMetadata, field string) bool {
+	switch field {
+	case FieldTitle:
+		return strings.TrimSpace(music.Title) != ""
+	case FieldArtist:
+		return strings.TrimSpace(music.Artist) != ""
+	case FieldGenre:
+		return strings.TrimSpace(music.Genre) != ""
+	case FieldISRC:
+		return IsValidISRC(music.ISRC)
+	case FieldExplicit:
+		return music.Explicit != nil
+	case FieldCoverImage:
+		return music.CoverImageURL != ""
+	case FieldAudioFile:
+		return music.AudioFileURL != ""
+	case FieldDuration:
+		return music.Duration > 0
+	default:
+		return false
+	}
+}
+
+// MissingFields returns the required fields the track lacks for a platform.
+// A track longer than the platform allows reports duration as missing.
+func (r PlatformRequirements) MissingFields(music *models.MusicMetadata) []string {
+	var missing []string
+	for _, field := range r.RequiredFields {
+		if !trackHasField(music, field) {
+			missing = append(missing, field)
+		}
+	}
+	if r.MaxDurationSeconds > 0 && music.Duration > r.MaxDurationSeconds && !containsString(missing, FieldDuration) {
+		missing = append(missing, FieldDuration)
+	}
+	return missing
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrNoPlatforms is returned when a distribution selects no platforms
+var ErrNoPlatforms = errors.New("at least one platform is required")
+
+// InvalidPlatformsError lists the selected platforms that are not supported
+type InvalidPlatformsError struct {
+	Invalid []string
+}
+
+func (e *InvalidPlatformsError) Error() string {
+	return "unsupported platforms: " + strings.Join(e.Invalid, ", ")
+}
+
+// NormalizePlatform maps a platform name as a client may write it ("Apple
+// Music", "apple-music", " SPOTIFY ") to its supported key, reporting
+// whether the platform is supported
+func NormalizePlatform(platform string) (string, bool) {
+	key := strings.ToLower(strings.TrimSpace(platform))
+	key = strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+	if _, ok := RequirementsFor(key); !ok {
+		return "", false
+	}
+	return key, true
+}
+
+// NormalizePlatforms normalizes a platform selection, dropping repeats while
+// keeping the first-seen order. It returns ErrNoPlatforms for an empty
+// selection and an *InvalidPlatformsError naming every unsupported entry.
+func NormalizePlatforms(platforms []string) ([]string, error) {
+	normalized := make([]string, 0, len(platforms))
+	seen := make(map[string]bool, len(platforms))
+	var invalid []string
+	for _, platform := range platforms {
+		key, ok := NormalizePlatform(platform)
+		if !ok {
+			invalid = append(invalid, platform)
+			continue
+		}
+		if !seen[key] {
+			seen[key] = true
+			normalized = append(normalized, key)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return nil, &InvalidPlatformsError{Invalid: invalid}
+	}
+	if len(normalized) == 0 {
+		return nil, ErrNoPlatforms
+	}
+	return normalized, nil
+}
+
+// MissingMetadataError lists, per platform, the track fields that block a submission
+type MissingMetadataError struct {
+	Missing map[string][]string
+}
+
+func (e *MissingMetadataError) Error() string {
+	platforms := make([]string, 0, len(e.Missing))
+	for platform := range e.Missing {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+
+	parts := make([]string, len(platforms))
+	for i, platform := range platforms {
+		parts[i] = fmt.Sprintf("%s (%s)", platform, strings.Join(e.Missing[platform], ", "))
+	}
+	return "track metadata incomplete for " + strings.Join(parts, "; ")
+}
+
+// ValidateForPlatforms checks the track against each selected platform's
+// requirements, returning a *MissingMetadataError when any are unmet
+func ValidateForPlatforms(music *models.MusicMetadata, platforms []string) error {
+	missing := make(map[string][]string)
+	for _, platform := range platforms {
+		req, ok := RequirementsFor(platform)
+		if !ok {
+			continue
+		}
+		if fields := req.MissingFields(music); len(fields) > 0 {
+			missing[platform] = fields
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingMetadataError{Missing: missing}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tunecent/backend/internal/models"
+)
+
+func TestISRC(t *testing.T) {
+	tests := []struct {
+		raw       string
+		want      string
+		wantValid bool
+	}{
+		{raw: "USRC17607839", want: "USRC17607839", wantValid: true},
+		{raw: " us-rc1-76-07839 ", want: "USRC17607839", wantValid: true},
+		{raw: "GBAYE6900001", want: "GBAYE6900001", wantValid: true},
+		{raw: "USRC1760783", want: "USRC1760783"},
+		{raw: "1SRC17607839", want: "1SRC17607839"},
+		{raw: "USRC1760783X", want: "USRC1760783X"},
+		{raw: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		got := NormalizeISRC(tt.raw)
+		if got != tt.want {
+			t.Errorf("NormalizeISRC(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+		if valid := IsValidISRC(got); valid != tt.wantValid {
+			t.Errorf("IsValidISRC(%q) = %v, want %v", got, valid, tt.wantValid)
+		}
+	}
+}
+
+// completeTrack returns a track meeting every platform's requirements
+func completeTrack() models.MusicMetadata {
+	explicit := false
+	return models.MusicMetadata{
+		Title:         "Song",
+		Artist:        "Artist",
+		Genre:         "Pop",
+		ISRC:          "USRC17607839",
+		Explicit:      &explicit,
+		CoverImageURL: "ipfs://cover",
+		AudioFileURL:  "ipfs://audio",
+		Duration:      180,
+	}
+}
+
+func TestMissingFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		edit     func(*models.MusicMetadata)
+		want     []string
+	}{
+		{name: "complete track", platform: "spotify", edit: func(*models.MusicMetadata) {}},
+		{name: "missing ISRC", platform: "spotify", edit: func(m *models.MusicMetadata) { m.ISRC = "" }, want: []string{FieldISRC}},
+		{name: "malformed ISRC", platform: "apple_music", edit: func(m *models.MusicMetadata) { m.ISRC = "not-an-isrc" }, want: []string{FieldISRC}},
+		{name: "undeclared explicit flag", platform: "youtube_music", edit: func(m *models.MusicMetadata) { m.Explicit = nil }, want: []string{FieldExplicit}},
+		{name: "blank title and artist", platform: "spotify", edit: func(m *models.MusicMetadata) { m.Title, m.Artist = " ", "" }, want: []string{FieldTitle, FieldArtist}},
+		{name: "platform without an ISRC requirement", platform: "tiktok", edit: func(m *models.MusicMetadata) { m.ISRC, m.Explicit = "", nil }},
+		{name: "unknown duration", platform: "tiktok", edit: func(m *models.MusicMetadata) { m.Duration = 0 }, want: []string{FieldDuration}},
+		{name: "longer than the platform allows", platform: "tiktok", edit: func(m *models.MusicMetadata) { m.Duration = 601 }, want: []string{FieldDuration}},
+		{name: "at the platform's length limit", platform: "tiktok", edit: func(m *models.MusicMetadata) { m.Duration = 600 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, ok := RequirementsFor(tt.platform)
+			if !ok {
+				t.Fatalf("RequirementsFor(%q) found nothing", tt.platform)
+			}
+			track := completeTrack()
+			tt.edit(&track)
+
+			if got := req.MissingFields(&track); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MissingFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateForPlatforms(t *testing.T) {
+	track := completeTrack()
+	track.ISRC = ""
+
+	err := ValidateForPlatforms(&track, []string{"spotify", "tiktok", "apple_music"})
+	var missing *MissingMetadataError
+	if !errors.As(err, &missing) {
+		t.Fatalf("ValidateForPlatforms() error = %v, want a *MissingMetadataError", err)
+	}
+	want := map[string][]string{"spotify": {FieldISRC}, "apple_music": {FieldISRC}}
+	if !reflect.DeepEqual(missing.Missing, want) {
+		t.Errorf("missing = %v, want %v", missing.Missing, want)
+	}
+	if got, want := err.Error(), "track metadata incomplete for apple_music (isrc); spotify (isrc)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	if err := ValidateForPlatforms(&track, []string{"tiktok", "youtube_music"}); err != nil {
+		t.Errorf("ValidateForPlatforms() for platforms without ISRC = %v, want nil", err)
+	}
+}
+
+func TestNormalizePlatform(t *testing.T) {
+	tests := []struct {
+		platform string
+		want     string
+		wantOK   bool
+	}{
+		{platform: "spotify", want: "spotify", wantOK: true},
+		{platform: " SPOTIFY ", want: "spotify", wantOK: true},
+		{platform: "Apple Music", want: "apple_music", wantOK: true},
+		{platform: "youtube-music", want: "youtube_music", wantOK: true},
+		{platform: "TikTok", want: "tiktok", wantOK: true},
+		{platform: "spotfy"},
+		{platform: "myspace"},
+		{platform: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			got, ok := NormalizePlatform(tt.platform)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizePlatform(%q) = %q, %v, want %q, %v", tt.platform, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNormalizePlatforms(t *testing.T) {
+	tests := []struct {
+		name        string
+		platforms   []string
+		want        []string
+		wantInvalid []string
+		wantErr     error
+	}{
+		{name: "supported platforms", platforms: []string{"spotify", "tiktok"}, want: []string{"spotify", "tiktok"}},
+		{name: "casing is normalized", platforms: []string{"Spotify", "Apple Music"}, want: []string{"spotify", "apple_music"}},
+		{name: "duplicates keep the first position", platforms: []string{"tiktok", "spotify", "TikTok", "spotify"}, want: []string{"tiktok", "spotify"}},
+		{name: "unknown platforms are all named", platforms: []string{"spotfy", "spotify", "myspace"}, wantInvalid: []string{"spotfy", "myspace"}},
+		{name: "blank entry", platforms: []string{"spotify", " "}, wantInvalid: []string{" "}},
+		{name: "empty list", platforms: []string{}, wantErr: ErrNoPlatforms},
+		{name: "missing list", wantErr: ErrNoPlatforms},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePlatforms(tt.platforms)
+
+			var invalid *InvalidPlatformsError
+			switch {
+			case tt.wantInvalid != nil:
+				if !errors.As(err, &invalid) || !reflect.DeepEqual(invalid.Invalid, tt.wantInvalid) {
+					t.Fatalf("NormalizePlatforms() error = %v, want %v invalid", err, tt.wantInvalid)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("NormalizePlatforms() error = %v, want %v", err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatalf("NormalizePlatforms() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizePlatforms() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
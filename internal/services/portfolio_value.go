@@ -0,0 +1,84 @@
+package services
+
+import (
+	"math/big"
+)
+
+// ActiveInvestment is what a user has put into one campaign that is still
+// raising or has been funded
+type ActiveInvestment struct {
+	CampaignID   uint64
+	Invested     *big.Int
+	EstimatedROI float64 // percent
+	RiskScore    uint8   // 0-100, lower = safer
+}
+
+// InvestmentValue is an active investment's estimated worth
+type InvestmentValue struct {
+	CampaignID     uint64  `json:"campaign_id"`
+	InvestedWei    string  `json:"invested_wei"`
+	EstimatedROI   float64 `json:"estimated_roi"`
+	RiskScore      uint8   `json:"risk_score"`
+	EstimatedValue string  `json:"estimated_value_wei"`
+}
+
+// PortfolioValue is a user's estimated holdings and the components they add up from
+type PortfolioValue struct {
+	PendingRoyaltiesWei  string            `json:"pending_royalties_wei"`
+	RealizedEarningsWei  string            `json:"realized_earnings_wei"`
+	ActiveInvestmentsWei string            `json:"active_investments_wei"`
+	TotalWei             string            `json:"total_wei"`
+	TotalETH             float64           `json:"total_eth"`
+	TotalUSD             float64           `json:"total_usd"`
+	ETHPriceUSD          float64           `json:"eth_price_usd"`
+	Investments          []InvestmentValue `json:"investments"`
+}
+
+// EstimateInvestmentValue values an investment at its principal plus the
+// campaign's estimated ROI discounted by its risk: a risk score of 0 counts
+// the full ROI, 100 counts none of it
+func EstimateInvestmentValue(invested *big.Int, estimatedROI float64, riskScore uint8) *big.Int {
+	risk := float64(riskScore)
+	if risk > 100 {
+		risk = 100
+	}
+	expectedReturn := estimatedROI * (100 - risk) / 100
+	if expectedReturn < 0 {
+		expectedReturn = 0
+	}
+	value := new(big.Int).Set(invested)
+	return value.Add(value, PercentOf(invested, expectedReturn))
+}
+
+// ComputePortfolioValue totals pending royalties, realized earnings and the
+// estimated value of active investments, converting the total at ethPriceUSD
+func ComputePortfolioValue(pending, realized *big.Int, investments []ActiveInvestment, ethPriceUSD float64) *PortfolioValue {
+	invested := new(big.Int)
+	values := make([]InvestmentValue, 0, len(investments))
+	for _, investment := range investments {
+		value := EstimateInvestmentValue(investment.Invested, investment.EstimatedROI, investment.RiskScore)
+		invested.Add(invested, value)
+		values = append(values, InvestmentValue{
+			CampaignID:     investment.CampaignID,
+			InvestedWei:    investment.Invested.String(),
+			EstimatedROI:   investment.EstimatedROI,
+			RiskScore:      investment.RiskScore,
+			EstimatedValue: value.String(),
+		})
+	}
+
+	total := new(big.Int).Add(pending, realized)
+	total.Add(total, invested)
+	totalETH, _ := new(big.Rat).SetFrac(total, big.NewInt(1e18)).Float64()
+
+	return &PortfolioValue{
+		PendingRoyaltiesWei:  pending.String(),
+		RealizedEarningsWei:  realized.String(),
+		ActiveInvestmentsWei: invested.String(),
+		TotalWei:             total.String(),
+		TotalETH:             totalETH,
+		TotalUSD:             totalETH * ethPriceUSD,
+		ETHPriceUSD:          ethPriceUSD,
+		Investments:          values,
+	}
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestEstimateInvestmentValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		invested string
+		roi      float64
+		risk     uint8
+		want     string
+	}{
+		{name: "no risk counts the full ROI", invested: "1000", roi: 20, risk: 0, want: "1200"},
+		{name: "half the risk halves the return", invested: "1000", roi: 20, risk: 50, want: "1100"},
+		{name: "maximum risk counts none of it", invested: "1000", roi: 20, risk: 100, want: "1000"},
+		{name: "risk above 100 is capped", invested: "1000", roi: 20, risk: 200, want: "1000"},
+		{name: "negative ROI keeps the principal", invested: "1000", roi: -15, risk: 10, want: "1000"},
+		{name: "fractions of a wei are dropped", invested: "999", roi: 25, risk: 0, want: "1248"},
+		{name: "amounts beyond int64", invested: "1000000000000000000000", roi: 50, risk: 0, want: "1500000000000000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invested, _ := new(big.Int).SetString(tt.invested, 10)
+			if got := EstimateInvestmentValue(invested, tt.roi, tt.risk); got.String() != tt.want {
+				t.Errorf("EstimateInvestmentValue(%s, %v, %d) = %s, want %s", tt.invested, tt.roi, tt.risk, got, tt.want)
+			}
+			if invested.String() != tt.invested {
+				t.Errorf("invested changed to %s", invested)
+			}
+		})
+	}
+}
+
+func TestComputePortfolioValue(t *testing.T) {
+	eth := func(tenths int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(tenths), big.NewInt(1e17))
+	}
+
+	tests := []struct {
+		name            string
+		pending         *big.Int
+		realized        *big.Int
+		investments     []ActiveInvestment
+		wantInvestments string
+		wantTotal       string
+		wantETH         float64
+	}{
+		{
+			name:     "every component",
+			pending:  eth(20),
+			realized: eth(5),
+			investments: []ActiveInvestment{
+				{CampaignID: 1, Invested: eth(10), EstimatedROI: 50, RiskScore: 50}, // worth 1.25 ETH
+				{CampaignID: 2, Invested: eth(5)},                                   // worth its principal
+			},
+			wantInvestments: "1750000000000000000",
+			wantTotal:       "4250000000000000000",
+			wantETH:         4.25,
+		},
+		{
+			name:            "royalties only",
+			pending:         eth(3),
+			realized:        new(big.Int),
+			wantInvestments: "0",
+			wantTotal:       "300000000000000000",
+			wantETH:         0.3,
+		},
+		{
+			name:            "empty portfolio",
+			pending:         new(big.Int),
+			realized:        new(big.Int),
+			wantInvestments: "0",
+			wantTotal:       "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputePortfolioValue(tt.pending, tt.realized, tt.investments, 2000)
+
+			if got.ActiveInvestmentsWei != tt.wantInvestments || got.TotalWei != tt.wantTotal {
+				t.Errorf("investments %s, total %s, want %s, %s", got.ActiveInvestmentsWei, got.TotalWei, tt.wantInvestments, tt.wantTotal)
+			}
+
+			// The total is exactly the sum of the components it reports
+			sum := new(big.Int)
+			for _, component := range []string{got.PendingRoyaltiesWei, got.RealizedEarningsWei, got.ActiveInvestmentsWei} {
+				value, ok := new(big.Int).SetString(component, 10)
+				if !ok {
+					t.Fatalf("component %q is not a wei amount", component)
+				}
+				sum.Add(sum, value)
+			}
+			if sum.String() != got.TotalWei {
+				t.Errorf("components sum to %s, total is %s", sum, got.TotalWei)
+			}
+			investments := new(big.Int)
+			for _, investment := range got.Investments {
+				value, _ := new(big.Int).SetString(investment.EstimatedValue, 10)
+				investments.Add(investments, value)
+			}
+			if len(got.Investments) != len(tt.investments) || investments.String() != got.ActiveInvestmentsWei {
+				t.Errorf("%d investments worth %s, want %d adding up to %s", len(got.Investments), investments, len(tt.investments), got.ActiveInvestmentsWei)
+			}
+
+			if got.TotalETH != tt.wantETH || got.TotalUSD != tt.wantETH*2000 || got.ETHPriceUSD != 2000 {
+				t.Errorf("%v ETH is $%v at $%v, want %v ETH at $2000", got.TotalETH, got.TotalUSD, got.ETHPriceUSD, tt.wantETH)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/tunecent/backend/internal/database"
+)
+
+type RecommendationService struct {
+	db *database.DB
+}
+
+func NewRecommendationService(db *database.DB) *RecommendationService {
+	return &RecommendationService{db: db}
+}
+
+// Scoring weights for campaign recommendations (sum to 1)
+const (
+	recommendGenreWeight = 0.5
+	recommendRiskWeight  = 0.25
+	recommendROIWeight   = 0.25
+	recommendMaxROI      = 300.0
+)
+
+// RecommendationCandidate is an active campaign considered for recommendation
+type RecommendationCandidate struct {
+	CampaignID        uint64  `json:"campaign_id"`
+	TokenID           uint64  `json:"token_id"`
+	MusicTitle        string  `json:"music_title"`
+	MusicArtist       string  `json:"music_artist"`
+	Genre             string  `json:"genre"`
+	RoyaltyPercentage uint16  `json:"royalty_percentage"`
+	EstimatedROI      float64 `json:"estimated_roi"`
+	RiskScore         uint8   `json:"risk_score"`
+}
+
+// RecommendedCampaign is a ranked recommendation with its score and reasoning
+type RecommendedCampaign struct {
+	RecommendationCandidate
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
+type RecommendationResponse struct {
+	UserAddress     string                `json:"user_address"`
+	GenreAffinity   map[string]float64    `json:"genre_affinity"`
+	Recommendations []RecommendedCampaign `json:"recommendations"`
+}
+
+// GenreAffinity turns per-genre investment counts into shares of the user's history (0-1)
+func GenreAffinity(genreCounts map[string]int) map[string]float64 {
+	total := 0
+	for _, count := range genreCounts {
+		total += count
+	}
+
+	affinity := make(map[string]float64, len(genreCounts))
+	if total == 0 {
+		return affinity
+	}
+	for genre, count := range genreCounts {
+		affinity[normalizeGenre(genre)] += float64(count) / float64(total)
+	}
+	return affinity
+}
+
+// RankCampaigns scores candidates by genre affinity, risk and ROI and returns them
+// sorted best first. Campaigns in the excluded set are dropped.
+func RankCampaigns(candidates []RecommendationCandidate, affinity map[string]float64, excluded map[uint64]bool) []RecommendedCampaign {
+	ranked := make([]RecommendedCampaign, 0, len(candidates))
+	for _, cand := range candidates {
+		if excluded[cand.CampaignID] {
+			continue
+		}
+
+		genreScore := affinity[normalizeGenre(cand.Genre)]
+		riskScore := 1 - float64(cand.RiskScore)/100
+		roiScore := math.Min(math.Max(cand.EstimatedROI, 0), recommendMaxROI) / recommendMaxROI
+
+		score := recommendGenreWeight*genreScore + recommendRiskWeight*riskScore + recommendROIWeight*roiScore
+
+		ranked = append(ranked, RecommendedCampaign{
+			RecommendationCandidate: cand,
+			Score:                   math.Round(score*10000) / 100,
+			Reason:                  recommendationReason(cand, genreScore),
+		})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].CampaignID < ranked[j].CampaignID
+	})
+
+	return ranked
+}
+
+func recommendationReason(cand RecommendationCandidate, genreScore float64) string {
+	parts := []string{}
+	if genreScore > 0 && cand.Genre != "" {
+		parts = append(parts, fmt.Sprintf("%.0f%% of your investments are in %s", genreScore*100, cand.Genre))
+	}
+	parts = append(parts, fmt.Sprintf("estimated ROI %.1f%%", cand.EstimatedROI))
+	parts = append(parts, fmt.Sprintf("risk score %d/100", cand.RiskScore))
+
+	reason := strings.Join(parts, ", ")
+	return strings.ToUpper(reason[:1]) + reason[1:]
+}
+
+func normalizeGenre(genre string) string {
+	return strings.ToLower(strings.TrimSpace(genre))
+}
+
+func (s *RecommendationService) GetRecommendations(ctx context.Context, userAddress string, limit int) (*RecommendationResponse, error) {
+	// Genres and campaigns from the user's contribution history
+	type historyRow struct {
+		CampaignID uint64
+		Genre      string
+	}
+	var history []historyRow
+	if err := s.db.Table("contributions c").
+		Select("c.campaign_id, COALESCE(m.genre, '') as genre").
+		Joins("JOIN campaigns camp ON c.campaign_id = camp.campaign_id").
+		Joins("JOIN music_metadata m ON camp.token_id = m.token_id").
+		Where("c.contributor_address = ?", userAddress).
+		Scan(&history).Error; err != nil {
+		return nil, fmt.Errorf("failed to load investment history: %w", err)
+	}
+
+	genreCounts := make(map[string]int)
+	invested := make(map[uint64]bool)
+	for _, row := range history {
+		invested[row.CampaignID] = true
+		if row.Genre != "" {
+			genreCounts[row.Genre]++
+		}
+	}
+
+	var candidates []RecommendationCandidate
+	if err := s.db.Table("campaigns").
+		Select(`campaigns.campaign_id, campaigns.token_id, campaigns.royalty_percentage,
+			campaigns.estimated_roi, campaigns.risk_score,
+			music_metadata.title as music_title, music_metadata.artist as music_artist,
+			COALESCE(music_metadata.genre, '') as genre`).
+		Joins("JOIN music_metadata ON campaigns.token_id = music_metadata.token_id").
+		Where("campaigns.status = ?", "active").
+		Scan(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("failed to load active campaigns: %w", err)
+	}
+
+	affinity := GenreAffinity(genreCounts)
+	ranked := RankCampaigns(candidates, affinity, invested)
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	return &RecommendationResponse{
+		UserAddress:     userAddress,
+		GenreAffinity:   affinity,
+		Recommendations: ranked,
+	}, nil
+}
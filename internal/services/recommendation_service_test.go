@@ -0,0 +1,101 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenreAffinity(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts map[string]int
+		want   map[string]float64
+	}{
+		{name: "no history", counts: nil, want: map[string]float64{}},
+		{name: "single genre", counts: map[string]int{"Pop": 3}, want: map[string]float64{"pop": 1}},
+		{name: "shares of the history", counts: map[string]int{"pop": 3, "rock": 1}, want: map[string]float64{"pop": 0.75, "rock": 0.25}},
+		{name: "genres differing in case and spacing are merged", counts: map[string]int{"Pop": 1, " pop ": 1}, want: map[string]float64{"pop": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GenreAffinity(tt.counts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenreAffinity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankCampaigns(t *testing.T) {
+	pop := RecommendationCandidate{CampaignID: 1, Genre: "Pop", EstimatedROI: 150, RiskScore: 40}
+	rock := RecommendationCandidate{CampaignID: 2, Genre: "Rock", EstimatedROI: 150, RiskScore: 40}
+	safe := RecommendationCandidate{CampaignID: 3, Genre: "Jazz", EstimatedROI: 150, RiskScore: 0}
+	extreme := RecommendationCandidate{CampaignID: 4, Genre: "Jazz", EstimatedROI: 1000, RiskScore: 100}
+
+	tests := []struct {
+		name       string
+		candidates []RecommendationCandidate
+		affinity   map[string]float64
+		excluded   map[uint64]bool
+		wantIDs    []uint64
+		wantScores []float64
+	}{
+		{
+			name:       "genre affinity lifts a campaign",
+			candidates: []RecommendationCandidate{rock, pop},
+			affinity:   map[string]float64{"pop": 1},
+			wantIDs:    []uint64{1, 2},
+			wantScores: []float64{77.5, 27.5},
+		},
+		{
+			name:       "lower risk ranks higher without affinity",
+			candidates: []RecommendationCandidate{pop, safe},
+			wantIDs:    []uint64{3, 1},
+			wantScores: []float64{37.5, 27.5},
+		},
+		{
+			name:       "ROI is capped",
+			candidates: []RecommendationCandidate{extreme},
+			wantIDs:    []uint64{4},
+			wantScores: []float64{25},
+		},
+		{
+			name:       "ties keep campaign order",
+			candidates: []RecommendationCandidate{rock, pop},
+			wantIDs:    []uint64{1, 2},
+			wantScores: []float64{27.5, 27.5},
+		},
+		{
+			name:       "excluded campaigns are dropped",
+			candidates: []RecommendationCandidate{pop, rock},
+			excluded:   map[uint64]bool{1: true},
+			wantIDs:    []uint64{2},
+			wantScores: []float64{27.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RankCampaigns(tt.candidates, tt.affinity, tt.excluded)
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("RankCampaigns() returned %d campaigns, want %d", len(got), len(tt.wantIDs))
+			}
+			for i, rec := range got {
+				if rec.CampaignID != tt.wantIDs[i] || rec.Score != tt.wantScores[i] {
+					t.Errorf("rank %d = campaign %d scoring %v, want campaign %d scoring %v", i, rec.CampaignID, rec.Score, tt.wantIDs[i], tt.wantScores[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRecommendationReason(t *testing.T) {
+	cand := RecommendationCandidate{Genre: "Pop", EstimatedROI: 12.34, RiskScore: 40}
+
+	if got, want := recommendationReason(cand, 0.5), "50% of your investments are in Pop, estimated ROI 12.3%, risk score 40/100"; got != want {
+		t.Errorf("recommendationReason() = %q, want %q", got, want)
+	}
+	if got, want := recommendationReason(cand, 0), "Estimated ROI 12.3%, risk score 40/100"; got != want {
+		t.Errorf("recommendationReason() without affinity = %q, want %q", got, want)
+	}
+}
@@ -3,13 +3,33 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/tunecent/backend/internal/database"
 	"github.com/tunecent/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var (
+	ErrSuggestionNotFound = errors.New("reinvestment suggestion not found")
+	ErrInsufficientFunds  = errors.New("amount exceeds available funds")
+	ErrInvalidAmount      = errors.New("amount must be a positive integer in wei")
+)
+
+// Contribution sources: whether a contribution was paid with fresh funds or
+// reinvested royalties
+const (
+	ContributionSourceDirect       = "direct"
+	ContributionSourceReinvestment = "reinvestment"
+)
+
+// MaxBatchReinvestItems caps how many pools one batch reinvestment may target
+const MaxBatchReinvestItems = 20
+
 type ReinvestmentService struct {
 	db *database.DB
 }
@@ -19,6 +39,7 @@ func NewReinvestmentService(db *database.DB) *ReinvestmentService {
 }
 
 type SuggestionResponse struct {
+	SuggestionID    uint                     `json:"suggestion_id"`
 	UserAddress     string                   `json:"user_address"`
 	AvailableFunds  string                   `json:"available_funds"`
 	SuggestedPools  []SuggestedPool          `json:"suggested_pools"`
@@ -45,14 +66,10 @@ type QuickReinvestRequest struct {
 
 func (s *ReinvestmentService) GetSuggestions(ctx context.Context, userAddress string) (*SuggestionResponse, error) {
 	// Calculate available funds
-	var totalEarnings struct {
-		Total string
+	availableFunds, err := s.availableFunds(ctx, userAddress)
+	if err != nil {
+		return nil, err
 	}
-	s.db.Model(&models.RoyaltyDistribution{}).
-		Select("COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) as total").
-		Joins("JOIN music_metadata ON royalty_distributions.token_id = music_metadata.token_id").
-		Where("music_metadata.creator_address = ?", userAddress).
-		Scan(&totalEarnings)
 
 	var totalInvested struct {
 		Total string
@@ -62,8 +79,6 @@ func (s *ReinvestmentService) GetSuggestions(ctx context.Context, userAddress st
 		Where("contributor_address = ?", userAddress).
 		Scan(&totalInvested)
 
-	availableFunds := totalEarnings.Total // Simplified for PoC
-
 	// Get active campaigns with good metrics
 	type CampaignData struct {
 		CampaignID        uint64
@@ -94,7 +109,7 @@ func (s *ReinvestmentService) GetSuggestions(ctx context.Context, userAddress st
 
 	for i, camp := range campaigns {
 		reasoning := fmt.Sprintf("High ROI potential (%.1f%%) with low risk score (%d/100). Currently %.0f%% funded.",
-			camp.EstimatedROI, camp.RiskScore, 75.0) // Mock funded percentage
+			camp.EstimatedROI, camp.RiskScore, FundingPercentage(camp.RaisedAmount, camp.GoalAmount))
 
 		suggestions[i] = SuggestedPool{
 			CampaignID:        camp.CampaignID,
@@ -114,23 +129,32 @@ func (s *ReinvestmentService) GetSuggestions(ctx context.Context, userAddress st
 		avgROI = totalROI / float64(len(suggestions))
 	}
 
-	// Save suggestion
+	// Save suggestion, refreshing the latest open one rather than adding a row per request
 	poolIDs := make([]uint64, len(campaigns))
 	for i, camp := range campaigns {
 		poolIDs[i] = camp.CampaignID
 	}
 	poolIDsJSON, _ := json.Marshal(poolIDs)
 
-	suggestion := &models.ReinvestmentSuggestion{
-		UserAddress:    userAddress,
-		AvailableFunds: availableFunds,
-		SuggestedPools: string(poolIDsJSON),
-		ExpectedROI:    avgROI,
-		Reasoning:      fmt.Sprintf("Top %d performing pools based on ROI and risk", len(suggestions)),
+	var suggestion models.ReinvestmentSuggestion
+	err = s.db.Where("user_address = ? AND is_actioned = ?", userAddress, false).
+		Order("created_at DESC").
+		First(&suggestion).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to load suggestion: %w", err)
+	}
+
+	suggestion.UserAddress = userAddress
+	suggestion.AvailableFunds = availableFunds
+	suggestion.SuggestedPools = string(poolIDsJSON)
+	suggestion.ExpectedROI = avgROI
+	suggestion.Reasoning = fmt.Sprintf("Top %d performing pools based on ROI and risk", len(suggestions))
+	if err := s.db.Save(&suggestion).Error; err != nil {
+		return nil, fmt.Errorf("failed to save suggestion: %w", err)
 	}
-	s.db.Create(suggestion)
 
 	return &SuggestionResponse{
+		SuggestionID:     suggestion.ID,
 		UserAddress:      userAddress,
 		AvailableFunds:   availableFunds,
 		SuggestedPools:   suggestions,
@@ -138,10 +162,89 @@ func (s *ReinvestmentService) GetSuggestions(ctx context.Context, userAddress st
 	}, nil
 }
 
+// DismissSuggestion marks a suggestion as actioned so it is no longer reused
+func (s *ReinvestmentService) DismissSuggestion(ctx context.Context, suggestionID uint, userAddress string) (*models.ReinvestmentSuggestion, error) {
+	var suggestion models.ReinvestmentSuggestion
+	err := s.db.Where("id = ? AND user_address = ?", suggestionID, userAddress).First(&suggestion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSuggestionNotFound
+		}
+		return nil, fmt.Errorf("failed to load suggestion: %w", err)
+	}
+
+	if !suggestion.IsActioned {
+		suggestion.IsActioned = true
+		if err := s.db.Model(&suggestion).Update("is_actioned", true).Error; err != nil {
+			return nil, fmt.Errorf("failed to dismiss suggestion: %w", err)
+		}
+	}
+
+	return &suggestion, nil
+}
+
+// markSuggestionsActioned closes every open suggestion for the user that
+// recommended the campaign they just reinvested into
+func markSuggestionsActioned(tx *gorm.DB, userAddress string, campaignID uint64) error {
+	var open []models.ReinvestmentSuggestion
+	if err := tx.Where("user_address = ? AND is_actioned = ?", userAddress, false).Find(&open).Error; err != nil {
+		return err
+	}
+
+	var matched []uint
+	for _, suggestion := range open {
+		var poolIDs []uint64
+		if err := json.Unmarshal([]byte(suggestion.SuggestedPools), &poolIDs); err != nil {
+			continue
+		}
+		for _, id := range poolIDs {
+			if id == campaignID {
+				matched = append(matched, suggestion.ID)
+				break
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	return tx.Model(&models.ReinvestmentSuggestion{}).
+		Where("id IN ?", matched).
+		Update("is_actioned", true).Error
+}
+
+// QuickReinvest reinvests into one active campaign, provided the amount is
+// within the user's available funds
 func (s *ReinvestmentService) QuickReinvest(ctx context.Context, req *QuickReinvestRequest) (*models.ReinvestmentHistory, error) {
+	amount, ok := new(big.Int).SetString(req.Amount, 10)
+	if !ok || amount.Sign() <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	var history *models.ReinvestmentHistory
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		available, err := lockAvailableFunds(tx, req.UserAddress)
+		if err != nil {
+			return err
+		}
+		if amount.Cmp(available) > 0 {
+			return fmt.Errorf("%w: requested %s, available %s", ErrInsufficientFunds, amount, available)
+		}
+		history, err = reinvest(tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// reinvest records a reinvestment and the contribution it pays for
+func reinvest(tx *gorm.DB, req *QuickReinvestRequest) (*models.ReinvestmentHistory, error) {
 	// Verify campaign exists and is active
 	var campaign models.Campaign
-	if err := s.db.Where("campaign_id = ? AND status = ?", req.CampaignID, "active").First(&campaign).Error; err != nil {
+	if err := tx.Where("campaign_id = ? AND status = ?", req.CampaignID, "active").First(&campaign).Error; err != nil {
 		return nil, fmt.Errorf("campaign not found or not active: %w", err)
 	}
 
@@ -154,7 +257,7 @@ func (s *ReinvestmentService) QuickReinvest(ctx context.Context, req *QuickReinv
 		TxHash:       fmt.Sprintf("0x%064x", time.Now().UnixNano()), // Mock tx hash
 	}
 
-	if err := s.db.Create(history).Error; err != nil {
+	if err := tx.Create(history).Error; err != nil {
 		return nil, fmt.Errorf("failed to create reinvestment history: %w", err)
 	}
 
@@ -165,13 +268,190 @@ func (s *ReinvestmentService) QuickReinvest(ctx context.Context, req *QuickReinv
 		Amount:             req.Amount,
 		SharePercentage:    0, // Calculate based on total
 		TxHash:             history.TxHash,
+		Source:             ContributionSourceReinvestment,
 		ContributedAt:      time.Now(),
 	}
-	s.db.Create(contribution)
+	if err := tx.Create(contribution).Error; err != nil {
+		return nil, fmt.Errorf("failed to create contribution: %w", err)
+	}
+
+	if err := markSuggestionsActioned(tx, req.UserAddress, req.CampaignID); err != nil {
+		return nil, fmt.Errorf("failed to update suggestions: %w", err)
+	}
 
 	return history, nil
 }
 
+// availableFunds is what a user can reinvest
+func (s *ReinvestmentService) availableFunds(ctx context.Context, userAddress string) (string, error) {
+	return AvailableReinvestmentFunds(s.db.WithContext(ctx), userAddress)
+}
+
+// lockAvailableFunds locks the user's reinvestment history, so concurrent
+// reinvestments cannot spend the same funds, and returns what is left
+func lockAvailableFunds(tx *gorm.DB, userAddress string) (*big.Int, error) {
+	var locked []uint
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Model(&models.ReinvestmentHistory{}).
+		Where("user_address = ?", userAddress).
+		Pluck("id", &locked).Error; err != nil {
+		return nil, fmt.Errorf("failed to lock reinvestment history: %w", err)
+	}
+
+	available, err := AvailableReinvestmentFunds(tx, userAddress)
+	if err != nil {
+		return nil, err
+	}
+	availableWei, ok := new(big.Int).SetString(available, 10)
+	if !ok {
+		availableWei = new(big.Int)
+	}
+	return availableWei, nil
+}
+
+// AvailableReinvestmentFunds is what a user can reinvest: the royalties
+// distributed on their tracks (simplified for PoC) less what they have
+// already reinvested
+func AvailableReinvestmentFunds(db *gorm.DB, userAddress string) (string, error) {
+	var totalEarnings struct {
+		Total string
+	}
+	if err := db.Model(&models.RoyaltyDistribution{}).
+		Select("COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) as total").
+		Joins("JOIN music_metadata ON royalty_distributions.token_id = music_metadata.token_id").
+		Where("music_metadata.creator_address = ?", userAddress).
+		Scan(&totalEarnings).Error; err != nil {
+		return "", fmt.Errorf("failed to calculate available funds: %w", err)
+	}
+
+	var totalReinvested struct {
+		Total string
+	}
+	if err := db.Model(&models.ReinvestmentHistory{}).
+		Select("COALESCE(SUM(CAST(amount AS DECIMAL(30,0))), 0) as total").
+		Where("user_address = ?", userAddress).
+		Scan(&totalReinvested).Error; err != nil {
+		return "", fmt.Errorf("failed to calculate reinvested funds: %w", err)
+	}
+
+	return remainingWei(totalEarnings.Total, totalReinvested.Total), nil
+}
+
+// remainingWei is earned less spent, floored at zero; unparsable sums count as zero
+func remainingWei(earned, spent string) string {
+	earnedWei, ok := new(big.Int).SetString(earned, 10)
+	if !ok {
+		earnedWei = new(big.Int)
+	}
+	spentWei, ok := new(big.Int).SetString(spent, 10)
+	if !ok {
+		spentWei = new(big.Int)
+	}
+	remaining := earnedWei.Sub(earnedWei, spentWei)
+	if remaining.Sign() < 0 {
+		return "0"
+	}
+	return remaining.String()
+}
+
+// BatchReinvestItem is one pool allocation of a batch reinvestment
+type BatchReinvestItem struct {
+	CampaignID uint64 `json:"campaign_id" binding:"required"`
+	Amount     string `json:"amount" binding:"required"`
+	FromSource string `json:"from_source" binding:"required"`
+}
+
+type BatchReinvestRequest struct {
+	UserAddress string              `json:"user_address" binding:"required"`
+	Items       []BatchReinvestItem `json:"items" binding:"required,min=1,dive"`
+}
+
+// BatchReinvestItemResult reports the outcome of one batch item
+type BatchReinvestItemResult struct {
+	Index      int                         `json:"index"`
+	CampaignID uint64                      `json:"campaign_id"`
+	Amount     string                      `json:"amount"`
+	Success    bool                        `json:"success"`
+	Error      string                      `json:"error,omitempty"`
+	History    *models.ReinvestmentHistory `json:"history,omitempty"`
+}
+
+type BatchReinvestResponse struct {
+	UserAddress     string                    `json:"user_address"`
+	AvailableFunds  string                    `json:"available_funds"`
+	Requested       string                    `json:"requested"`
+	TotalReinvested string                    `json:"total_reinvested"`
+	Succeeded       int                       `json:"succeeded"`
+	Failed          int                       `json:"failed"`
+	Results         []BatchReinvestItemResult `json:"results"`
+}
+
+// BatchReinvest locks the user's funds and checks the combined amount
+// against them, then reinvests each item in its own savepoint: an item that
+// fails (for example because its pool is no longer active) is reported
+// without affecting the others
+func (s *ReinvestmentService) BatchReinvest(ctx context.Context, req *BatchReinvestRequest) (*BatchReinvestResponse, error) {
+	requested := new(big.Int)
+	for i, item := range req.Items {
+		amount, ok := new(big.Int).SetString(item.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			return nil, fmt.Errorf("item %d: %w", i, ErrInvalidAmount)
+		}
+		requested.Add(requested, amount)
+	}
+
+	response := &BatchReinvestResponse{
+		UserAddress: req.UserAddress,
+		Requested:   requested.String(),
+		Results:     make([]BatchReinvestItemResult, len(req.Items)),
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		available, err := lockAvailableFunds(tx, req.UserAddress)
+		if err != nil {
+			return err
+		}
+		if requested.Cmp(available) > 0 {
+			return fmt.Errorf("%w: requested %s, available %s", ErrInsufficientFunds, requested, available)
+		}
+		response.AvailableFunds = available.String()
+
+		reinvested := new(big.Int)
+		for i, item := range req.Items {
+			result := BatchReinvestItemResult{Index: i, CampaignID: item.CampaignID, Amount: item.Amount}
+
+			var history *models.ReinvestmentHistory
+			err := tx.Transaction(func(itemTx *gorm.DB) error {
+				var err error
+				history, err = reinvest(itemTx, &QuickReinvestRequest{
+					UserAddress: req.UserAddress,
+					CampaignID:  item.CampaignID,
+					Amount:      item.Amount,
+					FromSource:  item.FromSource,
+				})
+				return err
+			})
+			if err != nil {
+				result.Error = err.Error()
+				response.Failed++
+			} else {
+				result.Success = true
+				result.History = history
+				response.Succeeded++
+				amount, _ := new(big.Int).SetString(item.Amount, 10)
+				reinvested.Add(reinvested, amount)
+			}
+			response.Results[i] = result
+		}
+		response.TotalReinvested = reinvested.String()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
 func (s *ReinvestmentService) GetReinvestmentHistory(ctx context.Context, userAddress string, limit, offset int) ([]*models.ReinvestmentHistory, int64, error) {
 	var history []*models.ReinvestmentHistory
 	var total int64
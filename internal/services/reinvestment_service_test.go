@@ -0,0 +1,293 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+var suggestionColumns = []string{"id", "user_address", "available_funds", "suggested_pools", "is_actioned", "created_at"}
+
+// expectSuggestionInputs answers the funds and campaign queries GetSuggestions
+// runs before it saves the suggestion
+func expectSuggestionInputs(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("FROM `royalty_distributions` JOIN music_metadata").
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow("1000"))
+	mock.ExpectQuery("FROM `reinvestment_histories`").
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow("400"))
+	mock.ExpectQuery("FROM `contributions`").
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow("0"))
+	mock.ExpectQuery("FROM `campaigns` JOIN music_metadata .* WHERE campaigns.status = \\? AND campaigns.risk_score < \\?").
+		WithArgs("active", 70).
+		WillReturnRows(sqlmock.NewRows([]string{"campaign_id", "token_id", "estimated_roi", "risk_score", "raised_amount", "goal_amount"}).
+			AddRow(3, 30, 12.5, 20, "500", "1000").
+			AddRow(4, 40, 7.5, 40, "100", "1000"))
+}
+
+func TestGetSuggestions(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		open   *sqlmock.Rows
+		save   func(mock sqlmock.Sqlmock)
+		wantID uint
+	}{
+		{
+			name: "first request creates a suggestion",
+			open: sqlmock.NewRows(suggestionColumns),
+			save: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO `reinvestment_suggestions`").
+					WithArgs("0xuser", "600", "[3,4]", 10.0, "Top 2 performing pools based on ROI and risk", false, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(12, 1))
+			},
+			wantID: 12,
+		},
+		{
+			name: "repeat request refreshes the open suggestion",
+			open: sqlmock.NewRows(suggestionColumns).AddRow(12, "0xuser", "900", "[1]", false, created),
+			save: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE `reinvestment_suggestions` SET .* WHERE `id` = \\?").
+					WithArgs("0xuser", "600", "[3,4]", 10.0, "Top 2 performing pools based on ROI and risk", false, created, sqlmock.AnyArg(), 12).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantID: 12,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			expectSuggestionInputs(mock)
+			mock.ExpectQuery("SELECT \\* FROM `reinvestment_suggestions` WHERE user_address = \\? AND is_actioned = \\? ORDER BY created_at DESC").
+				WithArgs("0xuser", false).
+				WillReturnRows(tt.open)
+			mock.ExpectBegin()
+			tt.save(mock)
+			mock.ExpectCommit()
+
+			got, err := NewReinvestmentService(db).GetSuggestions(t.Context(), "0xuser")
+			if err != nil {
+				t.Fatalf("GetSuggestions() error = %v", err)
+			}
+			if got.SuggestionID != tt.wantID || got.AvailableFunds != "600" || len(got.SuggestedPools) != 2 || got.TotalExpectedROI != 10 {
+				t.Errorf("GetSuggestions() = suggestion %d, funds %s, %d pools, ROI %v, want suggestion %d, funds 600, 2 pools, ROI 10",
+					got.SuggestionID, got.AvailableFunds, len(got.SuggestedPools), got.TotalExpectedROI, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestDismissSuggestion(t *testing.T) {
+	tests := []struct {
+		name     string
+		row      *sqlmock.Rows
+		wantErr  error
+		wantExec bool
+	}{
+		{
+			name:     "open suggestion",
+			row:      sqlmock.NewRows(suggestionColumns).AddRow(12, "0xuser", "600", "[3]", false, time.Now()),
+			wantExec: true,
+		},
+		{
+			name: "already dismissed",
+			row:  sqlmock.NewRows(suggestionColumns).AddRow(12, "0xuser", "600", "[3]", true, time.Now()),
+		},
+		{
+			name:    "unknown or another user's suggestion",
+			row:     sqlmock.NewRows(suggestionColumns),
+			wantErr: ErrSuggestionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT \\* FROM `reinvestment_suggestions` WHERE id = \\? AND user_address = \\?").
+				WithArgs(12, "0xuser").
+				WillReturnRows(tt.row)
+			if tt.wantExec {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `reinvestment_suggestions` SET `is_actioned`=\\?,`updated_at`=\\? WHERE `id` = \\?").
+					WithArgs(true, sqlmock.AnyArg(), 12).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			got, err := NewReinvestmentService(db).DismissSuggestion(t.Context(), 12, "0xuser")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("DismissSuggestion() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DismissSuggestion() error = %v", err)
+			}
+			if !got.IsActioned {
+				t.Error("IsActioned = false, want true")
+			}
+		})
+	}
+}
+
+func TestMarkSuggestionsActioned(t *testing.T) {
+	tests := []struct {
+		name        string
+		campaignID  uint64
+		wantUpdated []int
+	}{
+		{name: "suggestions recommending the campaign", campaignID: 4, wantUpdated: []int{1, 3}},
+		{name: "campaign no suggestion recommended", campaignID: 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectQuery("SELECT \\* FROM `reinvestment_suggestions` WHERE user_address = \\? AND is_actioned = \\?").
+				WithArgs("0xuser", false).
+				WillReturnRows(sqlmock.NewRows(suggestionColumns).
+					AddRow(1, "0xuser", "600", "[3,4]", false, time.Now()).
+					AddRow(2, "0xuser", "600", "[5]", false, time.Now()).
+					AddRow(3, "0xuser", "600", "[4]", false, time.Now()).
+					AddRow(4, "0xuser", "600", "not json", false, time.Now()))
+			if tt.wantUpdated != nil {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `reinvestment_suggestions` SET `is_actioned`=\\?,`updated_at`=\\? WHERE id IN \\(\\?,\\?\\)").
+					WithArgs(true, sqlmock.AnyArg(), tt.wantUpdated[0], tt.wantUpdated[1]).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			}
+
+			if err := markSuggestionsActioned(db.DB, "0xuser", tt.campaignID); err != nil {
+				t.Fatalf("markSuggestionsActioned() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestRemainingWei(t *testing.T) {
+	tests := []struct {
+		name   string
+		earned string
+		spent  string
+		want   string
+	}{
+		{name: "earned less spent", earned: "1000", spent: "400", want: "600"},
+		{name: "everything spent", earned: "1000", spent: "1000", want: "0"},
+		{name: "overspending floors at zero", earned: "1000", spent: "1500", want: "0"},
+		{name: "amounts beyond int64", earned: "30000000000000000000", spent: "10000000000000000000", want: "20000000000000000000"},
+		{name: "unparsable earnings count as zero", earned: "", spent: "0", want: "0"},
+		{name: "unparsable spending counts as zero", earned: "700", spent: "n/a", want: "700"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := remainingWei(tt.earned, tt.spent); got != tt.want {
+				t.Errorf("remainingWei(%q, %q) = %s, want %s", tt.earned, tt.spent, got, tt.want)
+			}
+		})
+	}
+}
+
+// expectLockedFunds answers the funds lock and the earnings and reinvested
+// totals, leaving 600 wei to reinvest
+func expectLockedFunds(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("SELECT `id` FROM `reinvestment_histories` WHERE user_address = \\? FOR UPDATE").
+		WithArgs("0xuser").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("FROM `royalty_distributions` JOIN music_metadata").
+		WithArgs("0xuser").
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow("1000"))
+	mock.ExpectQuery("FROM `reinvestment_histories`").
+		WithArgs("0xuser").
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow("400"))
+}
+
+func TestBatchReinvest(t *testing.T) {
+	db, mock := dbtest.New(t)
+	mock.ExpectBegin()
+	expectLockedFunds(mock)
+
+	// Campaign 3 is active and is reinvested into
+	mock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT \\* FROM `campaigns` WHERE \\(campaign_id = \\? AND status = \\?\\)").
+		WithArgs(3, "active").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "status"}).AddRow(1, 3, "active"))
+	mock.ExpectExec("INSERT INTO `reinvestment_histories`").
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec("INSERT INTO `contributions`").
+		WillReturnResult(sqlmock.NewResult(8, 1))
+	mock.ExpectQuery("SELECT \\* FROM `reinvestment_suggestions`").
+		WillReturnRows(sqlmock.NewRows(suggestionColumns))
+
+	// Campaign 8 is no longer active; only its savepoint is rolled back
+	mock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT \\* FROM `campaigns` WHERE \\(campaign_id = \\? AND status = \\?\\)").
+		WithArgs(8, "active").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	got, err := NewReinvestmentService(db).BatchReinvest(t.Context(), &BatchReinvestRequest{
+		UserAddress: "0xuser",
+		Items: []BatchReinvestItem{
+			{CampaignID: 3, Amount: "300", FromSource: "royalties"},
+			{CampaignID: 8, Amount: "200", FromSource: "royalties"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchReinvest() error = %v", err)
+	}
+
+	if got.AvailableFunds != "600" || got.Requested != "500" || got.TotalReinvested != "300" {
+		t.Errorf("available %s, requested %s, reinvested %s, want 600, 500, 300", got.AvailableFunds, got.Requested, got.TotalReinvested)
+	}
+	if got.Succeeded != 1 || got.Failed != 1 {
+		t.Errorf("succeeded %d, failed %d, want 1 and 1", got.Succeeded, got.Failed)
+	}
+	if ok := got.Results[0]; !ok.Success || ok.History == nil || ok.Error != "" {
+		t.Errorf("results[0] = %+v, want a success with its history", ok)
+	}
+	if failed := got.Results[1]; failed.Success || failed.History != nil || failed.Index != 1 || failed.CampaignID != 8 || failed.Error == "" {
+		t.Errorf("results[1] = %+v, want campaign 8 reported as failed", failed)
+	}
+}
+
+func TestBatchReinvestChecksTotalFirst(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts []string
+		wantErr error
+	}{
+		{name: "combined amount beyond available funds", amounts: []string{"400", "201"}, wantErr: ErrInsufficientFunds},
+		{name: "invalid amount", amounts: []string{"400", "-1"}, wantErr: ErrInvalidAmount},
+		{name: "zero amount", amounts: []string{"0"}, wantErr: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			// Invalid amounts are rejected before the database is touched;
+			// an unaffordable batch is rolled back before any item runs
+			if errors.Is(tt.wantErr, ErrInsufficientFunds) {
+				mock.ExpectBegin()
+				expectLockedFunds(mock)
+				mock.ExpectRollback()
+			}
+
+			items := make([]BatchReinvestItem, len(tt.amounts))
+			for i, amount := range tt.amounts {
+				items[i] = BatchReinvestItem{CampaignID: uint64(i + 1), Amount: amount, FromSource: "royalties"}
+			}
+			_, err := NewReinvestmentService(db).BatchReinvest(t.Context(), &BatchReinvestRequest{UserAddress: "0xuser", Items: items})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("BatchReinvest() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"sort"
+	"time"
+)
+
+// ReleaseStreak is a creator's run of consecutive weeks with at least one
+// release. Weeks start on Monday, UTC; the dates are the Mondays of the first
+// and last week of each run.
+type ReleaseStreak struct {
+	LongestWeeks int        `json:"longest_weeks"`
+	LongestStart *time.Time `json:"longest_start"`
+	LongestEnd   *time.Time `json:"longest_end"`
+	CurrentWeeks int        `json:"current_weeks"`
+	CurrentStart *time.Time `json:"current_start"`
+	CurrentEnd   *time.Time `json:"current_end"`
+	LastRelease  *time.Time `json:"last_release"`
+}
+
+// releaseWeek returns the Monday starting t's UTC week
+func releaseWeek(t time.Time) time.Time {
+	day := t.UTC().Truncate(24 * time.Hour)
+	offset := (int(day.Weekday()) + 6) % 7 // days since Monday
+	return day.AddDate(0, 0, -offset)
+}
+
+// ComputeReleaseStreak finds the longest run of consecutive release weeks and
+// the run still in progress at now. A streak stays current through the week
+// after its last release, so a creator is not shown a broken streak before
+// the week they could still release in is over.
+func ComputeReleaseStreak(releases []time.Time, now time.Time) ReleaseStreak {
+	var streak ReleaseStreak
+	if len(releases) == 0 {
+		return streak
+	}
+
+	seen := make(map[time.Time]bool, len(releases))
+	weeks := make([]time.Time, 0, len(releases))
+	for _, release := range releases {
+		week := releaseWeek(release)
+		if !seen[week] {
+			seen[week] = true
+			weeks = append(weeks, week)
+		}
+	}
+	sort.Slice(weeks, func(i, j int) bool { return weeks[i].Before(weeks[j]) })
+
+	runStart, runLength := weeks[0], 1
+	record := func(end time.Time) {
+		if runLength > streak.LongestWeeks {
+			start := runStart
+			streak.LongestWeeks = runLength
+			streak.LongestStart = &start
+			streak.LongestEnd = &end
+		}
+	}
+	for i := 1; i < len(weeks); i++ {
+		if weeks[i].Equal(weeks[i-1].AddDate(0, 0, 7)) {
+			runLength++
+			continue
+		}
+		record(weeks[i-1])
+		runStart, runLength = weeks[i], 1
+	}
+	lastWeek := weeks[len(weeks)-1]
+	record(lastWeek)
+
+	if !lastWeek.Before(releaseWeek(now).AddDate(0, 0, -7)) {
+		start, end := runStart, lastWeek
+		streak.CurrentWeeks = runLength
+		streak.CurrentStart = &start
+		streak.CurrentEnd = &end
+	}
+
+	latest := releases[0]
+	for _, release := range releases[1:] {
+		if release.After(latest) {
+			latest = release
+		}
+	}
+	streak.LastRelease = &latest
+
+	return streak
+}
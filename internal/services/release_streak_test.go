@@ -0,0 +1,120 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeReleaseStreak(t *testing.T) {
+	// Weeks start on Monday: Feb 2, 9, 16, 23, Mar 2, 9, 16
+	date := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	monday := func(month time.Month, day int) *time.Time {
+		week := date(month, day, 0)
+		return &week
+	}
+	now := date(time.March, 18, 12)
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	tests := []struct {
+		name         string
+		releases     []time.Time
+		wantLongest  int
+		longestStart *time.Time
+		longestEnd   *time.Time
+		wantCurrent  int
+		currentStart *time.Time
+		currentEnd   *time.Time
+	}{
+		{name: "no releases"},
+		{
+			name:         "consecutive weeks through this week",
+			releases:     []time.Time{date(time.March, 4, 10), date(time.March, 10, 10), date(time.March, 17, 10)},
+			wantLongest:  3,
+			longestStart: monday(time.March, 2),
+			longestEnd:   monday(time.March, 16),
+			wantCurrent:  3,
+			currentStart: monday(time.March, 2),
+			currentEnd:   monday(time.March, 16),
+		},
+		{
+			name: "broken weeks",
+			releases: []time.Time{
+				date(time.February, 3, 10), date(time.February, 10, 10), date(time.February, 17, 10),
+				date(time.March, 3, 10), date(time.March, 11, 10),
+			},
+			wantLongest:  3,
+			longestStart: monday(time.February, 2),
+			longestEnd:   monday(time.February, 16),
+			wantCurrent:  2, // last week's release keeps the run current
+			currentStart: monday(time.March, 2),
+			currentEnd:   monday(time.March, 9),
+		},
+		{
+			name:         "lapsed streak",
+			releases:     []time.Time{date(time.February, 3, 10), date(time.February, 10, 10)},
+			wantLongest:  2,
+			longestStart: monday(time.February, 2),
+			longestEnd:   monday(time.February, 9),
+		},
+		{
+			name:         "several releases in one week count once",
+			releases:     []time.Time{date(time.March, 16, 1), date(time.March, 18, 1), date(time.March, 22, 23)},
+			wantLongest:  1,
+			longestStart: monday(time.March, 16),
+			longestEnd:   monday(time.March, 16),
+			wantCurrent:  1,
+			currentStart: monday(time.March, 16),
+			currentEnd:   monday(time.March, 16),
+		},
+		{
+			name:         "Sunday night then Monday morning are consecutive",
+			releases:     []time.Time{date(time.March, 8, 23), date(time.March, 9, 0)},
+			wantLongest:  2,
+			longestStart: monday(time.March, 2),
+			longestEnd:   monday(time.March, 9),
+			wantCurrent:  2,
+			currentStart: monday(time.March, 2),
+			currentEnd:   monday(time.March, 9),
+		},
+		{
+			// Monday morning in Jakarta is still Sunday in UTC
+			name:         "weeks are taken in UTC",
+			releases:     []time.Time{time.Date(2026, time.March, 9, 3, 0, 0, 0, jakarta)},
+			wantLongest:  1,
+			longestStart: monday(time.March, 2),
+			longestEnd:   monday(time.March, 2),
+		},
+		{
+			name:         "unsorted input and equal runs keep the earliest",
+			releases:     []time.Time{date(time.March, 10, 10), date(time.February, 10, 10), date(time.March, 3, 10), date(time.February, 3, 10)},
+			wantLongest:  2,
+			longestStart: monday(time.February, 2),
+			longestEnd:   monday(time.February, 9),
+			wantCurrent:  2,
+			currentStart: monday(time.March, 2),
+			currentEnd:   monday(time.March, 9),
+		},
+	}
+
+	sameDate := func(got, want *time.Time) bool {
+		return (got == nil) == (want == nil) && (got == nil || got.Equal(*want))
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeReleaseStreak(tt.releases, now)
+
+			if got.LongestWeeks != tt.wantLongest || !sameDate(got.LongestStart, tt.longestStart) || !sameDate(got.LongestEnd, tt.longestEnd) {
+				t.Errorf("longest = %d weeks %v to %v, want %d weeks %v to %v", got.LongestWeeks, got.LongestStart, got.LongestEnd, tt.wantLongest, tt.longestStart, tt.longestEnd)
+			}
+			if got.CurrentWeeks != tt.wantCurrent || !sameDate(got.CurrentStart, tt.currentStart) || !sameDate(got.CurrentEnd, tt.currentEnd) {
+				t.Errorf("current = %d weeks %v to %v, want %d weeks %v to %v", got.CurrentWeeks, got.CurrentStart, got.CurrentEnd, tt.wantCurrent, tt.currentStart, tt.currentEnd)
+			}
+			if (got.LastRelease == nil) != (len(tt.releases) == 0) {
+				t.Errorf("last release = %v, want one only when there are releases", got.LastRelease)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// PlatformEfficiency is what one platform has paid a track per play. Plays
+// are views for video platforms. PayoutPerPlay is in wei, rounded down, and
+// nil when the platform has no plays to divide by.
+type PlatformEfficiency struct {
+	Rank           int     `json:"rank"`
+	Platform       string  `json:"platform"`
+	TotalRoyalties string  `json:"total_royalties"`
+	Plays          uint64  `json:"plays"`
+	PayoutPerPlay  *string `json:"payout_per_play"`
+}
+
+// PlatformKey maps a free-form platform name onto the snake_case keys used
+// by distribution, e.g. "Apple Music" to apple_music
+func PlatformKey(platform string) string {
+	if key, ok := NormalizePlatform(platform); ok {
+		return key
+	}
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(platform)))
+}
+
+// RankRoyaltyEfficiency ranks platforms by royalties paid per play, best
+// first. Every platform with royalties or plays is included; those without
+// plays cannot be rated and rank last, by total royalties. Ties keep the
+// larger payer first, then sort by name.
+func RankRoyaltyEfficiency(royalties map[string]*big.Int, plays map[string]uint64) []PlatformEfficiency {
+	type candidate struct {
+		platform string
+		total    *big.Int
+		plays    uint64
+		perPlay  *big.Rat
+	}
+
+	candidates := make([]candidate, 0, len(royalties)+len(plays))
+	seen := make(map[string]bool, len(royalties)+len(plays))
+	add := func(platform string) {
+		if seen[platform] {
+			return
+		}
+		seen[platform] = true
+		total := royalties[platform]
+		if total == nil {
+			total = new(big.Int)
+		}
+		entry := candidate{platform: platform, total: total, plays: plays[platform]}
+		if entry.plays > 0 {
+			entry.perPlay = new(big.Rat).SetFrac(total, new(big.Int).SetUint64(entry.plays))
+		}
+		candidates = append(candidates, entry)
+	}
+	for platform := range royalties {
+		add(platform)
+	}
+	for platform := range plays {
+		add(platform)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if (a.perPlay == nil) != (b.perPlay == nil) {
+			return a.perPlay != nil
+		}
+		if a.perPlay != nil {
+			if cmp := a.perPlay.Cmp(b.perPlay); cmp != 0 {
+				return cmp > 0
+			}
+		}
+		if cmp := a.total.Cmp(b.total); cmp != 0 {
+			return cmp > 0
+		}
+		return a.platform < b.platform
+	})
+
+	ranking := make([]PlatformEfficiency, len(candidates))
+	for i, entry := range candidates {
+		ranking[i] = PlatformEfficiency{
+			Rank:           i + 1,
+			Platform:       entry.platform,
+			TotalRoyalties: entry.total.String(),
+			Plays:          entry.plays,
+		}
+		if entry.perPlay != nil {
+			perPlay := new(big.Int).Quo(entry.perPlay.Num(), entry.perPlay.Denom()).String()
+			ranking[i].PayoutPerPlay = &perPlay
+		}
+	}
+	return ranking
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestPlatformKey(t *testing.T) {
+	tests := []struct {
+		platform string
+		want     string
+	}{
+		{platform: "spotify", want: "spotify"},
+		{platform: "Apple Music", want: "apple_music"},
+		{platform: " YouTube-Music ", want: "youtube_music"},
+		{platform: "Some Radio", want: "some_radio"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			if got := PlatformKey(tt.platform); got != tt.want {
+				t.Errorf("PlatformKey(%q) = %q, want %q", tt.platform, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankRoyaltyEfficiency(t *testing.T) {
+	type want struct {
+		platform string
+		perPlay  string // empty when the platform has no plays
+	}
+
+	tests := []struct {
+		name      string
+		royalties map[string]int64
+		plays     map[string]uint64
+		want      []want
+	}{
+		{
+			name:      "best payout per play first",
+			royalties: map[string]int64{"spotify": 3000, "apple_music": 2000, "youtube": 5000},
+			plays:     map[string]uint64{"spotify": 1000, "apple_music": 200, "youtube": 10000},
+			want:      []want{{"apple_music", "10"}, {"spotify", "3"}, {"youtube", "0"}},
+		},
+		{
+			name:      "platforms without plays rank last by royalties",
+			royalties: map[string]int64{"spotify": 100, "tidal": 50, "deezer": 500},
+			plays:     map[string]uint64{"spotify": 10},
+			want:      []want{{"spotify", "10"}, {"deezer", ""}, {"tidal", ""}},
+		},
+		{
+			name:  "plays without royalties pay nothing",
+			plays: map[string]uint64{"spotify": 10},
+			want:  []want{{"spotify", "0"}},
+		},
+		{
+			name:      "ties keep the larger payer first, then the name",
+			royalties: map[string]int64{"spotify": 100, "tidal": 200, "deezer": 100},
+			plays:     map[string]uint64{"spotify": 10, "tidal": 20, "deezer": 10},
+			want:      []want{{"tidal", "10"}, {"deezer", "10"}, {"spotify", "10"}},
+		},
+		{
+			name:      "fractional rates rank before rounding",
+			royalties: map[string]int64{"spotify": 19, "tidal": 11},
+			plays:     map[string]uint64{"spotify": 10, "tidal": 10},
+			want:      []want{{"spotify", "1"}, {"tidal", "1"}},
+		},
+		{
+			name: "nothing to rank",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			royalties := make(map[string]*big.Int, len(tt.royalties))
+			for platform, amount := range tt.royalties {
+				royalties[platform] = big.NewInt(amount)
+			}
+
+			got := RankRoyaltyEfficiency(royalties, tt.plays)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d platforms, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, w := range tt.want {
+				entry := got[i]
+				perPlay := ""
+				if entry.PayoutPerPlay != nil {
+					perPlay = *entry.PayoutPerPlay
+				}
+				if entry.Rank != i+1 || entry.Platform != w.platform || perPlay != w.perPlay {
+					t.Errorf("rank %d = %s at %q per play, want %s at %q", entry.Rank, entry.Platform, perPlay, w.platform, w.perPlay)
+				}
+				if entry.Plays != tt.plays[w.platform] {
+					t.Errorf("%s plays = %d, want %d", w.platform, entry.Plays, tt.plays[w.platform])
+				}
+			}
+		})
+	}
+}
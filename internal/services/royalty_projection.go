@@ -0,0 +1,71 @@
+package services
+
+import (
+	"math/big"
+	"time"
+)
+
+// ProjectionWindow is how far back payments are read when extrapolating a
+// track's royalty run-rate
+const ProjectionWindow = 90 * 24 * time.Hour
+
+// minProjectionSpan keeps a single fresh payment from being annualised over
+// a few seconds of history
+const minProjectionSpan = 7 * 24 * time.Hour
+
+const year = 365 * 24 * time.Hour
+
+// Projection confidence levels, from how much payment history backs the run-rate
+const (
+	ConfidenceNone   = "none"
+	ConfidenceLow    = "low"
+	ConfidenceMedium = "medium"
+	ConfidenceHigh   = "high"
+)
+
+// AnnualizeRoyalties extrapolates a total earned over the observed span to a
+// full year, in wei rounded down. Spans shorter than a week count as a week.
+func AnnualizeRoyalties(total *big.Int, observed time.Duration) *big.Int {
+	if total.Sign() <= 0 {
+		return new(big.Int)
+	}
+	if observed < minProjectionSpan {
+		observed = minProjectionSpan
+	}
+	annual := new(big.Int).Mul(total, big.NewInt(int64(year/time.Second)))
+	return annual.Quo(annual, big.NewInt(int64(observed/time.Second)))
+}
+
+// ProjectionConfidence rates a run-rate built from paymentCount payments over
+// the observed span
+func ProjectionConfidence(paymentCount int64, observed time.Duration) string {
+	switch {
+	case paymentCount == 0:
+		return ConfidenceNone
+	case paymentCount < 3 || observed < 30*24*time.Hour:
+		return ConfidenceLow
+	case paymentCount < 12 || observed < ProjectionWindow:
+		return ConfidenceMedium
+	default:
+		return ConfidenceHigh
+	}
+}
+
+// BasisPointsOf returns amount*bps/10000 rounded down
+func BasisPointsOf(amount *big.Int, bps uint16) *big.Int {
+	share := new(big.Int).Mul(amount, big.NewInt(int64(bps)))
+	return share.Quo(share, big.NewInt(10000))
+}
+
+// PercentOf returns amount*percent/100 rounded down
+func PercentOf(amount *big.Int, percent float64) *big.Int {
+	if percent <= 0 {
+		return new(big.Int)
+	}
+	ratio := new(big.Rat).SetFloat64(percent / 100)
+	if ratio == nil {
+		return new(big.Int)
+	}
+	share := new(big.Rat).Mul(new(big.Rat).SetInt(amount), ratio)
+	return new(big.Int).Quo(share.Num(), share.Denom())
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestAnnualizeRoyalties(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		total    int64
+		observed time.Duration
+		want     string
+	}{
+		{name: "full window", total: 900, observed: ProjectionWindow, want: "3650"},
+		{name: "a year is unchanged", total: 1000, observed: year, want: "1000"},
+		{name: "rounded down", total: 1, observed: 30 * day, want: "12"},
+		{name: "short spans count as a week", total: 7, observed: time.Hour, want: "365"},
+		{name: "exactly a week", total: 7, observed: 7 * day, want: "365"},
+		{name: "nothing earned", total: 0, observed: ProjectionWindow, want: "0"},
+		{name: "negative total", total: -5, observed: ProjectionWindow, want: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AnnualizeRoyalties(big.NewInt(tt.total), tt.observed); got.String() != tt.want {
+				t.Errorf("AnnualizeRoyalties(%d, %s) = %s, want %s", tt.total, tt.observed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnualizeRoyaltiesBeyondInt64(t *testing.T) {
+	total, _ := new(big.Int).SetString("90000000000000000000", 10) // 90 ether
+	if got, want := AnnualizeRoyalties(total, ProjectionWindow).String(), "365000000000000000000"; got != want {
+		t.Errorf("AnnualizeRoyalties() = %s, want %s", got, want)
+	}
+}
+
+func TestProjectionConfidence(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		count    int64
+		observed time.Duration
+		want     string
+	}{
+		{name: "no payments", count: 0, observed: ProjectionWindow, want: ConfidenceNone},
+		{name: "too few payments", count: 2, observed: ProjectionWindow, want: ConfidenceLow},
+		{name: "too short a span", count: 20, observed: 29 * day, want: ConfidenceLow},
+		{name: "some history", count: 3, observed: 30 * day, want: ConfidenceMedium},
+		{name: "many payments over a partial window", count: 12, observed: 60 * day, want: ConfidenceMedium},
+		{name: "full window", count: 12, observed: ProjectionWindow, want: ConfidenceHigh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ProjectionConfidence(tt.count, tt.observed); got != tt.want {
+				t.Errorf("ProjectionConfidence(%d, %s) = %q, want %q", tt.count, tt.observed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBasisPointsOf(t *testing.T) {
+	tests := []struct {
+		amount int64
+		bps    uint16
+		want   string
+	}{
+		{amount: 3650, bps: 3000, want: "1095"},
+		{amount: 10000, bps: 10000, want: "10000"},
+		{amount: 999, bps: 1, want: "0"},
+		{amount: 1000, bps: 0, want: "0"},
+	}
+
+	for _, tt := range tests {
+		if got := BasisPointsOf(big.NewInt(tt.amount), tt.bps); got.String() != tt.want {
+			t.Errorf("BasisPointsOf(%d, %d) = %s, want %s", tt.amount, tt.bps, got, tt.want)
+		}
+	}
+}
+
+func TestPercentOf(t *testing.T) {
+	tests := []struct {
+		amount  int64
+		percent float64
+		want    string
+	}{
+		{amount: 1000, percent: 25, want: "250"},
+		{amount: 1000, percent: 33.333333, want: "333"},
+		{amount: 3, percent: 50, want: "1"},
+		{amount: 1000, percent: 100, want: "1000"},
+		{amount: 1000, percent: 0, want: "0"},
+		{amount: 1000, percent: -5, want: "0"},
+	}
+
+	for _, tt := range tests {
+		if got := PercentOf(big.NewInt(tt.amount), tt.percent); got.String() != tt.want {
+			t.Errorf("PercentOf(%d, %v) = %s, want %s", tt.amount, tt.percent, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,450 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tunecent/backend/internal/database"
+	"github.com/tunecent/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrPaymentNotFound           = errors.New("royalty payment not found")
+	ErrPaymentAlreadyDistributed = errors.New("royalty payment already distributed")
+	ErrInvalidPaymentAmount      = errors.New("royalty payment amount is invalid")
+)
+
+type RoyaltyService struct {
+	db *database.DB
+
+	onDistributed []func()
+}
+
+// OnDistributed adds a callback run after each successful distribution, e.g.
+// to invalidate caches that total earnings
+func (s *RoyaltyService) OnDistributed(fn func()) {
+	s.onDistributed = append(s.onDistributed, fn)
+}
+
+func NewRoyaltyService(db *database.DB) *RoyaltyService {
+	return &RoyaltyService{db: db}
+}
+
+// Split is one beneficiary's part of a royalty payment
+type Split struct {
+	Beneficiary string `json:"beneficiary"`
+	Amount      string `json:"amount"` // Wei as string
+	Role        string `json:"role"`   // creator or contributor
+}
+
+// ComputeSplits divides amount between the creator and a campaign's
+// contributors. Contributors share royaltyBps of the payment pro rata to what
+// they contributed; the creator receives the rest, including rounding dust,
+// so the splits always add up to amount exactly. Addresses are compared
+// case-insensitively: contributions under differently cased spellings of one
+// address are merged, contributor splits use the lowercased address, and a
+// contributor who is also the creator is folded into the creator's split.
+func ComputeSplits(amount *big.Int, creator string, royaltyBps uint16, contributions map[string]*big.Int) []Split {
+	contributions = lowercaseContributions(contributions)
+	creatorKey := strings.ToLower(creator)
+	contributed := new(big.Int)
+	for _, value := range contributions {
+		contributed.Add(contributed, value)
+	}
+
+	investorPool := new(big.Int)
+	if contributed.Sign() > 0 {
+		investorPool = BasisPointsOf(amount, royaltyBps)
+	}
+
+	addresses := make([]string, 0, len(contributions))
+	for address := range contributions {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		if cmp := contributions[addresses[i]].Cmp(contributions[addresses[j]]); cmp != 0 {
+			return cmp > 0
+		}
+		return addresses[i] < addresses[j]
+	})
+
+	creatorAmount := new(big.Int).Set(amount)
+	var contributorSplits []Split
+	for _, address := range addresses {
+		share := new(big.Int).Mul(investorPool, contributions[address])
+		share.Quo(share, contributed)
+		if share.Sign() == 0 || address == creatorKey {
+			continue
+		}
+		creatorAmount.Sub(creatorAmount, share)
+		contributorSplits = append(contributorSplits, Split{
+			Beneficiary: address,
+			Amount:      share.String(),
+			Role:        "contributor",
+		})
+	}
+
+	splits := []Split{{Beneficiary: creator, Amount: creatorAmount.String(), Role: "creator"}}
+	return append(splits, contributorSplits...)
+}
+
+// lowercaseContributions merges contributions keyed by differently cased
+// spellings of the same address under the lowercased address
+func lowercaseContributions(contributions map[string]*big.Int) map[string]*big.Int {
+	merged := make(map[string]*big.Int, len(contributions))
+	for address, value := range contributions {
+		key := strings.ToLower(address)
+		if existing, seen := merged[key]; seen {
+			merged[key] = new(big.Int).Add(existing, value)
+		} else {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// splitInputs is what ComputeSplits needs to divide a track's payments
+type splitInputs struct {
+	creator       string
+	campaignID    *uint64 // the funded campaign, if any
+	royaltyBps    uint16
+	contributions map[string]*big.Int
+}
+
+// loadSplitInputs reads a track's creator and, when it has a funded campaign,
+// the campaign's royalty share and per-contributor totals
+func loadSplitInputs(db *gorm.DB, tokenID uint64) (*splitInputs, error) {
+	var music models.MusicMetadata
+	if err := db.Where("token_id = ?", tokenID).First(&music).Error; err != nil {
+		return nil, fmt.Errorf("failed to load music for token %d: %w", tokenID, err)
+	}
+	inputs := &splitInputs{creator: music.CreatorAddress, contributions: make(map[string]*big.Int)}
+
+	// Only a funded campaign entitles contributors to royalties
+	var campaign models.Campaign
+	err := db.Where("token_id = ? AND status = ?", tokenID, "successful").
+		Order("created_at DESC").
+		First(&campaign).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return inputs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load campaign: %w", err)
+	}
+	inputs.campaignID = &campaign.CampaignID
+	inputs.royaltyBps = campaign.RoyaltyPercentage
+
+	if inputs.contributions, err = CampaignContributionTotals(db, campaign.CampaignID); err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+// CampaignContributionTotals sums each contributor's contributions to a
+// campaign by lowercased address, skipping malformed and non-positive amounts
+func CampaignContributionTotals(db *gorm.DB, campaignID uint64) (map[string]*big.Int, error) {
+	var rows []models.Contribution
+	if err := db.Select("contributor_address", "amount").
+		Where("campaign_id = ?", campaignID).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load contributions: %w", err)
+	}
+
+	totals := make(map[string]*big.Int)
+	for _, row := range rows {
+		value, ok := new(big.Int).SetString(row.Amount, 10)
+		if !ok || value.Sign() <= 0 {
+			continue
+		}
+		key := strings.ToLower(row.ContributorAddress)
+		if existing, seen := totals[key]; seen {
+			existing.Add(existing, value)
+		} else {
+			totals[key] = value
+		}
+	}
+	return totals, nil
+}
+
+// SplitAmountFor adds up what address receives among splits
+func SplitAmountFor(splits []Split, address string) *big.Int {
+	total := new(big.Int)
+	for _, split := range splits {
+		if !strings.EqualFold(split.Beneficiary, address) {
+			continue
+		}
+		if value, ok := new(big.Int).SetString(split.Amount, 10); ok {
+			total.Add(total, value)
+		}
+	}
+	return total
+}
+
+// PendingRoyaltyShare is what address would receive, as creator or as a
+// contributor to a funded campaign, once every undistributed payment on
+// its tracks is split the way DistributePayment splits it
+func PendingRoyaltyShare(db *gorm.DB, address string) (*big.Int, error) {
+	var payments []models.RoyaltyPayment
+	if err := db.Where("is_distributed = ?", false).
+		Where(`token_id IN (SELECT token_id FROM music_metadata WHERE creator_address = ? AND deleted_at IS NULL)
+			OR token_id IN (
+				SELECT campaigns.token_id FROM campaigns
+				JOIN contributions ON contributions.campaign_id = campaigns.campaign_id
+				WHERE campaigns.status = ? AND contributions.contributor_address = ? AND campaigns.deleted_at IS NULL
+			)`, address, "successful", address).
+		Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("failed to load pending payments: %w", err)
+	}
+
+	share := new(big.Int)
+	inputsByToken := make(map[uint64]*splitInputs)
+	for _, payment := range payments {
+		amount, ok := new(big.Int).SetString(payment.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			continue
+		}
+		inputs, seen := inputsByToken[payment.TokenID]
+		if !seen {
+			var err error
+			if inputs, err = loadSplitInputs(db, payment.TokenID); err != nil {
+				return nil, err
+			}
+			inputsByToken[payment.TokenID] = inputs
+		}
+
+		share.Add(share, inputs.splitAmountFor(amount, address))
+	}
+	return share, nil
+}
+
+// DistributionResult is the outcome of distributing one payment
+type DistributionResult struct {
+	Payment       models.RoyaltyPayment        `json:"payment"`
+	Splits        []Split                      `json:"splits"`
+	Distributions []models.RoyaltyDistribution `json:"distributions"`
+	SplitRecord   *models.SplitRecord          `json:"split_record"`
+}
+
+// DistributePayment splits an undistributed payment between the track's
+// creator and its funded campaign's contributors, recording the
+// distributions and a split record in one transaction before flagging the
+// payment as distributed. Beneficiary notifications and a
+// royalty.distributed webhook are queued in the outbox.
+func (s *RoyaltyService) DistributePayment(ctx context.Context, paymentID uint) (*DistributionResult, error) {
+	var result *DistributionResult
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var payment models.RoyaltyPayment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&payment, paymentID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPaymentNotFound
+			}
+			return err
+		}
+		if payment.IsDistributed {
+			return ErrPaymentAlreadyDistributed
+		}
+
+		amount, ok := new(big.Int).SetString(payment.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			return ErrInvalidPaymentAmount
+		}
+
+		inputs, err := loadSplitInputs(tx, payment.TokenID)
+		if err != nil {
+			return err
+		}
+
+		splits := ComputeSplits(amount, inputs.creator, inputs.royaltyBps, inputs.contributions)
+
+		now := time.Now()
+		txHash := fmt.Sprintf("0x%064x", now.UnixNano()) // Mock tx hash
+		distributions := make([]models.RoyaltyDistribution, len(splits))
+		for i, split := range splits {
+			distributions[i] = models.RoyaltyDistribution{
+				PaymentID:     payment.ID,
+				TokenID:       payment.TokenID,
+				Beneficiary:   split.Beneficiary,
+				Amount:        split.Amount,
+				TxHash:        txHash,
+				DistributedAt: now,
+			}
+		}
+		if err := tx.Create(&distributions).Error; err != nil {
+			return fmt.Errorf("failed to create distributions: %w", err)
+		}
+
+		// The split record joins the transaction
+		splitRecord, err := NewLedgerService(&database.DB{DB: tx}).CreateSplitRecord(ctx, payment.TokenID, payment.ID, payment.Amount, len(splits), txHash, 0)
+		if err != nil {
+			return err
+		}
+
+		update := tx.Model(&models.RoyaltyPayment{}).
+			Where("id = ? AND is_distributed = ?", payment.ID, false).
+			Updates(map[string]interface{}{"is_distributed": true, "distributed_at": now})
+		if update.Error != nil {
+			return fmt.Errorf("failed to mark payment distributed: %w", update.Error)
+		}
+		if update.RowsAffected == 0 {
+			return ErrPaymentAlreadyDistributed
+		}
+		payment.IsDistributed = true
+		payment.DistributedAt = &now
+
+		// Beneficiary notifications and the webhook go through the outbox so
+		// they are delivered if and only if this transaction commits
+		for _, split := range splits {
+			key := fmt.Sprintf("royalty-distribution:%d:%s", payment.ID, split.Beneficiary)
+			if err := EnqueueNotification(tx, key, &CreateNotificationRequest{
+				UserAddress: split.Beneficiary,
+				Type:        "payment",
+				Title:       "Royalty Payment Received",
+				Message:     fmt.Sprintf("You received a royalty payment of %s wei", split.Amount),
+				RelatedID:   payment.TokenID,
+				TxHash:      txHash,
+			}); err != nil {
+				return err
+			}
+		}
+		if err := EnqueueWebhook(tx, fmt.Sprintf("royalty.distributed:%d", payment.ID), "royalty.distributed", map[string]interface{}{
+			"payment_id": payment.ID,
+			"token_id":   payment.TokenID,
+			"amount":     payment.Amount,
+			"tx_hash":    txHash,
+			"splits":     splits,
+		}); err != nil {
+			return err
+		}
+
+		result = &DistributionResult{
+			Payment:       payment,
+			Splits:        splits,
+			Distributions: distributions,
+			SplitRecord:   splitRecord,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fn := range s.onDistributed {
+		fn()
+	}
+
+	return result, nil
+}
+
+// Roles an address can hold in a track's royalty splits
+const (
+	CutRoleCreator     = "creator"
+	CutRoleContributor = "contributor"
+	CutRoleNone        = "none"
+)
+
+// cutReferenceAmount prices the share percentage when no payment is pending
+var cutReferenceAmount = big.NewInt(1e18)
+
+// ExpectedCut is what an address stands to receive from a track's pending royalties
+type ExpectedCut struct {
+	TokenID         uint64  `json:"token_id"`
+	Address         string  `json:"address"`
+	Eligible        bool    `json:"eligible"`
+	Role            string  `json:"role"`
+	Reason          string  `json:"reason,omitempty"`
+	CampaignID      *uint64 `json:"campaign_id"`
+	Contributed     string  `json:"contributed"`      // Wei as string
+	SharePercentage float64 `json:"share_percentage"` // percent of each payment
+	// The oldest undistributed payment is the next one to be distributed
+	NextPaymentID       *uint  `json:"next_payment_id"`
+	NextPaymentAmount   string `json:"next_payment_amount"`
+	ExpectedFromNext    string `json:"expected_from_next"`
+	PendingPayments     int    `json:"pending_payments"`
+	ExpectedFromPending string `json:"expected_from_pending"`
+}
+
+// splitAmountFor returns address's part of amount under the track's splits
+func (in *splitInputs) splitAmountFor(amount *big.Int, address string) *big.Int {
+	return SplitAmountFor(ComputeSplits(amount, in.creator, in.royaltyBps, in.contributions), address)
+}
+
+// ExpectedCut computes address's share of the track's undistributed payments
+// with the same splits DistributePayment would record. An address that is
+// neither the creator nor a contributor to the funded campaign gets zero,
+// with the reason.
+func (s *RoyaltyService) ExpectedCut(ctx context.Context, tokenID uint64, address string) (*ExpectedCut, error) {
+	db := s.db.WithContext(ctx)
+
+	inputs, err := loadSplitInputs(db, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	cut := &ExpectedCut{
+		TokenID:             tokenID,
+		Address:             address,
+		Role:                CutRoleNone,
+		Contributed:         "0",
+		NextPaymentAmount:   "0",
+		ExpectedFromNext:    "0",
+		ExpectedFromPending: "0",
+	}
+	if contributed, ok := inputs.contributions[strings.ToLower(address)]; ok {
+		cut.Contributed = contributed.String()
+	}
+
+	cut.CampaignID = inputs.campaignID
+
+	switch {
+	case strings.EqualFold(address, inputs.creator):
+		cut.Role = CutRoleCreator
+	case cut.Contributed != "0":
+		cut.Role = CutRoleContributor
+	case cut.CampaignID == nil:
+		cut.Reason = "the track has no funded campaign, so only its creator receives royalties"
+	default:
+		cut.Reason = "the address did not contribute to the track's funded campaign"
+	}
+	cut.Eligible = cut.Role != CutRoleNone
+
+	reference := inputs.splitAmountFor(cutReferenceAmount, address)
+	cut.SharePercentage, _ = new(big.Rat).SetFrac(new(big.Int).Mul(reference, big.NewInt(100)), cutReferenceAmount).Float64()
+
+	var payments []models.RoyaltyPayment
+	if err := db.Where("token_id = ? AND is_distributed = ?", tokenID, false).
+		Order("paid_at ASC, id ASC").
+		Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("failed to load pending payments: %w", err)
+	}
+	cut.PendingPayments = len(payments)
+	if len(payments) == 0 && cut.Eligible {
+		cut.Reason = "no payments are pending distribution"
+	}
+
+	pending := new(big.Int)
+	for i, payment := range payments {
+		amount, ok := new(big.Int).SetString(payment.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			continue
+		}
+		share := inputs.splitAmountFor(amount, address)
+		pending.Add(pending, share)
+		if i == 0 {
+			paymentID := payment.ID
+			cut.NextPaymentID = &paymentID
+			cut.NextPaymentAmount = payment.Amount
+			cut.ExpectedFromNext = share.String()
+		}
+	}
+	cut.ExpectedFromPending = pending.String()
+
+	return cut, nil
+}
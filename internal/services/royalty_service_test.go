@@ -0,0 +1,151 @@
+package services
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestComputeSplits(t *testing.T) {
+	const creator = "0xcreator"
+
+	tests := []struct {
+		name          string
+		amount        int64
+		royaltyBps    uint16
+		contributions map[string]int64
+		want          []Split
+	}{
+		{
+			name:       "no contributors leaves everything to the creator",
+			amount:     1000,
+			royaltyBps: 3000,
+			want:       []Split{{Beneficiary: creator, Amount: "1000", Role: "creator"}},
+		},
+		{
+			name:          "single contributor takes the whole investor pool",
+			amount:        1000,
+			royaltyBps:    3000,
+			contributions: map[string]int64{"0xa": 5},
+			want: []Split{
+				{Beneficiary: creator, Amount: "700", Role: "creator"},
+				{Beneficiary: "0xa", Amount: "300", Role: "contributor"},
+			},
+		},
+		{
+			name:          "rounding dust goes to the creator",
+			amount:        100,
+			royaltyBps:    5000,
+			contributions: map[string]int64{"0xc": 1, "0xa": 1, "0xb": 1},
+			want: []Split{
+				{Beneficiary: creator, Amount: "52", Role: "creator"},
+				{Beneficiary: "0xa", Amount: "16", Role: "contributor"},
+				{Beneficiary: "0xb", Amount: "16", Role: "contributor"},
+				{Beneficiary: "0xc", Amount: "16", Role: "contributor"},
+			},
+		},
+		{
+			name:          "contributors are ordered by contribution, largest first",
+			amount:        400,
+			royaltyBps:    10000,
+			contributions: map[string]int64{"0xa": 1, "0xb": 3},
+			want: []Split{
+				{Beneficiary: creator, Amount: "0", Role: "creator"},
+				{Beneficiary: "0xb", Amount: "300", Role: "contributor"},
+				{Beneficiary: "0xa", Amount: "100", Role: "contributor"},
+			},
+		},
+		{
+			name:          "creator's own contribution is folded into the creator split",
+			amount:        1000,
+			royaltyBps:    2000,
+			contributions: map[string]int64{creator: 1, "0xa": 1},
+			want: []Split{
+				{Beneficiary: creator, Amount: "900", Role: "creator"},
+				{Beneficiary: "0xa", Amount: "100", Role: "contributor"},
+			},
+		},
+		{
+			name:          "creator contributing under another case is not paid twice",
+			amount:        1000,
+			royaltyBps:    2000,
+			contributions: map[string]int64{"0xCREATOR": 1, "0xa": 1},
+			want: []Split{
+				{Beneficiary: creator, Amount: "900", Role: "creator"},
+				{Beneficiary: "0xa", Amount: "100", Role: "contributor"},
+			},
+		},
+		{
+			name:          "differently cased contributions are merged",
+			amount:        1000,
+			royaltyBps:    3000,
+			contributions: map[string]int64{"0xAbC": 1, "0xabc": 1, "0xdef": 2},
+			want: []Split{
+				{Beneficiary: creator, Amount: "700", Role: "creator"},
+				{Beneficiary: "0xabc", Amount: "150", Role: "contributor"},
+				{Beneficiary: "0xdef", Amount: "150", Role: "contributor"},
+			},
+		},
+		{
+			name:          "shares that round to zero are dropped",
+			amount:        100,
+			royaltyBps:    1000,
+			contributions: map[string]int64{"0xa": 99, "0xb": 1},
+			want: []Split{
+				{Beneficiary: creator, Amount: "91", Role: "creator"},
+				{Beneficiary: "0xa", Amount: "9", Role: "contributor"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contributions := make(map[string]*big.Int, len(tt.contributions))
+			for address, value := range tt.contributions {
+				contributions[address] = big.NewInt(value)
+			}
+
+			got := ComputeSplits(big.NewInt(tt.amount), creator, tt.royaltyBps, contributions)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ComputeSplits() = %+v, want %+v", got, tt.want)
+			}
+
+			sum := new(big.Int)
+			for _, split := range got {
+				value, ok := new(big.Int).SetString(split.Amount, 10)
+				if !ok {
+					t.Fatalf("split amount %q is not an integer", split.Amount)
+				}
+				sum.Add(sum, value)
+			}
+			if sum.Cmp(big.NewInt(tt.amount)) != 0 {
+				t.Errorf("splits add up to %s, want %d", sum, tt.amount)
+			}
+		})
+	}
+}
+
+func TestSplitAmountFor(t *testing.T) {
+	splits := []Split{
+		{Beneficiary: "0xCreator", Amount: "700", Role: "creator"},
+		{Beneficiary: "0xAbC", Amount: "300", Role: "contributor"},
+	}
+
+	tests := []struct {
+		name    string
+		address string
+		want    string
+	}{
+		{name: "exact match", address: "0xAbC", want: "300"},
+		{name: "case-insensitive match", address: "0xabc", want: "300"},
+		{name: "no split", address: "0xdef", want: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitAmountFor(splits, tt.address); got.String() != tt.want {
+				t.Errorf("SplitAmountFor(%q) = %s, want %s", tt.address, got, tt.want)
+			}
+		})
+	}
+}
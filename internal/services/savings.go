@@ -0,0 +1,76 @@
+package services
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/tunecent/backend/internal/config"
+)
+
+// SavingsCalculator applies the configured fee model behind user savings: the
+// platform normally keeps PlatformFeeBasisPoints of royalties and stakers get
+// StakingDiscountBasisPoints off that fee, so a user saves the staking
+// discount ComputeDistributionFees would give on every royalty they receive.
+type SavingsCalculator struct {
+	fees config.FeeConfig
+}
+
+func NewSavingsCalculator(fees config.FeeConfig) *SavingsCalculator {
+	return &SavingsCalculator{fees: fees}
+}
+
+// On returns the fee saved on a royalty amount in wei, rounded down
+func (s *SavingsCalculator) On(royalties *big.Int) *big.Int {
+	gross := BasisPointsOf(royalties, s.fees.PlatformFeeBasisPoints)
+	return BasisPointsOf(gross, s.fees.StakingDiscountBasisPoints)
+}
+
+// OnWei is On for a wei string; invalid input yields "0"
+func (s *SavingsCalculator) OnWei(royalties string) string {
+	amount, ok := new(big.Int).SetString(royalties, 10)
+	if !ok || amount.Sign() <= 0 {
+		return "0"
+	}
+	return s.On(amount).String()
+}
+
+// Source describes where savings come from, e.g. "Staking fee discount (10%)"
+func (s *SavingsCalculator) Source() string {
+	percent := strconv.FormatFloat(float64(s.fees.StakingDiscountBasisPoints)/100, 'f', -1, 64)
+	return fmt.Sprintf("Staking fee discount (%s%%)", percent)
+}
+
+// DistributionFees is the platform's cut of a royalty payment and what is
+// left for its beneficiaries. Amounts are wei; the gross fee and the staking
+// discount taken off it are each rounded down. Gas is paid separately by
+// whoever sends the distribution transaction.
+type DistributionFees struct {
+	PaymentAmount              string       `json:"payment_amount"`
+	PlatformFeeBasisPoints     uint16       `json:"platform_fee_basis_points"`
+	StakingDiscountBasisPoints uint16       `json:"staking_discount_basis_points"`
+	GrossPlatformFee           string       `json:"gross_platform_fee"` // before the staking discount
+	StakingDiscount            string       `json:"staking_discount"`
+	PlatformFee                string       `json:"platform_fee"`
+	NetToBeneficiaries         string       `json:"net_to_beneficiaries"`
+	Gas                        *FeeEstimate `json:"gas"`
+	GasError                   string       `json:"gas_error,omitempty"`
+}
+
+// ComputeDistributionFees applies a platform fee of feeBps, discounted by
+// discountBps for stakers, to a payment amount
+func ComputeDistributionFees(amount *big.Int, feeBps, discountBps uint16) *DistributionFees {
+	gross := BasisPointsOf(amount, feeBps)
+	discount := BasisPointsOf(gross, discountBps)
+	fee := new(big.Int).Sub(gross, discount)
+
+	return &DistributionFees{
+		PaymentAmount:              amount.String(),
+		PlatformFeeBasisPoints:     feeBps,
+		StakingDiscountBasisPoints: discountBps,
+		GrossPlatformFee:           gross.String(),
+		StakingDiscount:            discount.String(),
+		PlatformFee:                fee.String(),
+		NetToBeneficiaries:         new(big.Int).Sub(amount, fee).String(),
+	}
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/tunecent/backend/internal/config"
+)
+
+func TestSavingsCalculator(t *testing.T) {
+	tests := []struct {
+		name       string
+		fees       config.FeeConfig
+		royalties  string
+		wantSaved  string
+		wantSource string
+	}{
+		{
+			name:       "default fees save 1%",
+			fees:       config.FeeConfig{PlatformFeeBasisPoints: 1000, StakingDiscountBasisPoints: 1000},
+			royalties:  "1000000000000000000",
+			wantSaved:  "10000000000000000",
+			wantSource: "Staking fee discount (10%)",
+		},
+		{
+			name:       "fractional discount percentages are kept",
+			fees:       config.FeeConfig{PlatformFeeBasisPoints: 500, StakingDiscountBasisPoints: 250},
+			royalties:  "1000000",
+			wantSaved:  "1250",
+			wantSource: "Staking fee discount (2.5%)",
+		},
+		{
+			name:       "fee and discount are each rounded down",
+			fees:       config.FeeConfig{PlatformFeeBasisPoints: 1000, StakingDiscountBasisPoints: 1000},
+			royalties:  "99",
+			wantSaved:  "0",
+			wantSource: "Staking fee discount (10%)",
+		},
+		{
+			name:       "no discount saves nothing",
+			fees:       config.FeeConfig{PlatformFeeBasisPoints: 1000},
+			royalties:  "1000000",
+			wantSaved:  "0",
+			wantSource: "Staking fee discount (0%)",
+		},
+		{
+			name:       "invalid royalties save nothing",
+			fees:       config.FeeConfig{PlatformFeeBasisPoints: 1000, StakingDiscountBasisPoints: 1000},
+			royalties:  "abc",
+			wantSaved:  "0",
+			wantSource: "Staking fee discount (10%)",
+		},
+		{
+			name:       "negative royalties save nothing",
+			fees:       config.FeeConfig{PlatformFeeBasisPoints: 1000, StakingDiscountBasisPoints: 1000},
+			royalties:  "-1000",
+			wantSaved:  "0",
+			wantSource: "Staking fee discount (10%)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			savings := NewSavingsCalculator(tt.fees)
+			if got := savings.OnWei(tt.royalties); got != tt.wantSaved {
+				t.Errorf("OnWei(%s) = %s, want %s", tt.royalties, got, tt.wantSaved)
+			}
+			if got := savings.Source(); got != tt.wantSource {
+				t.Errorf("Source() = %q, want %q", got, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestSavingsMatchDistributionDiscount(t *testing.T) {
+	fees := config.FeeConfig{PlatformFeeBasisPoints: 750, StakingDiscountBasisPoints: 1500}
+	savings := NewSavingsCalculator(fees)
+
+	for _, amount := range []int64{0, 1, 133, 10_000, 987_654_321} {
+		distribution := ComputeDistributionFees(big.NewInt(amount), fees.PlatformFeeBasisPoints, fees.StakingDiscountBasisPoints)
+		if got := savings.On(big.NewInt(amount)).String(); got != distribution.StakingDiscount {
+			t.Errorf("On(%d) = %s, want the distribution's staking discount %s", amount, got, distribution.StakingDiscount)
+		}
+	}
+}
+
+func TestComputeDistributionFees(t *testing.T) {
+	tests := []struct {
+		name        string
+		amount      string
+		feeBps      uint16
+		discountBps uint16
+		wantGross   string
+		wantDisc    string
+		wantFee     string
+		wantNet     string
+	}{
+		{name: "default fees", amount: "1000000", feeBps: 1000, discountBps: 1000, wantGross: "100000", wantDisc: "10000", wantFee: "90000", wantNet: "910000"},
+		{name: "no fee", amount: "1000000", feeBps: 0, discountBps: 1000, wantGross: "0", wantDisc: "0", wantFee: "0", wantNet: "1000000"},
+		{name: "full discount", amount: "1000000", feeBps: 250, discountBps: 10000, wantGross: "25000", wantDisc: "25000", wantFee: "0", wantNet: "1000000"},
+		{name: "rounding favours beneficiaries", amount: "999", feeBps: 1000, discountBps: 1000, wantGross: "99", wantDisc: "9", wantFee: "90", wantNet: "909"},
+		{name: "beyond int64", amount: "100000000000000000000", feeBps: 1000, discountBps: 1000, wantGross: "10000000000000000000", wantDisc: "1000000000000000000", wantFee: "9000000000000000000", wantNet: "91000000000000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, _ := new(big.Int).SetString(tt.amount, 10)
+			got := ComputeDistributionFees(amount, tt.feeBps, tt.discountBps)
+
+			if got.PaymentAmount != tt.amount || got.PlatformFeeBasisPoints != tt.feeBps || got.StakingDiscountBasisPoints != tt.discountBps {
+				t.Errorf("inputs echoed as %s/%d/%d, want %s/%d/%d", got.PaymentAmount, got.PlatformFeeBasisPoints, got.StakingDiscountBasisPoints, tt.amount, tt.feeBps, tt.discountBps)
+			}
+			if got.GrossPlatformFee != tt.wantGross {
+				t.Errorf("GrossPlatformFee = %s, want %s", got.GrossPlatformFee, tt.wantGross)
+			}
+			if got.StakingDiscount != tt.wantDisc {
+				t.Errorf("StakingDiscount = %s, want %s", got.StakingDiscount, tt.wantDisc)
+			}
+			if got.PlatformFee != tt.wantFee {
+				t.Errorf("PlatformFee = %s, want %s", got.PlatformFee, tt.wantFee)
+			}
+			if got.NetToBeneficiaries != tt.wantNet {
+				t.Errorf("NetToBeneficiaries = %s, want %s", got.NetToBeneficiaries, tt.wantNet)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// DilutedShare is how an existing contributor's share changes when someone
+// else contributes
+type DilutedShare struct {
+	ContributorAddress string  `json:"contributor_address"`
+	Amount             string  `json:"amount"` // Wei as string
+	ShareBefore        float64 `json:"share_before"`
+	ShareAfter         float64 `json:"share_after"`
+	Change             float64 `json:"change"` // percentage points
+}
+
+// SharePreview is the stake a contribution would buy in a campaign. Shares
+// are percentages of the sum of contributions, as ContributorShares and the
+// royalty splits compute them, so every contribution dilutes the others.
+type SharePreview struct {
+	Amount          string         `json:"amount"`
+	ExistingAmount  string         `json:"existing_amount"` // the contributor's stake before
+	StakeAfter      string         `json:"stake_after"`
+	RaisedBefore    string         `json:"raised_before"`
+	RaisedAfter     string         `json:"raised_after"`
+	Goal            string         `json:"goal"`
+	ShareBefore     float64        `json:"share_before"`
+	SharePercentage float64        `json:"share_percentage"` // right after contributing
+	ShareAtGoal     float64        `json:"share_at_goal"`    // if the campaign closes at its goal
+	ExceedsGoal     bool           `json:"exceeds_goal"`
+	DilutionFactor  float64        `json:"dilution_factor"` // other shares are multiplied by this
+	Diluted         []DilutedShare `json:"diluted"`
+}
+
+// PreviewContributionShare computes the share contributor would hold after
+// adding amount to a campaign whose contributions so far are given per
+// address, and how each other contributor's share shrinks. The share at
+// goal assumes later contributions fill the campaign exactly to goal; once
+// the campaign is past its goal it equals the share right after.
+func PreviewContributionShare(amount, goal *big.Int, contributions map[string]*big.Int, contributor string) SharePreview {
+	raisedBefore := new(big.Int)
+	existing := new(big.Int)
+	for address, value := range contributions {
+		raisedBefore.Add(raisedBefore, value)
+		if contributor != "" && strings.EqualFold(address, contributor) {
+			existing.Add(existing, value)
+		}
+	}
+	raisedAfter := new(big.Int).Add(raisedBefore, amount)
+	stake := new(big.Int).Add(existing, amount)
+
+	closing := goal
+	if raisedAfter.Cmp(goal) > 0 {
+		closing = raisedAfter
+	}
+
+	preview := SharePreview{
+		Amount:          amount.String(),
+		ExistingAmount:  existing.String(),
+		StakeAfter:      stake.String(),
+		RaisedBefore:    raisedBefore.String(),
+		RaisedAfter:     raisedAfter.String(),
+		Goal:            goal.String(),
+		ShareBefore:     sharePercent(existing, raisedBefore),
+		SharePercentage: sharePercent(stake, raisedAfter),
+		ShareAtGoal:     sharePercent(stake, closing),
+		ExceedsGoal:     raisedAfter.Cmp(goal) > 0,
+		DilutionFactor:  1,
+		Diluted:         []DilutedShare{},
+	}
+	if raisedAfter.Sign() > 0 {
+		ratio, _ := new(big.Rat).SetFrac(raisedBefore, raisedAfter).Float64()
+		preview.DilutionFactor = math.Round(ratio*1e6) / 1e6
+	}
+
+	for address, value := range contributions {
+		if contributor != "" && strings.EqualFold(address, contributor) {
+			continue
+		}
+		before := sharePercent(value, raisedBefore)
+		after := sharePercent(value, raisedAfter)
+		preview.Diluted = append(preview.Diluted, DilutedShare{
+			ContributorAddress: address,
+			Amount:             value.String(),
+			ShareBefore:        before,
+			ShareAfter:         after,
+			Change:             math.Round((after-before)*1e6) / 1e6,
+		})
+	}
+	sort.Slice(preview.Diluted, func(i, j int) bool {
+		if preview.Diluted[i].ShareBefore != preview.Diluted[j].ShareBefore {
+			return preview.Diluted[i].ShareBefore > preview.Diluted[j].ShareBefore
+		}
+		return preview.Diluted[i].ContributorAddress < preview.Diluted[j].ContributorAddress
+	})
+	return preview
+}
+
+// sharePercent returns part as a percentage of total rounded to 6 decimals,
+// or 0 when total is zero
+func sharePercent(part, total *big.Int) float64 {
+	if total.Sign() <= 0 {
+		return 0
+	}
+	ratio, _ := new(big.Rat).SetFrac(new(big.Int).Mul(part, big.NewInt(100)), total).Float64()
+	return math.Round(ratio*1e6) / 1e6
+}
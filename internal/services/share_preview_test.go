@@ -0,0 +1,117 @@
+package services
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestPreviewContributionShare(t *testing.T) {
+	wei := func(s string) *big.Int {
+		value, _ := new(big.Int).SetString(s, 10)
+		return value
+	}
+
+	tests := []struct {
+		name          string
+		contributions map[string]string
+		goal          string
+		amount        string
+		contributor   string
+		wantBefore    float64
+		wantShare     float64
+		wantAtGoal    float64
+		wantExceeds   bool
+		wantDilution  float64
+		wantDiluted   []DilutedShare
+	}{
+		{
+			name:          "new contributor halves the others",
+			contributions: map[string]string{"0xa": "600", "0xb": "400"},
+			goal:          "2000",
+			amount:        "1000",
+			wantShare:     50,
+			wantAtGoal:    50,
+			wantDilution:  0.5,
+			wantDiluted: []DilutedShare{
+				{ContributorAddress: "0xa", Amount: "600", ShareBefore: 60, ShareAfter: 30, Change: -30},
+				{ContributorAddress: "0xb", Amount: "400", ShareBefore: 40, ShareAfter: 20, Change: -20},
+			},
+		},
+		{
+			name:          "existing contributor tops up",
+			contributions: map[string]string{"0xa": "600", "0xb": "400"},
+			goal:          "2000",
+			amount:        "200",
+			contributor:   "0xA",
+			wantBefore:    60,
+			wantShare:     66.666667,
+			wantAtGoal:    40,
+			wantDilution:  0.833333,
+			wantDiluted: []DilutedShare{
+				{ContributorAddress: "0xb", Amount: "400", ShareBefore: 40, ShareAfter: 33.333333, Change: -6.666667},
+			},
+		},
+		{
+			name:          "contribution past the goal",
+			contributions: map[string]string{"0xa": "900"},
+			goal:          "1000",
+			amount:        "300",
+			wantShare:     25,
+			wantAtGoal:    25,
+			wantExceeds:   true,
+			wantDilution:  0.75,
+			wantDiluted: []DilutedShare{
+				{ContributorAddress: "0xa", Amount: "900", ShareBefore: 100, ShareAfter: 75, Change: -25},
+			},
+		},
+		{
+			name:         "first contribution",
+			goal:         "1000",
+			amount:       "250",
+			wantShare:    100,
+			wantAtGoal:   25,
+			wantDilution: 0,
+			wantDiluted:  []DilutedShare{},
+		},
+		{
+			name:          "amounts beyond int64",
+			contributions: map[string]string{"0xa": "3000000000000000000000"},
+			goal:          "10000000000000000000000",
+			amount:        "1000000000000000000000",
+			wantShare:     25,
+			wantAtGoal:    10,
+			wantDilution:  0.75,
+			wantDiluted: []DilutedShare{
+				{ContributorAddress: "0xa", Amount: "3000000000000000000000", ShareBefore: 100, ShareAfter: 75, Change: -25},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contributions := make(map[string]*big.Int, len(tt.contributions))
+			for address, amount := range tt.contributions {
+				contributions[address] = wei(amount)
+			}
+
+			got := PreviewContributionShare(wei(tt.amount), wei(tt.goal), contributions, tt.contributor)
+
+			if got.ShareBefore != tt.wantBefore || got.SharePercentage != tt.wantShare || got.ShareAtGoal != tt.wantAtGoal {
+				t.Errorf("share before %v, after %v, at goal %v, want %v, %v, %v", got.ShareBefore, got.SharePercentage, got.ShareAtGoal, tt.wantBefore, tt.wantShare, tt.wantAtGoal)
+			}
+			if got.ExceedsGoal != tt.wantExceeds || got.DilutionFactor != tt.wantDilution {
+				t.Errorf("exceeds goal %v, dilution %v, want %v, %v", got.ExceedsGoal, got.DilutionFactor, tt.wantExceeds, tt.wantDilution)
+			}
+			if !reflect.DeepEqual(got.Diluted, tt.wantDiluted) {
+				t.Errorf("diluted = %+v, want %+v", got.Diluted, tt.wantDiluted)
+			}
+
+			raisedAfter := new(big.Int).Add(wei(got.RaisedBefore), wei(tt.amount))
+			stake := new(big.Int).Add(wei(got.ExistingAmount), wei(tt.amount))
+			if got.RaisedAfter != raisedAfter.String() || got.StakeAfter != stake.String() {
+				t.Errorf("raised after %s, stake %s, want %s, %s", got.RaisedAfter, got.StakeAfter, raisedAfter, stake)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/tunecent/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// DefaultFairnessTolerance is how many percentage points a beneficiary's
+// received proportion may differ from the expected one before the split is
+// flagged, allowing for the rounding dust ComputeSplits gives the creator
+const DefaultFairnessTolerance = 0.01
+
+// ErrTrackNotFound is returned for fairness checks on an unknown token
+var ErrTrackNotFound = errors.New("music not found")
+
+// BeneficiaryFairness compares what a beneficiary received from one split
+// with what their role and contribution share entitle them to. Percentages
+// are of the split's total amount.
+type BeneficiaryFairness struct {
+	Beneficiary       string  `json:"beneficiary"`
+	Role              string  `json:"role"`               // creator, contributor or unexpected
+	ContributionShare float64 `json:"contribution_share"` // percent of the campaign raised
+	ExpectedAmount    string  `json:"expected_amount"`
+	ActualAmount      string  `json:"actual_amount"`
+	ExpectedPercent   float64 `json:"expected_percent"`
+	ActualPercent     float64 `json:"actual_percent"`
+	Deviation         float64 `json:"deviation"` // actual minus expected, percentage points
+	Fair              bool    `json:"fair"`
+}
+
+// SplitFairness is the fairness check of one split record
+type SplitFairness struct {
+	SplitID       uint                  `json:"split_id"`
+	PaymentID     uint                  `json:"payment_id"`
+	TotalAmount   string                `json:"total_amount"`
+	Fair          bool                  `json:"fair"`
+	Beneficiaries []BeneficiaryFairness `json:"beneficiaries"`
+}
+
+// FairnessReport is the fairness check of a track's split records
+type FairnessReport struct {
+	TokenID       uint64          `json:"token_id"`
+	Tolerance     float64         `json:"tolerance"`
+	TotalSplits   int64           `json:"total_splits"`
+	FlaggedSplits int             `json:"flagged_splits"` // on this page
+	Splits        []SplitFairness `json:"splits"`
+}
+
+// CheckSplitFairness compares the amounts actually distributed from total
+// with the splits ComputeSplits gives for the same total, creator, royalty
+// share and contributions. A beneficiary is unfair when their received
+// proportion deviates by more than tolerance percentage points; someone paid
+// who is not entitled to anything appears with role "unexpected".
+func CheckSplitFairness(total *big.Int, distributions []models.RoyaltyDistribution, creator string, royaltyBps uint16, contributions map[string]*big.Int, tolerance float64) ([]BeneficiaryFairness, bool) {
+	contributions = lowercaseContributions(contributions)
+	contributed := new(big.Int)
+	for _, value := range contributions {
+		contributed.Add(contributed, value)
+	}
+
+	type amounts struct {
+		row              *BeneficiaryFairness
+		expected, actual *big.Int
+	}
+	var order []*amounts
+	byAddress := make(map[string]*amounts)
+	entry := func(address, role string) *amounts {
+		key := strings.ToLower(address)
+		if existing, ok := byAddress[key]; ok {
+			return existing
+		}
+		a := &amounts{
+			row:      &BeneficiaryFairness{Beneficiary: address, Role: role},
+			expected: new(big.Int),
+			actual:   new(big.Int),
+		}
+		byAddress[key] = a
+		order = append(order, a)
+		return a
+	}
+
+	for _, split := range ComputeSplits(total, creator, royaltyBps, contributions) {
+		a := entry(split.Beneficiary, split.Role)
+		if value, ok := new(big.Int).SetString(split.Amount, 10); ok {
+			a.expected.Add(a.expected, value)
+		}
+	}
+	for _, distribution := range distributions {
+		a := entry(distribution.Beneficiary, "unexpected")
+		if value, ok := new(big.Int).SetString(distribution.Amount, 10); ok {
+			a.actual.Add(a.actual, value)
+		}
+	}
+
+	fair := true
+	beneficiaries := make([]BeneficiaryFairness, len(order))
+	for i, a := range order {
+		b := a.row
+		if value, ok := contributions[strings.ToLower(b.Beneficiary)]; ok && contributed.Sign() > 0 {
+			b.ContributionShare = ratioPercent(value, contributed)
+		}
+		b.ExpectedAmount = a.expected.String()
+		b.ActualAmount = a.actual.String()
+		if total.Sign() > 0 {
+			b.ExpectedPercent = ratioPercent(a.expected, total)
+			b.ActualPercent = ratioPercent(a.actual, total)
+		}
+		b.Deviation = math.Round((b.ActualPercent-b.ExpectedPercent)*10000) / 10000
+		b.Fair = math.Abs(b.Deviation) <= tolerance
+		fair = fair && b.Fair
+		beneficiaries[i] = *b
+	}
+	return beneficiaries, fair
+}
+
+// ratioPercent returns part as a percentage of whole to four decimals
+func ratioPercent(part, whole *big.Int) float64 {
+	ratio, _ := new(big.Rat).SetFrac(part, whole).Float64()
+	return math.Round(ratio*1e6) / 1e4
+}
+
+// CheckFairness checks a page of the track's split records, newest first,
+// against the splits its funded campaign's current contributions give. A
+// contribution adjusted after a split was made can flag that split.
+func (s *LedgerService) CheckFairness(ctx context.Context, tokenID uint64, tolerance float64, limit, offset int) (*FairnessReport, error) {
+	db := s.db.WithContext(ctx)
+
+	inputs, err := loadSplitInputs(db, tokenID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTrackNotFound
+		}
+		return nil, err
+	}
+
+	query := db.Model(&models.SplitRecord{}).Where("token_id = ?", tokenID)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count split records: %w", err)
+	}
+	var records []models.SplitRecord
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to load split records: %w", err)
+	}
+
+	distributionsByPayment := make(map[uint][]models.RoyaltyDistribution)
+	if len(records) > 0 {
+		paymentIDs := make([]uint, len(records))
+		for i, record := range records {
+			paymentIDs[i] = record.PaymentID
+		}
+		var distributions []models.RoyaltyDistribution
+		if err := db.Where("payment_id IN ?", paymentIDs).Order("id ASC").Find(&distributions).Error; err != nil {
+			return nil, fmt.Errorf("failed to load distributions: %w", err)
+		}
+		for _, distribution := range distributions {
+			distributionsByPayment[distribution.PaymentID] = append(distributionsByPayment[distribution.PaymentID], distribution)
+		}
+	}
+
+	report := &FairnessReport{
+		TokenID:     tokenID,
+		Tolerance:   tolerance,
+		TotalSplits: total,
+		Splits:      make([]SplitFairness, len(records)),
+	}
+	for i, record := range records {
+		amount, ok := new(big.Int).SetString(record.TotalAmount, 10)
+		if !ok {
+			amount = new(big.Int)
+		}
+		beneficiaries, fair := CheckSplitFairness(amount, distributionsByPayment[record.PaymentID],
+			inputs.creator, inputs.royaltyBps, inputs.contributions, tolerance)
+		report.Splits[i] = SplitFairness{
+			SplitID:       record.ID,
+			PaymentID:     record.PaymentID,
+			TotalAmount:   record.TotalAmount,
+			Fair:          fair,
+			Beneficiaries: beneficiaries,
+		}
+		if !fair {
+			report.FlaggedSplits++
+		}
+	}
+	return report, nil
+}
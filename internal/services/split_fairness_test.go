@@ -0,0 +1,109 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/tunecent/backend/internal/models"
+)
+
+func TestCheckSplitFairness(t *testing.T) {
+	// A 30% investor pool of 10000 wei split 60/40 between 0xa and 0xb
+	// leaves the creator 7000
+	contributions := map[string]*big.Int{"0xA": big.NewInt(600), "0xb": big.NewInt(400)}
+
+	type want struct {
+		role      string
+		share     float64 // contribution share, percent
+		expected  string
+		actual    string
+		deviation float64
+		fair      bool
+	}
+
+	tests := []struct {
+		name     string
+		paid     map[string]string
+		wantFair bool
+		want     map[string]want
+	}{
+		{
+			name:     "fair split",
+			paid:     map[string]string{"0xcreator": "7000", "0xa": "1800", "0xb": "1200"},
+			wantFair: true,
+			want: map[string]want{
+				"0xcreator": {role: "creator", expected: "7000", actual: "7000", fair: true},
+				"0xa":       {role: "contributor", share: 60, expected: "1800", actual: "1800", fair: true},
+				"0xb":       {role: "contributor", share: 40, expected: "1200", actual: "1200", fair: true},
+			},
+		},
+		{
+			name:     "deviation within the tolerance",
+			paid:     map[string]string{"0xcreator": "6999", "0xa": "1801", "0xb": "1200"},
+			wantFair: true,
+			want: map[string]want{
+				"0xcreator": {role: "creator", expected: "7000", actual: "6999", deviation: -0.01, fair: true},
+				"0xa":       {role: "contributor", share: 60, expected: "1800", actual: "1801", deviation: 0.01, fair: true},
+				"0xb":       {role: "contributor", share: 40, expected: "1200", actual: "1200", fair: true},
+			},
+		},
+		{
+			name: "contributor underpaid",
+			paid: map[string]string{"0xcreator": "8000", "0xa": "800", "0xb": "1200"},
+			want: map[string]want{
+				"0xcreator": {role: "creator", expected: "7000", actual: "8000", deviation: 10},
+				"0xa":       {role: "contributor", share: 60, expected: "1800", actual: "800", deviation: -10},
+				"0xb":       {role: "contributor", share: 40, expected: "1200", actual: "1200", fair: true},
+			},
+		},
+		{
+			name: "someone paid who is not entitled",
+			paid: map[string]string{"0xcreator": "7000", "0xa": "1800", "0xb": "1000", "0xstranger": "200"},
+			want: map[string]want{
+				"0xcreator":  {role: "creator", expected: "7000", actual: "7000", fair: true},
+				"0xa":        {role: "contributor", share: 60, expected: "1800", actual: "1800", fair: true},
+				"0xb":        {role: "contributor", share: 40, expected: "1200", actual: "1000", deviation: -2},
+				"0xstranger": {role: "unexpected", expected: "0", actual: "200", deviation: 2},
+			},
+		},
+		{
+			name: "contributor never paid",
+			paid: map[string]string{"0xcreator": "8200", "0xa": "1800"},
+			want: map[string]want{
+				"0xcreator": {role: "creator", expected: "7000", actual: "8200", deviation: 12},
+				"0xa":       {role: "contributor", share: 60, expected: "1800", actual: "1800", fair: true},
+				"0xb":       {role: "contributor", share: 40, expected: "1200", actual: "0", deviation: -12},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var distributions []models.RoyaltyDistribution
+			for beneficiary, amount := range tt.paid {
+				distributions = append(distributions, models.RoyaltyDistribution{Beneficiary: beneficiary, Amount: amount})
+			}
+
+			got, fair := CheckSplitFairness(big.NewInt(10000), distributions, "0xcreator", 3000, contributions, DefaultFairnessTolerance)
+			if fair != tt.wantFair {
+				t.Errorf("fair = %v, want %v", fair, tt.wantFair)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d beneficiaries, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for _, b := range got {
+				w, ok := tt.want[b.Beneficiary]
+				if !ok {
+					t.Errorf("unexpected beneficiary %s", b.Beneficiary)
+					continue
+				}
+				if b.Role != w.role || b.ContributionShare != w.share || b.ExpectedAmount != w.expected || b.ActualAmount != w.actual {
+					t.Errorf("%s = %+v, want role %s, share %v, expected %s, actual %s", b.Beneficiary, b, w.role, w.share, w.expected, w.actual)
+				}
+				if b.Deviation != w.deviation || b.Fair != w.fair {
+					t.Errorf("%s deviates %v (fair %v), want %v (fair %v)", b.Beneficiary, b.Deviation, b.Fair, w.deviation, w.fair)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tunecent/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrStorageQuotaExceeded is returned when an upload would take a creator past the storage quota
+var ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+
+// StorageQuota is a creator's uploaded audio measured against the quota
+type StorageQuota struct {
+	CreatorAddress string `json:"creator_address"`
+	UsedBytes      int64  `json:"used_bytes"`
+	UploadCount    uint   `json:"upload_count"`
+	Unlimited      bool   `json:"unlimited"`
+	QuotaBytes     int64  `json:"quota_bytes,omitempty"`
+	AvailableBytes int64  `json:"available_bytes,omitempty"`
+}
+
+// SetStorageQuota limits the total audio bytes each creator may upload; 0 removes the limit
+func (s *MusicService) SetStorageQuota(bytes int64) {
+	s.storageQuota = bytes
+}
+
+// GetStorageQuota reports how much of the quota a creator has used
+func (s *MusicService) GetStorageQuota(ctx context.Context, creatorAddress string) (*StorageQuota, error) {
+	var usage models.StorageUsage
+	err := s.db.WithContext(ctx).Where("creator_address = ?", creatorAddress).First(&usage).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to load storage usage: %w", err)
+	}
+
+	quota := &StorageQuota{
+		CreatorAddress: creatorAddress,
+		UsedBytes:      usage.UsedBytes,
+		UploadCount:    usage.UploadCount,
+		Unlimited:      s.storageQuota <= 0,
+	}
+	if !quota.Unlimited {
+		quota.QuotaBytes = s.storageQuota
+		if usage.UsedBytes < s.storageQuota {
+			quota.AvailableBytes = s.storageQuota - usage.UsedBytes
+		}
+	}
+	return quota, nil
+}
+
+// reserveStorage adds size bytes to the creator's usage in a single
+// conditional update, so concurrent uploads cannot overshoot the quota
+func (s *MusicService) reserveStorage(ctx context.Context, creatorAddress string, size int64) error {
+	db := s.db.WithContext(ctx)
+
+	// The conditional update needs a row to work on
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.StorageUsage{CreatorAddress: creatorAddress}).Error; err != nil {
+		return fmt.Errorf("failed to initialise storage usage: %w", err)
+	}
+
+	query := db.Model(&models.StorageUsage{}).Where("creator_address = ?", creatorAddress)
+	if s.storageQuota > 0 {
+		query = query.Where("used_bytes + ? <= ?", size, s.storageQuota)
+	}
+	result := query.Updates(map[string]interface{}{
+		"used_bytes":   gorm.Expr("used_bytes + ?", size),
+		"upload_count": gorm.Expr("upload_count + 1"),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update storage usage: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: uploading %d bytes would exceed the %d byte quota", ErrStorageQuotaExceeded, size, s.storageQuota)
+	}
+	return nil
+}
+
+// releaseStorage gives back a reservation whose registration failed
+func (s *MusicService) releaseStorage(ctx context.Context, creatorAddress string, size int64) error {
+	return s.db.WithContext(ctx).Model(&models.StorageUsage{}).
+		Where("creator_address = ? AND upload_count > 0", creatorAddress).
+		Updates(map[string]interface{}{
+			"used_bytes":   gorm.Expr("GREATEST(used_bytes - ?, 0)", size),
+			"upload_count": gorm.Expr("upload_count - 1"),
+		}).Error
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestReserveStorage(t *testing.T) {
+	tests := []struct {
+		name     string
+		quota    int64
+		size     int64
+		affected int64 // rows the conditional update matched
+		wantErr  error
+	}{
+		{name: "within the quota", quota: 1000, size: 400, affected: 1},
+		{name: "exactly at the quota", quota: 1000, size: 1000, affected: 1},
+		{name: "one byte over the quota", quota: 1000, size: 1001, affected: 0, wantErr: ErrStorageQuotaExceeded},
+		{name: "unlimited", size: 1 << 40, affected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectBegin()
+			mock.ExpectExec("INSERT INTO `storage_usages` .* ON DUPLICATE KEY UPDATE").
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectCommit()
+			mock.ExpectBegin()
+			// The quota is enforced by the update itself, so the limit is
+			// passed through for the database to compare against
+			if tt.quota > 0 {
+				mock.ExpectExec("UPDATE `storage_usages` SET `upload_count`=upload_count \\+ 1,`used_bytes`=used_bytes \\+ \\?,`updated_at`=\\? WHERE creator_address = \\? AND used_bytes \\+ \\? <= \\?$").
+					WithArgs(tt.size, sqlmock.AnyArg(), "0xcreator", tt.size, tt.quota).
+					WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			} else {
+				mock.ExpectExec("UPDATE `storage_usages` SET `upload_count`=upload_count \\+ 1,`used_bytes`=used_bytes \\+ \\?,`updated_at`=\\? WHERE creator_address = \\?$").
+					WithArgs(tt.size, sqlmock.AnyArg(), "0xcreator").
+					WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			}
+			mock.ExpectCommit()
+
+			service := NewMusicService(db, nil, nil, nil)
+			service.SetStorageQuota(tt.quota)
+			err := service.reserveStorage(t.Context(), "0xcreator", tt.size)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("reserveStorage() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("reserveStorage() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetStorageQuota(t *testing.T) {
+	tests := []struct {
+		name          string
+		quota         int64
+		used          []int64 // no row when empty
+		wantUsed      int64
+		wantAvailable int64
+		wantUnlimited bool
+	}{
+		{name: "nothing uploaded yet", quota: 1000, wantAvailable: 1000},
+		{name: "partly used", quota: 1000, used: []int64{999}, wantUsed: 999, wantAvailable: 1},
+		{name: "exactly used up", quota: 1000, used: []int64{1000}, wantUsed: 1000},
+		{name: "over a lowered quota", quota: 1000, used: []int64{1500}, wantUsed: 1500},
+		{name: "unlimited", used: []int64{1500}, wantUsed: 1500, wantUnlimited: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			rows := sqlmock.NewRows([]string{"id", "creator_address", "used_bytes", "upload_count"})
+			for _, used := range tt.used {
+				rows.AddRow(1, "0xcreator", used, 3)
+			}
+			mock.ExpectQuery("SELECT \\* FROM `storage_usages` WHERE creator_address = \\?").
+				WithArgs("0xcreator").
+				WillReturnRows(rows)
+
+			service := NewMusicService(db, nil, nil, nil)
+			service.SetStorageQuota(tt.quota)
+			got, err := service.GetStorageQuota(t.Context(), "0xcreator")
+			if err != nil {
+				t.Fatalf("GetStorageQuota() error = %v", err)
+			}
+
+			if got.UsedBytes != tt.wantUsed || got.AvailableBytes != tt.wantAvailable || got.Unlimited != tt.wantUnlimited {
+				t.Errorf("used %d, available %d, unlimited %v, want %d, %d, %v", got.UsedBytes, got.AvailableBytes, got.Unlimited, tt.wantUsed, tt.wantAvailable, tt.wantUnlimited)
+			}
+			if !tt.wantUnlimited && got.QuotaBytes != tt.quota {
+				t.Errorf("quota = %d, want %d", got.QuotaBytes, tt.quota)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/tunecent/backend/internal/models"
+)
+
+// GoalCrossedAt returns when a campaign's contributions first added up to
+// goal, walking them in contribution order. ok is false when they never
+// reach it, e.g. because some contributions were not indexed.
+func GoalCrossedAt(goal *big.Int, contributions []models.Contribution) (crossed time.Time, ok bool) {
+	sorted := append([]models.Contribution(nil), contributions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ContributedAt.Before(sorted[j].ContributedAt)
+	})
+
+	raised := new(big.Int)
+	for _, contribution := range sorted {
+		amount, valid := new(big.Int).SetString(contribution.Amount, 10)
+		if !valid {
+			continue
+		}
+		raised.Add(raised, amount)
+		if raised.Cmp(goal) >= 0 {
+			return contribution.ContributedAt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// TimeToFund is how long a successful campaign took to reach its goal
+type TimeToFund struct {
+	CampaignID uint64    `json:"campaign_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	FundedAt   time.Time `json:"funded_at"`
+	Seconds    int64     `json:"seconds"`
+}
+
+// ComputeTimeToFund measures each campaign from creation to the
+// contribution that crossed its goal. Campaigns whose contributions never
+// reach the goal are returned by ID in unresolved.
+func ComputeTimeToFund(campaigns []models.Campaign, contributions map[uint64][]models.Contribution) (funded []TimeToFund, unresolved []uint64) {
+	for _, campaign := range campaigns {
+		goal, ok := new(big.Int).SetString(campaign.GoalAmount, 10)
+		if !ok {
+			unresolved = append(unresolved, campaign.CampaignID)
+			continue
+		}
+		crossed, ok := GoalCrossedAt(goal, contributions[campaign.CampaignID])
+		if !ok {
+			unresolved = append(unresolved, campaign.CampaignID)
+			continue
+		}
+		// Clock skew between the chain and the API can put the crossing
+		// slightly before creation
+		elapsed := max(crossed.Sub(campaign.CreatedAt), 0)
+		funded = append(funded, TimeToFund{
+			CampaignID: campaign.CampaignID,
+			CreatedAt:  campaign.CreatedAt,
+			FundedAt:   crossed,
+			Seconds:    int64(elapsed / time.Second),
+		})
+	}
+	return funded, unresolved
+}
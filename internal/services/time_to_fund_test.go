@@ -0,0 +1,107 @@
+package services
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tunecent/backend/internal/models"
+)
+
+func TestGoalCrossedAt(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return start.Add(time.Duration(hours) * time.Hour) }
+	contribution := func(amount string, hours int) models.Contribution {
+		return models.Contribution{Amount: amount, ContributedAt: at(hours)}
+	}
+
+	tests := []struct {
+		name          string
+		goal          int64
+		contributions []models.Contribution
+		want          time.Time
+		wantOK        bool
+	}{
+		{
+			name:          "crossed by a later contribution",
+			goal:          1000,
+			contributions: []models.Contribution{contribution("400", 1), contribution("700", 5), contribution("100", 9)},
+			want:          at(5),
+			wantOK:        true,
+		},
+		{
+			name:          "exactly reaching the goal counts",
+			goal:          1000,
+			contributions: []models.Contribution{contribution("500", 2), contribution("500", 3)},
+			want:          at(3),
+			wantOK:        true,
+		},
+		{
+			name:          "walked in contribution order",
+			goal:          1000,
+			contributions: []models.Contribution{contribution("900", 8), contribution("200", 2), contribution("850", 4)},
+			want:          at(4),
+			wantOK:        true,
+		},
+		{
+			name:          "malformed amounts are skipped",
+			goal:          1000,
+			contributions: []models.Contribution{contribution("600", 1), contribution("lots", 2), contribution("400", 6)},
+			want:          at(6),
+			wantOK:        true,
+		},
+		{
+			name:          "never reached",
+			goal:          1000,
+			contributions: []models.Contribution{contribution("300", 1), contribution("300", 2)},
+		},
+		{name: "no contributions", goal: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GoalCrossedAt(big.NewInt(tt.goal), tt.contributions)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("GoalCrossedAt() = %s, %v, want %s, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestComputeTimeToFund(t *testing.T) {
+	created := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	campaign := func(id uint64, goal string) models.Campaign {
+		return models.Campaign{CampaignID: id, GoalAmount: goal, CreatedAt: created}
+	}
+	contribution := func(amount string, after time.Duration) models.Contribution {
+		return models.Contribution{Amount: amount, ContributedAt: created.Add(after)}
+	}
+
+	campaigns := []models.Campaign{campaign(1, "1000"), campaign(2, "500"), campaign(3, "1000"), campaign(4, "not-a-number"), campaign(5, "100")}
+	contributions := map[uint64][]models.Contribution{
+		// Fills in two days
+		1: {contribution("600", time.Hour), contribution("400", 48*time.Hour)},
+		// Fills with its first contribution, half an hour in
+		2: {contribution("500", 30*time.Minute)},
+		// Never fills
+		3: {contribution("999", time.Hour)},
+		4: {contribution("1000", time.Hour)},
+		// Crossed before creation by the chain's clock
+		5: {contribution("100", -time.Minute)},
+	}
+
+	funded, unresolved := ComputeTimeToFund(campaigns, contributions)
+
+	want := []TimeToFund{
+		{CampaignID: 1, CreatedAt: created, FundedAt: created.Add(48 * time.Hour), Seconds: 48 * 3600},
+		{CampaignID: 2, CreatedAt: created, FundedAt: created.Add(30 * time.Minute), Seconds: 1800},
+		{CampaignID: 5, CreatedAt: created, FundedAt: created.Add(-time.Minute), Seconds: 0},
+	}
+	if !reflect.DeepEqual(funded, want) {
+		t.Errorf("funded = %+v, want %+v", funded, want)
+	}
+	if wantUnresolved := []uint64{3, 4}; !reflect.DeepEqual(unresolved, wantUnresolved) {
+		t.Errorf("unresolved = %v, want %v", unresolved, wantUnresolved)
+	}
+}
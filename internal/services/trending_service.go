@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/tunecent/backend/internal/database"
+	"github.com/tunecent/backend/internal/models"
+)
+
+// Trending scoring: a campaign trends when its weighted score of funding
+// velocity and contributor growth reaches TrendingThreshold
+const (
+	TrendingScoreInterval = 15 * time.Minute
+	TrendingThreshold     = 0.5
+
+	trendingVelocityWeight = 0.6
+	trendingGrowthWeight   = 0.4
+	// Funding velocity (percent of goal per day) that earns the full velocity score
+	trendingMaxVelocity = 10.0
+	// Window for comparing recent contributors against the window before it
+	trendingGrowthWindow = 7 * 24 * time.Hour
+)
+
+type TrendingService struct {
+	db *database.DB
+}
+
+func NewTrendingService(db *database.DB) *TrendingService {
+	return &TrendingService{db: db}
+}
+
+// TrendingScore combines funding velocity (percent of goal raised per day)
+// and contributor growth (relative change between windows) into a 0-1 score
+func TrendingScore(fundingVelocity, contributorGrowth float64) float64 {
+	velocity := math.Min(math.Max(fundingVelocity, 0), trendingMaxVelocity) / trendingMaxVelocity
+	growth := math.Min(math.Max(contributorGrowth, 0), 1)
+	return trendingVelocityWeight*velocity + trendingGrowthWeight*growth
+}
+
+// EffectiveTrending returns the admin override when set, otherwise the automatic flag
+func EffectiveTrending(override *bool, automatic bool) bool {
+	if override != nil {
+		return *override
+	}
+	return automatic
+}
+
+// ContributorGrowth is the relative change from prior to recent contributor
+// counts; with no prior contributors any recent activity counts as full growth
+func ContributorGrowth(recent, prior int64) float64 {
+	if prior == 0 {
+		if recent > 0 {
+			return 1
+		}
+		return 0
+	}
+	return float64(recent-prior) / float64(prior)
+}
+
+// Run rescores campaigns every interval until ctx is cancelled
+func (s *TrendingService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if updated, err := s.ScoreCampaigns(ctx); err != nil {
+			slog.ErrorContext(ctx, "Trending scoring failed", "error", err)
+		} else {
+			slog.DebugContext(ctx, "Trending scoring completed", "campaigns", updated)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ScoreCampaigns recomputes is_trending for all active campaigns and returns
+// how many were scored. Campaigns with a trending_override keep that value.
+func (s *TrendingService) ScoreCampaigns(ctx context.Context) (int, error) {
+	db := s.db.WithContext(ctx)
+
+	var campaigns []models.Campaign
+	if err := db.Where("status = ?", "active").Find(&campaigns).Error; err != nil {
+		return 0, fmt.Errorf("failed to load active campaigns: %w", err)
+	}
+	if len(campaigns) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+	recentSince := now.Add(-trendingGrowthWindow)
+	priorSince := recentSince.Add(-trendingGrowthWindow)
+
+	type growthRow struct {
+		CampaignID uint64
+		Recent     int64
+		Prior      int64
+	}
+	var rows []growthRow
+	if err := db.Model(&models.Contribution{}).
+		Select(`campaign_id,
+			COUNT(DISTINCT CASE WHEN contributed_at >= ? THEN contributor_address END) as recent,
+			COUNT(DISTINCT CASE WHEN contributed_at >= ? AND contributed_at < ? THEN contributor_address END) as prior`,
+			recentSince, priorSince, recentSince).
+		Where("contributed_at >= ?", priorSince).
+		Group("campaign_id").
+		Scan(&rows).Error; err != nil {
+		return 0, fmt.Errorf("failed to load contributor growth: %w", err)
+	}
+
+	growth := make(map[uint64]float64, len(rows))
+	for _, row := range rows {
+		growth[row.CampaignID] = ContributorGrowth(row.Recent, row.Prior)
+	}
+
+	for _, campaign := range campaigns {
+		days := math.Max(now.Sub(campaign.CreatedAt).Hours()/24, 1)
+		velocity := FundingPercentage(campaign.RaisedAmount, campaign.GoalAmount) / days
+
+		automatic := TrendingScore(velocity, growth[campaign.CampaignID]) >= TrendingThreshold
+		trending := EffectiveTrending(campaign.TrendingOverride, automatic)
+		if trending == campaign.IsTrending {
+			continue
+		}
+
+		if err := db.Model(&models.Campaign{}).
+			Where("id = ?", campaign.ID).
+			Update("is_trending", trending).Error; err != nil {
+			return 0, fmt.Errorf("failed to update campaign %d: %w", campaign.CampaignID, err)
+		}
+	}
+
+	return len(campaigns), nil
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestTrendingScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		velocity float64
+		growth   float64
+		want     float64
+	}{
+		{name: "no activity", velocity: 0, growth: 0, want: 0},
+		{name: "full velocity only", velocity: trendingMaxVelocity, growth: 0, want: 0.6},
+		{name: "full growth only", velocity: 0, growth: 1, want: 0.4},
+		{name: "velocity is capped", velocity: 50, growth: 1, want: 1},
+		{name: "growth is capped", velocity: 5, growth: 3, want: 0.7},
+		{name: "negative inputs count as zero", velocity: -5, growth: -0.5, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TrendingScore(tt.velocity, tt.growth); got != tt.want {
+				t.Errorf("TrendingScore(%v, %v) = %v, want %v", tt.velocity, tt.growth, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffectiveTrending(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name      string
+		override  *bool
+		automatic bool
+		want      bool
+	}{
+		{name: "no override follows scoring", override: nil, automatic: true, want: true},
+		{name: "no override, not trending", override: nil, automatic: false, want: false},
+		{name: "override on beats scoring", override: &yes, automatic: false, want: true},
+		{name: "override off beats scoring", override: &no, automatic: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EffectiveTrending(tt.override, tt.automatic); got != tt.want {
+				t.Errorf("EffectiveTrending() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContributorGrowth(t *testing.T) {
+	tests := []struct {
+		recent, prior int64
+		want          float64
+	}{
+		{recent: 0, prior: 0, want: 0},
+		{recent: 3, prior: 0, want: 1},
+		{recent: 6, prior: 3, want: 1},
+		{recent: 4, prior: 2, want: 1},
+		{recent: 3, prior: 2, want: 0.5},
+		{recent: 1, prior: 4, want: -0.75},
+	}
+
+	for _, tt := range tests {
+		if got := ContributorGrowth(tt.recent, tt.prior); got != tt.want {
+			t.Errorf("ContributorGrowth(%d, %d) = %v, want %v", tt.recent, tt.prior, got, tt.want)
+		}
+	}
+}
+
+func TestScoreCampaignsRespectsOverride(t *testing.T) {
+	db, mock := dbtest.New(t)
+	created := time.Now().UTC().Add(-time.Hour)
+
+	mock.ExpectQuery("SELECT \\* FROM `campaigns` WHERE status = \\?").
+		WithArgs("active").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "goal_amount", "raised_amount", "is_trending", "trending_override", "created_at"}).
+			AddRow(1, 11, "1000", "1000", false, nil, created).   // scores as trending
+			AddRow(2, 12, "1000", "1000", false, false, created). // scores as trending, pinned off
+			AddRow(3, 13, "1000", "0", false, true, created).     // scores as not trending, pinned on
+			AddRow(4, 14, "1000", "0", true, nil, created).       // no longer trending
+			AddRow(5, 15, "1000", "0", false, nil, created))      // unchanged
+	mock.ExpectQuery("FROM `contributions` WHERE contributed_at >= \\?").
+		WillReturnRows(sqlmock.NewRows([]string{"campaign_id", "recent", "prior"}))
+
+	for _, update := range []struct {
+		id       int
+		trending bool
+	}{{1, true}, {3, true}, {4, false}} {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE `campaigns` SET `is_trending`=\\?,`updated_at`=\\? WHERE id = \\?").
+			WithArgs(update.trending, sqlmock.AnyArg(), update.id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+
+	scored, err := NewTrendingService(db).ScoreCampaigns(t.Context())
+	if err != nil {
+		t.Fatalf("ScoreCampaigns() error = %v", err)
+	}
+	if scored != 5 {
+		t.Errorf("ScoreCampaigns() = %d, want 5", scored)
+	}
+}
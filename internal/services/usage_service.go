@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/tunecent/backend/internal/database"
+	"github.com/tunecent/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxUsageDetectionBatch caps how many detections one ingest request may carry
+const MaxUsageDetectionBatch = 500
+
+// usageDetectorAddress is the payer recorded on royalty payments created from detections
+const usageDetectorAddress = "0xUsageDetector"
+
+type UsageService struct {
+	db *database.DB
+
+	onIngested []func()
+}
+
+func NewUsageService(db *database.DB) *UsageService {
+	return &UsageService{db: db}
+}
+
+// OnIngested adds a callback run after each successful ingestion batch, e.g.
+// to invalidate caches that sum royalty payments
+func (s *UsageService) OnIngested(fn func()) {
+	s.onIngested = append(s.onIngested, fn)
+}
+
+// DetectionInput is one usage reported by a scanning service
+type DetectionInput struct {
+	TokenID    uint64     `json:"token_id" binding:"required"`
+	Platform   string     `json:"platform" binding:"required"`
+	ContentID  string     `json:"content_id" binding:"required"`
+	ContentURL string     `json:"content_url"`
+	DetectedAt *time.Time `json:"detected_at"` // defaults to the time of ingestion
+}
+
+// IngestDetectionsRequest is a batch of detections. With CreatePayments set,
+// every newly recorded detection gets a royalty payment of PaymentAmount wei.
+type IngestDetectionsRequest struct {
+	Detections     []DetectionInput `json:"detections" binding:"required,min=1,dive"`
+	CreatePayments bool             `json:"create_payments"`
+	PaymentAmount  string           `json:"payment_amount"`
+}
+
+// RejectedDetection is a batch item that was not recorded
+type RejectedDetection struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+type IngestDetectionsResult struct {
+	Received        int                     `json:"received"`
+	Created         int                     `json:"created"`
+	Duplicates      int                     `json:"duplicates"`
+	PaymentsCreated int                     `json:"payments_created"`
+	Rejected        []RejectedDetection     `json:"rejected"`
+	Detections      []models.UsageDetection `json:"detections"`
+}
+
+// IngestDetections records a batch of detections in one transaction.
+// Detections are unique per platform and content ID: items already recorded,
+// or repeated within the batch, count as duplicates and never create a second
+// payment. Items for unknown tracks are rejected without failing the batch.
+func (s *UsageService) IngestDetections(ctx context.Context, req *IngestDetectionsRequest) (*IngestDetectionsResult, error) {
+	if len(req.Detections) > MaxUsageDetectionBatch {
+		return nil, fmt.Errorf("at most %d detections per batch", MaxUsageDetectionBatch)
+	}
+	if req.CreatePayments {
+		amount, ok := new(big.Int).SetString(req.PaymentAmount, 10)
+		if !ok || amount.Sign() <= 0 {
+			return nil, fmt.Errorf("payment_amount: %w", ErrInvalidAmount)
+		}
+	}
+
+	tokenIDs := make([]uint64, 0, len(req.Detections))
+	for _, detection := range req.Detections {
+		tokenIDs = append(tokenIDs, detection.TokenID)
+	}
+
+	result := &IngestDetectionsResult{
+		Received:   len(req.Detections),
+		Rejected:   []RejectedDetection{},
+		Detections: []models.UsageDetection{},
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var known []uint64
+		if err := tx.Model(&models.MusicMetadata{}).Where("token_id IN ?", tokenIDs).Pluck("token_id", &known).Error; err != nil {
+			return fmt.Errorf("failed to load tracks: %w", err)
+		}
+		isKnown := make(map[uint64]bool, len(known))
+		for _, tokenID := range known {
+			isKnown[tokenID] = true
+		}
+
+		now := time.Now()
+		seen := make(map[string]bool, len(req.Detections))
+		for i, input := range req.Detections {
+			platform := strings.ToLower(strings.TrimSpace(input.Platform))
+			contentID := strings.TrimSpace(input.ContentID)
+			if platform == "" || contentID == "" {
+				result.Rejected = append(result.Rejected, RejectedDetection{Index: i, Error: "platform and content_id are required"})
+				continue
+			}
+			if !isKnown[input.TokenID] {
+				result.Rejected = append(result.Rejected, RejectedDetection{Index: i, Error: fmt.Sprintf("unknown token_id %d", input.TokenID)})
+				continue
+			}
+
+			key := platform + "\x00" + contentID
+			if seen[key] {
+				result.Duplicates++
+				continue
+			}
+			seen[key] = true
+
+			detection := models.UsageDetection{
+				TokenID:    input.TokenID,
+				Platform:   platform,
+				ContentID:  contentID,
+				ContentURL: input.ContentURL,
+				DetectedAt: now,
+			}
+			if input.DetectedAt != nil {
+				detection.DetectedAt = *input.DetectedAt
+			}
+
+			// The unique (platform, content_id) index makes re-ingestion a no-op
+			created := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&detection)
+			if created.Error != nil {
+				return fmt.Errorf("failed to record detection %d: %w", i, created.Error)
+			}
+			if created.RowsAffected == 0 {
+				result.Duplicates++
+				continue
+			}
+			result.Created++
+
+			if req.CreatePayments {
+				txHash := fmt.Sprintf("0x%064x", now.UnixNano()+int64(detection.ID)) // Mock tx hash
+				payment := &models.RoyaltyPayment{
+					TokenID:   detection.TokenID,
+					From:      usageDetectorAddress,
+					Amount:    req.PaymentAmount,
+					Platform:  platform,
+					UsageType: "detection",
+					TxHash:    txHash,
+					PaidAt:    now,
+				}
+				if err := tx.Create(payment).Error; err != nil {
+					return fmt.Errorf("failed to create payment for detection %d: %w", i, err)
+				}
+				if err := tx.Model(&detection).Updates(map[string]interface{}{
+					"payment_sent":    true,
+					"payment_tx_hash": txHash,
+				}).Error; err != nil {
+					return fmt.Errorf("failed to mark detection %d paid: %w", i, err)
+				}
+				detection.PaymentSent = true
+				detection.PaymentTxHash = txHash
+				result.PaymentsCreated++
+			}
+
+			result.Detections = append(result.Detections, detection)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fn := range s.onIngested {
+		fn()
+	}
+
+	return result, nil
+}
+
+// UsageSummary counts all of a track's detections, regardless of filters
+type UsageSummary struct {
+	TotalDetections int64 `json:"total_detections"`
+	PaidDetections  int64 `json:"paid_detections"`
+}
+
+// ListDetections returns a track's detections, newest first, optionally
+// narrowed to one platform and/or payment status
+func (s *UsageService) ListDetections(ctx context.Context, tokenID uint64, platform string, paymentSent *bool, limit, offset int) ([]models.UsageDetection, int64, *UsageSummary, error) {
+	db := s.db.WithContext(ctx)
+
+	var music models.MusicMetadata
+	if err := db.Select("id").Where("token_id = ?", tokenID).First(&music).Error; err != nil {
+		return nil, 0, nil, fmt.Errorf("music not found: %w", err)
+	}
+
+	var summary UsageSummary
+	if err := db.Model(&models.UsageDetection{}).
+		Select("COUNT(*) as total_detections, COALESCE(SUM(CASE WHEN payment_sent THEN 1 ELSE 0 END), 0) as paid_detections").
+		Where("token_id = ?", tokenID).
+		Scan(&summary).Error; err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to summarize detections: %w", err)
+	}
+
+	query := db.Model(&models.UsageDetection{}).Where("token_id = ?", tokenID)
+	if platform != "" {
+		query = query.Where("platform = ?", strings.ToLower(platform))
+	}
+	if paymentSent != nil {
+		query = query.Where("payment_sent = ?", *paymentSent)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to count detections: %w", err)
+	}
+
+	detections := []models.UsageDetection{}
+	if err := query.Order("detected_at DESC, id DESC").Limit(limit).Offset(offset).Find(&detections).Error; err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to list detections: %w", err)
+	}
+
+	return detections, total, &summary, nil
+}
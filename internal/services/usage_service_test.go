@@ -0,0 +1,108 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/tunecent/backend/internal/database/dbtest"
+)
+
+func TestIngestDetections(t *testing.T) {
+	// tiktok/v1 is new, repeated within the batch under another spelling,
+	// youtube/y1 was recorded by an earlier batch and token 99 is unknown
+	detections := []DetectionInput{
+		{TokenID: 7, Platform: "tiktok", ContentID: "v1", ContentURL: "https://tiktok.test/v1"},
+		{TokenID: 7, Platform: " TikTok ", ContentID: "v1 "},
+		{TokenID: 7, Platform: "youtube", ContentID: "y1"},
+		{TokenID: 99, Platform: "tiktok", ContentID: "v2"},
+		{TokenID: 7, Platform: "tiktok", ContentID: "  "},
+	}
+
+	tests := []struct {
+		name           string
+		createPayments bool
+		wantPayments   int
+	}{
+		{name: "detections only", createPayments: false},
+		{name: "payments for new detections", createPayments: true, wantPayments: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := dbtest.New(t)
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT `token_id` FROM `music_metadata` WHERE token_id IN \\(\\?,\\?,\\?,\\?,\\?\\)").
+				WithArgs(7, 7, 7, 99, 7).
+				WillReturnRows(sqlmock.NewRows([]string{"token_id"}).AddRow(7))
+			mock.ExpectExec("INSERT INTO `usage_detections` .* ON DUPLICATE KEY UPDATE").
+				WithArgs(7, "tiktok", "v1", "https://tiktok.test/v1", sqlmock.AnyArg(), false, "", sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			if tt.createPayments {
+				mock.ExpectExec("INSERT INTO `royalty_payments`").
+					WithArgs(7, usageDetectorAddress, "25", "tiktok", "detection", sqlmock.AnyArg(), false, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE `usage_detections` SET `payment_sent`=\\?,`payment_tx_hash`=\\? WHERE `id` = \\?").
+					WithArgs(true, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+			// The unique index turns the already-recorded detection into a no-op
+			mock.ExpectExec("INSERT INTO `usage_detections` .* ON DUPLICATE KEY UPDATE").
+				WithArgs(7, "youtube", "y1", "", sqlmock.AnyArg(), false, "", sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectCommit()
+
+			service := NewUsageService(db)
+			ingested := false
+			service.OnIngested(func() { ingested = true })
+
+			got, err := service.IngestDetections(t.Context(), &IngestDetectionsRequest{
+				Detections:     detections,
+				CreatePayments: tt.createPayments,
+				PaymentAmount:  "25",
+			})
+			if err != nil {
+				t.Fatalf("IngestDetections() error = %v", err)
+			}
+
+			if got.Received != 5 || got.Created != 1 || got.Duplicates != 2 || got.PaymentsCreated != tt.wantPayments {
+				t.Errorf("received %d, created %d, duplicates %d, payments %d, want 5, 1, 2, %d", got.Received, got.Created, got.Duplicates, got.PaymentsCreated, tt.wantPayments)
+			}
+			if len(got.Rejected) != 2 || got.Rejected[0].Index != 3 || got.Rejected[1].Index != 4 {
+				t.Errorf("rejected = %+v, want items 3 and 4", got.Rejected)
+			}
+			if len(got.Detections) != 1 || got.Detections[0].PaymentSent != tt.createPayments || (got.Detections[0].PaymentTxHash != "") != tt.createPayments {
+				t.Errorf("detections = %+v, want the new tiktok detection, paid = %v", got.Detections, tt.createPayments)
+			}
+			if !ingested {
+				t.Error("OnIngested hook did not run")
+			}
+		})
+	}
+}
+
+func TestIngestDetectionsRejectsPaymentAmount(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount string
+	}{
+		{name: "missing amount", amount: ""},
+		{name: "zero amount", amount: "0"},
+		{name: "negative amount", amount: "-5"},
+		{name: "non-numeric amount", amount: "1e18"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := dbtest.New(t)
+			_, err := NewUsageService(db).IngestDetections(t.Context(), &IngestDetectionsRequest{
+				Detections:     []DetectionInput{{TokenID: 7, Platform: "tiktok", ContentID: "v1"}},
+				CreatePayments: true,
+				PaymentAmount:  tt.amount,
+			})
+			if !errors.Is(err, ErrInvalidAmount) {
+				t.Errorf("IngestDetections() error = %v, want %v", err, ErrInvalidAmount)
+			}
+		})
+	}
+}
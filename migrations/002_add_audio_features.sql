@@ -0,0 +1,9 @@
+-- =====================================================
+-- Acoustic features extracted at music registration
+-- =====================================================
+
+ALTER TABLE music_metadata
+ADD COLUMN IF NOT EXISTS tempo DECIMAL(6,2) DEFAULT 0.00 COMMENT 'Tempo in BPM',
+ADD COLUMN IF NOT EXISTS musical_key VARCHAR(20) DEFAULT NULL COMMENT 'Musical key, e.g. C major',
+ADD COLUMN IF NOT EXISTS loudness DECIMAL(6,2) DEFAULT 0.00 COMMENT 'Loudness in dB',
+ADD COLUMN IF NOT EXISTS sample_rate INT UNSIGNED DEFAULT 0 COMMENT 'Sample rate in Hz';
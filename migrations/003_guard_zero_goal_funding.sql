@@ -0,0 +1,18 @@
+-- =====================================================
+-- Guard funding percentage against zero-goal campaigns
+-- =====================================================
+
+-- View: Active Trending Pools (NULLIF avoids division by zero)
+CREATE OR REPLACE VIEW vw_trending_pools AS
+SELECT
+    c.*,
+    m.title as music_title,
+    m.artist as music_artist,
+    u.display_name as creator_name,
+    u.is_verified as creator_verified,
+    COALESCE(CAST(c.raised_amount AS DECIMAL(30,0)) / NULLIF(CAST(c.goal_amount AS DECIMAL(30,0)), 0) * 100, 0) as funding_percentage
+FROM campaigns c
+JOIN music_metadata m ON c.token_id = m.token_id
+JOIN users u ON c.creator_address = u.wallet_address
+WHERE c.status = 'active' AND c.is_trending = TRUE
+ORDER BY funding_percentage DESC, c.created_at DESC;
@@ -0,0 +1,7 @@
+-- =====================================================
+-- Manual trending override for campaigns
+-- =====================================================
+
+-- NULL = trending flag is set by the scoring job; TRUE/FALSE = pinned by an admin
+ALTER TABLE campaigns
+ADD COLUMN IF NOT EXISTS trending_override BOOLEAN DEFAULT NULL COMMENT 'Admin override for is_trending (NULL = automatic)';
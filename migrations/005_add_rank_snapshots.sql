@@ -0,0 +1,13 @@
+-- =====================================================
+-- Periodic leaderboard rank snapshots for rank movement
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS rank_snapshots (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    wallet_address VARCHAR(42) NOT NULL,
+    `rank` INT NOT NULL,
+    score DECIMAL(20,2) DEFAULT 0.00,
+    snapshot_at DATETIME(3) NOT NULL,
+    created_at DATETIME(3) NULL,
+    INDEX idx_rank_snapshots_address_time (wallet_address, snapshot_at)
+);
@@ -0,0 +1,25 @@
+-- =====================================================
+-- Transactional outbox for notifications and webhooks
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    event_key VARCHAR(191) NOT NULL,
+    channel VARCHAR(32) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload TEXT NOT NULL,
+    status ENUM('pending','done','failed','skipped') DEFAULT 'pending',
+    attempts INT DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at DATETIME(3) NOT NULL,
+    processed_at DATETIME(3) NULL,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    UNIQUE INDEX idx_outbox_events_event_key (event_key),
+    INDEX idx_outbox_events_status_next (status, next_attempt_at)
+);
+
+-- Notifications delivered from the outbox remember their event so redelivery is a no-op
+ALTER TABLE notifications
+ADD COLUMN IF NOT EXISTS outbox_event_id BIGINT UNSIGNED NULL,
+ADD UNIQUE INDEX idx_notifications_outbox_event_id (outbox_event_id);
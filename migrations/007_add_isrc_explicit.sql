@@ -0,0 +1,7 @@
+-- =====================================================
+-- Release metadata required by distribution platforms
+-- =====================================================
+
+ALTER TABLE music_metadata
+ADD COLUMN IF NOT EXISTS isrc VARCHAR(12) NULL COMMENT 'International Standard Recording Code',
+ADD COLUMN IF NOT EXISTS explicit BOOLEAN DEFAULT NULL COMMENT 'Explicit content flag (NULL = not declared)';
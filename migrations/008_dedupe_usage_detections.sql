@@ -0,0 +1,16 @@
+-- =====================================================
+-- One usage detection per platform content item
+-- =====================================================
+
+-- Drop duplicates left by earlier re-ingestion, keeping the first detection of each item
+DELETE newer
+FROM usage_detections newer
+JOIN usage_detections older
+    ON older.platform = newer.platform
+    AND older.content_id = newer.content_id
+    AND older.id < newer.id;
+
+ALTER TABLE usage_detections
+MODIFY COLUMN platform VARCHAR(64) NOT NULL,
+MODIFY COLUMN content_id VARCHAR(191) NULL,
+ADD UNIQUE INDEX idx_usage_platform_content (platform, content_id);
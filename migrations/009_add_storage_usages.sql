@@ -0,0 +1,13 @@
+-- =====================================================
+-- Per-creator uploaded audio bytes for the storage quota
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS storage_usages (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    creator_address VARCHAR(191) NOT NULL,
+    used_bytes BIGINT NOT NULL DEFAULT 0,
+    upload_count INT UNSIGNED NOT NULL DEFAULT 0,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    UNIQUE INDEX idx_storage_usages_creator_address (creator_address)
+);
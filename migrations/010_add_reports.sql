@@ -0,0 +1,22 @@
+-- =====================================================
+-- User reports of inappropriate tracks and campaigns
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS reports (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    reporter_address VARCHAR(191) NOT NULL,
+    token_id BIGINT UNSIGNED NULL,
+    campaign_id BIGINT UNSIGNED NULL,
+    reason VARCHAR(32) NOT NULL,
+    details TEXT NULL,
+    status ENUM('open','dismissed','actioned') DEFAULT 'open',
+    resolved_by VARCHAR(191) NULL,
+    resolution_note TEXT NULL,
+    resolved_at DATETIME(3) NULL,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    INDEX idx_reports_reporter_created (reporter_address, created_at),
+    INDEX idx_reports_token_id (token_id),
+    INDEX idx_reports_campaign_id (campaign_id),
+    INDEX idx_reports_status (status)
+);
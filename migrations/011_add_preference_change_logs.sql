@@ -0,0 +1,13 @@
+-- =====================================================
+-- History of notification preference changes
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS preference_change_logs (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_address VARCHAR(191) NOT NULL,
+    field VARCHAR(64) NOT NULL,
+    old_value BOOLEAN NOT NULL DEFAULT FALSE,
+    new_value BOOLEAN NOT NULL DEFAULT FALSE,
+    changed_at DATETIME(3) NOT NULL,
+    INDEX idx_preference_changes_user_changed (user_address, changed_at)
+);
@@ -0,0 +1,13 @@
+-- =====================================================
+-- History of distribution status changes
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS distribution_events (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    token_id BIGINT UNSIGNED NOT NULL,
+    platform VARCHAR(64) NOT NULL DEFAULT '',
+    from_status VARCHAR(32) NOT NULL DEFAULT '',
+    to_status VARCHAR(32) NOT NULL,
+    created_at DATETIME(3) NOT NULL,
+    INDEX idx_distribution_events_token_created (token_id, created_at)
+);
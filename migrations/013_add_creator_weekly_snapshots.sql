@@ -0,0 +1,17 @@
+-- =====================================================
+-- Weekly snapshots of creator totals for week-over-week progress
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS creator_weekly_snapshots (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    wallet_address VARCHAR(42) NOT NULL,
+    week_start DATETIME(3) NOT NULL,
+    total_earnings VARCHAR(78) DEFAULT '0',
+    total_works INT UNSIGNED DEFAULT 0,
+    total_listeners BIGINT UNSIGNED DEFAULT 0,
+    total_plays BIGINT UNSIGNED DEFAULT 0,
+    total_views BIGINT UNSIGNED DEFAULT 0,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    UNIQUE INDEX idx_creator_weekly_snapshots_week (wallet_address, week_start)
+);
@@ -0,0 +1,15 @@
+-- =====================================================
+-- Tag contributions paid from reinvested royalties
+-- =====================================================
+
+ALTER TABLE contributions
+ADD COLUMN IF NOT EXISTS source VARCHAR(16) NOT NULL DEFAULT 'direct' COMMENT 'direct or reinvestment',
+ADD INDEX idx_contributions_source (source);
+
+-- Reinvestments record their contribution with the same tx hash
+UPDATE contributions c
+JOIN reinvestment_histories rh
+    ON rh.tx_hash = c.tx_hash
+    AND rh.user_address = c.contributor_address
+    AND rh.to_campaign_id = c.campaign_id
+SET c.source = 'reinvestment';
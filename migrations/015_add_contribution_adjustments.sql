@@ -0,0 +1,18 @@
+-- =====================================================
+-- Audit trail of manual contribution adjustments
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS contribution_adjustments (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    campaign_id BIGINT UNSIGNED NOT NULL,
+    contributor_address VARCHAR(191) NOT NULL,
+    amount VARCHAR(191) NOT NULL COMMENT 'Wei refunded',
+    reason TEXT NOT NULL,
+    previous_amount VARCHAR(191) NOT NULL COMMENT 'Contributor total before, wei',
+    new_amount VARCHAR(191) NOT NULL COMMENT 'Contributor total after, wei',
+    adjusted_by VARCHAR(191) NOT NULL,
+    transaction_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME(3) NULL,
+    INDEX idx_contribution_adjustments_campaign (campaign_id),
+    INDEX idx_contribution_adjustments_contributor (contributor_address)
+);
@@ -0,0 +1,12 @@
+-- =====================================================
+-- Campaigns investors are watching
+-- =====================================================
+
+CREATE TABLE IF NOT EXISTS watchlists (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_address VARCHAR(191) NOT NULL,
+    campaign_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME(3) NULL,
+    UNIQUE INDEX idx_watchlists_user_campaign (user_address, campaign_id),
+    INDEX idx_watchlists_campaign (campaign_id)
+);
@@ -0,0 +1,11 @@
+-- =====================================================
+-- Count manual webhook replays apart from worker attempts
+-- =====================================================
+
+ALTER TABLE outbox_events
+ADD COLUMN IF NOT EXISTS replays INT NOT NULL DEFAULT 0 AFTER attempts;
+
+-- Replays used to be counted in attempts, past the worker's limit of 10
+UPDATE outbox_events
+SET replays = LEAST(attempts - 10, 5)
+WHERE channel = 'webhook' AND attempts > 10;
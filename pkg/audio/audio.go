@@ -0,0 +1,186 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"mime"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Format identifies a supported audio container
+type Format string
+
+const (
+	FormatMP3  Format = "mp3"
+	FormatWAV  Format = "wav"
+	FormatFLAC Format = "flac"
+	FormatM4A  Format = "m4a"
+)
+
+var (
+	// ErrUnsupportedFormat is returned when the file is not one of the allowed formats
+	ErrUnsupportedFormat = errors.New("unsupported audio format")
+	// ErrFormatMismatch is returned when the extension, content type and file contents disagree
+	ErrFormatMismatch = errors.New("audio file content does not match its declared type")
+	// ErrTooLarge is returned when the file exceeds the configured maximum size
+	ErrTooLarge = errors.New("audio file exceeds maximum allowed size")
+)
+
+var extensionFormats = map[string]Format{
+	".mp3":  FormatMP3,
+	".wav":  FormatWAV,
+	".flac": FormatFLAC,
+	".m4a":  FormatM4A,
+}
+
+var contentTypeFormats = map[string]Format{
+	"audio/mpeg":     FormatMP3,
+	"audio/mp3":      FormatMP3,
+	"audio/wav":      FormatWAV,
+	"audio/wave":     FormatWAV,
+	"audio/x-wav":    FormatWAV,
+	"audio/vnd.wave": FormatWAV,
+	"audio/flac":     FormatFLAC,
+	"audio/x-flac":   FormatFLAC,
+	"audio/mp4":      FormatM4A,
+	"audio/m4a":      FormatM4A,
+	"audio/x-m4a":    FormatM4A,
+}
+
+// Info describes a validated audio file
+type Info struct {
+	Format   Format
+	Size     int64
+	Duration int // seconds, 0 when it could not be determined
+}
+
+// FormatForFilename returns the format implied by the filename extension
+func FormatForFilename(filename string) (Format, bool) {
+	format, ok := extensionFormats[strings.ToLower(filepath.Ext(filename))]
+	return format, ok
+}
+
+// ContentTypes lists the accepted audio MIME types in sorted order
+func ContentTypes() []string {
+	types := make([]string, 0, len(contentTypeFormats))
+	for contentType := range contentTypeFormats {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Validate checks the filename extension, the declared content type and the
+// magic bytes of data against the allowed formats and returns the detected format.
+// A generic content type (empty or application/octet-stream) is accepted and
+// the check falls back to the extension and file contents.
+func Validate(filename, contentType string, data []byte, maxSize int64) (*Info, error) {
+	if maxSize > 0 && int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("%w: %d bytes (max %d)", ErrTooLarge, len(data), maxSize)
+	}
+
+	extFormat, ok := FormatForFilename(filename)
+	if !ok {
+		return nil, fmt.Errorf("%w: extension %q (allowed: mp3, wav, flac, m4a)", ErrUnsupportedFormat, filepath.Ext(filename))
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType != "application/octet-stream" {
+		ctFormat, ok := contentTypeFormats[strings.ToLower(mediaType)]
+		if !ok {
+			return nil, fmt.Errorf("%w: content type %q", ErrUnsupportedFormat, mediaType)
+		}
+		if ctFormat != extFormat {
+			return nil, fmt.Errorf("%w: content type %q does not match extension %q", ErrFormatMismatch, mediaType, filepath.Ext(filename))
+		}
+	}
+
+	detected, ok := Detect(data)
+	if !ok {
+		return nil, fmt.Errorf("%w: file contents are not recognised audio", ErrFormatMismatch)
+	}
+	if detected != extFormat {
+		return nil, fmt.Errorf("%w: file contents are %s but declared as %s", ErrFormatMismatch, detected, extFormat)
+	}
+
+	return &Info{
+		Format:   detected,
+		Size:     int64(len(data)),
+		Duration: Duration(detected, data),
+	}, nil
+}
+
+// Detect identifies the audio format from the leading magic bytes
+func Detect(data []byte) (Format, bool) {
+	switch {
+	case len(data) >= 12 && bytes.Equal(data[0:4], []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WAVE")):
+		return FormatWAV, true
+	case len(data) >= 4 && bytes.Equal(data[0:4], []byte("fLaC")):
+		return FormatFLAC, true
+	case len(data) >= 8 && bytes.Equal(data[4:8], []byte("ftyp")):
+		return FormatM4A, true
+	case len(data) >= 3 && bytes.Equal(data[0:3], []byte("ID3")):
+		return FormatMP3, true
+	case len(data) >= 2 && data[0] == 0xFF && data[1]&0xE0 == 0xE0:
+		// MPEG audio frame sync
+		return FormatMP3, true
+	}
+	return "", false
+}
+
+// Duration extracts the track length in seconds from the container headers.
+// Only WAV and FLAC carry enough information in their headers; for other
+// formats (or malformed headers) it returns 0.
+func Duration(format Format, data []byte) int {
+	switch format {
+	case FormatWAV:
+		return wavDuration(data)
+	case FormatFLAC:
+		return flacDuration(data)
+	}
+	return 0
+}
+
+func wavDuration(data []byte) int {
+	var byteRate, dataSize uint32
+	offset := 12
+	for offset+8 <= len(data) {
+		chunkID := string(data[offset : offset+4])
+		chunkSize := binary.LittleEndian.Uint32(data[offset+4 : offset+8])
+		body := offset + 8
+
+		switch chunkID {
+		case "fmt ":
+			if body+12 <= len(data) {
+				byteRate = binary.LittleEndian.Uint32(data[body+8 : body+12])
+			}
+		case "data":
+			dataSize = chunkSize
+		}
+
+		if byteRate > 0 && dataSize > 0 {
+			return int(dataSize / byteRate)
+		}
+
+		// Chunks are word aligned
+		offset = body + int(chunkSize) + int(chunkSize%2)
+	}
+	return 0
+}
+
+func flacDuration(data []byte) int {
+	// "fLaC" marker, 4 byte block header, then the 34 byte STREAMINFO block
+	if len(data) < 8+18 || data[4]&0x7F != 0 {
+		return 0
+	}
+	info := data[8:]
+	sampleRate := uint64(info[10])<<12 | uint64(info[11])<<4 | uint64(info[12])>>4
+	totalSamples := uint64(info[13]&0x0F)<<32 | uint64(info[14])<<24 | uint64(info[15])<<16 | uint64(info[16])<<8 | uint64(info[17])
+	if sampleRate == 0 {
+		return 0
+	}
+	return int(totalSamples / sampleRate)
+}
@@ -0,0 +1,138 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// wavFile builds a RIFF/WAVE header with the given byte rate and data size
+func wavFile(byteRate, dataSize uint32) []byte {
+	data := []byte("RIFF\x00\x00\x00\x00WAVE")
+	fmtChunk := make([]byte, 8+16)
+	copy(fmtChunk, "fmt ")
+	binary.LittleEndian.PutUint32(fmtChunk[4:], 16)
+	binary.LittleEndian.PutUint32(fmtChunk[16:], byteRate)
+	data = append(data, fmtChunk...)
+	dataChunk := make([]byte, 8)
+	copy(dataChunk, "data")
+	binary.LittleEndian.PutUint32(dataChunk[4:], dataSize)
+	return append(data, dataChunk...)
+}
+
+// flacFile builds a fLaC marker and STREAMINFO block with the given sample
+// rate and total sample count
+func flacFile(sampleRate uint32, totalSamples uint64) []byte {
+	data := []byte("fLaC\x00\x00\x00\x22")
+	info := make([]byte, 34)
+	info[10] = byte(sampleRate >> 12)
+	info[11] = byte(sampleRate >> 4)
+	info[12] = byte(sampleRate << 4)
+	info[13] = byte(totalSamples >> 32 & 0x0F)
+	info[14] = byte(totalSamples >> 24)
+	info[15] = byte(totalSamples >> 16)
+	info[16] = byte(totalSamples >> 8)
+	info[17] = byte(totalSamples)
+	return append(data, info...)
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		want   Format
+		wantOK bool
+	}{
+		{name: "wav", data: wavFile(1, 1), want: FormatWAV, wantOK: true},
+		{name: "flac", data: []byte("fLaC\x00"), want: FormatFLAC, wantOK: true},
+		{name: "m4a", data: []byte("\x00\x00\x00\x20ftypM4A "), want: FormatM4A, wantOK: true},
+		{name: "mp3 with ID3 tag", data: []byte("ID3\x04"), want: FormatMP3, wantOK: true},
+		{name: "mp3 frame sync", data: []byte{0xFF, 0xFB, 0x90}, want: FormatMP3, wantOK: true},
+		{name: "riff that is not wave", data: []byte("RIFF\x00\x00\x00\x00AVI "), wantOK: false},
+		{name: "text", data: []byte("hello world"), wantOK: false},
+		{name: "empty", data: nil, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Detect(tt.data)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Detect() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		data   []byte
+		want   int
+	}{
+		{name: "wav", format: FormatWAV, data: wavFile(176400, 176400*180), want: 180},
+		{name: "wav with no byte rate", format: FormatWAV, data: wavFile(0, 1000), want: 0},
+		{name: "truncated wav", format: FormatWAV, data: []byte("RIFF\x00\x00\x00\x00WAVE"), want: 0},
+		{name: "flac", format: FormatFLAC, data: flacFile(44100, 44100*95), want: 95},
+		{name: "flac with no sample rate", format: FormatFLAC, data: flacFile(0, 1000), want: 0},
+		{name: "truncated flac", format: FormatFLAC, data: []byte("fLaC\x00\x00\x00\x22"), want: 0},
+		{name: "mp3 headers carry no duration", format: FormatMP3, data: []byte("ID3\x04"), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Duration(tt.format, tt.data); got != tt.want {
+				t.Errorf("Duration() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	mp3 := []byte("ID3\x04\x00\x00\x00\x00")
+	wav := wavFile(176400, 176400*3)
+
+	tests := []struct {
+		name         string
+		filename     string
+		contentType  string
+		data         []byte
+		maxSize      int64
+		wantErr      error
+		wantFormat   Format
+		wantDuration int
+	}{
+		{name: "mp3", filename: "song.mp3", contentType: "audio/mpeg", data: mp3, wantFormat: FormatMP3},
+		{name: "extension case is ignored", filename: "SONG.MP3", contentType: "audio/mpeg", data: mp3, wantFormat: FormatMP3},
+		{name: "wav duration is read from the headers", filename: "song.wav", contentType: "audio/wav", data: wav, wantFormat: FormatWAV, wantDuration: 3},
+		{name: "generic content type falls back to the contents", filename: "song.mp3", contentType: "application/octet-stream", data: mp3, wantFormat: FormatMP3},
+		{name: "missing content type falls back to the contents", filename: "song.mp3", data: mp3, wantFormat: FormatMP3},
+		{name: "content type parameters are ignored", filename: "song.mp3", contentType: "audio/mpeg; charset=binary", data: mp3, wantFormat: FormatMP3},
+		{name: "at the size limit", filename: "song.mp3", contentType: "audio/mpeg", data: mp3, maxSize: int64(len(mp3)), wantFormat: FormatMP3},
+		{name: "over the size limit", filename: "song.mp3", contentType: "audio/mpeg", data: mp3, maxSize: int64(len(mp3)) - 1, wantErr: ErrTooLarge},
+		{name: "unsupported extension", filename: "song.ogg", contentType: "audio/ogg", data: mp3, wantErr: ErrUnsupportedFormat},
+		{name: "no extension", filename: "song", data: mp3, wantErr: ErrUnsupportedFormat},
+		{name: "unsupported content type", filename: "song.mp3", contentType: "video/mp4", data: mp3, wantErr: ErrUnsupportedFormat},
+		{name: "content type disagrees with extension", filename: "song.mp3", contentType: "audio/wav", data: mp3, wantErr: ErrFormatMismatch},
+		{name: "contents disagree with extension", filename: "song.wav", contentType: "audio/wav", data: mp3, wantErr: ErrFormatMismatch},
+		{name: "contents are not audio", filename: "song.mp3", contentType: "audio/mpeg", data: []byte("<html></html>"), wantErr: ErrFormatMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := Validate(tt.filename, tt.contentType, tt.data, tt.maxSize)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if info.Format != tt.wantFormat || info.Duration != tt.wantDuration || info.Size != int64(len(tt.data)) {
+				t.Errorf("Validate() = %+v, want format %s, duration %d, size %d", info, tt.wantFormat, tt.wantDuration, len(tt.data))
+			}
+		})
+	}
+}
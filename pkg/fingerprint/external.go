@@ -0,0 +1,107 @@
+package fingerprint
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Chromaprint fingerprints, as printed by `fpcalc -plain`, are URL-safe
+// base64; a few seconds of audio already gives more than the minimum
+const (
+	minExternalFingerprintLength = 16
+	maxExternalFingerprintLength = 16 << 10
+)
+
+// External runs a fingerprinting binary such as Chromaprint's fpcalc, feeding
+// the audio on stdin and reading the fingerprint from stdout. The raw output
+// can run to kilobytes, so it is stored as "0x" + its SHA256 to fit the
+// fingerprint_hash column.
+// NOTE: Compare is a stub that only matches identical fingerprints; fuzzy
+// matching needs the raw (uncompressed) Chromaprint data.
+type External struct {
+	command string
+	args    []string
+	timeout time.Duration
+}
+
+// NewExternal parses command (e.g. "fpcalc -plain -") into a binary and its
+// arguments
+func NewExternal(command string, timeout time.Duration) (*External, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, errors.New("external fingerprint algorithm requires a command")
+	}
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &External{command: fields[0], args: fields[1:], timeout: timeout}, nil
+}
+
+func (e *External) Name() string {
+	return AlgorithmExternal
+}
+
+func (e *External) Generate(audioData []byte) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.command, e.args...)
+	cmd.Stdin = bytes.NewReader(audioData)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("fingerprint command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	raw := strings.TrimSpace(stdout.String())
+	if !validRawFingerprint(raw) {
+		return "", fmt.Errorf("fingerprint command returned an invalid fingerprint")
+	}
+	return hashExternalFingerprint(raw), nil
+}
+
+// hashExternalFingerprint is the stored form of a raw command fingerprint
+func hashExternalFingerprint(raw string) string {
+	hash := sha256.Sum256([]byte(raw))
+	return "0x" + hex.EncodeToString(hash[:])
+}
+
+// Validate accepts the stored form: "0x" followed by 64 hex characters
+func (e *External) Validate(fingerprint string) bool {
+	digest, ok := strings.CutPrefix(fingerprint, "0x")
+	if !ok || len(digest) != 2*sha256.Size {
+		return false
+	}
+	_, err := hex.DecodeString(digest)
+	return err == nil
+}
+
+// validRawFingerprint accepts URL-safe base64 within the expected length range
+func validRawFingerprint(fingerprint string) bool {
+	if len(fingerprint) < minExternalFingerprintLength || len(fingerprint) > maxExternalFingerprintLength {
+		return false
+	}
+	for _, r := range fingerprint {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (e *External) Compare(fp1, fp2 string) float64 {
+	if fp1 == fp2 {
+		return 1.0
+	}
+	return 0.0
+}
@@ -0,0 +1,97 @@
+package fingerprint
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidRawFingerprint(t *testing.T) {
+	tests := []struct {
+		name        string
+		fingerprint string
+		want        bool
+	}{
+		{name: "url-safe base64", fingerprint: "AQADtEmSRImSJEmS-_9abc", want: true},
+		{name: "shortest accepted", fingerprint: strings.Repeat("A", minExternalFingerprintLength), want: true},
+		{name: "longest accepted", fingerprint: strings.Repeat("A", maxExternalFingerprintLength), want: true},
+		{name: "too short", fingerprint: strings.Repeat("A", minExternalFingerprintLength-1), want: false},
+		{name: "too long", fingerprint: strings.Repeat("A", maxExternalFingerprintLength+1), want: false},
+		{name: "standard base64 padding", fingerprint: "AQADtEmSRImSJEmS==", want: false},
+		{name: "standard base64 alphabet", fingerprint: "AQADtEmSRImSJEmS+/", want: false},
+		{name: "embedded whitespace", fingerprint: "AQADtEmS RImSJEmS", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validRawFingerprint(tt.fingerprint); got != tt.want {
+				t.Errorf("validRawFingerprint(%q) = %v, want %v", tt.fingerprint, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExternalValidate(t *testing.T) {
+	e := &External{}
+	digest := hashExternalFingerprint("AQADtEmSRImSJEmS")
+
+	tests := []struct {
+		name        string
+		fingerprint string
+		want        bool
+	}{
+		{name: "stored digest", fingerprint: digest, want: true},
+		{name: "digest without prefix", fingerprint: strings.TrimPrefix(digest, "0x"), want: false},
+		{name: "raw fingerprint", fingerprint: "AQADtEmSRImSJEmS", want: false},
+		{name: "short digest", fingerprint: digest[:len(digest)-2], want: false},
+		{name: "non-hex digest", fingerprint: "0x" + strings.Repeat("z", 64), want: false},
+		{name: "uppercase prefix", fingerprint: "0X" + digest[2:], want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.Validate(tt.fingerprint); got != tt.want {
+				t.Errorf("Validate(%q) = %v, want %v", tt.fingerprint, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExternalGenerate(t *testing.T) {
+	long := strings.Repeat("A", maxExternalFingerprintLength)
+
+	tests := []struct {
+		name    string
+		command string
+		want    string
+		wantErr bool
+	}{
+		{name: "output is trimmed and hashed", command: "echo AQADtEmSRImSJEmS", want: hashExternalFingerprint("AQADtEmSRImSJEmS")},
+		{name: "long output fits the column", command: "printf " + long, want: hashExternalFingerprint(long)},
+		{name: "invalid output", command: "echo not+valid/base64", wantErr: true},
+		{name: "failing command", command: "false", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewExternal(tt.command, 5*time.Second)
+			if err != nil {
+				t.Fatalf("NewExternal: %v", err)
+			}
+
+			got, err := e.Generate([]byte("audio"))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Generate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Generate() = %q, want %q", got, tt.want)
+			}
+			if len(got) != 66 || !e.Validate(got) {
+				t.Errorf("Generate() = %q, want a valid 66-character digest", got)
+			}
+		})
+	}
+}
@@ -4,50 +4,72 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"time"
 )
 
-// Service handles audio fingerprinting (mock implementation for PoC)
-type Service struct{}
+// Algorithm names accepted by NewAlgorithm
+const (
+	AlgorithmSHA256   = "sha256"
+	AlgorithmExternal = "external"
+)
 
-func NewService() *Service {
-	return &Service{}
+// Algorithm computes, validates and compares fingerprints. Fingerprints are
+// only comparable with others from the same algorithm.
+type Algorithm interface {
+	Name() string
+	Generate(audioData []byte) (string, error)
+	Validate(fingerprint string) bool
+	Compare(fp1, fp2 string) float64 // similarity score (0-1)
 }
 
-// Generate creates a fingerprint hash from audio data
-// NOTE: This is a MOCK implementation for PoC
-// In production, use real audio fingerprinting algorithms like Chromaprint/AcoustID
-func (s *Service) Generate(audioData []byte) (string, error) {
-	if len(audioData) == 0 {
-		return "", fmt.Errorf("audio data is empty")
+// NewAlgorithm selects an algorithm by name; command and timeout are only
+// used by the external algorithm. An empty name selects the SHA256 mock.
+func NewAlgorithm(name, command string, timeout time.Duration) (Algorithm, error) {
+	switch name {
+	case "", AlgorithmSHA256:
+		return SHA256{}, nil
+	case AlgorithmExternal:
+		return NewExternal(command, timeout)
+	default:
+		return nil, fmt.Errorf("unknown fingerprint algorithm %q (use %s or %s)", name, AlgorithmSHA256, AlgorithmExternal)
 	}
+}
 
-	// Mock: Use SHA256 hash of audio data as fingerprint
-	// Real implementation would use acoustic features
-	hash := sha256.Sum256(audioData)
-	fingerprint := hex.EncodeToString(hash[:])
+// Service handles audio fingerprinting with the configured algorithm
+type Service struct {
+	algorithm Algorithm
+}
 
-	return fingerprint, nil
+// NewService creates a service using algorithm; nil selects the SHA256 mock
+func NewService(algorithm Algorithm) *Service {
+	if algorithm == nil {
+		algorithm = SHA256{}
+	}
+	return &Service{algorithm: algorithm}
 }
 
-// Validate checks if a fingerprint is in valid format
-func (s *Service) Validate(fingerprint string) bool {
-	// Check if it's a valid hex string of expected length (64 chars for SHA256)
-	if len(fingerprint) != 64 {
-		return false
+// Algorithm returns the name of the algorithm in use
+func (s *Service) Algorithm() string {
+	return s.algorithm.Name()
+}
+
+// Generate creates a fingerprint from audio data
+func (s *Service) Generate(audioData []byte) (string, error) {
+	if len(audioData) == 0 {
+		return "", fmt.Errorf("audio data is empty")
 	}
+	return s.algorithm.Generate(audioData)
+}
 
-	_, err := hex.DecodeString(fingerprint)
-	return err == nil
+// Validate checks if a fingerprint is in the algorithm's format
+func (s *Service) Validate(fingerprint string) bool {
+	return s.algorithm.Validate(fingerprint)
 }
 
 // Compare checks similarity between two fingerprints
 // Returns similarity score (0-1)
-// NOTE: Mock implementation - real audio fingerprinting has fuzzy matching
 func (s *Service) Compare(fp1, fp2 string) float64 {
-	if fp1 == fp2 {
-		return 1.0
-	}
-	return 0.0
+	return s.algorithm.Compare(fp1, fp2)
 }
 
 // GenerateFromFile would generate fingerprint from file path
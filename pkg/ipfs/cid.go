@@ -0,0 +1,30 @@
+package ipfs
+
+import "strings"
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base32Alphabet = "abcdefghijklmnopqrstuvwxyz234567"
+)
+
+// ValidCID reports whether cid is a CIDv0 (a base58 "Qm" sha2-256 hash) or
+// a CIDv1 in the lowercase base32 encoding gateways use. It checks the shape
+// of the string only, not that the content exists.
+func ValidCID(cid string) bool {
+	switch {
+	case len(cid) == 46 && strings.HasPrefix(cid, "Qm"):
+		return onlyRunesOf(cid, base58Alphabet)
+	case len(cid) >= 50 && len(cid) <= 120 && strings.HasPrefix(cid, "b"):
+		return onlyRunesOf(cid[1:], base32Alphabet)
+	}
+	return false
+}
+
+func onlyRunesOf(s, alphabet string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune(alphabet, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package ipfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// ErrNoGateways is returned when no IPFS gateway is configured
+var ErrNoGateways = errors.New("no IPFS gateways configured")
+
+// fromGateways requests cid from each configured gateway in order, each with
+// its own timeout, and hands the first successful response to handle. Errors
+// from handle other than ErrTooLarge move on to the next gateway.
+func (s *Service) fromGateways(ctx context.Context, cid, method string, handle func(gatewayURL string, resp *http.Response) error) error {
+	if len(s.gateways) == 0 {
+		return ErrNoGateways
+	}
+
+	var errs []error
+	for _, gateway := range s.gateways {
+		gatewayURL := gateway + cid
+		err := s.tryGateway(ctx, method, gatewayURL, handle)
+		if err == nil {
+			slog.DebugContext(ctx, "IPFS content served", "cid", cid, "gateway", gateway)
+			return nil
+		}
+		if errors.Is(err, ErrTooLarge) || ctx.Err() != nil {
+			return err
+		}
+
+		slog.WarnContext(ctx, "IPFS gateway failed, trying next", "cid", cid, "gateway", gateway, "error", err)
+		errs = append(errs, fmt.Errorf("%s: %w", gateway, err))
+	}
+
+	return fmt.Errorf("all IPFS gateways failed: %w", errors.Join(errs...))
+}
+
+func (s *Service) tryGateway(ctx context.Context, method, gatewayURL string, handle func(string, *http.Response) error) error {
+	if s.gatewayTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.gatewayTimeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, gatewayURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch from IPFS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("IPFS gateway error: status %d", resp.StatusCode)
+	}
+
+	return handle(gatewayURL, resp)
+}
@@ -0,0 +1,118 @@
+package ipfs
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tunecent/backend/internal/config"
+)
+
+// gateway serves every request with handler and counts the requests it saw
+func gateway(t *testing.T, handler http.HandlerFunc) (string, *int) {
+	t.Helper()
+	hits := new(int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*hits++
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL + "/ipfs/", hits
+}
+
+func serveJSON(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}
+}
+
+func failWith(status int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}
+}
+
+func TestFetchMetadataFallback(t *testing.T) {
+	const metadata = `{"title":"Fallback","artist":"Gateway"}`
+	hang := func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}
+
+	tests := []struct {
+		name      string
+		first     http.HandlerFunc
+		second    http.HandlerFunc
+		wantErr   bool
+		wantHits  [2]int
+		wantTitle string
+	}{
+		{name: "first gateway serves", first: serveJSON(metadata), second: serveJSON(`{}`), wantHits: [2]int{1, 0}, wantTitle: "Fallback"},
+		{name: "first gateway errors", first: failWith(http.StatusBadGateway), second: serveJSON(metadata), wantHits: [2]int{1, 1}, wantTitle: "Fallback"},
+		{name: "first gateway times out", first: hang, second: serveJSON(metadata), wantHits: [2]int{1, 1}, wantTitle: "Fallback"},
+		{name: "first gateway serves something else", first: serveJSON("<html>"), second: serveJSON(metadata), wantHits: [2]int{1, 1}, wantTitle: "Fallback"},
+		{name: "every gateway fails", first: failWith(http.StatusNotFound), second: failWith(http.StatusServiceUnavailable), wantErr: true, wantHits: [2]int{1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, firstHits := gateway(t, tt.first)
+			second, secondHits := gateway(t, tt.second)
+			s := NewService(&config.Config{IPFS: config.IPFSConfig{
+				Gateways:       []string{first, second},
+				GatewayTimeout: 100 * time.Millisecond,
+			}})
+
+			got, err := s.FetchMetadata(testCIDv0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FetchMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.Title != tt.wantTitle {
+				t.Errorf("FetchMetadata() title = %q, want %q", got.Title, tt.wantTitle)
+			}
+			if hits := [2]int{*firstHits, *secondHits}; hits != tt.wantHits {
+				t.Errorf("gateway hits = %v, want %v", hits, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestFetchMetadataWithoutGateways(t *testing.T) {
+	if _, err := newTestService().FetchMetadata(testCIDv0); !errors.Is(err, ErrNoGateways) {
+		t.Errorf("FetchMetadata() error = %v, want %v", err, ErrNoGateways)
+	}
+}
+
+func TestResolveURL(t *testing.T) {
+	down, _ := gateway(t, failWith(http.StatusBadGateway))
+	up, upHits := gateway(t, serveJSON(`{}`))
+
+	got, err := newTestService(down, up).ResolveURL(t.Context(), testCIDv0)
+	if err != nil {
+		t.Fatalf("ResolveURL() error = %v", err)
+	}
+	if want := up + testCIDv0; got != want {
+		t.Errorf("ResolveURL() = %q, want %q", got, want)
+	}
+	if *upHits != 1 {
+		t.Errorf("fallback gateway hit %d times, want 1", *upHits)
+	}
+}
+
+func TestFetchFileTooLargeStopsFallback(t *testing.T) {
+	large, _ := gateway(t, serveJSON(`{"title":"too large for the limit"}`))
+	fallback, fallbackHits := gateway(t, serveJSON(`{}`))
+
+	_, err := newTestService(large, fallback).FetchFile(t.Context(), testCIDv0, 8)
+	if !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("FetchFile() error = %v, want %v", err, ErrTooLarge)
+	}
+	if *fallbackHits != 0 {
+		t.Errorf("fallback gateway hit %d times after an oversized response, want 0", *fallbackHits)
+	}
+}
@@ -2,20 +2,25 @@ package ipfs
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/tunecent/backend/internal/config"
 )
 
 type Service struct {
-	apiKey    string
-	apiSecret string
-	gateway   string
-	client    *http.Client
+	apiKey         string
+	apiSecret      string
+	jwt            string
+	gateways       []string
+	gatewayTimeout time.Duration
+	client         *http.Client
 }
 
 type PinataResponse struct {
@@ -32,15 +37,18 @@ type MusicMetadata struct {
 	Duration        int    `json:"duration,omitempty"`
 	FingerprintHash string `json:"fingerprint_hash"`
 	Creator         string `json:"creator"`
+	Image           string `json:"image,omitempty"`
 	Timestamp       int64  `json:"timestamp"`
 }
 
 func NewService(cfg *config.Config) *Service {
 	return &Service{
-		apiKey:    cfg.IPFS.PinataAPIKey,
-		apiSecret: cfg.IPFS.PinataSecret,
-		gateway:   cfg.IPFS.Gateway,
-		client:    &http.Client{},
+		apiKey:         cfg.IPFS.PinataAPIKey,
+		apiSecret:      cfg.IPFS.PinataSecret,
+		jwt:            cfg.IPFS.PinataJWT,
+		gateways:       cfg.IPFS.Gateways,
+		gatewayTimeout: cfg.IPFS.GatewayTimeout,
+		client:         &http.Client{},
 	}
 }
 
@@ -143,28 +151,58 @@ func (s *Service) UploadFile(fileData []byte, filename string) (string, error) {
 	return pinataResp.IpfsHash, nil
 }
 
-// GetURL returns the gateway URL for an IPFS CID
+// GetURL returns the primary gateway URL for an IPFS CID without any network
+// access. Use ResolveURL to get a URL from a gateway that is currently reachable.
 func (s *Service) GetURL(cid string) string {
-	return fmt.Sprintf("%s%s", s.gateway, cid)
+	if len(s.gateways) == 0 {
+		return "ipfs://" + cid
+	}
+	return fmt.Sprintf("%s%s", s.gateways[0], cid)
 }
 
-// FetchMetadata retrieves metadata from IPFS
-func (s *Service) FetchMetadata(cid string) (*MusicMetadata, error) {
-	url := s.GetURL(cid)
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch from IPFS: %w", err)
+// URLsFor lists every URL GetURL can have produced for cid: its ipfs:// form
+// and its URL on each configured gateway
+func (s *Service) URLsFor(cid string) []string {
+	urls := []string{"ipfs://" + cid}
+	for _, gateway := range s.gateways {
+		urls = append(urls, gateway+cid)
 	}
-	defer resp.Body.Close()
+	return urls
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("IPFS gateway error: status %d", resp.StatusCode)
+// CIDFromURL returns the CID that an ipfs:// URL or a URL on one of the
+// configured gateways points at. Any other URL, or one with a path after the
+// CID, is rejected.
+func (s *Service) CIDFromURL(raw string) (string, bool) {
+	for _, prefix := range append([]string{"ipfs://"}, s.gateways...) {
+		if cid, ok := strings.CutPrefix(raw, prefix); ok && ValidCID(cid) {
+			return cid, true
+		}
 	}
+	return "", false
+}
+
+// ResolveURL returns the URL of cid on the first gateway that serves it
+func (s *Service) ResolveURL(ctx context.Context, cid string) (string, error) {
+	var resolved string
+	err := s.fromGateways(ctx, cid, http.MethodHead, func(gatewayURL string, resp *http.Response) error {
+		resolved = gatewayURL
+		return nil
+	})
+	return resolved, err
+}
 
+// FetchMetadata retrieves metadata from IPFS, trying each gateway in order
+func (s *Service) FetchMetadata(cid string) (*MusicMetadata, error) {
 	var metadata MusicMetadata
-	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
-		return nil, fmt.Errorf("failed to decode metadata: %w", err)
+	err := s.fromGateways(context.Background(), cid, http.MethodGet, func(_ string, resp *http.Response) error {
+		if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+			return fmt.Errorf("failed to decode metadata: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &metadata, nil
@@ -0,0 +1,87 @@
+package ipfs
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/tunecent/backend/internal/config"
+)
+
+const (
+	testCIDv0 = "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"
+	testCIDv1 = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+)
+
+func newTestService(gateways ...string) *Service {
+	return NewService(&config.Config{IPFS: config.IPFSConfig{Gateways: gateways}})
+}
+
+func TestCIDFromURL(t *testing.T) {
+	s := newTestService("https://gateway.pinata.cloud/ipfs/", "https://ipfs.io/ipfs/")
+
+	tests := []struct {
+		name    string
+		url     string
+		wantCID string
+		wantOK  bool
+	}{
+		{name: "ipfs scheme", url: "ipfs://" + testCIDv0, wantCID: testCIDv0, wantOK: true},
+		{name: "primary gateway", url: "https://gateway.pinata.cloud/ipfs/" + testCIDv1, wantCID: testCIDv1, wantOK: true},
+		{name: "fallback gateway", url: "https://ipfs.io/ipfs/" + testCIDv0, wantCID: testCIDv0, wantOK: true},
+		{name: "unconfigured host", url: "https://evil.example/ipfs/" + testCIDv0},
+		{name: "cid as a suffix of another path", url: "https://evil.example/x/" + testCIDv0},
+		{name: "gateway host on plain http", url: "http://gateway.pinata.cloud/ipfs/" + testCIDv0},
+		{name: "path after the cid", url: "ipfs://" + testCIDv0 + "/cover.png"},
+		{name: "query after the cid", url: "https://gateway.pinata.cloud/ipfs/" + testCIDv0 + "?filename=x.svg"},
+		{name: "invalid cid", url: "ipfs://not-a-cid"},
+		{name: "empty", url: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cid, ok := s.CIDFromURL(tt.url)
+			if ok != tt.wantOK || cid != tt.wantCID {
+				t.Errorf("CIDFromURL(%q) = %q, %v; want %q, %v", tt.url, cid, ok, tt.wantCID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestURLsFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		gateways []string
+		want     []string
+	}{
+		{name: "no gateways", want: []string{"ipfs://" + testCIDv0}},
+		{
+			name:     "each gateway",
+			gateways: []string{"https://gateway.pinata.cloud/ipfs/", "https://ipfs.io/ipfs/"},
+			want: []string{
+				"ipfs://" + testCIDv0,
+				"https://gateway.pinata.cloud/ipfs/" + testCIDv0,
+				"https://ipfs.io/ipfs/" + testCIDv0,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(tt.gateways...)
+			got := s.URLsFor(testCIDv0)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("URLsFor() = %v, want %v", got, tt.want)
+			}
+			// Every URL a record may store must map back to the CID
+			for _, url := range got {
+				if cid, ok := s.CIDFromURL(url); !ok || cid != testCIDv0 {
+					t.Errorf("CIDFromURL(%q) = %q, %v; want %q", url, cid, ok, testCIDv0)
+				}
+			}
+			if url := s.GetURL(testCIDv0); !slices.Contains(got, url) {
+				t.Errorf("GetURL() = %q is not among %v", url, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package ipfs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var (
+	ErrNotConfigured = errors.New("pinata credentials not configured")
+	ErrNotPinned     = errors.New("content is not pinned")
+	ErrTooLarge      = errors.New("content exceeds size limit")
+)
+
+// UploadTarget is a signed URL clients upload a file to directly
+type UploadTarget struct {
+	URL       string    `json:"url"`
+	Method    string    `json:"method"`
+	FormField string    `json:"form_field"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// PinInfo describes pinned content
+type PinInfo struct {
+	CID  string `json:"cid"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// CreateUploadURL asks Pinata for a signed URL that accepts a single file
+// of at most maxBytes with one of the given MIME types until it expires
+func (s *Service) CreateUploadURL(ctx context.Context, filename string, maxBytes int64, mimeTypes []string, ttl time.Duration) (*UploadTarget, error) {
+	if s.jwt == "" {
+		return nil, ErrNotConfigured
+	}
+
+	now := time.Now().UTC()
+	payload, err := json.Marshal(map[string]interface{}{
+		"date":             now.Unix(),
+		"expires":          int64(ttl.Seconds()),
+		"filename":         filename,
+		"max_file_size":    maxBytes,
+		"allow_mime_types": mimeTypes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sign request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://uploads.pinata.cloud/v3/files/sign", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.jwt)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request upload URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("pinata API error: %s", string(bodyBytes))
+	}
+
+	var signResp struct {
+		Data string `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&signResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &UploadTarget{
+		URL:       signResp.Data,
+		Method:    http.MethodPost,
+		FormField: "file",
+		ExpiresAt: now.Add(ttl),
+	}, nil
+}
+
+// VerifyPin checks that cid is pinned on the Pinata account
+func (s *Service) VerifyPin(ctx context.Context, cid string) (*PinInfo, error) {
+	if s.apiKey == "" || s.apiSecret == "" {
+		return nil, ErrNotConfigured
+	}
+
+	query := url.Values{}
+	query.Set("hashContains", cid)
+	query.Set("status", "pinned")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.pinata.cloud/data/pinList?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("pinata_api_key", s.apiKey)
+	req.Header.Set("pinata_secret_api_key", s.apiSecret)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pins: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("pinata API error: %s", string(bodyBytes))
+	}
+
+	var pinList struct {
+		Rows []struct {
+			IpfsPinHash string `json:"ipfs_pin_hash"`
+			Size        int64  `json:"size"`
+			Metadata    struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		} `json:"rows"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pinList); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for _, row := range pinList.Rows {
+		if row.IpfsPinHash == cid {
+			return &PinInfo{CID: cid, Name: row.Metadata.Name, Size: row.Size}, nil
+		}
+	}
+	return nil, ErrNotPinned
+}
+
+// FetchFile downloads cid through the configured gateways, failing with
+// ErrTooLarge when the content is larger than maxBytes
+func (s *Service) FetchFile(ctx context.Context, cid string, maxBytes int64) ([]byte, error) {
+	data, _, err := s.FetchContent(ctx, cid, maxBytes)
+	return data, err
+}
+
+// FetchContent is FetchFile that also returns the Content-Type the serving
+// gateway reported, empty when it sent none
+func (s *Service) FetchContent(ctx context.Context, cid string, maxBytes int64) ([]byte, string, error) {
+	var data []byte
+	var contentType string
+	err := s.fromGateways(ctx, cid, http.MethodGet, func(_ string, resp *http.Response) error {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+		if err != nil {
+			return fmt.Errorf("failed to read IPFS content: %w", err)
+		}
+		if int64(len(body)) > maxBytes {
+			return ErrTooLarge
+		}
+		data = body
+		contentType = resp.Header.Get("Content-Type")
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, contentType, nil
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Winner values for a metric comparison
+const (
+	WinnerA   = "a"
+	WinnerB   = "b"
+	WinnerTie = "tie"
+)
+
+// Comparison is one metric compared between two subjects, A and B.
+// Values are decimal strings so wei-denominated metrics keep full precision.
+type Comparison struct {
+	Metric       string  `json:"metric"`
+	A            string  `json:"a"`
+	B            string  `json:"b"`
+	Delta        string  `json:"delta"`         // A - B
+	DeltaPercent float64 `json:"delta_percent"` // (A - B) / B * 100, 0 when B is 0
+	Winner       string  `json:"winner"`        // a, b or tie (higher wins)
+}
+
+// CompareInts compares an integer metric where higher is better
+func CompareInts(metric string, a, b *big.Int) Comparison {
+	delta := new(big.Int).Sub(a, b)
+
+	percent := 0.0
+	if b.Sign() != 0 {
+		ratio, _ := new(big.Rat).SetFrac(delta, b).Float64()
+		percent = round2(ratio * 100)
+	}
+
+	return Comparison{
+		Metric:       metric,
+		A:            a.String(),
+		B:            b.String(),
+		Delta:        delta.String(),
+		DeltaPercent: percent,
+		Winner:       winner(a.Cmp(b)),
+	}
+}
+
+// CompareFloats compares a fractional metric (e.g. a score) where higher is better
+func CompareFloats(metric string, a, b float64) Comparison {
+	delta := round2(a - b)
+
+	percent := 0.0
+	if b != 0 {
+		percent = round2((a - b) / b * 100)
+	}
+
+	cmp := 0
+	switch {
+	case delta > 0:
+		cmp = 1
+	case delta < 0:
+		cmp = -1
+	}
+
+	return Comparison{
+		Metric:       metric,
+		A:            strconv.FormatFloat(a, 'f', 2, 64),
+		B:            strconv.FormatFloat(b, 'f', 2, 64),
+		Delta:        strconv.FormatFloat(delta, 'f', 2, 64),
+		DeltaPercent: percent,
+		Winner:       winner(cmp),
+	}
+}
+
+func winner(cmp int) string {
+	switch {
+	case cmp > 0:
+		return WinnerA
+	case cmp < 0:
+		return WinnerB
+	default:
+		return WinnerTie
+	}
+}
+
+// round2 rounds to two decimals; a negative value rounded away comes back as
+// 0 rather than -0, which would render as "-0.00"
+func round2(v float64) float64 {
+	rounded := math.Round(v*100) / 100
+	if rounded == 0 {
+		return 0
+	}
+	return rounded
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestCompareInts(t *testing.T) {
+	wei, _ := new(big.Int).SetString("30000000000000000000", 10)
+	halfWei, _ := new(big.Int).SetString("15000000000000000000", 10)
+
+	tests := []struct {
+		name string
+		a, b *big.Int
+		want Comparison
+	}{
+		{
+			name: "a wins",
+			a:    big.NewInt(150), b: big.NewInt(100),
+			want: Comparison{Metric: "plays", A: "150", B: "100", Delta: "50", DeltaPercent: 50, Winner: WinnerA},
+		},
+		{
+			name: "b wins",
+			a:    big.NewInt(75), b: big.NewInt(100),
+			want: Comparison{Metric: "plays", A: "75", B: "100", Delta: "-25", DeltaPercent: -25, Winner: WinnerB},
+		},
+		{
+			name: "tie",
+			a:    big.NewInt(100), b: big.NewInt(100),
+			want: Comparison{Metric: "plays", A: "100", B: "100", Delta: "0", DeltaPercent: 0, Winner: WinnerTie},
+		},
+		{
+			name: "b is zero so there is no percentage",
+			a:    big.NewInt(10), b: big.NewInt(0),
+			want: Comparison{Metric: "plays", A: "10", B: "0", Delta: "10", DeltaPercent: 0, Winner: WinnerA},
+		},
+		{
+			name: "percentage is rounded",
+			a:    big.NewInt(1), b: big.NewInt(3),
+			want: Comparison{Metric: "plays", A: "1", B: "3", Delta: "-2", DeltaPercent: -66.67, Winner: WinnerB},
+		},
+		{
+			name: "wei beyond int64",
+			a:    wei, b: halfWei,
+			want: Comparison{Metric: "plays", A: "30000000000000000000", B: "15000000000000000000", Delta: "15000000000000000000", DeltaPercent: 100, Winner: WinnerA},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareInts("plays", tt.a, tt.b); got != tt.want {
+				t.Errorf("CompareInts() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareFloats(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b float64
+		want Comparison
+	}{
+		{
+			name: "a wins",
+			a:    80, b: 64,
+			want: Comparison{Metric: "viral_score", A: "80.00", B: "64.00", Delta: "16.00", DeltaPercent: 25, Winner: WinnerA},
+		},
+		{
+			name: "b wins",
+			a:    12.5, b: 50,
+			want: Comparison{Metric: "viral_score", A: "12.50", B: "50.00", Delta: "-37.50", DeltaPercent: -75, Winner: WinnerB},
+		},
+		{
+			name: "differences below a hundredth tie",
+			a:    50.001, b: 50.002,
+			want: Comparison{Metric: "viral_score", A: "50.00", B: "50.00", Delta: "0.00", DeltaPercent: 0, Winner: WinnerTie},
+		},
+		{
+			name: "b is zero",
+			a:    5, b: 0,
+			want: Comparison{Metric: "viral_score", A: "5.00", B: "0.00", Delta: "5.00", DeltaPercent: 0, Winner: WinnerA},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareFloats("viral_score", tt.a, tt.b); got != tt.want {
+				t.Errorf("CompareFloats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRound2NoNegativeZero(t *testing.T) {
+	if got := round2(-0.001); math.Signbit(got) {
+		t.Errorf("round2(-0.001) = %v, want 0", got)
+	}
+}
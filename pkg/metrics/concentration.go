@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"math"
+	"math/big"
+	"sort"
+)
+
+// Share is one bucket's portion of a total
+type Share struct {
+	Key    string  `json:"key"`
+	Amount string  `json:"amount"` // Wei as string
+	Share  float64 `json:"share"`  // percentage of total
+}
+
+// Concentration summarizes how concentrated a set of amounts is
+type Concentration struct {
+	Index float64 `json:"index"` // Herfindahl-Hirschman index, 0-1 (1 = everything in one bucket)
+	Score float64 `json:"score"` // diversification score, 0-100 (higher = more spread)
+	Top   []Share `json:"top"`
+}
+
+// HerfindahlIndex returns the sum of squared shares (0-1) for the given amounts.
+// Zero and negative amounts are ignored; an empty set yields 0.
+func HerfindahlIndex(amounts map[string]*big.Int) float64 {
+	total := new(big.Int)
+	for _, amount := range amounts {
+		if amount != nil && amount.Sign() > 0 {
+			total.Add(total, amount)
+		}
+	}
+	if total.Sign() == 0 {
+		return 0
+	}
+
+	totalF := new(big.Float).SetInt(total)
+	index := 0.0
+	for _, amount := range amounts {
+		if amount == nil || amount.Sign() <= 0 {
+			continue
+		}
+		share, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), totalF).Float64()
+		index += share * share
+	}
+	return index
+}
+
+// Concentrate computes the Herfindahl index, a 0-100 diversification score and
+// the topN largest buckets for the given amounts
+func Concentrate(amounts map[string]*big.Int, topN int) Concentration {
+	index := HerfindahlIndex(amounts)
+
+	total := new(big.Int)
+	shares := make([]Share, 0, len(amounts))
+	for _, amount := range amounts {
+		if amount != nil && amount.Sign() > 0 {
+			total.Add(total, amount)
+		}
+	}
+	if total.Sign() > 0 {
+		totalF := new(big.Float).SetInt(total)
+		for key, amount := range amounts {
+			if amount == nil || amount.Sign() <= 0 {
+				continue
+			}
+			share, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), totalF).Float64()
+			shares = append(shares, Share{
+				Key:    key,
+				Amount: amount.String(),
+				Share:  math.Round(share*10000) / 100,
+			})
+		}
+	}
+
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Share != shares[j].Share {
+			return shares[i].Share > shares[j].Share
+		}
+		return shares[i].Key < shares[j].Key
+	})
+	if topN > 0 && len(shares) > topN {
+		shares = shares[:topN]
+	}
+
+	score := 0.0
+	if total.Sign() > 0 {
+		score = math.Round((1-index)*10000) / 100
+	}
+
+	return Concentration{
+		Index: math.Round(index*10000) / 10000,
+		Score: score,
+		Top:   shares,
+	}
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+// weis converts int64 amounts to the big.Int map the helpers take
+func weis(amounts map[string]int64) map[string]*big.Int {
+	out := make(map[string]*big.Int, len(amounts))
+	for key, amount := range amounts {
+		out[key] = big.NewInt(amount)
+	}
+	return out
+}
+
+func TestHerfindahlIndex(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts map[string]*big.Int
+		want    float64
+	}{
+		{name: "empty", amounts: nil, want: 0},
+		{name: "one bucket", amounts: weis(map[string]int64{"a": 5}), want: 1},
+		{name: "two equal buckets", amounts: weis(map[string]int64{"a": 5, "b": 5}), want: 0.5},
+		{name: "four equal buckets", amounts: weis(map[string]int64{"a": 1, "b": 1, "c": 1, "d": 1}), want: 0.25},
+		{name: "uneven buckets", amounts: weis(map[string]int64{"a": 3, "b": 1}), want: 0.625},
+		{name: "zero and negative amounts are ignored", amounts: weis(map[string]int64{"a": 5, "b": 0, "c": -5}), want: 1},
+		{name: "nil amounts are ignored", amounts: map[string]*big.Int{"a": big.NewInt(5), "b": nil}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HerfindahlIndex(tt.amounts); got != tt.want {
+				t.Errorf("HerfindahlIndex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcentrate(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts map[string]*big.Int
+		topN    int
+		want    Concentration
+	}{
+		{
+			name: "empty set scores zero",
+			want: Concentration{Top: []Share{}},
+		},
+		{
+			name:    "everything in one bucket",
+			amounts: weis(map[string]int64{"a": 100}),
+			topN:    3,
+			want:    Concentration{Index: 1, Score: 0, Top: []Share{{Key: "a", Amount: "100", Share: 100}}},
+		},
+		{
+			name:    "evenly spread",
+			amounts: weis(map[string]int64{"a": 25, "b": 25, "c": 25, "d": 25}),
+			topN:    2,
+			want: Concentration{Index: 0.25, Score: 75, Top: []Share{
+				{Key: "a", Amount: "25", Share: 25},
+				{Key: "b", Amount: "25", Share: 25},
+			}},
+		},
+		{
+			name:    "largest buckets first",
+			amounts: weis(map[string]int64{"small": 1, "big": 3}),
+			want: Concentration{Index: 0.625, Score: 37.5, Top: []Share{
+				{Key: "big", Amount: "3", Share: 75},
+				{Key: "small", Amount: "1", Share: 25},
+			}},
+		},
+		{
+			name:    "shares are rounded to two decimals",
+			amounts: weis(map[string]int64{"a": 1, "b": 1, "c": 1}),
+			topN:    1,
+			want:    Concentration{Index: 0.3333, Score: 66.67, Top: []Share{{Key: "a", Amount: "1", Share: 33.33}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Concentrate(tt.amounts, tt.topN); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Concentrate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcentrateSpreadScoresHigher(t *testing.T) {
+	concentrated := Concentrate(weis(map[string]int64{"a": 90, "b": 5, "c": 5}), 3)
+	spread := Concentrate(weis(map[string]int64{"a": 34, "b": 33, "c": 33}), 3)
+
+	if spread.Score <= concentrated.Score {
+		t.Errorf("spread score %v is not above concentrated score %v", spread.Score, concentrated.Score)
+	}
+	if spread.Index >= concentrated.Index {
+		t.Errorf("spread index %v is not below concentrated index %v", spread.Index, concentrated.Index)
+	}
+}
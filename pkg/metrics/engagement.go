@@ -0,0 +1,22 @@
+package metrics
+
+// LowRetentionFactor flags a track whose plays per listener fall below this
+// fraction of the catalog-wide plays per listener
+const LowRetentionFactor = 0.5
+
+// Ratio divides numerator by denominator, returning 0 when the denominator is 0
+func Ratio(numerator, denominator uint64) float64 {
+	if denominator == 0 {
+		return 0
+	}
+	return round2(float64(numerator) / float64(denominator))
+}
+
+// LowRetention reports whether a track's plays-per-listener ratio is unusually
+// low compared with the catalog ratio. Tracks without listeners are never flagged.
+func LowRetention(playToListener, catalogPlayToListener float64, listeners uint64) bool {
+	if listeners == 0 || catalogPlayToListener == 0 {
+		return false
+	}
+	return playToListener < catalogPlayToListener*LowRetentionFactor
+}
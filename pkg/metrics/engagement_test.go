@@ -0,0 +1,48 @@
+package metrics
+
+import "testing"
+
+func TestRatio(t *testing.T) {
+	tests := []struct {
+		numerator, denominator uint64
+		want                   float64
+	}{
+		{numerator: 300, denominator: 100, want: 3},
+		{numerator: 1, denominator: 3, want: 0.33},
+		{numerator: 2, denominator: 3, want: 0.67},
+		{numerator: 0, denominator: 10, want: 0},
+		{numerator: 10, denominator: 0, want: 0},
+		{numerator: 0, denominator: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := Ratio(tt.numerator, tt.denominator); got != tt.want {
+			t.Errorf("Ratio(%d, %d) = %v, want %v", tt.numerator, tt.denominator, got, tt.want)
+		}
+	}
+}
+
+func TestLowRetention(t *testing.T) {
+	tests := []struct {
+		name      string
+		track     float64
+		catalog   float64
+		listeners uint64
+		want      bool
+	}{
+		{name: "well below the catalog", track: 1, catalog: 4, listeners: 10, want: true},
+		{name: "exactly at the threshold", track: 2, catalog: 4, listeners: 10, want: false},
+		{name: "just under the threshold", track: 1.99, catalog: 4, listeners: 10, want: true},
+		{name: "above the threshold", track: 3, catalog: 4, listeners: 10, want: false},
+		{name: "no listeners", track: 0, catalog: 4, listeners: 0, want: false},
+		{name: "catalog without listeners", track: 0, catalog: 0, listeners: 10, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LowRetention(tt.track, tt.catalog, tt.listeners); got != tt.want {
+				t.Errorf("LowRetention(%v, %v, %d) = %v, want %v", tt.track, tt.catalog, tt.listeners, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+)
+
+// OverlapAffinity is the share of the smaller audience on a platform assumed
+// to also follow the other track when the two tracks' platform mixes are
+// identical
+const OverlapAffinity = 0.5
+
+// Overlap is the estimated audience two tracks share
+type Overlap struct {
+	AudienceA      uint64  `json:"audience_a"`
+	AudienceB      uint64  `json:"audience_b"`
+	SharedAudience uint64  `json:"shared_audience"`
+	CombinedReach  uint64  `json:"combined_reach"`  // unique listeners across both tracks
+	OverlapPercent float64 `json:"overlap_percent"` // shared audience as a percent of combined reach
+	PercentOfA     float64 `json:"percent_of_a"`    // share of A's audience that also follows B
+	PercentOfB     float64 `json:"percent_of_b"`
+	Similarity     float64 `json:"similarity"` // cosine similarity of the platform mixes, 0-1
+}
+
+// EstimateOverlap estimates the listeners two tracks share from their
+// audience per platform. On each platform at most OverlapAffinity of the
+// smaller audience is shared, scaled by how alike the tracks' platform mixes
+// are. The estimate is symmetric and the shared audience never exceeds
+// either track's audience, so every percentage is between 0 and 100.
+func EstimateOverlap(a, b map[string]uint64) Overlap {
+	// Sum over the platforms in a fixed order so that swapping a and b
+	// cannot change the floating-point result
+	platforms := make([]string, 0, len(a)+len(b))
+	for platform := range a {
+		platforms = append(platforms, platform)
+	}
+	for platform := range b {
+		if _, ok := a[platform]; !ok {
+			platforms = append(platforms, platform)
+		}
+	}
+	sort.Strings(platforms)
+
+	var audienceA, audienceB uint64
+	var dot, normA, normB float64
+	for _, platform := range platforms {
+		countA, countB := float64(a[platform]), float64(b[platform])
+		audienceA += a[platform]
+		audienceB += b[platform]
+		dot += countA * countB
+		normA += countA * countA
+		normB += countB * countB
+	}
+
+	overlap := Overlap{AudienceA: audienceA, AudienceB: audienceB}
+	if normA == 0 || normB == 0 {
+		overlap.CombinedReach = audienceA + audienceB
+		return overlap
+	}
+	similarity := math.Min(dot/(math.Sqrt(normA)*math.Sqrt(normB)), 1)
+
+	var shared float64
+	for _, platform := range platforms {
+		shared += float64(min(a[platform], b[platform])) * OverlapAffinity * similarity
+	}
+	// Round rather than truncate so identical mixes, whose similarity can
+	// come out a hair under 1, still share exactly OverlapAffinity
+	overlap.SharedAudience = uint64(math.Round(shared))
+	overlap.CombinedReach = audienceA + audienceB - overlap.SharedAudience
+	overlap.Similarity = round2(similarity)
+	overlap.OverlapPercent = percentOf(overlap.SharedAudience, overlap.CombinedReach)
+	overlap.PercentOfA = percentOf(overlap.SharedAudience, audienceA)
+	overlap.PercentOfB = percentOf(overlap.SharedAudience, audienceB)
+	return overlap
+}
+
+func percentOf(part, whole uint64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return round2(float64(part) / float64(whole) * 100)
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import "testing"
+
+func TestEstimateOverlap(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b map[string]uint64
+		want Overlap
+	}{
+		{
+			name: "identical platform mixes",
+			a:    map[string]uint64{"spotify": 1000, "youtube": 1000},
+			b:    map[string]uint64{"spotify": 1000, "youtube": 1000},
+			want: Overlap{AudienceA: 2000, AudienceB: 2000, SharedAudience: 1000, CombinedReach: 3000, OverlapPercent: 33.33, PercentOfA: 50, PercentOfB: 50, Similarity: 1},
+		},
+		{
+			name: "smaller audience on the same platform",
+			a:    map[string]uint64{"spotify": 1000},
+			b:    map[string]uint64{"spotify": 200},
+			want: Overlap{AudienceA: 1000, AudienceB: 200, SharedAudience: 100, CombinedReach: 1100, OverlapPercent: 9.09, PercentOfA: 10, PercentOfB: 50, Similarity: 1},
+		},
+		{
+			name: "no platform in common",
+			a:    map[string]uint64{"spotify": 1000},
+			b:    map[string]uint64{"youtube": 500},
+			want: Overlap{AudienceA: 1000, AudienceB: 500, CombinedReach: 1500},
+		},
+		{
+			name: "one track without an audience",
+			a:    map[string]uint64{"spotify": 1000},
+			b:    map[string]uint64{},
+			want: Overlap{AudienceA: 1000, CombinedReach: 1000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstimateOverlap(tt.a, tt.b); got != tt.want {
+				t.Errorf("EstimateOverlap() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateOverlapIsSymmetricAndBounded(t *testing.T) {
+	audiences := []map[string]uint64{
+		{},
+		{"spotify": 1},
+		{"spotify": 1000, "youtube": 1000},
+		{"spotify": 123457, "apple_music": 98765, "tiktok": 3},
+		{"youtube": 500, "tiktok": 90000},
+		{"spotify": 18446744073709, "youtube": 7},
+	}
+
+	for i, a := range audiences {
+		for j, b := range audiences {
+			ab, ba := EstimateOverlap(a, b), EstimateOverlap(b, a)
+
+			mirrored := Overlap{
+				AudienceA:      ba.AudienceB,
+				AudienceB:      ba.AudienceA,
+				SharedAudience: ba.SharedAudience,
+				CombinedReach:  ba.CombinedReach,
+				OverlapPercent: ba.OverlapPercent,
+				PercentOfA:     ba.PercentOfB,
+				PercentOfB:     ba.PercentOfA,
+				Similarity:     ba.Similarity,
+			}
+			if ab != mirrored {
+				t.Errorf("audiences %d and %d: (a, b) = %+v, (b, a) = %+v", i, j, ab, ba)
+			}
+
+			if ab.SharedAudience > min(ab.AudienceA, ab.AudienceB) {
+				t.Errorf("audiences %d and %d: shared %d exceeds the smaller audience", i, j, ab.SharedAudience)
+			}
+			if ab.CombinedReach < max(ab.AudienceA, ab.AudienceB) || ab.CombinedReach > ab.AudienceA+ab.AudienceB {
+				t.Errorf("audiences %d and %d: combined reach %d outside [%d, %d]", i, j, ab.CombinedReach, max(ab.AudienceA, ab.AudienceB), ab.AudienceA+ab.AudienceB)
+			}
+			for _, percent := range []float64{ab.OverlapPercent, ab.PercentOfA, ab.PercentOfB, ab.Similarity * 100} {
+				if percent < 0 || percent > 100 {
+					t.Errorf("audiences %d and %d: %+v has a percentage outside 0-100", i, j, ab)
+				}
+			}
+		}
+	}
+}
@@ -26,12 +26,18 @@ type PlatformStats struct {
 // GeneratePlatformStats generates realistic mock platform stats based on token ID and registration date
 // Uses tokenID as seed for consistent "random" data
 func GeneratePlatformStats(tokenID uint64, registeredAt time.Time) PlatformStats {
+	return GeneratePlatformStatsAt(tokenID, registeredAt, time.Now())
+}
+
+// GeneratePlatformStatsAt generates the mock platform stats a track had at asOf,
+// so past points of a series are consistent with today's GeneratePlatformStats
+func GeneratePlatformStatsAt(tokenID uint64, registeredAt, asOf time.Time) PlatformStats {
 	// Use tokenID as seed for deterministic randomness
 	seed := int64(tokenID)
 	r := rand.New(rand.NewSource(seed))
 
 	// Calculate days since registration
-	daysSince := time.Since(registeredAt).Hours() / 24
+	daysSince := asOf.Sub(registeredAt).Hours() / 24
 	if daysSince < 1 {
 		daysSince = 1 // Minimum 1 day
 	}
@@ -81,6 +87,32 @@ func GeneratePlatformStats(tokenID uint64, registeredAt time.Time) PlatformStats
 	}
 }
 
+// PlatformStatsPoint is a track's mock platform stats at the close of a UTC day
+type PlatformStatsPoint struct {
+	Date  string        `json:"date"`
+	Stats PlatformStats `json:"stats"`
+}
+
+// GeneratePlatformStatsHistory generates the mock platform stats at the close
+// of each of the days UTC days ending with lastDay, oldest first. A point
+// depends only on the token and its day, so a chart drawn today and tomorrow
+// agrees on every shared day, and the counts never fall from one day to the
+// next. Days that closed before registration have empty stats.
+func GeneratePlatformStatsHistory(tokenID uint64, registeredAt, lastDay time.Time, days int) []PlatformStatsPoint {
+	lastDay = lastDay.UTC().Truncate(24 * time.Hour)
+	history := make([]PlatformStatsPoint, days)
+	for i := range history {
+		day := lastDay.AddDate(0, 0, i-(days-1))
+		closeOfDay := day.Add(24 * time.Hour)
+
+		history[i].Date = day.Format("2006-01-02")
+		if closeOfDay.After(registeredAt) {
+			history[i].Stats = GeneratePlatformStatsAt(tokenID, registeredAt, closeOfDay)
+		}
+	}
+	return history
+}
+
 // GenerateViralScore calculates a viral score (0-100) based on engagement metrics
 func GenerateViralScore(playCount, viewCount, listenerCount uint64, daysSince float64) float64 {
 	if daysSince < 1 {
@@ -139,36 +171,62 @@ func GenerateEstimatedReach(stats PlatformStats) uint64 {
 	return reach
 }
 
+// PlatformAudiences returns the listeners a track reaches on each platform,
+// counting TikTok viewers the same way as GenerateEstimatedReach
+func PlatformAudiences(stats PlatformStats) map[string]uint64 {
+	return map[string]uint64{
+		"spotify":     stats.Spotify.Listeners,
+		"tiktok":      stats.TikTok.Views / 10,
+		"apple_music": stats.AppleMusic.Listeners,
+	}
+}
+
 // randomRange generates a random float between min and max
 func randomRange(r *rand.Rand, min, max float64) float64 {
 	return min + r.Float64()*(max-min)
 }
 
-// GenerateRiskScore calculates campaign risk score (0-100, lower = safer)
-func GenerateRiskScore(fundingPercentage float64, contributorCount uint, creatorReputation uint) uint8 {
-	// Start with high risk (100)
-	risk := 100.0
+// BaseRiskScore is the risk a campaign starts from before any reductions
+const BaseRiskScore = 100.0
+
+// RiskBreakdown decomposes a risk score: Score is BaseRisk minus the three
+// factor reductions, clamped to 0-100 and truncated
+type RiskBreakdown struct {
+	BaseRisk          float64 `json:"base_risk"`
+	FundingFactor     float64 `json:"funding_factor"`     // up to 40 points off
+	ContributorFactor float64 `json:"contributor_factor"` // up to 30 points off
+	ReputationFactor  float64 `json:"reputation_factor"`  // up to 30 points off
+	RawScore          float64 `json:"raw_score"`
+	Score             uint8   `json:"score"`
+}
+
+// CalculateRiskBreakdown returns each factor's reduction and the resulting score
+func CalculateRiskBreakdown(fundingPercentage float64, contributorCount uint, creatorReputation uint) RiskBreakdown {
+	b := RiskBreakdown{BaseRisk: BaseRiskScore}
 
 	// Reduce risk based on funding progress (max 40 points reduction)
-	risk -= math.Min(fundingPercentage, 100) * 0.4
+	b.FundingFactor = math.Max(math.Min(fundingPercentage, 100), 0) * 0.4
 
 	// Reduce risk based on number of contributors (max 30 points reduction)
 	contributorScore := math.Min(float64(contributorCount)*2, 100)
-	risk -= contributorScore * 0.3
+	b.ContributorFactor = contributorScore * 0.3
 
 	// Reduce risk based on creator reputation (max 30 points reduction)
 	reputationScore := math.Min(float64(creatorReputation)*10, 100)
-	risk -= reputationScore * 0.3
+	b.ReputationFactor = reputationScore * 0.3
+
+	b.RawScore = b.BaseRisk - b.FundingFactor - b.ContributorFactor - b.ReputationFactor
 
 	// Ensure risk is between 0 and 100
-	if risk < 0 {
-		risk = 0
-	}
-	if risk > 100 {
-		risk = 100
-	}
+	risk := math.Max(math.Min(b.RawScore, 100), 0)
+	b.Score = uint8(risk)
 
-	return uint8(risk)
+	return b
+}
+
+// GenerateRiskScore calculates campaign risk score (0-100, lower = safer)
+func GenerateRiskScore(fundingPercentage float64, contributorCount uint, creatorReputation uint) uint8 {
+	return CalculateRiskBreakdown(fundingPercentage, contributorCount, creatorReputation).Score
 }
 
 // GenerateROI calculates estimated ROI based on campaign metrics
@@ -198,30 +256,72 @@ func GenerateROI(fundingPercentage float64, riskScore uint8, daysSinceCreation f
 	return math.Round(estimatedROI*100) / 100
 }
 
+// CreatorTier is a creator tier and what it takes to reach it: either
+// MinWorks works or MinEarningsEth earned
+type CreatorTier struct {
+	Name           string  `json:"name"`
+	MinWorks       uint    `json:"min_works"`
+	MinEarningsEth float64 `json:"min_earnings_eth"`
+}
+
+// CreatorTiers lists the tiers from lowest to highest; GenerateTier and
+// GenerateTierProgress both read their thresholds from here
+var CreatorTiers = []CreatorTier{
+	{Name: "Registered Creator"},
+	{Name: "Verified Creator", MinWorks: 5, MinEarningsEth: 5},
+	{Name: "Established Creator", MinWorks: 10, MinEarningsEth: 20},
+	{Name: "Rising Star", MinWorks: 20, MinEarningsEth: 50},
+	{Name: "Legendary Creator", MinWorks: 50, MinEarningsEth: 100},
+}
+
+// tierIndex returns the position in CreatorTiers of the highest tier reached
+func tierIndex(totalWorks uint, totalEarningsEth float64) int {
+	for i := len(CreatorTiers) - 1; i > 0; i-- {
+		if totalWorks >= CreatorTiers[i].MinWorks || totalEarningsEth >= CreatorTiers[i].MinEarningsEth {
+			return i
+		}
+	}
+	return 0
+}
+
 // GenerateTier returns creator tier based on total works and earnings
 func GenerateTier(totalWorks uint, totalEarningsEth float64) string {
-	// Legendary: 50+ works OR 100+ ETH earned
-	if totalWorks >= 50 || totalEarningsEth >= 100 {
-		return "Legendary Creator"
-	}
+	return CreatorTiers[tierIndex(totalWorks, totalEarningsEth)].Name
+}
 
-	// Rising Star: 20+ works OR 50+ ETH earned
-	if totalWorks >= 20 || totalEarningsEth >= 50 {
-		return "Rising Star"
-	}
+// TierProgress is a creator's current tier and the gap to the next one.
+// Reaching either remaining figure is enough; Next is nil at the top tier.
+type TierProgress struct {
+	Current              CreatorTier  `json:"current"`
+	Next                 *CreatorTier `json:"next"`
+	TotalWorks           uint         `json:"total_works"`
+	TotalEarningsEth     float64      `json:"total_earnings_eth"`
+	WorksRemaining       uint         `json:"works_remaining"`
+	EarningsRemainingEth float64      `json:"earnings_remaining_eth"`
+}
 
-	// Established: 10+ works OR 20+ ETH earned
-	if totalWorks >= 10 || totalEarningsEth >= 20 {
-		return "Established Creator"
+// GenerateTierProgress reports the tier GenerateTier assigns and what is
+// still needed for the next one
+func GenerateTierProgress(totalWorks uint, totalEarningsEth float64) TierProgress {
+	index := tierIndex(totalWorks, totalEarningsEth)
+	progress := TierProgress{
+		Current:          CreatorTiers[index],
+		TotalWorks:       totalWorks,
+		TotalEarningsEth: totalEarningsEth,
 	}
-
-	// Verified: 5+ works OR 5+ ETH earned
-	if totalWorks >= 5 || totalEarningsEth >= 5 {
-		return "Verified Creator"
+	if index == len(CreatorTiers)-1 {
+		return progress
 	}
 
-	// Default
-	return "Registered Creator"
+	next := CreatorTiers[index+1]
+	progress.Next = &next
+	if totalWorks < next.MinWorks {
+		progress.WorksRemaining = next.MinWorks - totalWorks
+	}
+	if totalEarningsEth < next.MinEarningsEth {
+		progress.EarningsRemainingEth = math.Round((next.MinEarningsEth-totalEarningsEth)*1e6) / 1e6
+	}
+	return progress
 }
 
 // WeeklyGrowth calculates week-over-week growth percentage (mock)
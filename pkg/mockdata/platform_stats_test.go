@@ -0,0 +1,156 @@
+package mockdata
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCalculateRiskBreakdown(t *testing.T) {
+	tests := []struct {
+		name                                 string
+		funding                              float64
+		contributors                         uint
+		reputation                           uint
+		wantFunding, wantContrib, wantRepute float64
+		wantScore                            uint8
+	}{
+		{name: "new campaign", wantScore: 100},
+		{name: "partway", funding: 50, contributors: 10, reputation: 5, wantFunding: 20, wantContrib: 6, wantRepute: 15, wantScore: 59},
+		{name: "fractional score is truncated", funding: 33.3, contributors: 1, reputation: 1, wantFunding: 13.32, wantContrib: 0.6, wantRepute: 3, wantScore: 83},
+		{name: "every factor maxed", funding: 100, contributors: 50, reputation: 10, wantFunding: 40, wantContrib: 30, wantRepute: 30, wantScore: 0},
+		{name: "factors are capped", funding: 250, contributors: 500, reputation: 99, wantFunding: 40, wantContrib: 30, wantRepute: 30, wantScore: 0},
+		{name: "negative funding counts as none", funding: -20, wantScore: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateRiskBreakdown(tt.funding, tt.contributors, tt.reputation)
+
+			factors := []struct {
+				name      string
+				got, want float64
+			}{
+				{"FundingFactor", got.FundingFactor, tt.wantFunding},
+				{"ContributorFactor", got.ContributorFactor, tt.wantContrib},
+				{"ReputationFactor", got.ReputationFactor, tt.wantRepute},
+			}
+			for _, f := range factors {
+				if math.Abs(f.got-f.want) > 1e-9 {
+					t.Errorf("%s = %v, want %v", f.name, f.got, f.want)
+				}
+			}
+
+			if sum := got.BaseRisk - got.FundingFactor - got.ContributorFactor - got.ReputationFactor; sum != got.RawScore {
+				t.Errorf("base less the factors = %v, want the raw score %v", sum, got.RawScore)
+			}
+			if got.BaseRisk != BaseRiskScore || got.Score != tt.wantScore {
+				t.Errorf("base %v, score %d, want %v and %d", got.BaseRisk, got.Score, BaseRiskScore, tt.wantScore)
+			}
+			if aggregate := GenerateRiskScore(tt.funding, tt.contributors, tt.reputation); aggregate != got.Score {
+				t.Errorf("GenerateRiskScore() = %d, want the breakdown's score %d", aggregate, got.Score)
+			}
+		})
+	}
+}
+
+func TestGenerateTierProgress(t *testing.T) {
+	tests := []struct {
+		name         string
+		works        uint
+		earnings     float64
+		wantCurrent  string
+		wantNext     string // empty at the top tier
+		wantWorks    uint
+		wantEarnings float64
+	}{
+		{name: "new creator", wantCurrent: "Registered Creator", wantNext: "Verified Creator", wantWorks: 5, wantEarnings: 5},
+		{name: "one work short", works: 4, earnings: 1.5, wantCurrent: "Registered Creator", wantNext: "Verified Creator", wantWorks: 1, wantEarnings: 3.5},
+		{name: "exactly at the works threshold", works: 5, wantCurrent: "Verified Creator", wantNext: "Established Creator", wantWorks: 5, wantEarnings: 20},
+		{name: "exactly at the earnings threshold", earnings: 20, wantCurrent: "Established Creator", wantNext: "Rising Star", wantWorks: 20, wantEarnings: 30},
+		{name: "works alone reach a tier", works: 30, earnings: 1, wantCurrent: "Rising Star", wantNext: "Legendary Creator", wantWorks: 20, wantEarnings: 99},
+		{name: "earnings alone reach a tier", works: 12, earnings: 60, wantCurrent: "Rising Star", wantNext: "Legendary Creator", wantWorks: 38, wantEarnings: 40},
+		{name: "just below the top tier", works: 49, earnings: 99.999999, wantCurrent: "Rising Star", wantNext: "Legendary Creator", wantWorks: 1, wantEarnings: 0.000001},
+		{name: "top tier", works: 50, wantCurrent: "Legendary Creator"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GenerateTierProgress(tt.works, tt.earnings)
+
+			if got.Current.Name != tt.wantCurrent {
+				t.Errorf("current = %s, want %s", got.Current.Name, tt.wantCurrent)
+			}
+			if got.Current.Name != GenerateTier(tt.works, tt.earnings) {
+				t.Errorf("current = %s, but GenerateTier assigns %s", got.Current.Name, GenerateTier(tt.works, tt.earnings))
+			}
+			switch {
+			case tt.wantNext == "" && got.Next != nil:
+				t.Errorf("next = %s, want none at the top tier", got.Next.Name)
+			case tt.wantNext != "" && (got.Next == nil || got.Next.Name != tt.wantNext):
+				t.Errorf("next = %+v, want %s", got.Next, tt.wantNext)
+			}
+			if got.WorksRemaining != tt.wantWorks || got.EarningsRemainingEth != tt.wantEarnings {
+				t.Errorf("remaining %d works or %v ETH, want %d or %v", got.WorksRemaining, got.EarningsRemainingEth, tt.wantWorks, tt.wantEarnings)
+			}
+		})
+	}
+}
+
+func TestGeneratePlatformStatsHistory(t *testing.T) {
+	registered := time.Date(2026, 1, 10, 15, 0, 0, 0, time.UTC)
+	lastDay := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		tokenID   uint64
+		days      int
+		wantFirst string
+		wantEmpty int // leading days that closed before registration
+	}{
+		{name: "window after registration", tokenID: 7, days: 30, wantFirst: "2026-01-31"},
+		{name: "single day", tokenID: 7, days: 1, wantFirst: "2026-03-01"},
+		{name: "window reaching back before registration", tokenID: 42, days: 60, wantFirst: "2026-01-01", wantEmpty: 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GeneratePlatformStatsHistory(tt.tokenID, registered, lastDay, tt.days)
+			if len(got) != tt.days || got[0].Date != tt.wantFirst || got[len(got)-1].Date != "2026-03-01" {
+				t.Fatalf("got %d points from %s to %s, want %d from %s to 2026-03-01", len(got), got[0].Date, got[len(got)-1].Date, tt.days, tt.wantFirst)
+			}
+
+			// The same token and day always give the same point, whenever
+			// the history is drawn
+			if again := GeneratePlatformStatsHistory(tt.tokenID, registered, lastDay.Add(10*time.Hour), tt.days); !reflect.DeepEqual(again, got) {
+				t.Error("history changed when drawn later the same day")
+			}
+			tomorrow := GeneratePlatformStatsHistory(tt.tokenID, registered, lastDay.AddDate(0, 0, 1), tt.days)
+			for i := 1; i < tt.days; i++ {
+				if !reflect.DeepEqual(tomorrow[i-1], got[i]) {
+					t.Errorf("%s drawn a day later = %+v, want %+v", got[i].Date, tomorrow[i-1], got[i])
+				}
+			}
+
+			for i, point := range got {
+				if i < tt.wantEmpty {
+					if point.Stats != (PlatformStats{}) {
+						t.Errorf("%s = %+v, want empty stats before registration", point.Date, point.Stats)
+					}
+					continue
+				}
+				if point.Stats.Spotify.Plays == 0 || point.Stats.TikTok.Views == 0 || point.Stats.AppleMusic.Plays == 0 {
+					t.Errorf("%s = %+v, want stats after registration", point.Date, point.Stats)
+				}
+				if i == 0 {
+					continue
+				}
+				prev := got[i-1].Stats
+				if point.Stats.Spotify.Plays < prev.Spotify.Plays || point.Stats.TikTok.Views < prev.TikTok.Views || point.Stats.AppleMusic.Plays < prev.AppleMusic.Plays {
+					t.Errorf("counts fell from %s to %s: %+v then %+v", got[i-1].Date, point.Date, prev, point.Stats)
+				}
+			}
+		})
+	}
+}